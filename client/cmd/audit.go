@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/audit"
+	"data-vault/client/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// Audit command variables
+var (
+	auditLimit int
+	auditAll   bool
+)
+
+// auditCmd shows the audit log of the user
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log of your account",
+	Long: `Show the calls made on your account, including failed logins, with the client address,
+user agent and result of each. The server chains the entries by hash, the client checks the chain
+and prints the hash of the latest entry: note it down and compare it later to detect a log that
+was rewritten. The server also keys every hash with a secret of its own and flags entries that
+don't match it. With --all the whole log is fetched and checked from its first entry.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		if auditLimit < 1 && !auditAll {
+			fmt.Fprintf(os.Stderr, "Error: --limit must be positive\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		limit := auditLimit
+		if auditAll {
+			limit = 0
+		}
+
+		entries, err := service.GetAuditLog(context.Background(), jwtToken, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get audit log: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(entries) == 0 {
+			fmt.Println("Audit log is empty.")
+			return
+		}
+
+		fmt.Println("Audit log:")
+		unauthenticated := 0
+		for _, e := range entries {
+			if !e.Authentic {
+				unauthenticated++
+			}
+			fmt.Printf("%d. %s %s -> %s\n", e.Seq, e.At, e.Action, e.Result)
+			if e.RecordID != "" {
+				fmt.Printf("   Record: %s\n", e.RecordID)
+			}
+			if e.Org != "" {
+				fmt.Printf("   Organization: %s\n", e.Org)
+			}
+			fmt.Printf("   Client: %s (%s)\n", e.ClientIP, e.UserAgent)
+		}
+
+		if err := audit.Verify(entries); err != nil {
+			fmt.Fprintf(os.Stderr, "\nAudit log verification failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		first, last := entries[0], entries[len(entries)-1]
+		if first.Seq == 1 {
+			fmt.Printf("\nChain verified from the first entry to entry %d.\n", last.Seq)
+		} else {
+			fmt.Printf("\nChain verified from entry %d to entry %d, use --all to check the whole log.\n", first.Seq, last.Seq)
+		}
+		fmt.Printf("Latest entry: %d, hash %s\n", last.Seq, hex.EncodeToString(last.Hash))
+		if unauthenticated > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d entries don't match the server's audit key; they were recorded before the\n"+
+				"log was keyed or were written by someone without the key\n", unauthenticated)
+		}
+	},
+}
+
+// init registers the audit command and sets up its flags
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().StringVar(&jwtToken, "jwt", "", "JWT token for authentication")
+	auditCmd.Flags().IntVar(&auditLimit, "limit", 50, "Number of latest entries to show")
+	auditCmd.Flags().BoolVar(&auditAll, "all", false, "Fetch and verify the whole log")
+}
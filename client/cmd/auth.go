@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"data-vault/client/internal/auth"
+	"data-vault/client/internal/cache"
 	"data-vault/client/internal/models"
 
 	"github.com/spf13/cobra"
@@ -17,6 +18,7 @@ import (
 var (
 	username string
 	password string
+	totpCode string
 )
 
 // registerCmd handles user registration
@@ -53,18 +55,26 @@ var registerCmd = &cobra.Command{
 		}
 
 		user := models.User{Login: username, Password: password}
-		jwt, err := service.Register(context.Background(), user)
+		session, err := service.Register(context.Background(), user)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Registration failed: %v\n", err)
 			os.Exit(1)
 		}
 
-		if err := auth.SaveJWT(jwt, username); err != nil {
+		if err := auth.SaveJWT(session.JWT, username); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Could not save JWT token: %v\n", err)
 		}
 
+		if err := auth.SaveRefreshToken(session.RefreshToken); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Could not save refresh token: %v\n", err)
+		}
+
+		if err := saveKeyCheck(service, session.Salt); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Could not save key derivation salt: %v\n", err)
+		}
+
 		fmt.Printf("Registration successful!\n")
-		fmt.Printf("JWT Token: %s\n", jwt)
+		fmt.Printf("JWT Token: %s\n", session.JWT)
 		fmt.Println("Token saved for future operations.")
 	},
 }
@@ -103,18 +113,39 @@ var loginCmd = &cobra.Command{
 		}
 
 		user := models.User{Login: username, Password: password}
-		jwt, err := service.Login(context.Background(), user)
+		session, err := service.Login(context.Background(), user)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Login failed: %v\n", err)
 			os.Exit(1)
 		}
 
-		if err := auth.SaveJWT(jwt, username); err != nil {
+		if session.TOTPRequired {
+			if totpCode == "" {
+				fmt.Print("Two-factor code (or recovery code): ")
+				fmt.Scanln(&totpCode)
+			}
+
+			session, err = service.VerifyTOTP(context.Background(), user, session.ChallengeToken, totpCode)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Login failed: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if err := auth.SaveJWT(session.JWT, username); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Could not save JWT token: %v\n", err)
 		}
 
+		if err := auth.SaveRefreshToken(session.RefreshToken); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Could not save refresh token: %v\n", err)
+		}
+
+		if err := saveKeyCheck(service, session.Salt); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Could not save key derivation salt: %v\n", err)
+		}
+
 		fmt.Printf("Login successful!\n")
-		fmt.Printf("JWT Token: %s\n", jwt)
+		fmt.Printf("JWT Token: %s\n", session.JWT)
 		fmt.Println("Token saved for future operations.")
 	},
 }
@@ -123,12 +154,33 @@ var loginCmd = &cobra.Command{
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Logout and clear saved credentials",
-	Long:  "Remove saved JWT token and logout from the Data Vault client.",
+	Long: `End the session on the server, remove saved JWT and refresh tokens, key derivation salt and
+local data cache and logout from the Data Vault client.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if service, err := initService(); err == nil {
+			if savedJWT, err := auth.LoadJWT(); err == nil && savedJWT != "" {
+				if err := service.Logout(context.Background(), savedJWT); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: Could not end the session on the server: %v\n", err)
+				}
+			}
+		}
+
 		if err := auth.ClearJWT(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error clearing credentials: %v\n", err)
 			os.Exit(1)
 		}
+
+		cachePath, err := auth.CachePath()
+		if err == nil {
+			if _, statErr := os.Stat(cachePath); statErr == nil {
+				fmt.Fprintln(os.Stderr, "Warning: removing the local cache, offline changes not sent with 'sync' are discarded")
+			}
+			err = cache.Remove(cachePath)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error removing local cache: %v\n", err)
+			os.Exit(1)
+		}
 		fmt.Println("Logged out successfully. Credentials cleared.")
 	},
 }
@@ -144,4 +196,5 @@ func init() {
 
 	loginCmd.Flags().StringVarP(&username, "username", "u", "", "Username for login")
 	loginCmd.Flags().StringVarP(&password, "password", "p", "", "Password for login")
+	loginCmd.Flags().StringVar(&totpCode, "code", "", "Two-factor code or recovery code, if enabled for the account")
 }
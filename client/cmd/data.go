@@ -4,10 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"data-vault/client/internal/auth"
+	"data-vault/client/internal/cache"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+	"data-vault/client/internal/services"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // Data command variables
@@ -16,20 +23,34 @@ var (
 	dataText string
 	dataType string
 	dataID   string
+
+	dataVersion int64
+	dataMeta    map[string]string
+
+	filterType string
+	filterFrom string
+	filterTo   string
+	pageSize   int32
+	pageToken  string
 )
 
 // dataCmd represents the data command group
 var dataCmd = &cobra.Command{
 	Use:   "data",
-	Short: "Data operations (post, get, delete)",
-	Long:  "Perform data operations like storing, retrieving, and deleting data from the vault.",
+	Short: "Data operations (post, get, update, delete, otp, trash, history, restore, upload, download)",
+	Long:  "Perform data operations like storing, retrieving, updating, and deleting data from the vault.",
 }
 
 // postCmd handles storing data in the vault
 var postCmd = &cobra.Command{
 	Use:   "post",
 	Short: "Store data in the vault",
-	Long:  "Store encrypted data in the Data Vault server.",
+	Long: `Encrypt a record with your vault key and store it in the Data Vault server.
+Each type has its own fields: text (--data), password (--website, --login, --password),
+card (--number, --holder, --exp, --cvv, --bank), binary (--file) and otp (--uri or --secret,
+--issuer, --account, --otp-type, --algorithm, --digits, --period, --counter). Required fields
+that aren't given as flags are prompted for. Labels attached with --meta key=value are
+encrypted too; the server filters by keyed hashes of them.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if jwtToken == "" {
 			savedJWT, err := auth.LoadJWT()
@@ -45,18 +66,19 @@ var postCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		if dataText == "" {
-			fmt.Print("Enter data to store: ")
-			fmt.Scanln(&dataText)
+		if dataType == "" {
+			dataType = models.DataTypeText
 		}
 
-		if dataText == "" {
-			fmt.Fprintf(os.Stderr, "Error: data is required\n")
+		record, err := readRecord(dataType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		if dataType == "" {
-			dataType = "text"
+		if err := records.Validate(record); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 
 		service, err := initService()
@@ -65,11 +87,22 @@ var postCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		err = service.PostData(context.Background(), jwtToken, dataType, []byte(dataText))
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		err = service.PostRecord(context.Background(), jwtToken, record, dataMeta)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to post data: %v\n", err)
 			os.Exit(1)
 		}
+		reportRejected(service)
+
+		if service.Offline() {
+			fmt.Printf("Server is unreachable, data queued locally (%d pending operations).\n", service.PendingCount())
+			return
+		}
 
 		fmt.Println("Data posted successfully!")
 	},
@@ -79,7 +112,9 @@ var postCmd = &cobra.Command{
 var getCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Retrieve data from the vault",
-	Long:  "Retrieve all your stored data from the Data Vault server.",
+	Long: `Retrieve your stored data from the Data Vault server, newest first, one page at a time.
+Results can be narrowed down by type, upload date and metadata. Passwords, card numbers
+and CVVs are masked unless --reveal is given.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if jwtToken == "" {
 			savedJWT, err := auth.LoadJWT()
@@ -101,22 +136,137 @@ var getCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		data, err := service.GetData(context.Background(), jwtToken)
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		query, err := dataQuery()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get data: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		if len(data) == 0 {
-			fmt.Println("No data found.")
-			return
+		shown := 0
+		for {
+			page, err := service.GetDataPage(context.Background(), jwtToken, query)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get data: %v\n", err)
+				os.Exit(1)
+			}
+
+			if shown == 0 {
+				reportRejected(service)
+			}
+
+			if service.Offline() && shown == 0 {
+				fmt.Printf("Server is unreachable, showing cached data (%d pending operations).\n\n", service.PendingCount())
+			}
+
+			if len(page.Data) == 0 && shown == 0 {
+				fmt.Println("No data found.")
+				return
+			}
+
+			if shown == 0 {
+				fmt.Println("Your stored data:")
+			}
+			for _, item := range page.Data {
+				shown++
+				id := item.ID
+				if id == "" {
+					id = "(pending)"
+				}
+				fmt.Printf("%d. ID: %s\n   Type: %s\n", shown, id, item.Type)
+				printRecord(item.Data, revealSecrets)
+				if len(item.Metadata) > 0 {
+					fmt.Printf("   Meta: %s\n", formatMetadata(item.Metadata))
+				}
+				fmt.Printf("   Uploaded: %s\n   Version: %d\n\n", item.UploadedAt, item.Version)
+			}
+
+			if page.NextPageToken == "" {
+				return
+			}
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				fmt.Printf("More entries available, continue with --page-token %s\n", page.NextPageToken)
+				return
+			}
+
+			fmt.Print("-- Press Enter for the next page, q to stop --")
+			answer, _ := stdin.ReadString('\n')
+			if strings.TrimSpace(answer) == "q" {
+				fmt.Printf("Continue later with --page-token %s\n", page.NextPageToken)
+				return
+			}
+			fmt.Println()
+			query.PageToken = page.NextPageToken
+		}
+	},
+}
+
+// updateCmd handles replacing an existing data entry in the vault
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update data in the vault",
+	Long: `Replace the type and contents of an existing data entry, keeping its ID.
+The new record is given with the same per-type flags as 'data post'.
+The --version flag must match the version shown by 'data get'; if the entry was
+changed by another client in the meantime the update is rejected.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		if dataID == "" {
+			fmt.Print("Enter data ID to update: ")
+			fmt.Scanln(&dataID)
+		}
+
+		if dataID == "" || dataVersion <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: data ID and version are required\n")
+			os.Exit(1)
+		}
+
+		record, err := readRecord(dataType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := records.Validate(record); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
 		}
 
-		fmt.Println("Your stored data:")
-		for i, item := range data {
-			fmt.Printf("%d. ID: %s\n   Type: %s\n   Data: %s\n   Uploaded: %s\n\n",
-				i+1, item.ID, item.Type, string(item.Data), item.UploadedAt)
+		version, err := service.UpdateRecord(context.Background(), jwtToken, dataID, record, dataVersion)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to update data: %v\n", err)
+			os.Exit(1)
 		}
+
+		fmt.Printf("Data with ID %s updated successfully! New version: %d\n", dataID, version)
 	},
 }
 
@@ -124,7 +274,7 @@ var getCmd = &cobra.Command{
 var deleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete data from the vault",
-	Long:  "Delete a specific data entry from the Data Vault server by ID.",
+	Long:  "Move a specific data entry to the trash by ID. It can be restored with 'data trash restore' until it is purged.",
 	Run: func(cmd *cobra.Command, args []string) {
 		if jwtToken == "" {
 			savedJWT, err := auth.LoadJWT()
@@ -156,26 +306,120 @@ var deleteCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
 		err = service.DeleteData(context.Background(), jwtToken, dataID)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to delete data: %v\n", err)
 			os.Exit(1)
 		}
+		reportRejected(service)
+
+		if service.Offline() {
+			fmt.Printf("Server is unreachable, delete of %s queued locally (%d pending operations).\n", dataID, service.PendingCount())
+			return
+		}
 
 		fmt.Printf("Data with ID %s deleted successfully!\n", dataID)
 	},
 }
 
+// reportRejected tells the user about the operations queued offline that the server refused
+func reportRejected(service *services.Vault) {
+	if notice := rejectedNotice(service); notice != "" {
+		fmt.Fprintln(os.Stderr, notice)
+	}
+}
+
+// rejectedNotice takes the operations queued offline that the server refused for good and
+// describes them, an empty string if there are none
+func rejectedNotice(service *services.Vault) string {
+	rejected, err := service.TakeRejected()
+	if err != nil {
+		return fmt.Sprintf("Error reading the rejected offline operations: %v", err)
+	}
+	if len(rejected) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The server rejected %d operations queued offline, they were dropped:", len(rejected))
+	for _, op := range rejected {
+		switch op.Kind {
+		case cache.OpPost:
+			fmt.Fprintf(&b, "\n  post of %s data queued at %s: %s", op.Type, op.QueuedAt, op.Reason)
+		case cache.OpDelete:
+			fmt.Fprintf(&b, "\n  delete of %s queued at %s: %s", op.ID, op.QueuedAt, op.Reason)
+		}
+	}
+	return b.String()
+}
+
+// dataQuery builds the query of the get command from its filter flags
+func dataQuery() (models.DataQuery, error) {
+	query := models.DataQuery{
+		Type:      filterType,
+		Metadata:  dataMeta,
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	}
+
+	var err error
+	if query.UploadedFrom, err = parseDateFlag(filterFrom, false); err != nil {
+		return query, err
+	}
+	if query.UploadedTo, err = parseDateFlag(filterTo, true); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseDateFlag converts a date or RFC 3339 timestamp flag into an RFC 3339 timestamp.
+// A date used as the end of a range stands for the end of that day.
+func parseDateFlag(value string, end bool) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return "", fmt.Errorf("invalid date %q, use YYYY-MM-DD or RFC 3339", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
 // init registers data commands and sets up their flags
 func init() {
 	rootCmd.AddCommand(dataCmd)
 	dataCmd.AddCommand(postCmd)
 	dataCmd.AddCommand(getCmd)
+	dataCmd.AddCommand(updateCmd)
 	dataCmd.AddCommand(deleteCmd)
 
 	dataCmd.PersistentFlags().StringVar(&jwtToken, "jwt", "", "JWT token for authentication")
 
-	postCmd.Flags().StringVarP(&dataText, "data", "d", "", "Data to store")
-	postCmd.Flags().StringVarP(&dataType, "type", "t", "text", "Type of data (text, password, binary, card)")
+	addRecordFlags(postCmd)
+	postCmd.Flags().StringToStringVar(&dataMeta, "meta", nil, "Metadata as key=value, encrypted with your vault key (repeatable)")
+	getCmd.Flags().BoolVar(&revealSecrets, "reveal", false, "Show passwords, card numbers and CVVs in clear text")
+	getCmd.Flags().StringToStringVar(&dataMeta, "meta", nil, "Only show entries with this metadata, key= matches any value (repeatable)")
+	getCmd.Flags().StringVarP(&filterType, "type", "t", "", "Only show entries of this type (text, password, card, binary, otp)")
+	getCmd.Flags().StringVar(&filterFrom, "from", "", "Only show entries uploaded since this date (YYYY-MM-DD or RFC 3339)")
+	getCmd.Flags().StringVar(&filterTo, "to", "", "Only show entries uploaded before this time, a date includes the whole day")
+	getCmd.Flags().Int32Var(&pageSize, "page-size", 20, "Number of entries per page")
+	getCmd.Flags().StringVar(&pageToken, "page-token", "", "Continue listing from a previously printed page token")
+	updateCmd.Flags().StringVar(&dataID, "id", "", "ID of data to update")
+	addRecordFlags(updateCmd)
+	updateCmd.Flags().Int64Var(&dataVersion, "version", 0, "Version of the data last seen by this client")
 	deleteCmd.Flags().StringVar(&dataID, "id", "", "ID of data to delete")
 }
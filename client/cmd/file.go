@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// File command variables
+var (
+	uploadResumeID string
+	downloadPath   string
+	downloadResume bool
+)
+
+// uploadCmd handles streaming a file into the vault
+var uploadCmd = &cobra.Command{
+	Use:   "upload <path>",
+	Short: "Upload a file to the vault",
+	Long: `Encrypt a file chunk by chunk and stream it to the Data Vault server.
+If the upload is interrupted, run the command again with --resume <id> to
+continue from the last chunk the server stored.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		path := args[0]
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		stat, err := f.Stat()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		info, err := service.UploadFile(context.Background(), jwtToken, stat.Name(), f, stat.Size(), uploadResumeID, printProgress("Uploading"))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to upload file: %v\n", err)
+			if info.ID != "" {
+				fmt.Fprintf(os.Stderr, "Resume with: data-vault-client data upload %s --resume %s\n", path, info.ID)
+			}
+			os.Exit(1)
+		}
+
+		fmt.Printf("File uploaded successfully! ID: %s\n", info.ID)
+	},
+}
+
+// downloadCmd handles streaming a file out of the vault
+var downloadCmd = &cobra.Command{
+	Use:   "download <id>",
+	Short: "Download a file from the vault",
+	Long: `Stream a file from the Data Vault server, decrypt it and verify its digest.
+With --resume an existing partial output file is continued instead of overwritten.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		if downloadPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: output path is required\n")
+			os.Exit(1)
+		}
+
+		flags := os.O_RDWR | os.O_CREATE
+		if !downloadResume {
+			flags |= os.O_TRUNC
+		}
+
+		f, err := os.OpenFile(downloadPath, flags, 0600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		var offset int64
+		if downloadResume {
+			stat, err := f.Stat()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading output file: %v\n", err)
+				os.Exit(1)
+			}
+			offset = stat.Size()
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		meta, err := service.DownloadFile(context.Background(), jwtToken, args[0], f, offset, printProgress("Downloading"))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to download file: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("File %s downloaded to %s (%d bytes, sha256 %s)\n", meta.Filename, downloadPath, meta.Size, meta.SHA256)
+	},
+}
+
+// printProgress returns a callback printing transfer progress on a single terminal line
+func printProgress(action string) func(done, total int64) {
+	return func(done, total int64) {
+		percent := int64(100)
+		if total > 0 {
+			percent = done * 100 / total
+		}
+		fmt.Fprintf(os.Stderr, "\r%s: %3d%% (%d/%d bytes)", action, percent, done, total)
+	}
+}
+
+// init registers file commands and sets up their flags
+func init() {
+	dataCmd.AddCommand(uploadCmd)
+	dataCmd.AddCommand(downloadCmd)
+
+	uploadCmd.Flags().StringVar(&uploadResumeID, "resume", "", "ID of an interrupted upload to continue")
+	downloadCmd.Flags().StringVarP(&downloadPath, "output", "o", "", "Path to write the downloaded file to")
+	downloadCmd.Flags().BoolVar(&downloadResume, "resume", false, "Continue a partially downloaded output file")
+}
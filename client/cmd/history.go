@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// historyCmd lists the prior versions of a data entry
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show prior versions of a data entry",
+	Long: `List the versions a data entry had before it was updated, deleted or restored,
+newest first. The server keeps a limited number of versions, so the oldest ones may be gone.
+Versions of entries in the trash are listed too and can be brought back with 'data restore'.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		versions, err := service.ListVersions(context.Background(), jwtToken, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get history: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(versions) == 0 {
+			fmt.Printf("No prior versions of %s found.\n", args[0])
+			return
+		}
+
+		fmt.Printf("Prior versions of %s:\n", args[0])
+		for _, v := range versions {
+			fmt.Printf("Version %d\n   Type: %s\n", v.Version, v.Type)
+			printRecord(v.Data, revealSecrets)
+			if len(v.Metadata) > 0 {
+				fmt.Printf("   Meta: %s\n", formatMetadata(v.Metadata))
+			}
+			fmt.Printf("   Replaced: %s\n\n", v.ArchivedAt)
+		}
+	},
+}
+
+// restoreCmd brings back a prior version of a data entry
+var restoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore a prior version of a data entry",
+	Long: `Make a version listed by 'data history' the current contents of a data entry.
+The replaced contents are kept in the history, so a restore can be undone the same way.
+An entry in the trash is moved back to the vault as well.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		if dataVersion <= 0 {
+			fmt.Fprintf(os.Stderr, "Error: version to restore is required\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		version, err := service.RestoreVersion(context.Background(), jwtToken, args[0], dataVersion)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to restore version: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Version %d of %s restored successfully! New version: %d\n", dataVersion, args[0], version)
+	},
+}
+
+// init registers history commands and sets up their flags
+func init() {
+	dataCmd.AddCommand(historyCmd)
+	dataCmd.AddCommand(restoreCmd)
+
+	historyCmd.Flags().BoolVar(&revealSecrets, "reveal", false, "Show passwords, card numbers and CVVs in clear text")
+	restoreCmd.Flags().Int64Var(&dataVersion, "version", 0, "Version to restore, as listed by 'data history'")
+}
@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 
+	"data-vault/client/internal/auth"
+	"data-vault/client/internal/cache"
 	"data-vault/client/internal/config"
+	"data-vault/client/internal/encryption"
 	"data-vault/client/internal/grpcclient"
 	"data-vault/client/internal/logger"
 	"data-vault/client/internal/services"
@@ -25,5 +28,70 @@ func initService() (*services.Vault, error) {
 	}
 
 	service := services.New(ctx, log, client)
+	service.SetTokenStore(auth.Store{})
 	return service, nil
 }
+
+// masterPassword is the master password entered to unlock the vault key for a command
+var masterPassword string
+
+// loadKey derives the client-side encryption key from the master password and loads it and the
+// local cache into the service. The key is never saved, so the master password is asked for and
+// checked against the key check saved at login. With --org the service is switched to the
+// organization vault, which has no local cache.
+func loadKey(service *services.Vault) error {
+	salt, check, err := auth.LoadKeyCheck()
+	if err != nil {
+		return err
+	}
+
+	if len(salt) == 0 || len(check) == 0 {
+		return services.ErrorNoKey
+	}
+
+	username, err := auth.LoadUsername()
+	if err != nil {
+		return err
+	}
+
+	promptSecret("Master password: ", &masterPassword)
+	key, _, err := encryption.DeriveKeys(masterPassword, salt)
+	if err != nil {
+		return err
+	}
+	if err := encryption.VerifyKey(key, check); err != nil {
+		return err
+	}
+
+	service.SetKey(key)
+	if dataOrg != "" {
+		return service.UseOrg(context.Background(), jwtToken, dataOrg)
+	}
+	return openCache(service, username, key)
+}
+
+// saveKeyCheck saves what is needed to derive and check the vault key of a new session again
+func saveKeyCheck(service *services.Vault, salt []byte) error {
+	check, err := encryption.NewKeyCheck(service.Key())
+	if err != nil {
+		return err
+	}
+
+	return auth.SaveKeyCheck(salt, check)
+}
+
+// openCache attaches the user's encrypted local cache to the service for offline use
+func openCache(service *services.Vault, username string, key []byte) error {
+	path, err := auth.CachePath()
+	if err != nil {
+		return err
+	}
+
+	c, err := cache.Open(path, username, key)
+	if err != nil {
+		return err
+	}
+
+	service.SetCache(c)
+	return nil
+}
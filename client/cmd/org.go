@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/auth"
+	"data-vault/client/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// Organization command variables
+var (
+	dataOrg    string
+	inviteRole string
+)
+
+// orgCmd represents the organization command group
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Organization operations (create, invite, members, list)",
+	Long: `Organizations are vaults shared by a team. Members are owners, admins, members or read-only:
+everyone reads the records, all but read-only members change them and owners and admins invite
+members. Data commands work on an organization vault with --org <name>.`,
+}
+
+// orgCreateCmd creates an organization owned by the user
+var orgCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an organization vault",
+	Long: `Create an organization vault with you as its owner. Its records are encrypted with a new
+organization key that the server only stores encrypted for the members' public keys.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := service.CreateOrg(context.Background(), jwtToken, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create organization: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Organization %s created, use --org %s with data commands\n", args[0], args[0])
+	},
+}
+
+// orgInviteCmd adds a user to an organization or changes a member's role
+var orgInviteCmd = &cobra.Command{
+	Use:   "invite <org> <login>",
+	Short: "Add a user to an organization",
+	Long: `Add a user to an organization with the role given by --role (owner, admin, member or
+read-only), or change the role of a member. The organization key is encrypted for the user's
+public key; compare the printed key fingerprint with the one the user sees in 'data shared'.
+Only owners make other users owners.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		switch inviteRole {
+		case models.RoleOwner, models.RoleAdmin, models.RoleMember, models.RoleReadOnly:
+		default:
+			fmt.Fprintf(os.Stderr, "Error: role must be owner, admin, member or read-only\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		fingerprint, err := service.InviteMember(context.Background(), jwtToken, args[0], args[1], inviteRole)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to invite member: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("%s is now %s of %s\n", args[1], inviteRole, args[0])
+		fmt.Printf("Member key fingerprint: %s\n", fingerprint)
+	},
+}
+
+// orgMembersCmd lists the members of an organization
+var orgMembersCmd = &cobra.Command{
+	Use:   "members <org>",
+	Short: "Show the members of an organization",
+	Long:  "List the members of an organization you belong to with their roles, in the order they joined.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		members, err := service.ListMembers(context.Background(), jwtToken, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list members: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Members of %s:\n", args[0])
+		for i, m := range members {
+			fmt.Printf("%d. %s (%s)\n   Joined: %s\n", i+1, m.Login, m.Role, m.JoinedAt)
+		}
+	},
+}
+
+// orgListCmd lists the organizations the user belongs to
+var orgListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show your organizations",
+	Long:  "List the organizations you belong to with your role in each.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		memberships, err := service.ListOrgs(context.Background(), jwtToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list organizations: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(memberships) == 0 {
+			fmt.Println("You are not a member of any organization.")
+			return
+		}
+
+		fmt.Println("Organizations:")
+		for i, m := range memberships {
+			fmt.Printf("%d. %s (%s)\n", i+1, m.Org, m.Role)
+		}
+	},
+}
+
+// init registers organization commands and sets up their flags
+func init() {
+	rootCmd.AddCommand(orgCmd)
+	orgCmd.AddCommand(orgCreateCmd)
+	orgCmd.AddCommand(orgInviteCmd)
+	orgCmd.AddCommand(orgMembersCmd)
+	orgCmd.AddCommand(orgListCmd)
+
+	orgCmd.PersistentFlags().StringVar(&jwtToken, "jwt", "", "JWT token for authentication")
+	orgInviteCmd.Flags().StringVar(&inviteRole, "role", models.RoleMember, "Role of the member: owner, admin, member or read-only")
+
+	dataCmd.PersistentFlags().StringVar(&dataOrg, "org", "", "Work on the vault of this organization instead of your own")
+}
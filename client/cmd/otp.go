@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"data-vault/client/internal/auth"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+
+	"github.com/spf13/cobra"
+)
+
+// watchOTP keeps printing the current code until interrupted
+var watchOTP bool
+
+// otpCmd shows the current code of an otp record
+var otpCmd = &cobra.Command{
+	Use:   "otp <id>",
+	Short: "Show the current one-time code of an otp entry",
+	Long: `Compute the current code of a stored TOTP or HOTP secret. TOTP codes are shown with the
+seconds they stay valid, --watch keeps showing new codes until Ctrl+C. Showing an HOTP code
+advances its stored counter, so every code is only shown once.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		data, err := service.GetData(context.Background(), jwtToken, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get data: %v\n", err)
+			os.Exit(1)
+		}
+
+		var entry *models.Data
+		for i := range data {
+			if data[i].ID == args[0] {
+				entry = &data[i]
+				break
+			}
+		}
+		if entry == nil {
+			fmt.Fprintf(os.Stderr, "Error: data %s not found\n", args[0])
+			os.Exit(1)
+		}
+
+		rec := records.Decode(entry.Data)
+		if rec.OTP == nil {
+			fmt.Fprintf(os.Stderr, "Error: data %s is a %s entry, not otp\n", args[0], entry.Type)
+			os.Exit(1)
+		}
+
+		if summary := records.Summary(rec); summary != "" {
+			fmt.Println(summary)
+		}
+
+		if rec.OTP.Type == models.OTPTypeHOTP {
+			code, _, err := records.OTPCode(rec.OTP, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			rec.OTP.Counter++
+			if _, err := service.UpdateRecord(context.Background(), jwtToken, entry.ID, rec, entry.Version); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to advance the counter: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Code: %s (counter %d)\n", code, rec.OTP.Counter-1)
+			return
+		}
+
+		if !watchOTP {
+			code, remaining, err := records.OTPCode(rec.OTP, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Code: %s (valid for %ds)\n", code, int(remaining.Seconds()))
+			return
+		}
+
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			code, remaining, err := records.OTPCode(rec.OTP, time.Now())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("\rCode: %s (valid for %2ds)", code, int(remaining.Seconds()))
+
+			select {
+			case <-interrupt:
+				fmt.Println()
+				return
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// init registers the otp command and sets up its flags
+func init() {
+	dataCmd.AddCommand(otpCmd)
+
+	otpCmd.Flags().BoolVarP(&watchOTP, "watch", "w", false, "Keep showing the current code until interrupted")
+}
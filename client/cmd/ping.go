@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"time"
+
+	"data-vault/client/internal/grpcclient"
 
 	"github.com/spf13/cobra"
 )
@@ -12,7 +16,7 @@ import (
 var pingCmd = &cobra.Command{
 	Use:   "ping",
 	Short: "Check server connectivity",
-	Long:  "Test the connection to the Data Vault server.",
+	Long:  "Test the connection to the Data Vault server and its database, showing the server version and latency.",
 	Run: func(cmd *cobra.Command, args []string) {
 		service, err := initService()
 		if err != nil {
@@ -20,12 +24,20 @@ var pingCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		if service.PingServer(context.Background()) {
-			fmt.Println("✓ Server is reachable!")
-		} else {
+		res, err := service.Ping(context.Background())
+		if errors.Is(err, grpcclient.ErrorDatabase) {
+			fmt.Printf("✗ Server %s is reachable, but its database is not!\n", res.Version)
+			os.Exit(1)
+		}
+		if err != nil {
 			fmt.Println("✗ Server is not reachable!")
 			os.Exit(1)
 		}
+
+		fmt.Println("✓ Server is reachable!")
+		fmt.Printf("Server version: %s\n", res.Version)
+		fmt.Printf("Round trip: %s\n", res.Latency.Round(time.Microsecond))
+		fmt.Printf("Database: %s\n", res.DBLatency)
 	},
 }
 
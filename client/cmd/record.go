@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// maxInlineBinary is the largest file stored inline with --file, bigger files go through 'data upload'
+const maxInlineBinary = 1 << 20
+
+// Record field variables shared by the post and update commands
+var (
+	recordWebsite  string
+	recordLogin    string
+	recordPassword string
+	recordBank     string
+	recordNumber   string
+	recordHolder   string
+	recordExpiry   string
+	recordCVV      string
+	recordFile     string
+	recordNotes    string
+
+	recordOTPURI    string
+	recordOTPType   string
+	recordIssuer    string
+	recordAccount   string
+	recordSecret    string
+	recordAlgorithm string
+	recordDigits    int
+	recordPeriod    int
+	recordCounter   uint64
+
+	revealSecrets bool
+)
+
+// stdin reads whole lines for interactive prompts
+var stdin = bufio.NewReader(os.Stdin)
+
+// addRecordFlags registers the per-type record fields on a command
+func addRecordFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&dataText, "data", "d", "", "Text to store (text)")
+	cmd.Flags().StringVarP(&dataType, "type", "t", models.DataTypeText, "Type of data (text, password, card, binary, otp)")
+	cmd.Flags().StringVar(&recordWebsite, "website", "", "Website the credentials belong to (password)")
+	cmd.Flags().StringVar(&recordLogin, "login", "", "Login (password)")
+	cmd.Flags().StringVar(&recordPassword, "password", "", "Password (password)")
+	cmd.Flags().StringVar(&recordBank, "bank", "", "Issuing bank (card)")
+	cmd.Flags().StringVar(&recordNumber, "number", "", "Card number (card)")
+	cmd.Flags().StringVar(&recordHolder, "holder", "", "Card holder name (card)")
+	cmd.Flags().StringVar(&recordExpiry, "exp", "", "Card expiry date as MM/YY (card)")
+	cmd.Flags().StringVar(&recordCVV, "cvv", "", "Card CVV (card)")
+	cmd.Flags().StringVar(&recordFile, "file", "", "File to store inline, up to 1 MiB (binary)")
+	cmd.Flags().StringVar(&recordOTPURI, "uri", "", "otpauth:// URI to import, replaces the other otp flags (otp)")
+	cmd.Flags().StringVar(&recordOTPType, "otp-type", models.OTPTypeTOTP, "Code generator, totp or hotp (otp)")
+	cmd.Flags().StringVar(&recordIssuer, "issuer", "", "Service the codes are for (otp)")
+	cmd.Flags().StringVar(&recordAccount, "account", "", "Account name at the service (otp)")
+	cmd.Flags().StringVar(&recordSecret, "secret", "", "Base32 shared secret (otp)")
+	cmd.Flags().StringVar(&recordAlgorithm, "algorithm", "SHA1", "HMAC algorithm, SHA1, SHA256 or SHA512 (otp)")
+	cmd.Flags().IntVar(&recordDigits, "digits", 6, "Number of code digits (otp)")
+	cmd.Flags().IntVar(&recordPeriod, "period", 30, "Seconds each totp code is valid (otp)")
+	cmd.Flags().Uint64Var(&recordCounter, "counter", 0, "Next counter value (hotp)")
+	cmd.Flags().StringVar(&recordNotes, "notes", "", "Free-form notes")
+}
+
+// readRecord builds a record of the given type from the command flags,
+// prompting for required fields that weren't provided
+func readRecord(dataType string) (models.Record, error) {
+	switch dataType {
+	case models.DataTypePassword:
+		promptLine("Website: ", &recordWebsite)
+		promptLine("Login: ", &recordLogin)
+		promptSecret("Password: ", &recordPassword)
+		return models.Record{Password: &models.LoginPasswordData{
+			Website:  recordWebsite,
+			Login:    recordLogin,
+			Password: recordPassword,
+			Notes:    recordNotes,
+		}}, nil
+	case models.DataTypeCard:
+		promptLine("Card number: ", &recordNumber)
+		promptLine("Card holder: ", &recordHolder)
+		promptLine("Expiry (MM/YY): ", &recordExpiry)
+		promptSecret("CVV: ", &recordCVV)
+		month, year, err := parseExpiry(recordExpiry)
+		if err != nil {
+			return models.Record{}, err
+		}
+		return models.Record{Card: &models.BankCardData{
+			Bank:     recordBank,
+			Number:   records.NormalizeCardNumber(recordNumber),
+			Holder:   recordHolder,
+			CVV:      recordCVV,
+			ExpMonth: month,
+			ExpYear:  year,
+			Notes:    recordNotes,
+		}}, nil
+	case models.DataTypeBinary:
+		promptLine("File path: ", &recordFile)
+		binary, err := readInlineFile(recordFile)
+		if err != nil {
+			return models.Record{}, err
+		}
+		binary.Notes = recordNotes
+		return models.Record{Binary: binary}, nil
+	case models.DataTypeOTP:
+		if recordOTPURI == "" && recordSecret == "" {
+			promptSecret("Secret or otpauth:// URI: ", &recordSecret)
+			if strings.HasPrefix(recordSecret, "otpauth://") {
+				recordOTPURI = recordSecret
+			}
+		}
+		if recordOTPURI != "" {
+			otp, err := records.ParseOTPURI(recordOTPURI)
+			if err != nil {
+				return models.Record{}, err
+			}
+			otp.Notes = recordNotes
+			return models.Record{OTP: otp}, nil
+		}
+		otp := &models.OTPData{
+			Type:      recordOTPType,
+			Issuer:    recordIssuer,
+			Account:   recordAccount,
+			Secret:    recordSecret,
+			Algorithm: recordAlgorithm,
+			Digits:    recordDigits,
+			Period:    recordPeriod,
+			Counter:   recordCounter,
+			Notes:     recordNotes,
+		}
+		records.NormalizeOTP(otp)
+		return models.Record{OTP: otp}, nil
+	case models.DataTypeText:
+		promptLine("Enter data to store: ", &dataText)
+		return models.Record{Text: &models.TextData{
+			Content: dataText,
+			Notes:   recordNotes,
+		}}, nil
+	}
+
+	return models.Record{}, fmt.Errorf("%w: %s", records.ErrUnknownType, dataType)
+}
+
+// readInlineFile reads a small file into a binary record
+func readInlineFile(path string) (*models.BinaryData, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxInlineBinary {
+		return nil, fmt.Errorf("%s is larger than 1 MiB, use 'data upload' instead", path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BinaryData{
+		Filename: filepath.Base(path),
+		Content:  content,
+		Size:     int64(len(content)),
+	}, nil
+}
+
+// parseExpiry parses a card expiry date written as MM/YY or MM/YYYY
+func parseExpiry(expiry string) (int, int, error) {
+	month, year, ok := strings.Cut(strings.TrimSpace(expiry), "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("expiry date %q must be written as MM/YY", expiry)
+	}
+
+	m, err := strconv.Atoi(month)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid expiry month %q", month)
+	}
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid expiry year %q", year)
+	}
+	if y < 100 {
+		y += 2000
+	}
+
+	return m, y, nil
+}
+
+// promptLine asks for a value on stdin unless it is already set
+func promptLine(label string, value *string) {
+	if *value != "" {
+		return
+	}
+	fmt.Print(label)
+	line, _ := stdin.ReadString('\n')
+	*value = strings.TrimRight(line, "\r\n")
+}
+
+// promptSecret asks for a value on stdin without echoing it unless it is already set
+func promptSecret(label string, value *string) {
+	if *value != "" {
+		return
+	}
+	fmt.Print(label)
+	secret, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Println()
+	if err != nil {
+		promptLine("", value)
+		return
+	}
+	*value = string(secret)
+}
+
+// parseMetadata parses metadata written as comma separated key=value pairs
+func parseMetadata(text string) (map[string]string, error) {
+	meta := make(map[string]string)
+	for _, pair := range strings.Split(text, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("metadata %q must be written as key=value", pair)
+		}
+		meta[key] = strings.TrimSpace(value)
+	}
+	return meta, nil
+}
+
+// formatMetadata formats metadata as key=value pairs sorted by key
+func formatMetadata(meta map[string]string) string {
+	pairs := make([]string, 0, len(meta))
+	for k, v := range meta {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// printRecord prints the decrypted payload of an entry with each field on its own indented line
+func printRecord(data []byte, reveal bool) {
+	for _, line := range strings.Split(records.Render(records.Decode(data), reveal), "\n") {
+		fmt.Printf("   %s\n", line)
+	}
+}
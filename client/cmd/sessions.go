@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// sessionsCmd represents the sessions command group
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Session operations (list, revoke)",
+	Long: `Every login or registration starts a session on the server. Revoking a session, e.g. of a
+lost device, logs that device out as soon as its access token expires or is checked.`,
+}
+
+// sessionsListCmd lists the live sessions of the user
+var sessionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show devices you are logged in on",
+	Long:  "List the live sessions of your account, most recently used first.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		sessions, err := service.ListSessions(context.Background(), jwtToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list sessions: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(sessions) == 0 {
+			fmt.Println("No active sessions found.")
+			return
+		}
+
+		fmt.Println("Active sessions:")
+		for i, s := range sessions {
+			current := ""
+			if s.Current {
+				current = " (this device)"
+			}
+			fmt.Printf("%d. ID: %s%s\n   Device: %s\n", i+1, s.ID, current, s.Device)
+			fmt.Printf("   Logged in: %s\n   Last used: %s\n   Expires: %s\n\n", s.CreatedAt, s.LastUsedAt, s.ExpiresAt)
+		}
+	},
+}
+
+// sessionsRevokeCmd ends a session of the user
+var sessionsRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Log out another device",
+	Long:  "Revoke a session so that its access and refresh tokens are no longer accepted by the server.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := service.RevokeSession(context.Background(), jwtToken, args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to revoke session: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Session %s revoked successfully!\n", args[0])
+	},
+}
+
+// init registers session commands and sets up their flags
+func init() {
+	rootCmd.AddCommand(sessionsCmd)
+	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsRevokeCmd)
+
+	sessionsCmd.PersistentFlags().StringVar(&jwtToken, "jwt", "", "JWT token for authentication")
+}
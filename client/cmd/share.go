@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/auth"
+	"data-vault/client/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the share and unshare commands
+var (
+	shareWith  string
+	shareWrite bool
+)
+
+// shareCmd grants another user access to a data entry
+var shareCmd = &cobra.Command{
+	Use:   "share <id> --with <login>",
+	Short: "Share a data entry with another user",
+	Long: `Give another user read access to a single data entry, or read and write access with --write.
+The entry's own key is encrypted for the recipient's public key, so the server can't read it and
+the recipient can't read any other entry. Compare the printed key fingerprint with the one the
+recipient sees in 'data shared' to make sure the server handed out the right key.
+Sharing again with the same user replaces the permission.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		if shareWith == "" {
+			fmt.Fprintf(os.Stderr, "Error: login to share with is required\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		permission := models.PermissionRead
+		if shareWrite {
+			permission = models.PermissionWrite
+		}
+
+		fingerprint, err := service.ShareData(context.Background(), jwtToken, args[0], shareWith, permission)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to share data: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Data with ID %s shared with %s (%s)\n", args[0], shareWith, permission)
+		fmt.Printf("Recipient key fingerprint: %s\n", fingerprint)
+	},
+}
+
+// unshareCmd removes the access of another user to a data entry
+var unshareCmd = &cobra.Command{
+	Use:   "unshare <id> --with <login>",
+	Short: "Stop sharing a data entry with another user",
+	Long: `Remove the access of another user to a data entry. The user keeps what they have already
+read, so change shared secrets after revoking access.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		if shareWith == "" {
+			fmt.Fprintf(os.Stderr, "Error: login to share with is required\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := service.RevokeShare(context.Background(), jwtToken, args[0], shareWith); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to revoke share: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Data with ID %s is no longer shared with %s\n", args[0], shareWith)
+	},
+}
+
+// sharedCmd lists the data entries other users shared with the user
+var sharedCmd = &cobra.Command{
+	Use:   "shared",
+	Short: "Show data entries shared with you",
+	Long:  "List the data entries other users shared with you, most recently shared first.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		shared, err := service.ListSharedWithMe(context.Background(), jwtToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list shared data: %v\n", err)
+			os.Exit(1)
+		}
+
+		if fingerprint, err := service.SharingFingerprint(); err == nil {
+			fmt.Printf("Your key fingerprint: %s\n\n", fingerprint)
+		}
+
+		if len(shared) == 0 {
+			fmt.Println("Nothing is shared with you.")
+			return
+		}
+
+		fmt.Println("Shared with me:")
+		for i, item := range shared {
+			fmt.Printf("%d. ID: %s\n   Type: %s\n", i+1, item.Data.ID, item.Data.Type)
+			printRecord(item.Data.Data, revealSecrets)
+			if len(item.Data.Metadata) > 0 {
+				fmt.Printf("   Meta: %s\n", formatMetadata(item.Data.Metadata))
+			}
+			fmt.Printf("   Owner: %s (%s)\n   Shared: %s\n\n", item.Owner, item.Permission, item.SharedAt)
+		}
+	},
+}
+
+// init registers sharing commands and sets up their flags
+func init() {
+	shareCmd.Flags().StringVar(&shareWith, "with", "", "Login of the user to share with")
+	shareCmd.Flags().BoolVar(&shareWrite, "write", false, "Allow the user to update the entry")
+
+	unshareCmd.Flags().StringVar(&shareWith, "with", "", "Login of the user to stop sharing with")
+
+	sharedCmd.Flags().BoolVar(&revealSecrets, "reveal", false, "Show passwords, card numbers and CVVs in clear text")
+
+	dataCmd.AddCommand(shareCmd)
+	dataCmd.AddCommand(unshareCmd)
+	dataCmd.AddCommand(sharedCmd)
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// fullSync forces a sync from the beginning of the change feed
+var fullSync bool
+
+// syncCmd fetches the changes made on the server since the last sync
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fetch changes since the last sync",
+	Long: `Fetch data created, updated and deleted on the server since the last sync
+from this machine. The sync cursor is saved next to the auth config, so only
+new changes are shown on the next run. Use --full to start from scratch.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		username, err := auth.LoadUsername()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+			os.Exit(1)
+		}
+
+		var cursor int64
+		if !fullSync {
+			cursor, err = auth.LoadCursor(username)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading sync cursor: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		res, err := service.Sync(context.Background(), jwtToken, cursor, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to sync data: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(res.Created) == 0 && len(res.Updated) == 0 && len(res.Deleted) == 0 {
+			fmt.Println("Already up to date.")
+		}
+
+		for _, item := range res.Created {
+			fmt.Printf("+ ID: %s\n   Type: %s\n", item.ID, item.Type)
+			printRecord(item.Data, false)
+			fmt.Printf("   Version: %d\n\n", item.Version)
+		}
+		for _, item := range res.Updated {
+			fmt.Printf("~ ID: %s\n   Type: %s\n", item.ID, item.Type)
+			printRecord(item.Data, false)
+			fmt.Printf("   Version: %d\n\n", item.Version)
+		}
+		for _, item := range res.Deleted {
+			fmt.Printf("- ID: %s\n   Deleted: %s\n\n", item.ID, item.DeletedAt)
+		}
+		for _, c := range res.Conflicts {
+			fmt.Printf("! Conflict on ID %s: local version %d is stale\n", c.ID, c.ClientVersion)
+		}
+
+		if err := auth.SaveCursor(username, res.Cursor); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving sync cursor: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Synced %d created, %d updated, %d deleted (cursor %d)\n",
+			len(res.Created), len(res.Updated), len(res.Deleted), res.Cursor)
+	},
+}
+
+// init registers the sync command and sets up its flags
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().StringVar(&jwtToken, "jwt", "", "JWT token for authentication")
+	syncCmd.Flags().BoolVar(&fullSync, "full", false, "Ignore the saved cursor and fetch everything")
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"data-vault/client/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// totpCmd represents the two-factor authentication command group
+var totpCmd = &cobra.Command{
+	Use:   "totp",
+	Short: "Two-factor authentication operations (enable)",
+	Long: `With two-factor authentication, logging in also asks for a code of an authenticator app
+(Google Authenticator, Aegis, 1Password, ...) or one of the single use recovery codes.`,
+}
+
+// totpEnableCmd sets up two-factor authentication for the account
+var totpEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Enable two-factor authentication",
+	Long: `Generate a secret for your authenticator app and turn on two-factor authentication once a
+code of the app is confirmed. Store the printed recovery codes in a safe place, each of them can
+replace a code once if the app is lost.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		setup, err := service.EnableTOTP(context.Background(), jwtToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to enable two-factor authentication: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Add this account to your authenticator app:")
+		fmt.Printf("Secret: %s\n", setup.Secret)
+		fmt.Printf("URI: %s\n\n", setup.URI)
+
+		if totpCode == "" {
+			fmt.Print("Code from the app: ")
+			fmt.Scanln(&totpCode)
+		}
+
+		codes, err := service.ConfirmTOTP(context.Background(), jwtToken, totpCode)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to confirm two-factor authentication: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Println("Two-factor authentication enabled!")
+		fmt.Println("Recovery codes, each can be used once instead of a code:")
+		for _, code := range codes {
+			fmt.Printf("  %s\n", code)
+		}
+	},
+}
+
+// init registers two-factor authentication commands and sets up their flags
+func init() {
+	rootCmd.AddCommand(totpCmd)
+	totpCmd.AddCommand(totpEnableCmd)
+
+	totpCmd.PersistentFlags().StringVar(&jwtToken, "jwt", "", "JWT token for authentication")
+	totpEnableCmd.Flags().StringVar(&totpCode, "code", "", "Code from the authenticator app")
+}
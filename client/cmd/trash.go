@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"data-vault/client/internal/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// emptyConfirmed skips the confirmation prompt of the empty command
+var emptyConfirmed bool
+
+// trashCmd represents the trash command group
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Trash operations (list, restore, empty)",
+	Long: `Deleted data entries are moved to the trash, where they can be restored until
+the server purges them after its retention period or the trash is emptied.`,
+}
+
+// trashListCmd lists the trashed data entries
+var trashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show deleted data entries",
+	Long:  "List the data entries in the trash, most recently deleted first.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		data, err := service.ListTrash(context.Background(), jwtToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list trash: %v\n", err)
+			os.Exit(1)
+		}
+
+		if len(data) == 0 {
+			fmt.Println("Trash is empty.")
+			return
+		}
+
+		fmt.Println("Deleted data:")
+		for i, item := range data {
+			fmt.Printf("%d. ID: %s\n   Type: %s\n", i+1, item.ID, item.Type)
+			printRecord(item.Data, revealSecrets)
+			if len(item.Metadata) > 0 {
+				fmt.Printf("   Meta: %s\n", formatMetadata(item.Metadata))
+			}
+			fmt.Printf("   Deleted: %s\n\n", item.DeletedAt)
+		}
+	},
+}
+
+// trashRestoreCmd moves a trashed data entry back to the vault
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore <id>",
+	Short: "Restore a deleted data entry",
+	Long:  "Move a data entry from the trash back to the vault, keeping its ID and version.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		version, err := service.RestoreFromTrash(context.Background(), jwtToken, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to restore data: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Data with ID %s restored successfully! Version: %d\n", args[0], version)
+	},
+}
+
+// trashEmptyCmd permanently removes the trashed data entries
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently remove deleted data entries",
+	Long:  "Permanently remove every data entry in the trash together with its version history. This can't be undone.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if jwtToken == "" {
+			savedJWT, err := auth.LoadJWT()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading saved credentials: %v\n", err)
+				os.Exit(1)
+			}
+			jwtToken = savedJWT
+		}
+
+		if jwtToken == "" {
+			fmt.Fprintf(os.Stderr, "Error: Not authenticated. Please login first with 'data-vault-client login'\n")
+			os.Exit(1)
+		}
+
+		if !emptyConfirmed {
+			fmt.Print("Permanently remove all deleted data? [y/N] ")
+			answer, _ := stdin.ReadString('\n')
+			if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+				fmt.Println("Aborted.")
+				return
+			}
+		}
+
+		service, err := initService()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing service: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := loadKey(service); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading encryption key: %v\n", err)
+			os.Exit(1)
+		}
+
+		purged, err := service.EmptyTrash(context.Background(), jwtToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to empty trash: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Trash emptied, %d entries removed.\n", purged)
+	},
+}
+
+// init registers trash commands and sets up their flags
+func init() {
+	dataCmd.AddCommand(trashCmd)
+	trashCmd.AddCommand(trashListCmd)
+	trashCmd.AddCommand(trashRestoreCmd)
+	trashCmd.AddCommand(trashEmptyCmd)
+
+	trashListCmd.Flags().BoolVar(&revealSecrets, "reveal", false, "Show passwords, card numbers and CVVs in clear text")
+	trashEmptyCmd.Flags().BoolVarP(&emptyConfirmed, "yes", "y", false, "Don't ask for confirmation")
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+	"data-vault/client/internal/services"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -19,12 +23,26 @@ const (
 	loginView
 	registerView
 	dataMenuView
+	recordTypeView
 	postDataView
 	getDataView
+	sharedView
 	deleteDataView
 	pingView
 )
 
+// reconnectInterval is how often queued offline operations are retried
+const reconnectInterval = 10 * time.Second
+
+// tuiPageSize is the number of entries shown per page of the data list
+const tuiPageSize = 10
+
+// otpRefreshInterval is how often the codes of otp entries in the data list are recomputed
+const otpRefreshInterval = time.Second
+
+// dataChoices are the entries of the data operations menu
+var dataChoices = []string{"Post Data", "Get Data", "Shared with Me", "Delete Data", "Back to Main Menu"}
+
 // model represents the complete TUI application state
 type model struct {
 	state      sessionState
@@ -33,15 +51,54 @@ type model struct {
 	selected   map[int]struct{}
 	username   string
 	password   string
-	data       string
-	dataType   string
+	challenge  string
+	totpCode   string
+	form       recordForm
 	dataID     string
 	jwtToken   string
+	tokens     *sessionTokens
+	key        []byte
+	login      string
 	message    string
 	inputMode  bool
 	inputField string
 	userData   []models.Data
+	shared     []models.SharedData
+	reveal     bool
+	otpTicking bool
+
+	pageToken  string
+	nextToken  string
+	prevTokens []string
 	err        error
+
+	offline      bool
+	pending      int
+	reconnecting bool
+}
+
+// sessionTokens keeps the token pair of the TUI session in memory, so that the services
+// created for each command renew an expired access token only once
+type sessionTokens struct {
+	mu           sync.Mutex
+	jwt          string
+	refreshToken string
+}
+
+// LoadTokens returns the current token pair
+func (t *sessionTokens) LoadTokens() (string, string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.jwt, t.refreshToken, nil
+}
+
+// SaveTokens replaces the token pair after a renewal
+func (t *sessionTokens) SaveTokens(jwt, refreshToken string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.jwt = jwt
+	t.refreshToken = refreshToken
+	return nil
 }
 
 // initialModel creates and returns the initial TUI model
@@ -72,18 +129,38 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateRegister(msg)
 		case dataMenuView:
 			return m.updateDataMenu(msg)
+		case recordTypeView:
+			return m.updateRecordType(msg)
 		case postDataView:
 			return m.updatePostData(msg)
 		case getDataView:
 			return m.updateGetData(msg)
+		case sharedView:
+			return m.updateShared(msg)
 		case deleteDataView:
 			return m.updateDeleteData(msg)
 		case pingView:
 			return m.updatePing(msg)
 		}
 	case loginMsg:
+		if msg.success && msg.challenge != "" {
+			m.challenge = msg.challenge
+			m.totpCode = ""
+			m.inputField = "code"
+			m.message = "Two-factor authentication is enabled for this account."
+			return m, nil
+		}
+		if !msg.success && m.challenge != "" {
+			m.totpCode = ""
+			m.message = fmt.Sprintf("Login failed: %v", msg.err)
+			return m, nil
+		}
 		if msg.success {
+			m.challenge = ""
 			m.jwtToken = msg.token
+			m.tokens = &sessionTokens{jwt: msg.token, refreshToken: msg.refreshToken}
+			m.key = msg.key
+			m.login = msg.login
 			m.message = "Login successful! JWT token received."
 			m.state = dataMenuView
 			m.cursor = 0
@@ -94,6 +171,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case registerMsg:
 		if msg.success {
 			m.jwtToken = msg.token
+			m.tokens = &sessionTokens{jwt: msg.token, refreshToken: msg.refreshToken}
+			m.key = msg.key
+			m.login = msg.login
 			m.message = "Registration successful! JWT token received."
 			m.state = dataMenuView
 			m.cursor = 0
@@ -102,7 +182,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.resetInput()
 	case postDataMsg:
-		if msg.success {
+		if msg.success && msg.offline {
+			m.message = "Server is unreachable, data queued locally."
+		} else if msg.success {
 			m.message = "Data posted successfully!"
 		} else {
 			m.message = fmt.Sprintf("Failed to post data: %v", msg.err)
@@ -110,19 +192,58 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = dataMenuView
 		m.cursor = 0
 		m.resetInput()
+		return m.setConnectivity(msg.offline, msg.pending, msg.rejected)
 	case getDataMsg:
 		if msg.err != nil {
 			m.message = fmt.Sprintf("Failed to get data: %v", msg.err)
 		} else {
 			m.userData = msg.data
+			m.nextToken = msg.nextToken
 			if len(msg.data) == 0 {
 				m.message = "No data found."
+			} else if msg.offline {
+				m.message = fmt.Sprintf("Showing %d cached data items.", len(msg.data))
 			} else {
 				m.message = fmt.Sprintf("Retrieved %d data items.", len(msg.data))
 			}
 		}
+
+		var tick tea.Cmd
+		if !m.otpTicking && hasOTP(m.userData) {
+			m.otpTicking = true
+			tick = otpTickCmd()
+		}
+		next, cmd := m.setConnectivity(msg.offline, msg.pending, msg.rejected)
+		return next, tea.Batch(cmd, tick)
+	case sharedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Failed to get shared data: %v", msg.err)
+			return m, nil
+		}
+
+		m.shared = msg.shared
+		if len(msg.shared) == 0 {
+			m.message = "Nothing is shared with you."
+		} else {
+			m.message = fmt.Sprintf("Retrieved %d shared items.", len(msg.shared))
+		}
+
+		if !m.otpTicking && hasOTP(sharedEntries(m.shared)) {
+			m.otpTicking = true
+			return m, otpTickCmd()
+		}
+	case otpTickMsg:
+		visible := (m.state == getDataView && hasOTP(m.userData)) ||
+			(m.state == sharedView && hasOTP(sharedEntries(m.shared)))
+		if !visible {
+			m.otpTicking = false
+			return m, nil
+		}
+		return m, otpTickCmd()
 	case deleteDataMsg:
-		if msg.success {
+		if msg.success && msg.offline {
+			m.message = "Server is unreachable, delete queued locally."
+		} else if msg.success {
 			m.message = "Data deleted successfully!"
 		} else {
 			m.message = fmt.Sprintf("Failed to delete data: %v", msg.err)
@@ -130,6 +251,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = dataMenuView
 		m.cursor = 0
 		m.resetInput()
+		return m.setConnectivity(msg.offline, msg.pending, msg.rejected)
+	case reconnectMsg:
+		m.reconnecting = false
+		return m, m.flushCmd()
+	case flushMsg:
+		if msg.err == nil && msg.replayed > 0 {
+			m.message = fmt.Sprintf("Back online, %d queued operations sent.", msg.replayed)
+		}
+		return m.setConnectivity(msg.offline, msg.pending, msg.rejected)
 	case pingMsg:
 		if msg.success {
 			m.message = "✓ Server is reachable!"
@@ -183,18 +313,24 @@ func (m model) updateLogin(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		m.state = mainMenuView
 		m.cursor = 0
+		m.challenge = ""
+		m.totpCode = ""
 		m.resetInput()
 	case "enter":
 		if m.inputField == "username" && m.username != "" {
 			m.inputField = "password"
 		} else if m.inputField == "password" && m.password != "" {
 			return m, m.loginCmd()
+		} else if m.inputField == "code" && m.totpCode != "" {
+			return m, m.verifyTOTPCmd()
 		}
 	case "backspace":
 		if m.inputField == "username" && len(m.username) > 0 {
 			m.username = m.username[:len(m.username)-1]
 		} else if m.inputField == "password" && len(m.password) > 0 {
 			m.password = m.password[:len(m.password)-1]
+		} else if m.inputField == "code" && len(m.totpCode) > 0 {
+			m.totpCode = m.totpCode[:len(m.totpCode)-1]
 		}
 	default:
 		if len(msg.String()) == 1 {
@@ -202,6 +338,8 @@ func (m model) updateLogin(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.username += msg.String()
 			} else if m.inputField == "password" {
 				m.password += msg.String()
+			} else if m.inputField == "code" {
+				m.totpCode += msg.String()
 			}
 		}
 	}
@@ -243,8 +381,6 @@ func (m model) updateRegister(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateDataMenu handles data operations menu navigation
 func (m model) updateDataMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	dataChoices := []string{"Post Data", "Get Data", "Delete Data", "Back to Main Menu"}
-
 	switch msg.String() {
 	case "ctrl+c", "q":
 		return m, tea.Quit
@@ -262,19 +398,27 @@ func (m model) updateDataMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter", " ":
 		switch m.cursor {
 		case 0:
-			m.state = postDataView
-			m.inputMode = true
-			m.inputField = "data"
+			m.state = recordTypeView
+			m.cursor = 0
 			m.message = ""
 		case 1:
 			m.state = getDataView
+			m.reveal = false
+			m.pageToken = ""
+			m.nextToken = ""
+			m.prevTokens = nil
 			return m, m.getDataCmd()
 		case 2:
+			m.state = sharedView
+			m.reveal = false
+			m.shared = nil
+			return m, m.getSharedCmd()
+		case 3:
 			m.state = deleteDataView
 			m.inputMode = true
 			m.inputField = "dataID"
 			m.message = ""
-		case 3:
+		case 4:
 			m.state = mainMenuView
 			m.cursor = 0
 		}
@@ -282,6 +426,31 @@ func (m model) updateDataMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateRecordType handles record type selection before posting data
+func (m model) updateRecordType(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.state = dataMenuView
+		m.cursor = 0
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(recordTypes)-1 {
+			m.cursor++
+		}
+	case "enter", " ":
+		m.form = newRecordForm(recordTypes[m.cursor])
+		m.state = postDataView
+		m.inputMode = true
+		m.message = ""
+	}
+	return m, nil
+}
+
 // updatePostData handles post data form input
 func (m model) updatePostData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -291,17 +460,31 @@ func (m model) updatePostData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = dataMenuView
 		m.cursor = 0
 		m.resetInput()
+	case "tab", "down":
+		m.form.next()
+	case "shift+tab", "up":
+		m.form.prev()
 	case "enter":
-		if m.data != "" {
-			return m, m.postDataCmd()
+		if !m.form.last() {
+			m.form.next()
+			return m, nil
 		}
-	case "backspace":
-		if len(m.data) > 0 {
-			m.data = m.data[:len(m.data)-1]
+		record, err := m.form.record()
+		if err != nil {
+			m.message = fmt.Sprintf("Error: %v", err)
+			return m, nil
 		}
+		meta, err := m.form.metadata()
+		if err != nil {
+			m.message = fmt.Sprintf("Error: %v", err)
+			return m, nil
+		}
+		return m, m.postDataCmd(record, meta)
+	case "backspace":
+		m.form.backspace()
 	default:
-		if len(msg.String()) == 1 {
-			m.data += msg.String()
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.form.input(string(msg.Runes))
 		}
 	}
 	return m, nil
@@ -312,6 +495,34 @@ func (m model) updateGetData(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
 		return m, tea.Quit
+	case "r":
+		m.reveal = !m.reveal
+	case "n", "right":
+		if m.nextToken != "" {
+			m.prevTokens = append(m.prevTokens, m.pageToken)
+			m.pageToken = m.nextToken
+			return m, m.getDataCmd()
+		}
+	case "p", "left":
+		if len(m.prevTokens) > 0 {
+			m.pageToken = m.prevTokens[len(m.prevTokens)-1]
+			m.prevTokens = m.prevTokens[:len(m.prevTokens)-1]
+			return m, m.getDataCmd()
+		}
+	case "esc", "enter":
+		m.state = dataMenuView
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+// updateShared handles navigation of the data shared with the user
+func (m model) updateShared(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "r":
+		m.reveal = !m.reveal
 	case "esc", "enter":
 		m.state = dataMenuView
 		m.cursor = 0
@@ -356,48 +567,143 @@ func (m model) updatePing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// setConnectivity records the offline state, reports the queued operations the server rejected
+// and schedules a retry of queued operations while offline
+func (m model) setConnectivity(offline bool, pending int, rejected string) (tea.Model, tea.Cmd) {
+	m.offline = offline
+	m.pending = pending
+	if rejected != "" {
+		m.message = strings.TrimSpace(m.message + "\n" + rejected)
+	}
+
+	if !offline || m.reconnecting {
+		return m, nil
+	}
+
+	m.reconnecting = true
+	return m, tea.Tick(reconnectInterval, func(time.Time) tea.Msg {
+		return reconnectMsg{}
+	})
+}
+
+// otpTickCmd schedules the next refresh of the otp codes in the data list
+func otpTickCmd() tea.Cmd {
+	return tea.Tick(otpRefreshInterval, func(time.Time) tea.Msg {
+		return otpTickMsg{}
+	})
+}
+
+// hasOTP reports whether any of the entries is an otp record
+func hasOTP(data []models.Data) bool {
+	for _, item := range data {
+		if item.Type == models.DataTypeOTP {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedEntries returns the data entries of shared records
+func sharedEntries(shared []models.SharedData) []models.Data {
+	data := make([]models.Data, 0, len(shared))
+	for _, item := range shared {
+		data = append(data, item.Data)
+	}
+	return data
+}
+
+// otpLine formats the current code of an otp record with the seconds it stays valid
+func otpLine(otp *models.OTPData, now time.Time) string {
+	code, remaining, err := records.OTPCode(otp, now)
+	if err != nil {
+		return fmt.Sprintf("Code: %v", err)
+	}
+	if otp.Type == models.OTPTypeHOTP {
+		return fmt.Sprintf("Code: %s (counter %d)", code, otp.Counter)
+	}
+	return fmt.Sprintf("Code: %s (%ds left)", code, int(remaining.Seconds()))
+}
+
 // resetInput clears all input fields and resets input state
 func (m model) resetInput() {
 	m.username = ""
 	m.password = ""
-	m.data = ""
-	m.dataType = ""
+	m.form = recordForm{}
 	m.dataID = ""
 	m.inputMode = false
 	m.inputField = ""
 	m.message = ""
 }
 
-// loginMsg represents the result of a login operation
+// loginMsg represents the result of a login operation. A challenge means the login still
+// needs a two-factor code.
 type loginMsg struct {
-	success bool
-	token   string
-	err     error
+	success      bool
+	challenge    string
+	token        string
+	refreshToken string
+	key          []byte
+	login        string
+	err          error
 }
 
 // registerMsg represents the result of a registration operation
 type registerMsg struct {
-	success bool
-	token   string
-	err     error
+	success      bool
+	token        string
+	refreshToken string
+	key          []byte
+	login        string
+	err          error
 }
 
 // postDataMsg represents the result of a post data operation
 type postDataMsg struct {
-	success bool
-	err     error
+	success  bool
+	offline  bool
+	pending  int
+	rejected string
+	err      error
 }
 
 // getDataMsg represents the result of a get data operation
 type getDataMsg struct {
-	data []models.Data
-	err  error
+	data      []models.Data
+	nextToken string
+	offline   bool
+	pending   int
+	rejected  string
+	err       error
+}
+
+// sharedMsg represents the result of listing the data shared with the user
+type sharedMsg struct {
+	shared []models.SharedData
+	err    error
 }
 
 // deleteDataMsg represents the result of a delete data operation
 type deleteDataMsg struct {
-	success bool
-	err     error
+	success  bool
+	offline  bool
+	pending  int
+	rejected string
+	err      error
+}
+
+// reconnectMsg triggers a retry of operations queued while offline
+type reconnectMsg struct{}
+
+// otpTickMsg redraws the data list so that the codes of otp entries stay current
+type otpTickMsg struct{}
+
+// flushMsg represents the result of replaying queued operations
+type flushMsg struct {
+	replayed int
+	offline  bool
+	pending  int
+	rejected string
+	err      error
 }
 
 // pingMsg represents the result of a server ping operation
@@ -415,12 +721,34 @@ func (m model) loginCmd() tea.Cmd {
 		}
 
 		user := models.User{Login: m.username, Password: m.password}
-		jwt, err := service.Login(context.Background(), user)
+		session, err := service.Login(context.Background(), user)
 		if err != nil {
 			return loginMsg{success: false, err: err}
 		}
 
-		return loginMsg{success: true, token: jwt}
+		if session.TOTPRequired {
+			return loginMsg{success: true, challenge: session.ChallengeToken, login: user.Login}
+		}
+
+		return loginMsg{success: true, token: session.JWT, refreshToken: session.RefreshToken, key: service.Key(), login: user.Login}
+	}
+}
+
+// verifyTOTPCmd creates a command to complete a login with a two-factor code
+func (m model) verifyTOTPCmd() tea.Cmd {
+	return func() tea.Msg {
+		service, err := initService()
+		if err != nil {
+			return loginMsg{success: false, err: err}
+		}
+
+		user := models.User{Login: m.username, Password: m.password}
+		session, err := service.VerifyTOTP(context.Background(), user, m.challenge, m.totpCode)
+		if err != nil {
+			return loginMsg{success: false, err: err}
+		}
+
+		return loginMsg{success: true, token: session.JWT, refreshToken: session.RefreshToken, key: service.Key(), login: user.Login}
 	}
 }
 
@@ -433,58 +761,91 @@ func (m model) registerCmd() tea.Cmd {
 		}
 
 		user := models.User{Login: m.username, Password: m.password}
-		jwt, err := service.Register(context.Background(), user)
+		session, err := service.Register(context.Background(), user)
 		if err != nil {
 			return registerMsg{success: false, err: err}
 		}
 
-		return registerMsg{success: true, token: jwt}
+		return registerMsg{success: true, token: session.JWT, refreshToken: session.RefreshToken, key: service.Key(), login: user.Login}
+	}
+}
+
+// newService initializes the service with the session key and the user's local cache
+func (m model) newService() (*services.Vault, error) {
+	service, err := initService()
+	if err != nil {
+		return nil, err
 	}
+
+	service.SetKey(m.key)
+	if m.tokens != nil {
+		service.SetTokenStore(m.tokens)
+	}
+	if err := openCache(service, m.login, m.key); err != nil {
+		return nil, err
+	}
+
+	return service, nil
 }
 
-// postDataCmd creates a command to post data to the server
-func (m model) postDataCmd() tea.Cmd {
+// postDataCmd creates a command to post a record to the server
+func (m model) postDataCmd(record models.Record, meta map[string]string) tea.Cmd {
 	return func() tea.Msg {
-		service, err := initService()
+		service, err := m.newService()
 		if err != nil {
 			return postDataMsg{success: false, err: err}
 		}
 
-		dataType := m.dataType
-		if dataType == "" {
-			dataType = "text"
-		}
-
-		err = service.PostData(context.Background(), m.jwtToken, dataType, []byte(m.data))
+		err = service.PostRecord(context.Background(), m.jwtToken, record, meta)
 		if err != nil {
 			return postDataMsg{success: false, err: err}
 		}
 
-		return postDataMsg{success: true}
+		return postDataMsg{success: true, offline: service.Offline(), pending: service.PendingCount(), rejected: rejectedNotice(service)}
 	}
 }
 
 // getDataCmd creates a command to retrieve data from the server
 func (m model) getDataCmd() tea.Cmd {
 	return func() tea.Msg {
-		service, err := initService()
+		service, err := m.newService()
 		if err != nil {
 			return getDataMsg{err: err}
 		}
 
-		data, err := service.GetData(context.Background(), m.jwtToken)
+		page, err := service.GetDataPage(context.Background(), m.jwtToken, models.DataQuery{
+			PageSize:  tuiPageSize,
+			PageToken: m.pageToken,
+		})
 		if err != nil {
 			return getDataMsg{err: err}
 		}
 
-		return getDataMsg{data: data}
+		return getDataMsg{data: page.Data, nextToken: page.NextPageToken, offline: service.Offline(), pending: service.PendingCount(), rejected: rejectedNotice(service)}
+	}
+}
+
+// getSharedCmd creates a command to retrieve the data other users shared with the user
+func (m model) getSharedCmd() tea.Cmd {
+	return func() tea.Msg {
+		service, err := m.newService()
+		if err != nil {
+			return sharedMsg{err: err}
+		}
+
+		shared, err := service.ListSharedWithMe(context.Background(), m.jwtToken)
+		if err != nil {
+			return sharedMsg{err: err}
+		}
+
+		return sharedMsg{shared: shared}
 	}
 }
 
 // deleteDataCmd creates a command to delete data from the server
 func (m model) deleteDataCmd() tea.Cmd {
 	return func() tea.Msg {
-		service, err := initService()
+		service, err := m.newService()
 		if err != nil {
 			return deleteDataMsg{success: false, err: err}
 		}
@@ -494,7 +855,24 @@ func (m model) deleteDataCmd() tea.Cmd {
 			return deleteDataMsg{success: false, err: err}
 		}
 
-		return deleteDataMsg{success: true}
+		return deleteDataMsg{success: true, offline: service.Offline(), pending: service.PendingCount(), rejected: rejectedNotice(service)}
+	}
+}
+
+// flushCmd creates a command to replay operations queued while offline
+func (m model) flushCmd() tea.Cmd {
+	return func() tea.Msg {
+		service, err := m.newService()
+		if err != nil {
+			return flushMsg{offline: m.offline, pending: m.pending, err: err}
+		}
+
+		replayed, err := service.Flush(context.Background(), m.jwtToken)
+		if err == nil && !service.Offline() {
+			// Nothing left to replay doesn't prove the server is back, fetching does
+			_, err = service.GetData(context.Background(), m.jwtToken, nil)
+		}
+		return flushMsg{replayed: replayed, offline: service.Offline(), pending: service.PendingCount(), rejected: rejectedNotice(service), err: err}
 	}
 }
 
@@ -547,6 +925,13 @@ func (m model) View() string {
 	// Show JWT status if logged in
 	if m.jwtToken != "" {
 		s.WriteString(messageStyle.Render("✓ Authenticated"))
+		if m.offline {
+			s.WriteString("  ")
+			s.WriteString(errorStyle.Render(fmt.Sprintf("● Offline, %d pending operations", m.pending)))
+		} else if m.pending > 0 {
+			s.WriteString("  ")
+			s.WriteString(inputStyle.Render(fmt.Sprintf("%d pending operations", m.pending)))
+		}
 		s.WriteString("\n\n")
 	}
 
@@ -575,7 +960,16 @@ func (m model) View() string {
 		if m.inputField == "password" {
 			s.WriteString("█")
 		}
-		s.WriteString("\n\nPress Enter to continue, Esc to go back")
+		s.WriteString("\n")
+
+		if m.challenge != "" {
+			s.WriteString(fmt.Sprintf("Two-factor code (or recovery code): %s\n", inputStyle.Render(m.totpCode)))
+			if m.inputField == "code" {
+				s.WriteString("█")
+			}
+			s.WriteString("\n")
+		}
+		s.WriteString("\nPress Enter to continue, Esc to go back")
 
 	case registerView:
 		s.WriteString("Register\n\n")
@@ -598,7 +992,6 @@ func (m model) View() string {
 			s.WriteString("\n\nPress Esc to go back to main menu")
 		} else {
 			s.WriteString("Data Operations:\n\n")
-			dataChoices := []string{"Post Data", "Get Data", "Delete Data", "Back to Main Menu"}
 			for i, choice := range dataChoices {
 				cursor := " "
 				if m.cursor == i {
@@ -609,25 +1002,81 @@ func (m model) View() string {
 			}
 		}
 
+	case recordTypeView:
+		s.WriteString("Choose the type of data to post:\n\n")
+		for i, choice := range recordTypes {
+			cursor := " "
+			if m.cursor == i {
+				cursor = ">"
+				choice = selectedStyle.Render(choice)
+			}
+			s.WriteString(fmt.Sprintf("%s %s\n", cursor, choice))
+		}
+		s.WriteString("\nPress Enter to select, Esc to go back")
+
 	case postDataView:
-		s.WriteString("Post Data\n\n")
-		s.WriteString(fmt.Sprintf("Data: %s\n", inputStyle.Render(m.data)))
-		s.WriteString("█")
-		s.WriteString("\n\nPress Enter to submit, Esc to go back")
+		s.WriteString(fmt.Sprintf("Post %s\n\n", m.form.dataType))
+		s.WriteString(m.form.view())
+		s.WriteString("\nTab moves between fields, Enter on the last field submits, Esc to go back")
 
 	case getDataView:
 		s.WriteString("Your Data:\n\n")
 		if len(m.userData) == 0 {
 			s.WriteString("No data found.")
 		} else {
+			first := len(m.prevTokens)*tuiPageSize + 1
 			for i, item := range m.userData {
-				s.WriteString(fmt.Sprintf("%d. ID: %s\n", i+1, item.ID))
+				id := item.ID
+				if id == "" {
+					id = "(pending)"
+				}
+				s.WriteString(fmt.Sprintf("%d. ID: %s\n", first+i, id))
 				s.WriteString(fmt.Sprintf("   Type: %s\n", item.Type))
-				s.WriteString(fmt.Sprintf("   Data: %s\n", string(item.Data)))
+				rec := records.Decode(item.Data)
+				for _, line := range strings.Split(records.Render(rec, m.reveal), "\n") {
+					s.WriteString(fmt.Sprintf("   %s\n", line))
+				}
+				if rec.OTP != nil {
+					s.WriteString(fmt.Sprintf("   %s\n", otpLine(rec.OTP, time.Now())))
+				}
+				if len(item.Metadata) > 0 {
+					s.WriteString(fmt.Sprintf("   Meta: %s\n", formatMetadata(item.Metadata)))
+				}
 				s.WriteString(fmt.Sprintf("   Uploaded: %s\n\n", item.UploadedAt))
 			}
 		}
-		s.WriteString("\nPress Enter or Esc to go back")
+		s.WriteString(fmt.Sprintf("\nPage %d", len(m.prevTokens)+1))
+		if len(m.prevTokens) > 0 {
+			s.WriteString(", p for previous")
+		}
+		if m.nextToken != "" {
+			s.WriteString(", n for next")
+		}
+		s.WriteString("\nPress r to reveal or hide secrets, Enter or Esc to go back")
+
+	case sharedView:
+		s.WriteString("Shared with Me:\n\n")
+		if len(m.shared) == 0 {
+			s.WriteString("Nothing is shared with you.")
+		} else {
+			for i, item := range m.shared {
+				s.WriteString(fmt.Sprintf("%d. ID: %s\n", i+1, item.Data.ID))
+				s.WriteString(fmt.Sprintf("   Type: %s\n", item.Data.Type))
+				rec := records.Decode(item.Data.Data)
+				for _, line := range strings.Split(records.Render(rec, m.reveal), "\n") {
+					s.WriteString(fmt.Sprintf("   %s\n", line))
+				}
+				if rec.OTP != nil {
+					s.WriteString(fmt.Sprintf("   %s\n", otpLine(rec.OTP, time.Now())))
+				}
+				if len(item.Data.Metadata) > 0 {
+					s.WriteString(fmt.Sprintf("   Meta: %s\n", formatMetadata(item.Data.Metadata)))
+				}
+				s.WriteString(fmt.Sprintf("   Owner: %s (%s)\n", item.Owner, item.Permission))
+				s.WriteString(fmt.Sprintf("   Shared: %s\n\n", item.SharedAt))
+			}
+		}
+		s.WriteString("\nPress r to reveal or hide secrets, Enter or Esc to go back")
 
 	case deleteDataView:
 		s.WriteString("Delete Data\n\n")
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+)
+
+// recordTypes lists the record types offered when posting data, in menu order
+var recordTypes = []string{models.DataTypeText, models.DataTypePassword, models.DataTypeCard, models.DataTypeBinary, models.DataTypeOTP}
+
+// formField is a single labelled input of a record form
+type formField struct {
+	label  string
+	value  string
+	secret bool
+}
+
+// recordForm holds the inputs of the record being posted from the TUI
+type recordForm struct {
+	dataType string
+	fields   []formField
+	focus    int
+}
+
+// newRecordForm creates an empty form with the fields of the given record type
+func newRecordForm(dataType string) recordForm {
+	var fields []formField
+	switch dataType {
+	case models.DataTypePassword:
+		fields = []formField{{label: "Website"}, {label: "Login"}, {label: "Password", secret: true}}
+	case models.DataTypeCard:
+		fields = []formField{{label: "Bank"}, {label: "Number"}, {label: "Holder"}, {label: "Expiry (MM/YY)"}, {label: "CVV", secret: true}}
+	case models.DataTypeBinary:
+		fields = []formField{{label: "File path"}}
+	case models.DataTypeOTP:
+		fields = []formField{{label: "Secret or otpauth:// URI", secret: true}, {label: "Issuer"}, {label: "Account"}}
+	default:
+		dataType = models.DataTypeText
+		fields = []formField{{label: "Text"}}
+	}
+
+	return recordForm{
+		dataType: dataType,
+		fields:   append(fields, formField{label: "Notes"}, formField{label: "Metadata (key=value, ...)"}),
+	}
+}
+
+// last reports whether the focused field is the last one of the form
+func (f recordForm) last() bool {
+	return f.focus == len(f.fields)-1
+}
+
+// next moves the focus to the following field
+func (f *recordForm) next() {
+	if f.focus < len(f.fields)-1 {
+		f.focus++
+	}
+}
+
+// prev moves the focus to the preceding field
+func (f *recordForm) prev() {
+	if f.focus > 0 {
+		f.focus--
+	}
+}
+
+// input appends typed text to the focused field
+func (f *recordForm) input(text string) {
+	f.fields[f.focus].value += text
+}
+
+// backspace removes the last character of the focused field
+func (f *recordForm) backspace() {
+	value := []rune(f.fields[f.focus].value)
+	if len(value) > 0 {
+		f.fields[f.focus].value = string(value[:len(value)-1])
+	}
+}
+
+// value returns the input of the field with the given label, secrets are kept verbatim
+func (f recordForm) value(label string) string {
+	for _, field := range f.fields {
+		if field.label == label && field.secret {
+			return field.value
+		}
+		if field.label == label {
+			return strings.TrimSpace(field.value)
+		}
+	}
+	return ""
+}
+
+// metadata parses the metadata field of the form
+func (f recordForm) metadata() (map[string]string, error) {
+	return parseMetadata(f.value("Metadata (key=value, ...)"))
+}
+
+// record builds and validates the record described by the form
+func (f recordForm) record() (models.Record, error) {
+	var rec models.Record
+	notes := f.value("Notes")
+
+	switch f.dataType {
+	case models.DataTypePassword:
+		rec.Password = &models.LoginPasswordData{
+			Website:  f.value("Website"),
+			Login:    f.value("Login"),
+			Password: f.value("Password"),
+			Notes:    notes,
+		}
+	case models.DataTypeCard:
+		month, year, err := parseExpiry(f.value("Expiry (MM/YY)"))
+		if err != nil {
+			return rec, err
+		}
+		rec.Card = &models.BankCardData{
+			Bank:     f.value("Bank"),
+			Number:   records.NormalizeCardNumber(f.value("Number")),
+			Holder:   f.value("Holder"),
+			CVV:      f.value("CVV"),
+			ExpMonth: month,
+			ExpYear:  year,
+			Notes:    notes,
+		}
+	case models.DataTypeBinary:
+		binary, err := readInlineFile(f.value("File path"))
+		if err != nil {
+			return rec, err
+		}
+		binary.Notes = notes
+		rec.Binary = binary
+	case models.DataTypeOTP:
+		secret := strings.TrimSpace(f.value("Secret or otpauth:// URI"))
+		otp := &models.OTPData{Secret: secret}
+		if strings.HasPrefix(secret, "otpauth://") {
+			parsed, err := records.ParseOTPURI(secret)
+			if err != nil {
+				return rec, err
+			}
+			otp = parsed
+		}
+		if issuer := f.value("Issuer"); issuer != "" {
+			otp.Issuer = issuer
+		}
+		if account := f.value("Account"); account != "" {
+			otp.Account = account
+		}
+		otp.Notes = notes
+		records.NormalizeOTP(otp)
+		rec.OTP = otp
+	default:
+		rec.Text = &models.TextData{Content: f.value("Text"), Notes: notes}
+	}
+
+	return rec, records.Validate(rec)
+}
+
+// view renders the form with the cursor on the focused field
+func (f recordForm) view() string {
+	var s strings.Builder
+	for i, field := range f.fields {
+		value := field.value
+		if field.secret {
+			value = strings.Repeat("*", len([]rune(value)))
+		}
+		s.WriteString(fmt.Sprintf("%s: %s", field.label, inputStyle.Render(value)))
+		if i == f.focus {
+			s.WriteString("█")
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"data-vault/client/internal/models"
+)
+
+// Hash computes the chained hash of an audit entry the way the server does: SHA-256 over the
+// previous entry's hash followed by the entry's fields, each prefixed with its length as a
+// big-endian uint32
+func Hash(entry models.AuditEntry) []byte {
+	h := sha256.New()
+	h.Write(entry.PrevHash)
+
+	fields := []string{
+		entry.User, strconv.FormatInt(entry.Seq, 10), entry.At, entry.Action, entry.RecordID,
+		entry.Org, entry.ClientIP, entry.UserAgent, entry.Result,
+	}
+	for _, f := range fields {
+		var size [4]byte
+		binary.BigEndian.PutUint32(size[:], uint32(len(f)))
+		h.Write(size[:])
+		h.Write([]byte(f))
+	}
+
+	return h.Sum(nil)
+}
+
+// Verify checks a contiguous run of audit entries ordered by Seq, oldest first: every entry must
+// match its hash and link to the hash of the entry before it. The run starting at the first entry
+// of the log must also start the chain. A tampered, removed or reordered entry fails the check.
+func Verify(entries []models.AuditEntry) error {
+	for i, e := range entries {
+		if !bytes.Equal(Hash(e), e.Hash) {
+			return fmt.Errorf("%w: entry %d", ErrTampered, e.Seq)
+		}
+
+		if i == 0 {
+			if e.Seq == 1 && len(e.PrevHash) != 0 {
+				return fmt.Errorf("%w: entry 1 doesn't start the chain", ErrBroken)
+			}
+			continue
+		}
+
+		prev := entries[i-1]
+		if e.Seq != prev.Seq+1 {
+			return fmt.Errorf("%w: entry %d follows entry %d", ErrBroken, e.Seq, prev.Seq)
+		}
+		if !bytes.Equal(e.PrevHash, prev.Hash) {
+			return fmt.Errorf("%w: entry %d doesn't link to entry %d", ErrBroken, e.Seq, prev.Seq)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"data-vault/client/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain returns a two-entry audit log, hashed by the server
+func testChain(t *testing.T) []models.AuditEntry {
+	first, err := hex.DecodeString("905531d84e439a6d0242cd4ea05793659d4ea8407a54d284f3fac688c400a5e5")
+	require.NoError(t, err)
+	second, err := hex.DecodeString("2b8c6ea0aaa1f81e0443dac05326c73ffbfdfce79994bfcb5aac1c640e6df1bd")
+	require.NoError(t, err)
+
+	return []models.AuditEntry{
+		{
+			User: "alice", Seq: 1, At: "2024-01-01T10:00:00Z", Action: "Login",
+			ClientIP: "10.0.0.1", UserAgent: "grpc-go/1.70.0", Result: "OK", Hash: first,
+		},
+		{
+			User: "alice", Seq: 2, At: "2024-01-01T10:01:00Z", Action: "DeleteData", RecordID: "rec-1", Org: "acme",
+			ClientIP: "10.0.0.1", UserAgent: "grpc-go/1.70.0", Result: "PermissionDenied", PrevHash: first, Hash: second,
+		},
+	}
+}
+
+func TestHash(t *testing.T) {
+	for _, e := range testChain(t) {
+		assert.Equal(t, e.Hash, Hash(e), "entry %d", e.Seq)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		tamper      func(entries []models.AuditEntry) []models.AuditEntry
+		expectedErr error
+	}{
+		{
+			name:   "intact chain",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry { return entries },
+		},
+		{
+			name:   "page from the middle of the log",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry { return entries[1:] },
+		},
+		{
+			name:   "empty log",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry { return nil },
+		},
+		{
+			name: "modified field",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry {
+				entries[1].Result = "OK"
+				return entries
+			},
+			expectedErr: ErrTampered,
+		},
+		{
+			name: "rehashed entry",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry {
+				entries[0].ClientIP = "192.168.0.1"
+				entries[0].Hash = Hash(entries[0])
+				return entries
+			},
+			expectedErr: ErrBroken,
+		},
+		{
+			name: "removed entry",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry {
+				third := models.AuditEntry{User: "alice", Seq: 3, Action: "GetData", Result: "OK", PrevHash: entries[1].Hash}
+				third.Hash = Hash(third)
+				return []models.AuditEntry{entries[0], third}
+			},
+			expectedErr: ErrBroken,
+		},
+		{
+			name: "reordered entries",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry {
+				return []models.AuditEntry{entries[1], entries[0]}
+			},
+			expectedErr: ErrBroken,
+		},
+		{
+			name: "forged genesis",
+			tamper: func(entries []models.AuditEntry) []models.AuditEntry {
+				forged := models.AuditEntry{User: "alice", Seq: 1, Action: "Login", Result: "OK", PrevHash: []byte("elsewhere")}
+				forged.Hash = Hash(forged)
+				return []models.AuditEntry{forged}
+			},
+			expectedErr: ErrBroken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.tamper(testChain(t)))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
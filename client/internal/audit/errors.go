@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"errors"
+)
+
+// Package level errors for audit log verification
+var (
+	ErrTampered = errors.New("audit entry doesn't match its hash")
+	ErrBroken   = errors.New("audit chain is broken")
+)
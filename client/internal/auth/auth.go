@@ -6,10 +6,15 @@ import (
 	"path/filepath"
 )
 
-// AuthConfig represents the authentication configuration stored in file
+// AuthConfig represents the authentication configuration stored in file. The vault key itself
+// is never stored: Salt lets it be derived again from the master password and KeyCheck tells
+// whether the password entered was the right one.
 type AuthConfig struct {
-	JWT      string `json:"jwt"`
-	Username string `json:"username,omitempty"`
+	JWT          string `json:"jwt"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Username     string `json:"username,omitempty"`
+	Salt         []byte `json:"salt,omitempty"`
+	KeyCheck     []byte `json:"key_check,omitempty"`
 }
 
 // getConfigPath returns the path to the config file
@@ -27,16 +32,35 @@ func getConfigPath() (string, error) {
 	return filepath.Join(configDir, "auth.json"), nil
 }
 
-// SaveJWT saves JWT token to config file
-func SaveJWT(jwt, username string) error {
+// loadConfig reads the auth config file, returning an empty config if it doesn't exist
+func loadConfig() (AuthConfig, error) {
+	var config AuthConfig
+
 	configPath, err := getConfigPath()
 	if err != nil {
-		return err
+		return config, err
 	}
 
-	config := AuthConfig{
-		JWT:      jwt,
-		Username: username,
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// saveConfig writes the auth config file readable only by the current user
+func saveConfig(config AuthConfig) error {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return err
 	}
 
 	data, err := json.MarshalIndent(config, "", "  ")
@@ -47,30 +71,104 @@ func SaveJWT(jwt, username string) error {
 	return os.WriteFile(configPath, data, 0600)
 }
 
+// SaveJWT saves JWT token to config file
+func SaveJWT(jwt, username string) error {
+	config := AuthConfig{
+		JWT:      jwt,
+		Username: username,
+	}
+
+	return saveConfig(config)
+}
+
 // LoadJWT loads JWT token from config file
 func LoadJWT() (string, error) {
-	configPath, err := getConfigPath()
+	config, err := loadConfig()
 	if err != nil {
 		return "", err
 	}
 
-	data, err := os.ReadFile(configPath)
+	return config.JWT, nil
+}
+
+// SaveKeyCheck stores the key derivation salt and the key check of the logged in user next to
+// the saved JWT, so the vault key can be derived again from the master password
+func SaveKeyCheck(salt, check []byte) error {
+	config, err := loadConfig()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return "", nil
-		}
-		return "", err
+		return err
 	}
 
-	var config AuthConfig
-	if err := json.Unmarshal(data, &config); err != nil {
+	config.Salt = salt
+	config.KeyCheck = check
+
+	return saveConfig(config)
+}
+
+// LoadKeyCheck loads the key derivation salt and the key check from config file
+func LoadKeyCheck() ([]byte, []byte, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config.Salt, config.KeyCheck, nil
+}
+
+// SaveRefreshToken stores the refresh token of the session next to the saved JWT
+func SaveRefreshToken(refreshToken string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	config.RefreshToken = refreshToken
+
+	return saveConfig(config)
+}
+
+// LoadRefreshToken loads the refresh token of the session from config file
+func LoadRefreshToken() (string, error) {
+	config, err := loadConfig()
+	if err != nil {
 		return "", err
 	}
 
-	return config.JWT, nil
+	return config.RefreshToken, nil
+}
+
+// SaveTokens replaces the saved JWT and refresh token after a renewal, keeping the username and key check
+func SaveTokens(jwt, refreshToken string) error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	config.JWT = jwt
+	config.RefreshToken = refreshToken
+
+	return saveConfig(config)
+}
+
+// Store exposes the saved tokens to the gRPC client so it can renew expired access tokens
+type Store struct{}
+
+// LoadTokens returns the saved JWT and refresh token
+func (Store) LoadTokens() (string, string, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return "", "", err
+	}
+
+	return config.JWT, config.RefreshToken, nil
+}
+
+// SaveTokens saves a renewed token pair
+func (Store) SaveTokens(jwt, refreshToken string) error {
+	return SaveTokens(jwt, refreshToken)
 }
 
-// ClearJWT removes saved JWT for logout
+// ClearJWT removes saved JWT and key check for logout
 func ClearJWT() error {
 	configPath, err := getConfigPath()
 	if err != nil {
@@ -3,6 +3,7 @@ package auth
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -88,3 +89,121 @@ func TestClearJWTNotExists(t *testing.T) {
 		t.Fatalf("Expected no error clearing non-existent JWT, got %v", err)
 	}
 }
+
+func TestSaveAndLoadKeyCheck(t *testing.T) {
+	testSalt := []byte("0123456789abcdef")
+	testCheck := []byte("sealed-key-check")
+
+	err := SaveJWT("test.jwt.token", "testuser")
+	if err != nil {
+		t.Fatalf("Expected no error saving JWT, got %v", err)
+	}
+
+	err = SaveKeyCheck(testSalt, testCheck)
+	if err != nil {
+		t.Fatalf("Expected no error saving key check, got %v", err)
+	}
+
+	loadedSalt, loadedCheck, err := LoadKeyCheck()
+	if err != nil {
+		t.Fatalf("Expected no error loading key check, got %v", err)
+	}
+
+	if string(loadedSalt) != string(testSalt) || string(loadedCheck) != string(testCheck) {
+		t.Errorf("Expected salt %x and check %x, got %x and %x", testSalt, testCheck, loadedSalt, loadedCheck)
+	}
+
+	configPath, err := getConfigPath()
+	if err != nil {
+		t.Fatalf("Expected no error getting config path, got %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatalf("Expected no error reading config file, got %v", err)
+	}
+
+	if strings.Contains(string(data), `"key"`) {
+		t.Errorf("Expected no vault key in the config file, got %s", data)
+	}
+
+	loadedJWT, err := LoadJWT()
+	if err != nil {
+		t.Fatalf("Expected no error loading JWT, got %v", err)
+	}
+
+	if loadedJWT != "test.jwt.token" {
+		t.Errorf("Expected JWT to survive saving the key check, got %s", loadedJWT)
+	}
+
+	err = ClearJWT()
+	if err != nil {
+		t.Fatalf("Expected no error clearing JWT, got %v", err)
+	}
+
+	loadedSalt, loadedCheck, err = LoadKeyCheck()
+	if err != nil {
+		t.Fatalf("Expected no error loading key check after clear, got %v", err)
+	}
+
+	if len(loadedSalt) != 0 || len(loadedCheck) != 0 {
+		t.Errorf("Expected empty key check after clearing, got %x and %x", loadedSalt, loadedCheck)
+	}
+}
+
+func TestSaveAndLoadRefreshToken(t *testing.T) {
+	testSalt := []byte("0123456789abcdef")
+
+	err := SaveJWT("test.jwt.token", "testuser")
+	if err != nil {
+		t.Fatalf("Expected no error saving JWT, got %v", err)
+	}
+
+	err = SaveKeyCheck(testSalt, []byte("sealed-key-check"))
+	if err != nil {
+		t.Fatalf("Expected no error saving key check, got %v", err)
+	}
+
+	err = SaveRefreshToken("session.refresh1")
+	if err != nil {
+		t.Fatalf("Expected no error saving refresh token, got %v", err)
+	}
+
+	var store Store
+	loadedJWT, loadedRefresh, err := store.LoadTokens()
+	if err != nil {
+		t.Fatalf("Expected no error loading tokens, got %v", err)
+	}
+
+	if loadedJWT != "test.jwt.token" || loadedRefresh != "session.refresh1" {
+		t.Errorf("Expected saved tokens, got %s and %s", loadedJWT, loadedRefresh)
+	}
+
+	err = store.SaveTokens("renewed.jwt.token", "session.refresh2")
+	if err != nil {
+		t.Fatalf("Expected no error saving renewed tokens, got %v", err)
+	}
+
+	loadedJWT, _ = LoadJWT()
+	loadedRefresh, _ = LoadRefreshToken()
+	loadedSalt, _, _ := LoadKeyCheck()
+	loadedUsername, _ := LoadUsername()
+
+	if loadedJWT != "renewed.jwt.token" || loadedRefresh != "session.refresh2" {
+		t.Errorf("Expected renewed tokens, got %s and %s", loadedJWT, loadedRefresh)
+	}
+
+	if string(loadedSalt) != string(testSalt) || loadedUsername != "testuser" {
+		t.Errorf("Expected salt and username to survive a renewal, got %x and %s", loadedSalt, loadedUsername)
+	}
+
+	err = ClearJWT()
+	if err != nil {
+		t.Fatalf("Expected no error clearing JWT, got %v", err)
+	}
+
+	loadedRefresh, _ = LoadRefreshToken()
+	if loadedRefresh != "" {
+		t.Errorf("Expected empty refresh token after clearing, got %s", loadedRefresh)
+	}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// SyncState represents the synchronization cursor stored next to the auth config
+type SyncState struct {
+	Username string `json:"username"`
+	Cursor   int64  `json:"cursor"`
+}
+
+// getSyncPath returns the path to the sync state file
+func getSyncPath() (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configPath), "sync.json"), nil
+}
+
+// CachePath returns the path to the encrypted local data cache
+func CachePath() (string, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configPath), "cache.bin"), nil
+}
+
+// LoadUsername loads the username of the logged in user from config file
+func LoadUsername() (string, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return config.Username, nil
+}
+
+// SaveCursor saves the last synchronized server revision for a user
+func SaveCursor(username string, cursor int64) error {
+	syncPath, err := getSyncPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(SyncState{Username: username, Cursor: cursor}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(syncPath, data, 0600)
+}
+
+// LoadCursor loads the last synchronized server revision for a user.
+// It returns zero, meaning a full sync, if nothing was saved for this user.
+func LoadCursor(username string) (int64, error) {
+	syncPath, err := getSyncPath()
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := os.ReadFile(syncPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var state SyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, err
+	}
+
+	if state.Username != username {
+		return 0, nil
+	}
+
+	return state.Cursor, nil
+}
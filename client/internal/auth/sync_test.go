@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestSaveAndLoadCursor(t *testing.T) {
+	err := SaveCursor("testuser", 42)
+	if err != nil {
+		t.Fatalf("Expected no error saving cursor, got %v", err)
+	}
+
+	cursor, err := LoadCursor("testuser")
+	if err != nil {
+		t.Fatalf("Expected no error loading cursor, got %v", err)
+	}
+
+	if cursor != 42 {
+		t.Errorf("Expected cursor 42, got %d", cursor)
+	}
+
+	cursor, err = LoadCursor("otheruser")
+	if err != nil {
+		t.Fatalf("Expected no error loading cursor for another user, got %v", err)
+	}
+
+	if cursor != 0 {
+		t.Errorf("Expected cursor 0 for another user, got %d", cursor)
+	}
+}
+
+func TestLoadUsername(t *testing.T) {
+	err := SaveJWT("test.jwt.token", "syncuser")
+	if err != nil {
+		t.Fatalf("Expected no error saving JWT, got %v", err)
+	}
+
+	username, err := LoadUsername()
+	if err != nil {
+		t.Fatalf("Expected no error loading username, got %v", err)
+	}
+
+	if username != "syncuser" {
+		t.Errorf("Expected username syncuser, got %s", username)
+	}
+
+	_ = ClearJWT()
+}
@@ -0,0 +1,265 @@
+package cache
+
+import (
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Kinds of operations queued while offline
+const (
+	OpPost   = "post"
+	OpDelete = "delete"
+)
+
+// StatusPending marks records posted offline that the server hasn't stored yet
+const StatusPending = "PENDING"
+
+// Cache is a local mirror of a user's decrypted records and a queue of changes made
+// offline. It is kept in a single file encrypted with the user's vault key.
+type Cache struct {
+	mu    sync.Mutex
+	path  string
+	key   []byte
+	state state
+}
+
+// state is the content of the cache file
+type state struct {
+	Username string             `json:"username"`
+	Records  []models.Data      `json:"records"`
+	Pending  []models.PendingOp `json:"pending"`
+	SyncedAt string             `json:"synced_at"`
+
+	Rejected []models.RejectedOp `json:"rejected,omitempty"`
+}
+
+// Open loads the cache file for a user. A missing file, a file of another user or one
+// that can't be decrypted with the key results in an empty cache.
+func Open(path, username string, key []byte) (*Cache, error) {
+	if len(key) == 0 {
+		return nil, ErrNoKey
+	}
+
+	c := &Cache{
+		path:  path,
+		key:   key,
+		state: state{Username: username},
+	}
+
+	cipherData, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, err
+	}
+
+	plain, err := encryption.Decrypt(key, cipherData)
+	if err != nil {
+		return c, nil
+	}
+
+	var st state
+	if err := json.Unmarshal(plain, &st); err != nil {
+		return nil, err
+	}
+
+	if st.Username == username {
+		c.state = st
+	}
+
+	return c, nil
+}
+
+// Remove deletes the cache file
+func Remove(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Records returns the cached records as the user would see them after the pending
+// operations are replayed: queued deletes are hidden and queued posts are included
+// with the pending status and no ID.
+func (c *Cache) Records() []models.Data {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := make(map[string]bool)
+	for _, op := range c.state.Pending {
+		if op.Kind == OpDelete {
+			deleted[op.ID] = true
+		}
+	}
+
+	res := make([]models.Data, 0, len(c.state.Records))
+	for _, d := range c.state.Records {
+		if !deleted[d.ID] {
+			res = append(res, d)
+		}
+	}
+
+	for _, op := range c.state.Pending {
+		if op.Kind == OpPost {
+			res = append(res, models.Data{
+				Status:     StatusPending,
+				Type:       op.Type,
+				Data:       op.Data,
+				UploadedAt: op.QueuedAt,
+				Metadata:   op.Metadata,
+			})
+		}
+	}
+
+	return res
+}
+
+// Replace stores the records just fetched from the server
+func (c *Cache) Replace(records []models.Data) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state.Records = slices.Clone(records)
+	c.state.SyncedAt = time.Now().UTC().Format(time.RFC3339)
+
+	return c.save()
+}
+
+// Merge stores a partial result fetched from the server, such as a single page,
+// replacing the cached copies of the fetched records and keeping the others
+func (c *Cache) Merge(records []models.Data) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := make(map[string]int, len(c.state.Records))
+	for i, d := range c.state.Records {
+		index[d.ID] = i
+	}
+
+	for _, d := range records {
+		if i, ok := index[d.ID]; ok {
+			c.state.Records[i] = d
+			continue
+		}
+		index[d.ID] = len(c.state.Records)
+		c.state.Records = append(c.state.Records, d)
+	}
+
+	return c.save()
+}
+
+// SyncedAt returns when the records were last fetched from the server
+func (c *Cache) SyncedAt() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.state.SyncedAt
+}
+
+// Enqueue adds an operation made offline to the end of the queue
+func (c *Cache) Enqueue(op models.PendingOp) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if op.QueuedAt == "" {
+		op.QueuedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	c.state.Pending = append(c.state.Pending, op)
+
+	return c.save()
+}
+
+// Pending returns the queued operations in the order they were made
+func (c *Cache) Pending() []models.PendingOp {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.state.Pending)
+}
+
+// Done removes the oldest queued operation after it was replayed
+func (c *Cache) Done() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.state.Pending) == 0 {
+		return ErrNoPending
+	}
+	c.state.Pending = c.state.Pending[1:]
+
+	return c.save()
+}
+
+// Reject moves the oldest queued operation out of the queue after the server refused it for good,
+// keeping it with the reason until TakeRejected reports it
+func (c *Cache) Reject(reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.state.Pending) == 0 {
+		return ErrNoPending
+	}
+	c.state.Rejected = append(c.state.Rejected, models.RejectedOp{
+		PendingOp:  c.state.Pending[0],
+		Reason:     reason,
+		RejectedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+	c.state.Pending = c.state.Pending[1:]
+
+	return c.save()
+}
+
+// TakeRejected returns the operations the server refused in the order they were made and forgets them
+func (c *Cache) TakeRejected() ([]models.RejectedOp, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rejected := c.state.Rejected
+	if len(rejected) == 0 {
+		return nil, nil
+	}
+
+	c.state.Rejected = nil
+	if err := c.save(); err != nil {
+		c.state.Rejected = rejected
+		return nil, err
+	}
+
+	return rejected, nil
+}
+
+// save encrypts the cache state and atomically replaces the cache file
+func (c *Cache) save() error {
+	plain, err := json.Marshal(c.state)
+	if err != nil {
+		return err
+	}
+
+	cipherData, err := encryption.Encrypt(c.key, plain)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(cipherData); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(t *testing.T) []byte {
+	salt, err := encryption.NewSalt()
+	require.NoError(t, err)
+	key, _, err := encryption.DeriveKeys("master-password", salt)
+	require.NoError(t, err)
+	return key
+}
+
+func TestCache_PersistsEncrypted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.bin")
+	key := testKey(t)
+
+	c, err := Open(path, "testuser", key)
+	require.NoError(t, err)
+	assert.Empty(t, c.Records())
+
+	records := []models.Data{
+		{ID: "1", Type: "text", Data: []byte("first secret"), Version: 1},
+		{ID: "2", Type: "password", Data: []byte("second secret"), Version: 3},
+	}
+	require.NoError(t, c.Replace(records))
+	assert.NotEmpty(t, c.SyncedAt())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "first secret", "Cache file must be encrypted")
+
+	reopened, err := Open(path, "testuser", key)
+	require.NoError(t, err)
+	assert.Equal(t, records, reopened.Records())
+
+	other, err := Open(path, "otheruser", key)
+	require.NoError(t, err)
+	assert.Empty(t, other.Records(), "Cache of another user must not be served")
+
+	wrongKey, err := Open(path, "testuser", testKey(t))
+	require.NoError(t, err)
+	assert.Empty(t, wrongKey.Records(), "Cache that can't be decrypted starts empty")
+
+	require.NoError(t, Remove(path))
+	require.NoError(t, Remove(path), "Removing a missing cache is not an error")
+}
+
+func TestCache_PendingQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.bin")
+	key := testKey(t)
+
+	c, err := Open(path, "testuser", key)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Replace([]models.Data{
+		{ID: "1", Type: "text", Data: []byte("kept")},
+		{ID: "2", Type: "text", Data: []byte("deleted offline")},
+	}))
+
+	require.NoError(t, c.Enqueue(models.PendingOp{Kind: OpDelete, ID: "2"}))
+	require.NoError(t, c.Enqueue(models.PendingOp{Kind: OpPost, Type: "text", Data: []byte("posted offline"), Metadata: map[string]string{"env": "prod"}}))
+
+	records := c.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "1", records[0].ID)
+	assert.Equal(t, StatusPending, records[1].Status)
+	assert.Equal(t, "posted offline", string(records[1].Data))
+	assert.Equal(t, map[string]string{"env": "prod"}, records[1].Metadata)
+
+	reopened, err := Open(path, "testuser", key)
+	require.NoError(t, err)
+	pending := reopened.Pending()
+	require.Len(t, pending, 2)
+	assert.Equal(t, OpDelete, pending[0].Kind)
+	assert.NotEmpty(t, pending[0].QueuedAt)
+
+	require.NoError(t, reopened.Done())
+	require.Len(t, reopened.Pending(), 1)
+	assert.Equal(t, OpPost, reopened.Pending()[0].Kind)
+	assert.Equal(t, "prod", reopened.Pending()[0].Metadata["env"])
+
+	require.NoError(t, reopened.Done())
+	assert.ErrorIs(t, reopened.Done(), ErrNoPending)
+}
+
+func TestCache_Rejected(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.bin")
+	key := testKey(t)
+
+	c, err := Open(path, "testuser", key)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Enqueue(models.PendingOp{Kind: OpDelete, ID: "gone"}))
+	require.NoError(t, c.Enqueue(models.PendingOp{Kind: OpPost, Type: "text", Data: []byte("kept")}))
+
+	require.NoError(t, c.Reject("data not found"))
+	require.Len(t, c.Pending(), 1)
+	assert.Equal(t, OpPost, c.Pending()[0].Kind, "The rejected operation should leave the queue")
+
+	reopened, err := Open(path, "testuser", key)
+	require.NoError(t, err)
+	rejected, err := reopened.TakeRejected()
+	require.NoError(t, err)
+	require.Len(t, rejected, 1, "Rejected operations should be kept until reported")
+	assert.Equal(t, "gone", rejected[0].ID)
+	assert.Equal(t, "data not found", rejected[0].Reason)
+	assert.NotEmpty(t, rejected[0].RejectedAt)
+
+	rejected, err = reopened.TakeRejected()
+	require.NoError(t, err)
+	assert.Empty(t, rejected, "Reported operations should be forgotten")
+
+	require.NoError(t, reopened.Done())
+	assert.ErrorIs(t, reopened.Reject("nothing queued"), ErrNoPending)
+}
+
+func TestCache_RequiresKey(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), "cache.bin"), "testuser", nil)
+	assert.ErrorIs(t, err, ErrNoKey)
+}
+
+func TestCache_Merge(t *testing.T) {
+	key := testKey(t)
+	c, err := Open(filepath.Join(t.TempDir(), "cache.bin"), "testuser", key)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Replace([]models.Data{
+		{ID: "1", Data: []byte("one"), Version: 1},
+		{ID: "2", Data: []byte("two"), Version: 1},
+	}))
+	require.NoError(t, c.Merge([]models.Data{
+		{ID: "2", Data: []byte("two, edited"), Version: 2},
+		{ID: "3", Data: []byte("three"), Version: 1},
+	}))
+
+	records := c.Records()
+	require.Len(t, records, 3)
+	assert.Equal(t, "one", string(records[0].Data))
+	assert.Equal(t, "two, edited", string(records[1].Data))
+	assert.Equal(t, int64(2), records[1].Version)
+	assert.Equal(t, "3", records[2].ID)
+}
@@ -0,0 +1,11 @@
+package cache
+
+import (
+	"errors"
+)
+
+// Package level errors for the local cache
+var (
+	ErrNoPending = errors.New("no pending operations")
+	ErrNoKey     = errors.New("cache requires an encryption key")
+)
@@ -0,0 +1,161 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id parameters used to derive the vault key from the master password
+const (
+	SaltSize     = 16
+	KeySize      = 32
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+)
+
+// NewSalt generates a random salt for key derivation
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+// NewKey generates a random AES-256 key, used as the key of an organization vault
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// authKeyInfo is the HKDF context of the authentication key derived from the vault key
+const authKeyInfo = "data-vault authentication key"
+
+// keyCheck is sealed with the vault key to tell a mistyped master password from the right one
+var keyCheck = []byte("data-vault key check")
+
+// DeriveKeys derives the per-user vault key and the authentication key from the master password.
+// The vault key is the Argon2id output and never leaves the client. The authentication key is
+// expanded from it with HKDF and is what the server stores a hash of, it can't be turned back
+// into the vault key or the master password.
+func DeriveKeys(password string, salt []byte) ([]byte, []byte, error) {
+	if password == "" || len(salt) == 0 {
+		return nil, nil, ErrInvalidKeyParams
+	}
+
+	vaultKey := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, KeySize)
+
+	authKey, err := derive(vaultKey, nil, authKeyInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vaultKey, authKey, nil
+}
+
+// AuthSecret encodes an authentication key as the password sent to the server
+func AuthSecret(authKey []byte) string {
+	return base64.RawStdEncoding.EncodeToString(authKey)
+}
+
+// NewKeyCheck seals a known value with the vault key. It is kept on disk instead of the key so
+// that the master password entered to unlock the vault can be verified before it is used.
+func NewKeyCheck(key []byte) ([]byte, error) {
+	return Encrypt(key, keyCheck)
+}
+
+// VerifyKey checks a vault key against a value made by NewKeyCheck
+func VerifyKey(key, check []byte) error {
+	plain, err := Decrypt(key, check)
+	if err != nil || !bytes.Equal(plain, keyCheck) {
+		return ErrWrongPassword
+	}
+	return nil
+}
+
+// Encrypt seals the plaintext with AES-GCM, prepending the random nonce to the result
+func Encrypt(key, plaintext []byte) ([]byte, error) {
+	return seal(key, plaintext, nil)
+}
+
+// Decrypt opens a nonce-prefixed AES-GCM ciphertext produced by Encrypt
+func Decrypt(key, ciphertext []byte) ([]byte, error) {
+	return open(key, ciphertext, nil)
+}
+
+// EncryptChunk seals one chunk of a file with its own random nonce.
+// The sequence number is authenticated, so chunks can't be reordered or swapped.
+func EncryptChunk(key []byte, seq int64, plaintext []byte) ([]byte, error) {
+	return seal(key, plaintext, chunkAAD(seq))
+}
+
+// DecryptChunk opens a file chunk produced by EncryptChunk with the same sequence number
+func DecryptChunk(key []byte, seq int64, ciphertext []byte) ([]byte, error) {
+	return open(key, ciphertext, chunkAAD(seq))
+}
+
+// chunkAAD encodes a chunk sequence number as additional authenticated data
+func chunkAAD(seq int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(seq))
+}
+
+// seal encrypts the plaintext with a random nonce prepended to the result
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts a nonce-prefixed ciphertext produced by seal
+func open(key, ciphertext, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, ErrCiphertextTooShort
+	}
+
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return plaintext, nil
+}
+
+// newGCM creates an AES-GCM cipher for the given key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(c)
+}
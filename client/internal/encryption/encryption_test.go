@@ -0,0 +1,343 @@
+package encryption
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveKeys(t *testing.T) {
+	salt, err := NewSalt()
+	require.NoError(t, err)
+	require.Len(t, salt, SaltSize)
+
+	vault1, auth1, err := DeriveKeys("master-password", salt)
+	require.NoError(t, err)
+	assert.Len(t, vault1, KeySize)
+	assert.Len(t, auth1, KeySize)
+	assert.NotEqual(t, vault1, auth1, "The key sent to the server must differ from the vault key")
+
+	vault2, auth2, err := DeriveKeys("master-password", salt)
+	require.NoError(t, err)
+	assert.Equal(t, vault1, vault2, "Same password and salt should derive the same keys")
+	assert.Equal(t, auth1, auth2)
+
+	otherSalt, err := NewSalt()
+	require.NoError(t, err)
+	vault3, auth3, err := DeriveKeys("master-password", otherSalt)
+	require.NoError(t, err)
+	assert.NotEqual(t, vault1, vault3, "Different salts should derive different keys")
+	assert.NotEqual(t, auth1, auth3)
+
+	assert.NotContains(t, AuthSecret(auth1), "master-password")
+	assert.NotEqual(t, AuthSecret(auth1), AuthSecret(vault1))
+
+	_, _, err = DeriveKeys("", salt)
+	assert.ErrorIs(t, err, ErrInvalidKeyParams)
+
+	_, _, err = DeriveKeys("master-password", nil)
+	assert.ErrorIs(t, err, ErrInvalidKeyParams)
+}
+
+func TestVerifyKey(t *testing.T) {
+	salt, err := NewSalt()
+	require.NoError(t, err)
+	key, _, err := DeriveKeys("master-password", salt)
+	require.NoError(t, err)
+
+	check, err := NewKeyCheck(key)
+	require.NoError(t, err)
+	assert.NoError(t, VerifyKey(key, check))
+
+	wrong, _, err := DeriveKeys("mistyped-password", salt)
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyKey(wrong, check), ErrWrongPassword)
+
+	assert.ErrorIs(t, VerifyKey(key, nil), ErrWrongPassword)
+}
+
+func TestNewKey(t *testing.T) {
+	key1, err := NewKey()
+	require.NoError(t, err)
+	assert.Len(t, key1, KeySize)
+
+	key2, err := NewKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key1, key2, "Keys should be random")
+
+	_, err = RecordKey(key1, "record-1")
+	assert.NoError(t, err, "Organization keys should derive record keys like vault keys")
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	salt, err := NewSalt()
+	require.NoError(t, err)
+	key, _, err := DeriveKeys("master-password", salt)
+	require.NoError(t, err)
+
+	plaintext := []byte("card 4111 1111 1111 1111")
+
+	ciphertext, err := Encrypt(key, plaintext)
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), string(plaintext))
+
+	decrypted, err := Decrypt(key, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	again, err := Encrypt(key, plaintext)
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again, "Each encryption should use a fresh nonce")
+}
+
+func TestDecryptErrors(t *testing.T) {
+	key := make([]byte, KeySize)
+	otherKey := make([]byte, KeySize)
+	otherKey[0] = 1
+
+	ciphertext, err := Encrypt(key, []byte("secret"))
+	require.NoError(t, err)
+
+	_, err = Decrypt(otherKey, ciphertext)
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = Decrypt(key, []byte("short"))
+	assert.ErrorIs(t, err, ErrCiphertextTooShort)
+
+	_, err = Decrypt([]byte("bad-key"), ciphertext)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = Encrypt([]byte("bad-key"), []byte("secret"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestEncryptDecryptChunk(t *testing.T) {
+	salt, err := NewSalt()
+	require.NoError(t, err)
+	key, _, err := DeriveKeys("master-password", salt)
+	require.NoError(t, err)
+
+	chunk := []byte("file chunk contents")
+
+	first, err := EncryptChunk(key, 3, chunk)
+	require.NoError(t, err)
+	second, err := EncryptChunk(key, 3, chunk)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second, "Each chunk should get its own nonce")
+
+	plaintext, err := DecryptChunk(key, 3, first)
+	require.NoError(t, err)
+	assert.Equal(t, chunk, plaintext)
+
+	_, err = DecryptChunk(key, 4, first)
+	assert.ErrorIs(t, err, ErrDecrypt, "Chunk must not open under another sequence number")
+
+	_, err = Decrypt(key, first)
+	assert.ErrorIs(t, err, ErrDecrypt, "Chunk must not open as a regular payload")
+}
+
+func TestRecordKey(t *testing.T) {
+	key := make([]byte, KeySize)
+	otherKey := make([]byte, KeySize)
+	otherKey[0] = 1
+
+	first, err := RecordKey(key, "1")
+	require.NoError(t, err)
+	again, err := RecordKey(key, "1")
+	require.NoError(t, err)
+	assert.Equal(t, first, again, "Record keys should be stable across updates")
+
+	second, err := RecordKey(key, "2")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second, "Records should get their own keys")
+
+	other, err := RecordKey(otherKey, "1")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+
+	_, err = RecordKey(key, "")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestEncryptDecryptRecord(t *testing.T) {
+	key := make([]byte, KeySize)
+	plaintext := []byte("shared service password")
+
+	recordKey, err := RecordKey(key, "42")
+	require.NoError(t, err)
+
+	ciphertext, err := EncryptRecord(recordKey, plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, RecordMarker, ciphertext[0])
+
+	opened, err := OpenRecord(recordKey, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, opened)
+
+	decrypted, err := DecryptRecord(key, "42", ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	_, err = DecryptRecord(key, "43", ciphertext)
+	assert.ErrorIs(t, err, ErrDecrypt, "Record must not open under another ID")
+
+	legacy, err := Encrypt(key, plaintext)
+	require.NoError(t, err)
+	decrypted, err = DecryptRecord(key, "42", legacy)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted, "Payloads sealed with the vault key should stay readable")
+
+	_, err = OpenRecord(recordKey, legacy[1:])
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestWrapUnwrapKey(t *testing.T) {
+	ownerKey := make([]byte, KeySize)
+	recipientKey := make([]byte, KeySize)
+	recipientKey[0] = 1
+	strangerKey := make([]byte, KeySize)
+	strangerKey[0] = 2
+
+	recipient, err := SharingKey(recipientKey)
+	require.NoError(t, err)
+	again, err := SharingKey(recipientKey)
+	require.NoError(t, err)
+	assert.Equal(t, recipient.PublicKey().Bytes(), again.PublicKey().Bytes(), "Sharing key should be derived from the vault key")
+
+	recordKey, err := RecordKey(ownerKey, "7")
+	require.NoError(t, err)
+
+	wrapped, err := WrapKey(recipient.PublicKey().Bytes(), recordKey)
+	require.NoError(t, err)
+	assert.NotContains(t, string(wrapped), string(recordKey))
+
+	unwrapped, err := UnwrapKey(recipient, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, recordKey, unwrapped)
+
+	stranger, err := SharingKey(strangerKey)
+	require.NoError(t, err)
+	_, err = UnwrapKey(stranger, wrapped)
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = UnwrapKey(recipient, wrapped[:10])
+	assert.ErrorIs(t, err, ErrCiphertextTooShort)
+
+	_, err = WrapKey([]byte("short"), recordKey)
+	assert.ErrorIs(t, err, ErrInvalidPublicKey)
+
+	fingerprint := Fingerprint(recipient.PublicKey().Bytes())
+	assert.Len(t, fingerprint, 24)
+	assert.NotEqual(t, fingerprint, Fingerprint(stranger.PublicKey().Bytes()))
+}
+
+func TestSealOpenMetadata(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	other, err := NewKey()
+	require.NoError(t, err)
+
+	metadata := map[string]string{"env": "prod", "site": "example.com", "note": ""}
+
+	sealed, err := SealMetadata(key, metadata)
+	require.NoError(t, err)
+	assert.Len(t, sealed, 2*len(metadata))
+	for k, v := range sealed {
+		assert.True(t, strings.HasPrefix(k, MetadataPrefix))
+		assert.LessOrEqual(t, len(k), 64, "Index keys must fit the server limit")
+		for name, value := range metadata {
+			assert.NotContains(t, k, name)
+			if value != "" {
+				assert.NotContains(t, v, value)
+			}
+		}
+	}
+
+	again, err := SealMetadata(key, metadata)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, keys(sealed), keys(again), "Index keys must be deterministic to be filtered by")
+
+	assert.Equal(t, metadata, OpenMetadata(key, sealed))
+	assert.Nil(t, OpenMetadata(other, sealed), "Entries sealed with another key should be dropped")
+
+	legacy := map[string]string{"env": "dev"}
+	for k, v := range sealed {
+		legacy[k] = v
+	}
+	assert.Equal(t, map[string]string{"env": "dev", "site": "example.com", "note": ""}, OpenMetadata(key, legacy),
+		"Plaintext entries should be kept as they are")
+
+	empty, err := SealMetadata(key, nil)
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxMetadataPairs; i++ {
+		tooMany[string(rune('a'+i))] = "x"
+	}
+	_, err = SealMetadata(key, tooMany)
+	assert.ErrorIs(t, err, ErrMetadataTooLarge)
+
+	_, err = SealMetadata(key, map[string]string{"k": strings.Repeat("x", 1024)})
+	assert.ErrorIs(t, err, ErrMetadataTooLarge)
+
+	_, err = SealMetadata(key[:16], metadata)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestMetadataFilter(t *testing.T) {
+	key, err := NewKey()
+	require.NoError(t, err)
+	other, err := NewKey()
+	require.NoError(t, err)
+
+	sealed, err := SealMetadata(key, map[string]string{"env": "prod", "site": "example.com"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		key    []byte
+		filter map[string]string
+		match  bool
+	}{
+		{name: "pair", key: key, filter: map[string]string{"env": "prod"}, match: true},
+		{name: "any value", key: key, filter: map[string]string{"site": ""}, match: true},
+		{name: "every pair", key: key, filter: map[string]string{"env": "prod", "site": "example.com"}, match: true},
+		{name: "other value", key: key, filter: map[string]string{"env": "dev"}, match: false},
+		{name: "missing key", key: key, filter: map[string]string{"bank": ""}, match: false},
+		{name: "value as key", key: key, filter: map[string]string{"prod": ""}, match: false},
+		{name: "other vault", key: other, filter: map[string]string{"env": "prod"}, match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := MetadataFilter(tt.key, tt.filter)
+			require.NoError(t, err)
+
+			// The server matches entries holding every filter key with an equal or empty filter value
+			match := true
+			for k, v := range filter {
+				assert.Empty(t, v)
+				if _, ok := sealed[k]; !ok {
+					match = false
+				}
+			}
+			assert.Equal(t, tt.match, match)
+		})
+	}
+
+	filter, err := MetadataFilter(key, nil)
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+}
+
+// keys returns the keys of a map
+func keys(m map[string]string) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
@@ -0,0 +1,16 @@
+package encryption
+
+import (
+	"errors"
+)
+
+// Package level errors for the client-side encryption layer
+var (
+	ErrInvalidKey         = errors.New("encryption key must be 32 bytes")
+	ErrInvalidKeyParams   = errors.New("password and salt are required to derive a key")
+	ErrCiphertextTooShort = errors.New("ciphertext is too short")
+	ErrDecrypt            = errors.New("can't decrypt data, wrong key or corrupted payload")
+	ErrWrongPassword      = errors.New("master password doesn't match the one used at login")
+	ErrInvalidPublicKey   = errors.New("public key must be a 32 byte X25519 key")
+	ErrMetadataTooLarge   = errors.New("metadata is too large, at most 16 pairs of about 700 bytes each")
+)
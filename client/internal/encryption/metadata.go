@@ -0,0 +1,165 @@
+package encryption
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// Limits of sealed metadata, chosen so that it stays within the server's limits of 32 entries
+// and 1024 byte values
+const (
+	MaxMetadataPairs = 16
+	maxSealedValue   = 1024
+)
+
+// MetadataPrefix starts the keys of metadata entries made by SealMetadata. Entries without it
+// were stored in plaintext before metadata was encrypted.
+const MetadataPrefix = "m:"
+
+// HKDF contexts of the keys protecting metadata
+const (
+	metadataKeyInfo      = "data-vault metadata key"
+	metadataIndexKeyInfo = "data-vault metadata index key"
+)
+
+// SealMetadata turns metadata into the form stored on the server. Every pair becomes two
+// entries keyed with HMACs of the key and of the pair: the first is empty and is matched by
+// key-only filters, the second holds the pair sealed with a key derived from the vault key and
+// is matched by key=value filters. The server can filter by the keyed hashes but learns
+// neither names nor values, only how many pairs there are and which entries share them.
+func SealMetadata(key []byte, metadata map[string]string) (map[string]string, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	if len(metadata) > MaxMetadataPairs {
+		return nil, ErrMetadataTooLarge
+	}
+
+	sealKey, indexKey, err := metadataKeys(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make(map[string]string, 2*len(metadata))
+	for k, v := range metadata {
+		pair, err := json.Marshal([]string{k, v})
+		if err != nil {
+			return nil, err
+		}
+
+		ciphertext, err := seal(sealKey, pair, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		value := base64.RawStdEncoding.EncodeToString(ciphertext)
+		if len(value) > maxSealedValue {
+			return nil, ErrMetadataTooLarge
+		}
+
+		sealed[metadataTag(indexKey, k)] = ""
+		sealed[metadataTag(indexKey, k, v)] = value
+	}
+
+	return sealed, nil
+}
+
+// OpenMetadata restores metadata sealed by SealMetadata. Entries sealed with another key, e.g.
+// those of a record shared by another user, are dropped; plaintext entries stored before
+// metadata was encrypted are returned as they are.
+func OpenMetadata(key []byte, stored map[string]string) map[string]string {
+	if len(stored) == 0 {
+		return nil
+	}
+
+	sealKey, _, err := metadataKeys(key)
+	if err != nil {
+		sealKey = nil
+	}
+
+	metadata := make(map[string]string)
+	for k, v := range stored {
+		if !strings.HasPrefix(k, MetadataPrefix) {
+			metadata[k] = v
+			continue
+		}
+		if v == "" || sealKey == nil {
+			continue
+		}
+
+		ciphertext, err := base64.RawStdEncoding.DecodeString(v)
+		if err != nil {
+			continue
+		}
+		plaintext, err := open(sealKey, ciphertext, nil)
+		if err != nil {
+			continue
+		}
+
+		var pair []string
+		if err := json.Unmarshal(plaintext, &pair); err != nil || len(pair) != 2 {
+			continue
+		}
+		metadata[pair[0]] = pair[1]
+	}
+
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// MetadataFilter turns a metadata filter into the keyed hashes SealMetadata stores, so that the
+// server matches it by key presence alone. An empty value matches any value of the key.
+func MetadataFilter(key []byte, filter map[string]string) (map[string]string, error) {
+	if len(filter) == 0 {
+		return nil, nil
+	}
+
+	_, indexKey, err := metadataKeys(key)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string, len(filter))
+	for k, v := range filter {
+		if v == "" {
+			res[metadataTag(indexKey, k)] = ""
+		} else {
+			res[metadataTag(indexKey, k, v)] = ""
+		}
+	}
+	return res, nil
+}
+
+// metadataKeys derives the keys sealing metadata pairs and hashing them for the index
+func metadataKeys(key []byte) ([]byte, []byte, error) {
+	if len(key) != KeySize {
+		return nil, nil, ErrInvalidKey
+	}
+
+	sealKey, err := derive(key, nil, metadataKeyInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	indexKey, err := derive(key, nil, metadataIndexKeyInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sealKey, indexKey, nil
+}
+
+// metadataTag computes the index entry of a metadata key or of a key=value pair
+func metadataTag(indexKey []byte, parts ...string) string {
+	// JSON keeps a key and a pair, or two differently split pairs, from colliding
+	encoded, _ := json.Marshal(parts)
+
+	mac := hmac.New(sha256.New, indexKey)
+	mac.Write(encoded)
+	return MetadataPrefix + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
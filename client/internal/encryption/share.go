@@ -0,0 +1,163 @@
+package encryption
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// RecordMarker starts payloads sealed with a per-record key by EncryptRecord. Older payloads
+// are sealed with the vault key directly and start with a random nonce.
+const RecordMarker byte = 0x52
+
+// HKDF contexts separating the keys derived from the vault key and from shared secrets
+const (
+	recordKeyInfo  = "data-vault record key "
+	sharingKeyInfo = "data-vault sharing key"
+	wrapKeyInfo    = "data-vault wrapped record key"
+)
+
+// RecordKey derives the key of a single record from the vault key. Sharing a record hands out
+// this key only, so the recipient can't read any other record of the owner.
+func RecordKey(key []byte, id string) ([]byte, error) {
+	if len(key) != KeySize || id == "" {
+		return nil, ErrInvalidKey
+	}
+	return derive(key, nil, recordKeyInfo+id)
+}
+
+// EncryptRecord seals a record payload with its record key, prefixed with RecordMarker
+func EncryptRecord(recordKey, plaintext []byte) ([]byte, error) {
+	sealed, err := seal(recordKey, plaintext, []byte{RecordMarker})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{RecordMarker}, sealed...), nil
+}
+
+// OpenRecord opens a payload sealed by EncryptRecord with the record key
+func OpenRecord(recordKey, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) == 0 || ciphertext[0] != RecordMarker {
+		return nil, ErrDecrypt
+	}
+	return open(recordKey, ciphertext[1:], []byte{RecordMarker})
+}
+
+// DecryptRecord opens the payload of the record with the given ID, whether it was sealed with
+// its record key or, before sharing existed, with the vault key itself
+func DecryptRecord(key []byte, id string, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) > 0 && ciphertext[0] == RecordMarker && id != "" {
+		recordKey, err := RecordKey(key, id)
+		if err != nil {
+			return nil, err
+		}
+		if plaintext, err := OpenRecord(recordKey, ciphertext); err == nil {
+			return plaintext, nil
+		}
+	}
+
+	// A vault key nonce may start with the marker by chance
+	return Decrypt(key, ciphertext)
+}
+
+// SharingKey derives the user's X25519 key pair from the vault key. Its public half is published
+// so that other users can wrap record keys for the user; the private half never leaves the client.
+func SharingKey(key []byte) (*ecdh.PrivateKey, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+
+	seed, err := derive(key, nil, sharingKeyInfo)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPrivateKey(seed)
+}
+
+// WrapKey seals a record key to a recipient's X25519 public key with an ephemeral key pair.
+// The result is the ephemeral public key followed by the AES-GCM sealed record key.
+func WrapKey(publicKey, recordKey []byte) ([]byte, error) {
+	recipient, err := ecdh.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	kek, err := wrapKey(ephemeral, recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := seal(kek, recordKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(ephemeral.PublicKey().Bytes(), sealed...), nil
+}
+
+// UnwrapKey opens a record key wrapped by WrapKey with the recipient's private key
+func UnwrapKey(private *ecdh.PrivateKey, wrapped []byte) ([]byte, error) {
+	size := len(private.PublicKey().Bytes())
+	if len(wrapped) < size {
+		return nil, ErrCiphertextTooShort
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(wrapped[:size])
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	kek, err := wrapKey(private, ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(kek, wrapped[size:], nil)
+}
+
+// Fingerprint returns a short form of a public key that users can compare out of band
+// to make sure the server handed out the right key
+func Fingerprint(publicKey []byte) string {
+	sum := sha256.Sum256(publicKey)
+	digits := hex.EncodeToString(sum[:10])
+
+	groups := make([]string, 0, len(digits)/4)
+	for i := 0; i < len(digits); i += 4 {
+		groups = append(groups, digits[i:i+4])
+	}
+	return strings.Join(groups, " ")
+}
+
+// wrapKey derives the key-wrapping key from the X25519 shared secret of a key pair and a
+// public key, bound to both public keys of the exchange
+func wrapKey(private *ecdh.PrivateKey, public *ecdh.PublicKey) ([]byte, error) {
+	secret, err := private.ECDH(public)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	// The salt orders the ephemeral and the recipient key the same way on both sides
+	a, b := private.PublicKey().Bytes(), public.Bytes()
+	if string(a) > string(b) {
+		a, b = b, a
+	}
+	return derive(secret, append(append([]byte{}, a...), b...), wrapKeyInfo)
+}
+
+// derive expands a secret into a key with HKDF-SHA256
+func derive(secret, salt []byte, info string) ([]byte, error) {
+	out := make([]byte, KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
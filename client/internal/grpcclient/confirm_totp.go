@@ -0,0 +1,40 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ConfirmTOTP turns on two-factor authentication via gRPC with a code of the secret returned by
+// EnableTOTP and returns the single use recovery codes
+func (c *Client) ConfirmTOTP(ctx context.Context, jwt, code string) ([]string, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || code == "" {
+		return nil, ErrorTOTP
+	}
+
+	grpcResp, err := c.ClientConn.ConfirmTOTP(ctx, &proto.ConfirmTOTPRequest{Code: code})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.InvalidArgument:
+			return nil, ErrorTOTPCode
+		case unavailable(err):
+			return nil, ErrorUnavailable
+		}
+		return nil, ErrorTOTP
+	}
+	if !grpcResp.Success {
+		return nil, ErrorTOTP
+	}
+
+	return grpcResp.RecoveryCodes, nil
+}
@@ -0,0 +1,126 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfirmTOTP implements the mock ConfirmTOTP method
+func (m *MockVaultServer) ConfirmTOTP(ctx context.Context, req *proto.ConfirmTOTPRequest) (*proto.ConfirmTOTPResponse, error) {
+	fmt.Printf("DEBUG MockServer: ConfirmTOTP called with code: %s, shouldSucceed: %t\n", req.Code, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Code != mockTOTPCode {
+		return nil, status.Error(codes.InvalidArgument, "Invalid two-factor code")
+	}
+
+	login := m.totpLogin(ctx)
+	if m.totp[login] != totpPending {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication setup not started")
+	}
+	m.totp[login] = totpEnabled
+
+	return &proto.ConfirmTOTPResponse{
+		Success:       true,
+		RecoveryCodes: mockRecoveryCodes,
+	}, nil
+}
+
+func TestDataVault_ConfirmTOTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		enable        bool
+		jwt           string
+		code          string
+		expectedErr   error
+	}{
+		{
+			name:          "successful confirmation",
+			shouldSucceed: true,
+			enable:        true,
+			jwt:           "totp-token",
+			code:          mockTOTPCode,
+		},
+		{
+			name:          "wrong code",
+			shouldSucceed: true,
+			enable:        true,
+			jwt:           "totp-token",
+			code:          "000000",
+			expectedErr:   ErrorTOTPCode,
+		},
+		{
+			name:          "setup not started",
+			shouldSucceed: true,
+			jwt:           "totp-token",
+			code:          mockTOTPCode,
+			expectedErr:   ErrorTOTP,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "totp-token",
+			code:          mockTOTPCode,
+			expectedErr:   ErrorTOTP,
+		},
+		{
+			name:          "empty code",
+			shouldSucceed: true,
+			jwt:           "totp-token",
+			code:          "",
+			expectedErr:   ErrorTOTP,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			code:          mockTOTPCode,
+			expectedErr:   ErrorTOTP,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			if tt.enable {
+				_, err := client.EnableTOTP(context.Background(), tt.jwt)
+				require.NoError(t, err)
+			}
+
+			codes, err := client.ConfirmTOTP(context.Background(), tt.jwt, tt.code)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, codes)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, mockRecoveryCodes, codes)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// CreateOrg creates an organization vault owned by the user via gRPC. The wrapped key is the
+// organization key sealed to the user's own sharing key.
+func (c *Client) CreateOrg(ctx context.Context, jwt, name string, wrappedKey []byte) error {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || name == "" || len(wrappedKey) == 0 {
+		return ErrorOrg
+	}
+
+	grpcResp, err := c.ClientConn.CreateOrg(ctx, &proto.CreateOrgRequest{
+		Name:       name,
+		WrappedKey: wrappedKey,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.AlreadyExists:
+			return ErrorOrgExists
+		case unavailable(err):
+			return ErrorUnavailable
+		}
+		return ErrorOrg
+	}
+	if !grpcResp.Success {
+		return ErrorOrg
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateOrg implements the mock CreateOrg method
+func (m *MockVaultServer) CreateOrg(ctx context.Context, req *proto.CreateOrgRequest) (*proto.CreateOrgResponse, error) {
+	fmt.Printf("DEBUG MockServer: CreateOrg called with name: %s, shouldSucceed: %t\n", req.Name, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Name == "taken" {
+		return nil, status.Error(codes.AlreadyExists, "Organization already exists")
+	}
+
+	return &proto.CreateOrgResponse{
+		Success: true,
+	}, nil
+}
+
+func TestDataVault_CreateOrg(t *testing.T) {
+	t.Parallel()
+
+	wrapped := []byte("wrapped org key")
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		org           string
+		wrapped       []byte
+		expectedErr   error
+	}{
+		{
+			name:          "successful creation",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           "acme",
+			wrapped:       wrapped,
+		},
+		{
+			name:          "name taken",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           "taken",
+			wrapped:       wrapped,
+			expectedErr:   ErrorOrgExists,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "org-token",
+			org:           "acme",
+			wrapped:       wrapped,
+			expectedErr:   ErrorOrg,
+		},
+		{
+			name:          "empty wrapped key",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           "acme",
+			expectedErr:   ErrorOrg,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			org:           "acme",
+			wrapped:       wrapped,
+			expectedErr:   ErrorOrg,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			err := client.CreateOrg(context.Background(), tt.jwt, tt.org, tt.wrapped)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
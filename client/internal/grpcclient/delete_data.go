@@ -2,13 +2,14 @@ package grpcclient
 
 import (
 	"context"
+	"fmt"
 
 	"data-vault/client/internal/proto"
 
 	"google.golang.org/grpc/metadata"
 )
 
-// DeleteData removes a specific data entry from the vault via gRPC
+// DeleteData moves a specific data entry of the vault to the trash via gRPC
 func (c *Client) DeleteData(ctx context.Context, jwt, id string) error {
 	md := metadata.New(map[string]string{
 		"authorization": "Bearer " + jwt,
@@ -24,6 +25,15 @@ func (c *Client) DeleteData(ctx context.Context, jwt, id string) error {
 	}
 
 	grpcResp, err := c.ClientConn.DeleteData(ctx, req)
+	if unavailable(err) {
+		return ErrorUnavailable
+	}
+	if forbidden(err) {
+		return ErrorForbidden
+	}
+	if rejected(err) {
+		return fmt.Errorf("%w: %w", ErrorDelete, rejection(err))
+	}
 	if err != nil || !grpcResp.Success {
 		return ErrorDelete
 	}
@@ -81,7 +81,8 @@ func TestDataVault_DeleteData_WithJWTIntegration(t *testing.T) {
 		Password: "securepassword123",
 	}
 
-	jwt, err := client.Register(ctx, user)
+	registered, err := client.Register(ctx, user)
+	jwt := registered.JWT
 	assert.NoError(t, err, "Registration should succeed")
 	assert.NotEmpty(t, jwt, "JWT should not be empty")
 
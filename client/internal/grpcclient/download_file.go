@@ -0,0 +1,77 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DownloadFile opens a file download stream via gRPC starting near the given offset.
+// It returns the file info, whose Offset is where the stream actually starts, and a
+// function yielding the encrypted chunks one by one until io.EOF.
+func (c *Client) DownloadFile(ctx context.Context, jwt, id string, offset int64) (models.FileInfo, func() ([]byte, error), error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || id == "" || offset < 0 {
+		return models.FileInfo{}, nil, ErrorDownload
+	}
+
+	stream, err := c.ClientConn.DownloadFile(ctx, &proto.DownloadFileRequest{
+		Id:     id,
+		Offset: offset,
+	})
+	if err != nil {
+		return models.FileInfo{}, nil, ErrorDownload
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return models.FileInfo{}, nil, downloadError(err)
+	}
+
+	info := first.GetInfo()
+	if info == nil || info.ChunkSize <= 0 {
+		return models.FileInfo{}, nil, ErrorDownload
+	}
+
+	next := func() ([]byte, error) {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, downloadError(err)
+		}
+
+		chunk := resp.GetChunk()
+		if chunk == nil {
+			return nil, ErrorDownload
+		}
+		return chunk, nil
+	}
+
+	return fileInfoFromProto(info), next, nil
+}
+
+// downloadError maps a download stream status to a client error
+func downloadError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ErrorNotFound
+	case codes.FailedPrecondition:
+		return ErrorPartial
+	case codes.PermissionDenied:
+		return ErrorForbidden
+	}
+	return ErrorDownload
+}
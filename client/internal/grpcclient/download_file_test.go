@@ -0,0 +1,129 @@
+package grpcclient
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DownloadFile implements the mock DownloadFile method
+func (m *MockVaultServer) DownloadFile(req *proto.DownloadFileRequest, stream grpc.ServerStreamingServer[proto.DownloadFileResponse]) error {
+	if err := m.checkStreamJWT(stream.Context()); err != nil {
+		return err
+	}
+
+	if !m.shouldSucceed {
+		return status.Error(codes.Internal, "server failure")
+	}
+
+	file, ok := m.files[req.Id]
+	if !ok {
+		return status.Error(codes.NotFound, "File not found")
+	}
+
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	info := file.info(req.Id)
+	if !info.Complete {
+		return status.Error(codes.FailedPrecondition, "File upload is not complete")
+	}
+
+	from := req.Offset / file.chunkSize
+	info.Offset = from * file.chunkSize
+	if err := stream.Send(&proto.DownloadFileResponse{Payload: &proto.DownloadFileResponse_Info{Info: info}}); err != nil {
+		return err
+	}
+
+	for _, c := range file.chunks[from:] {
+		if err := stream.Send(&proto.DownloadFileResponse{Payload: &proto.DownloadFileResponse_Chunk{Chunk: c}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readAll drains a download chunk iterator
+func readAll(t *testing.T, next func() ([]byte, error)) []string {
+	var chunks []string
+	for {
+		c, err := next()
+		if err == io.EOF {
+			return chunks
+		}
+		require.NoError(t, err)
+		chunks = append(chunks, string(c))
+	}
+}
+
+func TestDataVault_DownloadFile(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "download-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	created, err := client.UploadFile(ctx, expectedToken, models.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: 4}, nil)
+	require.NoError(t, err)
+
+	chunks := []string{"aaaa", "bbbb", "cc"}
+	i := 0
+	_, err = client.UploadFile(ctx, expectedToken, models.FileInfo{ID: created.ID}, func() ([]byte, error) {
+		if i == len(chunks) {
+			return nil, io.EOF
+		}
+		i++
+		return []byte(chunks[i-1]), nil
+	})
+	require.NoError(t, err)
+
+	info, next, err := client.DownloadFile(ctx, expectedToken, created.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), info.Offset)
+	assert.Equal(t, []byte("meta"), info.Meta)
+	assert.Equal(t, chunks, readAll(t, next))
+
+	info, next, err = client.DownloadFile(ctx, expectedToken, created.ID, 9)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), info.Offset, "Offset should be rounded down to a chunk boundary")
+	assert.Equal(t, []string{"cc"}, readAll(t, next))
+}
+
+func TestDataVault_DownloadFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "download-error-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	_, _, err := client.DownloadFile(ctx, "", "file-1", 0)
+	assert.ErrorIs(t, err, ErrorDownload, "Empty JWT should be rejected")
+
+	_, _, err = client.DownloadFile(ctx, expectedToken, "", 0)
+	assert.ErrorIs(t, err, ErrorDownload, "Empty ID should be rejected")
+
+	_, _, err = client.DownloadFile(ctx, expectedToken, "missing", 0)
+	assert.ErrorIs(t, err, ErrorNotFound)
+
+	created, err := client.UploadFile(ctx, expectedToken, models.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: 4}, nil)
+	require.NoError(t, err)
+
+	_, _, err = client.DownloadFile(ctx, expectedToken, created.ID, 0)
+	assert.ErrorIs(t, err, ErrorPartial, "Incomplete uploads can't be downloaded")
+}
@@ -0,0 +1,37 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// EmptyTrash permanently removes the trashed data entries via gRPC and returns how many were removed
+func (c *Client) EmptyTrash(ctx context.Context, jwt string) (int64, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return 0, ErrorTrash
+	}
+
+	grpcResp, err := c.ClientConn.EmptyTrash(ctx, &proto.EmptyTrashRequest{})
+	if err != nil {
+		if unavailable(err) {
+			return 0, ErrorUnavailable
+		}
+		if forbidden(err) {
+			return 0, ErrorForbidden
+		}
+		return 0, ErrorTrash
+	}
+	if !grpcResp.Success {
+		return 0, ErrorTrash
+	}
+
+	return grpcResp.Purged, nil
+}
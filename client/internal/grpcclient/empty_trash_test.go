@@ -0,0 +1,106 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EmptyTrash implements the mock EmptyTrash method
+func (m *MockVaultServer) EmptyTrash(ctx context.Context, req *proto.EmptyTrashRequest) (*proto.EmptyTrashResponse, error) {
+	fmt.Printf("DEBUG MockServer: EmptyTrash called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	return &proto.EmptyTrashResponse{
+		Success: true,
+		Purged:  mockTrashSize,
+	}, nil
+}
+
+func TestDataVault_EmptyTrash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful purge",
+			shouldSucceed: true,
+			jwt:           "trash-token",
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "trash-token",
+			expectedErr:   ErrorTrash,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorTrash,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			purged, err := client.EmptyTrash(context.Background(), tt.jwt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Zero(t, purged)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(mockTrashSize), purged)
+			}
+		})
+	}
+}
+
+func TestDataVault_EmptyTrash_WithJWTIntegration(t *testing.T) {
+	t.Parallel()
+
+	jwtSecret := "test-secret-for-trash"
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, jwtSecret)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	registered, err := client.Register(ctx, models.User{Login: "trashuser", Password: "trashpassword"})
+	jwt := registered.JWT
+	require.NoError(t, err)
+
+	purged, err := client.EmptyTrash(ctx, jwt)
+	require.NoError(t, err, "EmptyTrash should succeed with valid JWT")
+	assert.Equal(t, int64(mockTrashSize), purged)
+
+	_, err = client.EmptyTrash(ctx, "invalid.jwt.token")
+	assert.Error(t, err, "EmptyTrash should fail with invalid JWT")
+}
@@ -0,0 +1,44 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// EnableTOTP starts the two-factor authentication setup via gRPC and returns the new secret.
+// The setup takes effect once a code of the secret is passed to ConfirmTOTP.
+func (c *Client) EnableTOTP(ctx context.Context, jwt string) (models.TOTPSetup, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return models.TOTPSetup{}, ErrorTOTP
+	}
+
+	grpcResp, err := c.ClientConn.EnableTOTP(ctx, &proto.EnableTOTPRequest{})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.FailedPrecondition:
+			return models.TOTPSetup{}, ErrorTOTPOn
+		case unavailable(err):
+			return models.TOTPSetup{}, ErrorUnavailable
+		}
+		return models.TOTPSetup{}, ErrorTOTP
+	}
+	if !grpcResp.Success {
+		return models.TOTPSetup{}, ErrorTOTP
+	}
+
+	return models.TOTPSetup{
+		Secret: grpcResp.Secret,
+		URI:    grpcResp.Uri,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Two-factor states of the mock users
+const (
+	totpPending = "pending"
+	totpEnabled = "enabled"
+)
+
+// mockTOTPCode is the only code the mock authenticator app ever shows
+const mockTOTPCode = "123456"
+
+// mockRecoveryCodes are the recovery codes the mock server hands out
+var mockRecoveryCodes = []string{"abcde-fghij", "klmno-pqrst"}
+
+// totpLogin returns the user of the bearer token of a mock two-factor call
+func (m *MockVaultServer) totpLogin(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	auth := md.Get("authorization")
+	if len(auth) == 0 {
+		return ""
+	}
+
+	login, _ := m.ValidateTestJWT(strings.TrimPrefix(auth[0], "Bearer "))
+	return login
+}
+
+// EnableTOTP implements the mock EnableTOTP method
+func (m *MockVaultServer) EnableTOTP(ctx context.Context, req *proto.EnableTOTPRequest) (*proto.EnableTOTPResponse, error) {
+	fmt.Printf("DEBUG MockServer: EnableTOTP called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	login := m.totpLogin(ctx)
+	if m.totp[login] == totpEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication already enabled")
+	}
+	m.totp[login] = totpPending
+
+	return &proto.EnableTOTPResponse{
+		Success: true,
+		Secret:  "JBSWY3DPEHPK3PXP",
+		Uri:     "otpauth://totp/DataVault:" + login + "?secret=JBSWY3DPEHPK3PXP",
+	}, nil
+}
+
+func TestDataVault_EnableTOTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful setup",
+			shouldSucceed: true,
+			jwt:           "totp-token",
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "totp-token",
+			expectedErr:   ErrorTOTP,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorTOTP,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			setup, err := client.EnableTOTP(context.Background(), tt.jwt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, setup.Secret)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "JBSWY3DPEHPK3PXP", setup.Secret)
+				assert.Contains(t, setup.URI, "otpauth://totp/")
+			}
+		})
+	}
+}
+
+func TestDataVault_EnableTOTP_AlreadyEnabled(t *testing.T) {
+	t.Parallel()
+
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, "totp-secret")
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+
+	registered, err := client.Register(context.Background(), models.User{Login: "totpuser", Password: "totppassword"})
+	require.NoError(t, err)
+
+	_, err = client.EnableTOTP(context.Background(), registered.JWT)
+	require.NoError(t, err)
+
+	_, err = client.ConfirmTOTP(context.Background(), registered.JWT, mockTOTPCode)
+	require.NoError(t, err)
+
+	_, err = client.EnableTOTP(context.Background(), registered.JWT)
+	assert.ErrorIs(t, err, ErrorTOTPOn)
+}
@@ -2,11 +2,91 @@ package grpcclient
 
 import (
 	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// retryAfterHeader is the response header in which the server tells a rate limited client how
+// many seconds to wait
+const retryAfterHeader = "retry-after"
+
 // Package level errors for the gRPC client layer
 var (
-	ErrorLogin    = errors.New("can't login")
-	ErrorRegister = errors.New("can't register")
-	ErrorDelete   = errors.New("can't delete data")
+	ErrorLogin      = errors.New("can't login")
+	ErrorRegister   = errors.New("can't register")
+	ErrorDelete     = errors.New("can't delete data")
+	ErrorUpdate     = errors.New("can't update data")
+	ErrorConflict   = errors.New("data was modified since it was last read, fetch it again and retry")
+	ErrorNotFound   = errors.New("data not found")
+	ErrorSync       = errors.New("can't sync data")
+	ErrorUpload     = errors.New("can't upload file")
+	ErrorDownload   = errors.New("can't download file")
+	ErrorOffset     = errors.New("upload offset doesn't match the server, retry to resume")
+	ErrorPartial    = errors.New("file upload is not complete")
+	ErrorHistory    = errors.New("can't read data history")
+	ErrorRestore    = errors.New("can't restore data version")
+	ErrorNoVersion  = errors.New("version not found in data history")
+	ErrorTrash      = errors.New("can't access trash")
+	ErrorNotInTrash = errors.New("data not found in trash")
+	ErrorRefresh    = errors.New("session expired, please login again")
+	ErrorSession    = errors.New("can't manage sessions")
+	ErrorNoSession  = errors.New("session not found")
+	ErrorTOTP       = errors.New("can't manage two-factor authentication")
+	ErrorTOTPCode   = errors.New("invalid two-factor code")
+	ErrorTOTPOn     = errors.New("two-factor authentication is already enabled")
+	ErrorShare      = errors.New("can't manage shared data")
+	ErrorNoUser     = errors.New("user not found")
+	ErrorNoKey      = errors.New("user has no sharing key yet, they have to log in once first")
+	ErrorNoShare    = errors.New("share not found")
+	ErrorOrg        = errors.New("can't manage organizations")
+	ErrorNoOrg      = errors.New("organization not found or you are not a member")
+	ErrorOrgExists  = errors.New("organization already exists")
+	ErrorForbidden  = errors.New("your organization role does not allow this operation")
+	ErrorAudit      = errors.New("can't read audit log")
+	ErrorTooMany    = errors.New("too many login attempts")
+	ErrorDatabase   = errors.New("server can't reach its database")
+
+	ErrorUnavailable = errors.New("server is unreachable")
+	ErrorRejected    = errors.New("server rejected the request")
 )
+
+// forbidden reports whether a call was rejected because of the caller's organization role
+func forbidden(err error) bool {
+	return status.Code(err) == codes.PermissionDenied
+}
+
+// unavailable reports whether a call failed because the server couldn't be reached
+func unavailable(err error) bool {
+	code := status.Code(err)
+	return code == codes.Unavailable || code == codes.DeadlineExceeded
+}
+
+// rejected reports whether the server refused a call for good, so that sending it again can't succeed
+func rejected(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return true
+	}
+	return false
+}
+
+// rejection returns the error of a call the server refused for good, with the server's reason
+func rejection(err error) error {
+	return fmt.Errorf("%w: %s", ErrorRejected, status.Convert(err).Message())
+}
+
+// rateLimited reports whether a call was rejected by the server's rate limiter
+func rateLimited(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
+
+// retryLater returns the error of a rate limited call, naming the wait the server asked for
+func retryLater(header metadata.MD) error {
+	if wait := header.Get(retryAfterHeader); len(wait) > 0 {
+		return fmt.Errorf("%w, retry in %s seconds", ErrorTooMany, wait[0])
+	}
+	return ErrorTooMany
+}
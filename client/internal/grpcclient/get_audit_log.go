@@ -0,0 +1,54 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// GetAuditLog retrieves a page of the user's audit log via gRPC, newest first. A positive
+// beforeSeq continues after the last entry of the previous page.
+func (c *Client) GetAuditLog(ctx context.Context, jwt string, beforeSeq int64, pageSize int32) ([]models.AuditEntry, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return nil, ErrorAudit
+	}
+
+	grpcResp, err := c.ClientConn.GetAuditLog(ctx, &proto.GetAuditLogRequest{
+		PageSize:  pageSize,
+		BeforeSeq: beforeSeq,
+	})
+	if err != nil {
+		if unavailable(err) {
+			return nil, ErrorUnavailable
+		}
+		return nil, ErrorAudit
+	}
+
+	entries := make([]models.AuditEntry, 0, len(grpcResp.Entries))
+	for _, e := range grpcResp.Entries {
+		entries = append(entries, models.AuditEntry{
+			User:      e.User,
+			Seq:       e.Seq,
+			At:        e.At,
+			Action:    e.Action,
+			RecordID:  e.RecordId,
+			Org:       e.Org,
+			ClientIP:  e.ClientIp,
+			UserAgent: e.UserAgent,
+			Result:    e.Result,
+			PrevHash:  e.PrevHash,
+			Hash:      e.Hash,
+			Authentic: e.Authentic,
+		})
+	}
+
+	return entries, nil
+}
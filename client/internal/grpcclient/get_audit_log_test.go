@@ -0,0 +1,108 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetAuditLog implements the mock GetAuditLog method
+func (m *MockVaultServer) GetAuditLog(ctx context.Context, req *proto.GetAuditLogRequest) (*proto.GetAuditLogResponse, error) {
+	fmt.Printf("DEBUG MockServer: GetAuditLog called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	log := []*proto.AuditEntry{
+		{User: "test-user", Seq: 2, Action: "GetData", Result: "OK", PrevHash: []byte("hash1"), Hash: []byte("hash2")},
+		{User: "test-user", Seq: 1, Action: "Login", ClientIp: "10.0.0.1", Result: "OK", Hash: []byte("hash1")},
+	}
+
+	response := &proto.GetAuditLogResponse{}
+	for _, e := range log {
+		if req.BeforeSeq == 0 || e.Seq < req.BeforeSeq {
+			response.Entries = append(response.Entries, e)
+		}
+	}
+
+	return response, nil
+}
+
+func TestDataVault_GetAuditLog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		beforeSeq     int64
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name:          "successful listing",
+			shouldSucceed: true,
+			jwt:           "audit-token",
+			expectedCount: 2,
+		},
+		{
+			name:          "next page",
+			shouldSucceed: true,
+			jwt:           "audit-token",
+			beforeSeq:     2,
+			expectedCount: 1,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "audit-token",
+			expectedErr:   ErrorAudit,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorAudit,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			entries, err := client.GetAuditLog(context.Background(), tt.jwt, tt.beforeSeq, 10)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, entries)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, entries, tt.expectedCount)
+			last := entries[len(entries)-1]
+			assert.Equal(t, int64(1), last.Seq)
+			assert.Equal(t, "Login", last.Action)
+			assert.Equal(t, "10.0.0.1", last.ClientIP)
+			assert.Equal(t, []byte("hash1"), last.Hash)
+		})
+	}
+}
@@ -9,36 +9,82 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
-// GetData retrieves all user data from the vault via gRPC
-func (c *Client) GetData(ctx context.Context, jwt string) ([]models.Data, error) {
+// maxPageSize is the largest page the server returns, used when reading every entry
+const maxPageSize = 500
+
+// GetData retrieves all user data whose metadata matches the filter from the vault via gRPC,
+// reading every page. A nil filter returns all data.
+func (c *Client) GetData(ctx context.Context, jwt string, filter map[string]string) ([]models.Data, error) {
 	var resp []models.Data
 
+	query := models.DataQuery{Metadata: filter, PageSize: maxPageSize}
+	for {
+		page, err := c.GetDataPage(ctx, jwt, query)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, page.Data...)
+
+		if page.NextPageToken == "" {
+			return resp, nil
+		}
+		query.PageToken = page.NextPageToken
+	}
+}
+
+// GetDataPage retrieves a single page of user data matching the query from the vault via gRPC
+func (c *Client) GetDataPage(ctx context.Context, jwt string, query models.DataQuery) (models.DataPage, error) {
+	var resp models.DataPage
+
 	md := metadata.New(map[string]string{
 		"authorization": "Bearer " + jwt,
 	})
 	ctx = metadata.NewOutgoingContext(ctx, md)
 
 	if jwt == "" {
-		return nil, errors.New("JWT token is empty")
+		return resp, errors.New("JWT token is empty")
 	}
 
-	req := &proto.GetDataRequest{}
+	req := &proto.GetDataRequest{
+		Metadata:     query.Metadata,
+		Type:         query.Type,
+		UploadedFrom: query.UploadedFrom,
+		UploadedTo:   query.UploadedTo,
+		PageSize:     query.PageSize,
+		PageToken:    query.PageToken,
+	}
 
 	grpcResp, err := c.ClientConn.GetData(ctx, req)
 	if err != nil {
-		return nil, err
+		if unavailable(err) {
+			return resp, ErrorUnavailable
+		}
+		if forbidden(err) {
+			return resp, ErrorForbidden
+		}
+		return resp, err
 	}
 
 	for _, d := range grpcResp.Data {
-		resp = append(resp, models.Data{
-			ID:         d.Id,
-			User:       d.User,
-			Status:     d.Status,
-			Type:       d.Type,
-			Data:       d.Data,
-			UploadedAt: d.UploadedAt,
-		})
+		resp.Data = append(resp.Data, dataFromProto(d))
 	}
+	resp.NextPageToken = grpcResp.NextPageToken
 
 	return resp, nil
 }
+
+// dataFromProto converts a protobuf data entry into the client model
+func dataFromProto(d *proto.Data) models.Data {
+	return models.Data{
+		ID:         d.Id,
+		User:       d.User,
+		Status:     d.Status,
+		Type:       d.Type,
+		Data:       d.Data,
+		UploadedAt: d.UploadedAt,
+		Version:    d.Version,
+		Revision:   d.Revision,
+		Metadata:   d.Metadata,
+		DeletedAt:  d.DeletedAt,
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"data-vault/client/internal/models"
 	"data-vault/client/internal/proto"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -56,21 +58,54 @@ func (m *MockVaultServer) GetData(ctx context.Context, req *proto.GetDataRequest
 	mockData := []*proto.Data{
 		{
 			Id:         "data-1",
+			Type:       "password",
 			Data:       []byte("sample encrypted data 1"),
 			Status:     "ACTIVE",
 			UploadedAt: "2025-08-24T10:00:00Z",
+			Metadata:   map[string]string{"env": "prod", "website": "example.com"},
 		},
 		{
 			Id:         "data-2",
+			Type:       "text",
 			Data:       []byte("sample encrypted data 2"),
 			Status:     "ACTIVE",
 			UploadedAt: "2025-08-24T11:00:00Z",
+			Metadata:   map[string]string{"env": "dev"},
 		},
 	}
 
+	filtered := make([]*proto.Data, 0, len(mockData))
+	for _, d := range mockData {
+		match := req.Type == "" || req.Type == d.Type
+		for k, v := range req.Metadata {
+			if value, ok := d.Metadata[k]; !ok || (v != "" && value != v) {
+				match = false
+			}
+		}
+		if match {
+			filtered = append(filtered, d)
+		}
+	}
+	mockData = filtered
+
+	// The mock serves one entry per page, whatever page size was asked for
+	start := 0
+	if req.PageToken != "" {
+		var err error
+		if start, err = strconv.Atoi(req.PageToken); err != nil || start > len(mockData) {
+			return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+		}
+	}
+	nextToken := ""
+	if start+1 < len(mockData) {
+		nextToken = strconv.Itoa(start + 1)
+	}
+	mockData = mockData[start:min(start+1, len(mockData))]
+
 	fmt.Printf("DEBUG MockServer: GetData successful, returning %d items\n", len(mockData))
 	return &proto.GetDataResponse{
-		Data: mockData,
+		Data:          mockData,
+		NextPageToken: nextToken,
 	}, nil
 }
 
@@ -89,11 +124,12 @@ func TestDataVault_GetData_WithJWTIntegration(t *testing.T) {
 		Password: "securepassword123",
 	}
 
-	jwt, err := client.Register(ctx, user)
+	registered, err := client.Register(ctx, user)
+	jwt := registered.JWT
 	assert.NoError(t, err, "Registration should succeed")
 	assert.NotEmpty(t, jwt, "JWT should not be empty")
 
-	data, err := client.GetData(ctx, jwt)
+	data, err := client.GetData(ctx, jwt, nil)
 	assert.NoError(t, err, "GetData should succeed with valid JWT")
 	assert.NotNil(t, data, "Data should not be nil")
 	assert.Len(t, data, 2, "Should return 2 mock data items")
@@ -115,7 +151,7 @@ func TestDataVault_GetData_WithInvalidJWT(t *testing.T) {
 
 	invalidJWT := "invalid.jwt.token"
 
-	data, err := client.GetData(ctx, invalidJWT)
+	data, err := client.GetData(ctx, invalidJWT, nil)
 	assert.Error(t, err, "GetData should fail with invalid JWT")
 	assert.Nil(t, data, "Data should be nil on error")
 }
@@ -130,7 +166,7 @@ func TestDataVault_GetData_WithoutJWT(t *testing.T) {
 	client := SetupTestClient(t, lis)
 	ctx := context.Background()
 
-	data, err := client.GetData(ctx, "")
+	data, err := client.GetData(ctx, "", nil)
 	assert.Error(t, err, "GetData should fail with empty JWT")
 	assert.Nil(t, data, "Data should be nil on error")
 }
@@ -224,7 +260,7 @@ func TestDataVault_GetData(t *testing.T) {
 			client, ctx, cleanup := tt.setupFunc(t)
 			defer cleanup()
 
-			data, err := client.GetData(ctx, tt.jwt)
+			data, err := client.GetData(ctx, tt.jwt, nil)
 
 			tt.validateResult(t, data, err)
 			if tt.expectedError {
@@ -248,7 +284,7 @@ func TestDataVault_GetData_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	data, err := client.GetData(ctx, expectedToken)
+	data, err := client.GetData(ctx, expectedToken, nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, data)
@@ -268,7 +304,7 @@ func TestDataVault_GetData_ContextTimeout(t *testing.T) {
 
 	time.Sleep(1 * time.Millisecond)
 
-	data, err := client.GetData(ctx, expectedToken)
+	data, err := client.GetData(ctx, expectedToken, nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, data)
@@ -285,7 +321,7 @@ func TestDataVault_GetData_MultipleConsecutive(t *testing.T) {
 	ctx := context.Background()
 
 	for i := 0; i < 3; i++ {
-		data, err := client.GetData(ctx, expectedToken)
+		data, err := client.GetData(ctx, expectedToken, nil)
 		assert.NoError(t, err, "Expected get data %d to succeed", i+1)
 		assert.Len(t, data, 2, "Expected 2 data items on request %d", i+1)
 	}
@@ -301,7 +337,7 @@ func TestDataVault_GetData_EmptyResponse(t *testing.T) {
 	client := SetupTestClient(t, lis)
 	ctx := context.Background()
 
-	data, err := client.GetData(ctx, expectedToken)
+	data, err := client.GetData(ctx, expectedToken, nil)
 
 	assert.NoError(t, err, "Expected get data to succeed")
 	assert.NotNil(t, data, "Data slice should not be nil even if empty")
@@ -317,7 +353,7 @@ func TestDataVault_GetData_DataIntegrity(t *testing.T) {
 	client := SetupTestClient(t, lis)
 	ctx := context.Background()
 
-	data, err := client.GetData(ctx, expectedToken)
+	data, err := client.GetData(ctx, expectedToken, nil)
 	assert.NoError(t, err)
 	assert.Len(t, data, 2)
 
@@ -328,3 +364,62 @@ func TestDataVault_GetData_DataIntegrity(t *testing.T) {
 		assert.NotEmpty(t, item.UploadedAt, "Data item %d should have non-empty UploadedAt", i)
 	}
 }
+
+func TestDataVault_GetData_MetadataFilter(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "metadata-filter-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	data, err := client.GetData(ctx, expectedToken, map[string]string{"env": "prod"})
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+	assert.Equal(t, "data-1", data[0].ID)
+	assert.Equal(t, map[string]string{"env": "prod", "website": "example.com"}, data[0].Metadata)
+
+	data, err = client.GetData(ctx, expectedToken, map[string]string{"env": ""})
+	require.NoError(t, err)
+	assert.Len(t, data, 2)
+
+	data, err = client.GetData(ctx, expectedToken, map[string]string{"env": "staging"})
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestDataVault_GetDataPage(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "paging-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	page, err := client.GetDataPage(ctx, expectedToken, models.DataQuery{PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "data-1", page.Data[0].ID)
+	require.NotEmpty(t, page.NextPageToken)
+
+	page, err = client.GetDataPage(ctx, expectedToken, models.DataQuery{PageSize: 10, PageToken: page.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "data-2", page.Data[0].ID)
+	assert.Empty(t, page.NextPageToken)
+
+	page, err = client.GetDataPage(ctx, expectedToken, models.DataQuery{Type: "text"})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "data-2", page.Data[0].ID)
+
+	_, err = client.GetDataPage(ctx, expectedToken, models.DataQuery{PageToken: "garbage"})
+	assert.Error(t, err)
+
+	_, err = client.GetDataPage(ctx, "", models.DataQuery{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GetPublicKey retrieves the sharing public key of another user via gRPC
+func (c *Client) GetPublicKey(ctx context.Context, jwt, login string) ([]byte, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || login == "" {
+		return nil, ErrorShare
+	}
+
+	grpcResp, err := c.ClientConn.GetPublicKey(ctx, &proto.GetPublicKeyRequest{Login: login})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return nil, ErrorNoUser
+		case status.Code(err) == codes.FailedPrecondition:
+			return nil, ErrorNoKey
+		case unavailable(err):
+			return nil, ErrorUnavailable
+		}
+		return nil, ErrorShare
+	}
+	if !grpcResp.Success {
+		return nil, ErrorShare
+	}
+
+	return grpcResp.PublicKey, nil
+}
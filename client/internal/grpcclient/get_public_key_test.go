@@ -0,0 +1,115 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPublicKey implements the mock GetPublicKey method. User "nobody" doesn't exist and
+// user "nokey" never published a key.
+func (m *MockVaultServer) GetPublicKey(ctx context.Context, req *proto.GetPublicKeyRequest) (*proto.GetPublicKeyResponse, error) {
+	fmt.Printf("DEBUG MockServer: GetPublicKey called with login: %s, shouldSucceed: %t\n", req.Login, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	switch req.Login {
+	case "nobody":
+		return nil, status.Error(codes.NotFound, "User not found")
+	case "nokey":
+		return nil, status.Error(codes.FailedPrecondition, "User has not published a public key yet")
+	}
+
+	return &proto.GetPublicKeyResponse{
+		Success:   true,
+		PublicKey: mockPublicKey,
+	}, nil
+}
+
+func TestDataVault_GetPublicKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		login         string
+		expectedErr   error
+	}{
+		{
+			name:          "successful lookup",
+			shouldSucceed: true,
+			jwt:           "key-token",
+			login:         "alice",
+		},
+		{
+			name:          "unknown user",
+			shouldSucceed: true,
+			jwt:           "key-token",
+			login:         "nobody",
+			expectedErr:   ErrorNoUser,
+		},
+		{
+			name:          "user without key",
+			shouldSucceed: true,
+			jwt:           "key-token",
+			login:         "nokey",
+			expectedErr:   ErrorNoKey,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "key-token",
+			login:         "alice",
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty login",
+			shouldSucceed: true,
+			jwt:           "key-token",
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			login:         "alice",
+			expectedErr:   ErrorShare,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			key, err := client.GetPublicKey(context.Background(), tt.jwt, tt.login)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, key)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, mockPublicKey, key)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package grpcclient
 import (
 	"context"
 	"data-vault/client/internal/config"
+	"sync"
 
 	"data-vault/client/internal/proto"
 
@@ -14,6 +15,10 @@ import (
 type Client struct {
 	cfg        config.Config
 	ClientConn proto.VaultServiceClient
+
+	tokens TokenStore
+	mu     sync.Mutex
+	org    string
 }
 
 // New creates a new gRPC client instance with TLS connection
@@ -23,20 +28,21 @@ func New(ctx context.Context, cfg config.Config) (*Client, error) {
 		return nil, err
 	}
 
+	clientInstance := &Client{
+		cfg: cfg,
+	}
+
 	conn, err := grpc.NewClient(
 		cfg.ServerAddr,
 		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(clientInstance.renewUnary, clientInstance.orgUnary),
+		grpc.WithChainStreamInterceptor(clientInstance.renewStream, clientInstance.orgStream),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	grpcClient := proto.NewVaultServiceClient(conn)
-
-	clientInstance := Client{
-		cfg:        cfg,
-		ClientConn: grpcClient,
-	}
+	clientInstance.ClientConn = proto.NewVaultServiceClient(conn)
 
-	return &clientInstance, nil
+	return clientInstance, nil
 }
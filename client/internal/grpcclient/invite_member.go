@@ -0,0 +1,49 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// InviteMember adds a user to an organization or changes a member's role via gRPC. The wrapped
+// key is the organization key sealed to the invitee's sharing key.
+func (c *Client) InviteMember(ctx context.Context, jwt, org, login, role string, wrappedKey []byte) error {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || org == "" || login == "" || len(wrappedKey) == 0 {
+		return ErrorOrg
+	}
+
+	grpcResp, err := c.ClientConn.InviteMember(ctx, &proto.InviteMemberRequest{
+		Org:        org,
+		Login:      login,
+		Role:       role,
+		WrappedKey: wrappedKey,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return ErrorNoUser
+		case status.Code(err) == codes.FailedPrecondition:
+			return ErrorNoKey
+		case forbidden(err):
+			return ErrorForbidden
+		case unavailable(err):
+			return ErrorUnavailable
+		}
+		return ErrorOrg
+	}
+	if !grpcResp.Success {
+		return ErrorOrg
+	}
+
+	return nil
+}
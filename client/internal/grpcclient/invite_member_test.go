@@ -0,0 +1,133 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockForbiddenOrg is an organization in which every mock user has the read-only role
+const mockForbiddenOrg = "forbidden"
+
+// InviteMember implements the mock InviteMember method
+func (m *MockVaultServer) InviteMember(ctx context.Context, req *proto.InviteMemberRequest) (*proto.InviteMemberResponse, error) {
+	fmt.Printf("DEBUG MockServer: InviteMember called with org: %s, login: %s, shouldSucceed: %t\n", req.Org, req.Login, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	switch {
+	case req.Org == mockForbiddenOrg:
+		return nil, status.Error(codes.PermissionDenied, "Organization role does not allow this operation")
+	case req.Login == "nobody":
+		return nil, status.Error(codes.NotFound, "User not found")
+	case req.Login == "nokey":
+		return nil, status.Error(codes.FailedPrecondition, "User has not published a public key yet")
+	}
+
+	return &proto.InviteMemberResponse{
+		Success: true,
+	}, nil
+}
+
+func TestDataVault_InviteMember(t *testing.T) {
+	t.Parallel()
+
+	wrapped := []byte("wrapped org key")
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		org           string
+		login         string
+		wrapped       []byte
+		expectedErr   error
+	}{
+		{
+			name:          "successful invite",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           "acme",
+			login:         "alice",
+			wrapped:       wrapped,
+		},
+		{
+			name:          "role does not allow inviting",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           mockForbiddenOrg,
+			login:         "alice",
+			wrapped:       wrapped,
+			expectedErr:   ErrorForbidden,
+		},
+		{
+			name:          "invitee not found",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           "acme",
+			login:         "nobody",
+			wrapped:       wrapped,
+			expectedErr:   ErrorNoUser,
+		},
+		{
+			name:          "invitee without key",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           "acme",
+			login:         "nokey",
+			wrapped:       wrapped,
+			expectedErr:   ErrorNoKey,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "org-token",
+			org:           "acme",
+			login:         "alice",
+			wrapped:       wrapped,
+			expectedErr:   ErrorOrg,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			org:           "acme",
+			login:         "alice",
+			wrapped:       wrapped,
+			expectedErr:   ErrorOrg,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			err := client.InviteMember(context.Background(), tt.jwt, tt.org, tt.login, "member", tt.wrapped)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
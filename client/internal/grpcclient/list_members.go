@@ -0,0 +1,51 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ListMembers retrieves the members of an organization the user belongs to via gRPC
+func (c *Client) ListMembers(ctx context.Context, jwt, org string) ([]models.Member, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || org == "" {
+		return nil, ErrorOrg
+	}
+
+	grpcResp, err := c.ClientConn.ListMembers(ctx, &proto.ListMembersRequest{Org: org})
+	if err != nil {
+		switch {
+		case forbidden(err):
+			return nil, ErrorNoOrg
+		case unavailable(err):
+			return nil, ErrorUnavailable
+		}
+		return nil, ErrorOrg
+	}
+
+	members := make([]models.Member, 0, len(grpcResp.Members))
+	for _, m := range grpcResp.Members {
+		members = append(members, memberFromProto(m))
+	}
+
+	return members, nil
+}
+
+// memberFromProto converts an organization membership from its protobuf representation
+func memberFromProto(m *proto.Member) models.Member {
+	return models.Member{
+		Org:        m.Org,
+		Login:      m.Login,
+		Role:       m.Role,
+		JoinedAt:   m.JoinedAt,
+		WrappedKey: m.WrappedKey,
+	}
+}
@@ -0,0 +1,103 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListMembers implements the mock ListMembers method
+func (m *MockVaultServer) ListMembers(ctx context.Context, req *proto.ListMembersRequest) (*proto.ListMembersResponse, error) {
+	fmt.Printf("DEBUG MockServer: ListMembers called with org: %s, shouldSucceed: %t\n", req.Org, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Org == mockForbiddenOrg {
+		return nil, status.Error(codes.PermissionDenied, "Organization role does not allow this operation")
+	}
+
+	return &proto.ListMembersResponse{
+		Members: []*proto.Member{
+			{Org: req.Org, Login: "owner", Role: "owner", JoinedAt: "2026-01-01T00:00:00Z"},
+			{Org: req.Org, Login: "alice", Role: "read-only", JoinedAt: "2026-01-02T00:00:00Z"},
+		},
+	}, nil
+}
+
+func TestDataVault_ListMembers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		org           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful listing",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           "acme",
+		},
+		{
+			name:          "not a member",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			org:           mockForbiddenOrg,
+			expectedErr:   ErrorNoOrg,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "org-token",
+			org:           "acme",
+			expectedErr:   ErrorOrg,
+		},
+		{
+			name:          "empty organization",
+			shouldSucceed: true,
+			jwt:           "org-token",
+			expectedErr:   ErrorOrg,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			members, err := client.ListMembers(context.Background(), tt.jwt, tt.org)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, members)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, members, 2)
+			assert.Equal(t, "owner", members[0].Role)
+			assert.Equal(t, "alice", members[1].Login)
+			assert.Equal(t, "read-only", members[1].Role)
+		})
+	}
+}
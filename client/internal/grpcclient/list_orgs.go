@@ -0,0 +1,38 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ListOrgs retrieves the organizations the user belongs to via gRPC, with the organization
+// keys sealed to the user's sharing key
+func (c *Client) ListOrgs(ctx context.Context, jwt string) ([]models.Member, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return nil, ErrorOrg
+	}
+
+	grpcResp, err := c.ClientConn.ListOrgs(ctx, &proto.ListOrgsRequest{})
+	if err != nil {
+		if unavailable(err) {
+			return nil, ErrorUnavailable
+		}
+		return nil, ErrorOrg
+	}
+
+	memberships := make([]models.Member, 0, len(grpcResp.Memberships))
+	for _, m := range grpcResp.Memberships {
+		memberships = append(memberships, memberFromProto(m))
+	}
+
+	return memberships, nil
+}
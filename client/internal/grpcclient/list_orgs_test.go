@@ -0,0 +1,89 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListOrgs implements the mock ListOrgs method
+func (m *MockVaultServer) ListOrgs(ctx context.Context, req *proto.ListOrgsRequest) (*proto.ListOrgsResponse, error) {
+	fmt.Printf("DEBUG MockServer: ListOrgs called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	return &proto.ListOrgsResponse{
+		Memberships: []*proto.Member{
+			{Org: "acme", Login: "test-user", Role: "admin", WrappedKey: []byte("wrapped acme key")},
+			{Org: mockForbiddenOrg, Login: "test-user", Role: "read-only", WrappedKey: []byte("wrapped forbidden key")},
+		},
+	}, nil
+}
+
+func TestDataVault_ListOrgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful listing",
+			shouldSucceed: true,
+			jwt:           "org-token",
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "org-token",
+			expectedErr:   ErrorOrg,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorOrg,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			memberships, err := client.ListOrgs(context.Background(), tt.jwt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, memberships)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, memberships, 2)
+			assert.Equal(t, "acme", memberships[0].Org)
+			assert.Equal(t, "admin", memberships[0].Role)
+			assert.Equal(t, []byte("wrapped acme key"), memberships[0].WrappedKey)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ListSessions retrieves the live sessions of the user from the vault via gRPC, most recently used first
+func (c *Client) ListSessions(ctx context.Context, jwt string) ([]models.DeviceSession, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return nil, ErrorSession
+	}
+
+	grpcResp, err := c.ClientConn.ListSessions(ctx, &proto.ListSessionsRequest{})
+	if err != nil {
+		if unavailable(err) {
+			return nil, ErrorUnavailable
+		}
+		return nil, ErrorSession
+	}
+
+	sessions := make([]models.DeviceSession, 0, len(grpcResp.Sessions))
+	for _, s := range grpcResp.Sessions {
+		sessions = append(sessions, models.DeviceSession{
+			ID:         s.Id,
+			Device:     s.Device,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.Current,
+		})
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,108 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSessions implements the mock ListSessions method, the first session is the caller's
+func (m *MockVaultServer) ListSessions(ctx context.Context, req *proto.ListSessionsRequest) (*proto.ListSessionsResponse, error) {
+	fmt.Printf("DEBUG MockServer: ListSessions called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	return &proto.ListSessionsResponse{
+		Sessions: []*proto.Session{
+			{
+				Id:         "session-1",
+				Device:     "laptop",
+				CreatedAt:  "2026-01-01T00:00:00Z",
+				LastUsedAt: "2026-01-03T00:00:00Z",
+				ExpiresAt:  "2026-02-02T00:00:00Z",
+				Current:    true,
+			},
+			{
+				Id:         "session-2",
+				Device:     "phone",
+				CreatedAt:  "2026-01-01T00:00:00Z",
+				LastUsedAt: "2026-01-02T00:00:00Z",
+				ExpiresAt:  "2026-02-01T00:00:00Z",
+			},
+		},
+	}, nil
+}
+
+func TestDataVault_ListSessions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful listing",
+			shouldSucceed: true,
+			jwt:           "sessions-token",
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "sessions-token",
+			expectedErr:   ErrorSession,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorSession,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			sessions, err := client.ListSessions(context.Background(), tt.jwt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, sessions)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, sessions, 2)
+				assert.Equal(t, models.DeviceSession{
+					ID:         "session-1",
+					Device:     "laptop",
+					CreatedAt:  "2026-01-01T00:00:00Z",
+					LastUsedAt: "2026-01-03T00:00:00Z",
+					ExpiresAt:  "2026-02-02T00:00:00Z",
+					Current:    true,
+				}, sessions[0])
+				assert.False(t, sessions[1].Current)
+			}
+		})
+	}
+}
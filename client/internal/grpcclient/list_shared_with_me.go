@@ -0,0 +1,47 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ListSharedWithMe retrieves the data entries other users shared with the user via gRPC,
+// most recently shared first. The entries are still encrypted with their record keys.
+func (c *Client) ListSharedWithMe(ctx context.Context, jwt string) ([]models.SharedData, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return nil, ErrorShare
+	}
+
+	grpcResp, err := c.ClientConn.ListSharedWithMe(ctx, &proto.ListSharedWithMeRequest{})
+	if err != nil {
+		if unavailable(err) {
+			return nil, ErrorUnavailable
+		}
+		return nil, ErrorShare
+	}
+
+	shared := make([]models.SharedData, 0, len(grpcResp.Shares))
+	for _, s := range grpcResp.Shares {
+		if s.Data == nil {
+			continue
+		}
+		shared = append(shared, models.SharedData{
+			Data:       dataFromProto(s.Data),
+			Owner:      s.Owner,
+			Permission: s.Permission,
+			SharedAt:   s.SharedAt,
+			WrappedKey: s.WrappedKey,
+		})
+	}
+
+	return shared, nil
+}
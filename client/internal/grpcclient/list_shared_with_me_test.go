@@ -0,0 +1,107 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockSharedSize is the number of entries shared with every mock user
+const mockSharedSize = 2
+
+// ListSharedWithMe implements the mock ListSharedWithMe method
+func (m *MockVaultServer) ListSharedWithMe(ctx context.Context, req *proto.ListSharedWithMeRequest) (*proto.ListSharedWithMeResponse, error) {
+	fmt.Printf("DEBUG MockServer: ListSharedWithMe called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	resp := &proto.ListSharedWithMeResponse{}
+	for i := 1; i <= mockSharedSize; i++ {
+		resp.Shares = append(resp.Shares, &proto.SharedData{
+			Data: &proto.Data{
+				Id:      fmt.Sprintf("shared-%d", i),
+				User:    "owner",
+				Type:    "password",
+				Data:    []byte(fmt.Sprintf("shared data %d", i)),
+				Version: mockCurrentVersion,
+			},
+			Owner:      "owner",
+			Permission: "read",
+			WrappedKey: []byte(fmt.Sprintf("wrapped-%d", i)),
+			SharedAt:   fmt.Sprintf("2026-01-0%dT00:00:00Z", i),
+		})
+	}
+
+	return resp, nil
+}
+
+func TestDataVault_ListSharedWithMe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful listing",
+			shouldSucceed: true,
+			jwt:           "share-token",
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "share-token",
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorShare,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			shared, err := client.ListSharedWithMe(context.Background(), tt.jwt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, shared)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, shared, mockSharedSize)
+			for i, s := range shared {
+				assert.Equal(t, fmt.Sprintf("shared-%d", i+1), s.Data.ID)
+				assert.Equal(t, "owner", s.Owner)
+				assert.Equal(t, "read", s.Permission)
+				assert.Equal(t, []byte(fmt.Sprintf("wrapped-%d", i+1)), s.WrappedKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ListTrash retrieves the trashed data entries from the vault via gRPC, most recently deleted first
+func (c *Client) ListTrash(ctx context.Context, jwt string) ([]models.Data, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return nil, ErrorTrash
+	}
+
+	grpcResp, err := c.ClientConn.ListTrash(ctx, &proto.ListTrashRequest{})
+	if err != nil {
+		if unavailable(err) {
+			return nil, ErrorUnavailable
+		}
+		if forbidden(err) {
+			return nil, ErrorForbidden
+		}
+		return nil, ErrorTrash
+	}
+
+	data := make([]models.Data, 0, len(grpcResp.Data))
+	for _, d := range grpcResp.Data {
+		data = append(data, dataFromProto(d))
+	}
+
+	return data, nil
+}
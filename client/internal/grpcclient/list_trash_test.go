@@ -0,0 +1,101 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockTrashSize is the number of entries in the mock trash
+const mockTrashSize = 2
+
+// ListTrash implements the mock ListTrash method
+func (m *MockVaultServer) ListTrash(ctx context.Context, req *proto.ListTrashRequest) (*proto.ListTrashResponse, error) {
+	fmt.Printf("DEBUG MockServer: ListTrash called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	resp := &proto.ListTrashResponse{}
+	for i := 1; i <= mockTrashSize; i++ {
+		resp.Data = append(resp.Data, &proto.Data{
+			Id:        fmt.Sprintf("trashed-%d", i),
+			Status:    "DELETED",
+			Type:      "text",
+			Data:      []byte(fmt.Sprintf("trashed data %d", i)),
+			Version:   mockCurrentVersion,
+			DeletedAt: fmt.Sprintf("2026-01-0%dT00:00:00Z", i),
+		})
+	}
+
+	return resp, nil
+}
+
+func TestDataVault_ListTrash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful listing",
+			shouldSucceed: true,
+			jwt:           "trash-token",
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "trash-token",
+			expectedErr:   ErrorTrash,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorTrash,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			data, err := client.ListTrash(context.Background(), tt.jwt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, data)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, data, mockTrashSize)
+			for i, d := range data {
+				assert.Equal(t, fmt.Sprintf("trashed-%d", i+1), d.ID)
+				assert.Equal(t, "DELETED", d.Status)
+				assert.NotEmpty(t, d.DeletedAt)
+			}
+		})
+	}
+}
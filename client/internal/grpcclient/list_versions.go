@@ -0,0 +1,48 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ListVersions retrieves the prior versions of a data entry from the vault via gRPC, newest first
+func (c *Client) ListVersions(ctx context.Context, jwt, id string) ([]models.Version, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if id == "" || jwt == "" {
+		return nil, ErrorHistory
+	}
+
+	grpcResp, err := c.ClientConn.ListVersions(ctx, &proto.ListVersionsRequest{Id: id})
+	if err != nil {
+		if unavailable(err) {
+			return nil, ErrorUnavailable
+		}
+		if forbidden(err) {
+			return nil, ErrorForbidden
+		}
+		return nil, ErrorHistory
+	}
+
+	versions := make([]models.Version, 0, len(grpcResp.Versions))
+	for _, v := range grpcResp.Versions {
+		versions = append(versions, models.Version{
+			DataID:     v.DataId,
+			Version:    v.Version,
+			Type:       v.Type,
+			Data:       v.Data,
+			Metadata:   v.Metadata,
+			UploadedAt: v.UploadedAt,
+			ArchivedAt: v.ArchivedAt,
+		})
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,141 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mockHistoryVersions is the number of prior versions every mock record has
+const mockHistoryVersions = 2
+
+// ListVersions implements the mock ListVersions method
+func (m *MockVaultServer) ListVersions(ctx context.Context, req *proto.ListVersionsRequest) (*proto.ListVersionsResponse, error) {
+	fmt.Printf("DEBUG MockServer: ListVersions called with ID: %s, shouldSucceed: %t\n", req.Id, m.shouldSucceed)
+
+	if m.validateJWT {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "no metadata found")
+		}
+
+		authHeaders := md.Get("authorization")
+		if len(authHeaders) == 0 || !strings.HasPrefix(authHeaders[0], "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "no authorization header")
+		}
+
+		if _, valid := m.ValidateTestJWT(authHeaders[0][7:]); !valid {
+			return nil, status.Error(codes.Unauthenticated, "invalid JWT token")
+		}
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	resp := &proto.ListVersionsResponse{}
+	if req.Id == "missing-id" {
+		return resp, nil
+	}
+
+	for v := int64(mockHistoryVersions); v > 0; v-- {
+		resp.Versions = append(resp.Versions, &proto.Version{
+			DataId:     req.Id,
+			Version:    v,
+			Type:       "text",
+			Data:       []byte(fmt.Sprintf("version %d", v)),
+			Metadata:   map[string]string{"rev": fmt.Sprint(v)},
+			ArchivedAt: fmt.Sprintf("2026-01-0%dT00:00:00Z", v),
+		})
+	}
+
+	return resp, nil
+}
+
+func TestDataVault_ListVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		id            string
+		expectedErr   error
+		expectedCount int
+	}{
+		{
+			name:          "record with history",
+			shouldSucceed: true,
+			jwt:           "history-token",
+			id:            "data-1",
+			expectedCount: mockHistoryVersions,
+		},
+		{
+			name:          "record without history",
+			shouldSucceed: true,
+			jwt:           "history-token",
+			id:            "missing-id",
+			expectedCount: 0,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "history-token",
+			id:            "data-1",
+			expectedErr:   ErrorHistory,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			id:            "data-1",
+			expectedErr:   ErrorHistory,
+		},
+		{
+			name:          "empty ID",
+			shouldSucceed: true,
+			jwt:           "history-token",
+			id:            "",
+			expectedErr:   ErrorHistory,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			versions, err := client.ListVersions(context.Background(), tt.jwt, tt.id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, versions)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, versions, tt.expectedCount)
+			for i, v := range versions {
+				assert.Equal(t, int64(mockHistoryVersions-i), v.Version, "versions are listed newest first")
+				assert.Equal(t, tt.id, v.DataID)
+				assert.Equal(t, fmt.Sprintf("version %d", v.Version), string(v.Data))
+				assert.Equal(t, fmt.Sprint(v.Version), v.Metadata["rev"])
+				assert.NotEmpty(t, v.ArchivedAt)
+			}
+		})
+	}
+}
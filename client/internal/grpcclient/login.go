@@ -4,27 +4,48 @@ import (
 	"context"
 	"data-vault/client/internal/models"
 	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
-// Login authenticates a user via gRPC and returns a JWT token
-func (c *Client) Login(ctx context.Context, user models.User) (string, error) {
+// Login authenticates a user via gRPC and returns a session with the access and refresh tokens and key derivation salt.
+// Accounts with two-factor authentication get a challenge token to pass to VerifyTOTP instead.
+func (c *Client) Login(ctx context.Context, user models.User) (models.Session, error) {
 	req := &proto.LoginRequest{
 		User: &proto.User{
 			Login:    user.Login,
 			Password: user.Password,
 		},
+		Device:  deviceName(),
+		AuthKey: user.AuthKey,
 	}
 
 	if user.Login == "" || user.Password == "" {
-		return "", ErrorLogin
+		return models.Session{}, ErrorLogin
 	}
 
-	grpcResp, err := c.ClientConn.Login(ctx, req)
+	var header metadata.MD
+	grpcResp, err := c.ClientConn.Login(ctx, req, grpc.Header(&header))
+	if rateLimited(err) {
+		return models.Session{}, retryLater(header)
+	}
 	if err != nil || !grpcResp.Success {
-		return "", ErrorLogin
+		return models.Session{}, ErrorLogin
 	}
 
-	jwt := grpcResp.JwtToken
+	if grpcResp.TotpRequired {
+		return models.Session{
+			TOTPRequired:   true,
+			ChallengeToken: grpcResp.ChallengeToken,
+		}, nil
+	}
+
+	session := models.Session{
+		JWT:          grpcResp.JwtToken,
+		RefreshToken: grpcResp.RefreshToken,
+		Salt:         grpcResp.KdfSalt,
+	}
 
-	return jwt, nil
+	return session, nil
 }
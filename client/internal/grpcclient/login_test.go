@@ -10,8 +10,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// mockLockedLogin is a login the mock server rejects as locked out by the rate limiter
+const mockLockedLogin = "locked-user"
+
 // Login implements the mock Login method
 func (m *MockVaultServer) Login(ctx context.Context, req *proto.LoginRequest) (*proto.LoginResponse, error) {
 	fmt.Printf("DEBUG MockServer: Login called with login: %s, shouldSucceed: %t\n", req.User.Login, m.shouldSucceed)
@@ -20,6 +27,11 @@ func (m *MockVaultServer) Login(ctx context.Context, req *proto.LoginRequest) (*
 		m.registeredUsers = make(map[string]string)
 	}
 
+	if req.User.Login == mockLockedLogin {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", "30"))
+		return nil, status.Error(codes.ResourceExhausted, "too many attempts, retry in 30 seconds")
+	}
+
 	if !m.shouldSucceed {
 		fmt.Printf("DEBUG MockServer: shouldSucceed is false, returning failure\n")
 		return &proto.LoginResponse{
@@ -36,6 +48,20 @@ func (m *MockVaultServer) Login(ctx context.Context, req *proto.LoginRequest) (*
 		}, nil
 	}
 
+	if req.AuthKey != "" && m.rawPasswords[req.User.Login] {
+		m.registeredUsers[req.User.Login] = req.AuthKey
+		delete(m.rawPasswords, req.User.Login)
+	}
+
+	if m.totp[req.User.Login] == totpEnabled {
+		fmt.Printf("DEBUG MockServer: Login requires two-factor code for: %s\n", req.User.Login)
+		return &proto.LoginResponse{
+			Success:        true,
+			TotpRequired:   true,
+			ChallengeToken: "challenge-" + req.User.Login,
+		}, nil
+	}
+
 	fmt.Printf("DEBUG MockServer: Login successful for: %s\n", req.User.Login)
 
 	var jwtToken string
@@ -47,8 +73,10 @@ func (m *MockVaultServer) Login(ctx context.Context, req *proto.LoginRequest) (*
 	}
 
 	return &proto.LoginResponse{
-		Success:  true,
-		JwtToken: jwtToken,
+		Success:      true,
+		JwtToken:     jwtToken,
+		KdfSalt:      m.registeredSalts[req.User.Login],
+		RefreshToken: m.issueRefreshToken(req.User.Login),
 	}, nil
 }
 
@@ -226,6 +254,25 @@ func TestDataVault_Login(t *testing.T) {
 				assert.Empty(t, token)
 			},
 		},
+		{
+			name: "locked out login",
+			setupFunc: func(t *testing.T) (*Client, context.Context, func()) {
+				_, lis, cleanup := SetupMockServer(true, "valid-jwt-token-12345")
+				client := SetupTestClient(t, lis)
+				return client, context.Background(), cleanup
+			},
+			user: models.User{
+				Login:    mockLockedLogin,
+				Password: "anypassword",
+			},
+			expectedToken: "",
+			expectedError: ErrorTooMany,
+			validateResult: func(t *testing.T, token string, err error) {
+				assert.ErrorIs(t, err, ErrorTooMany)
+				assert.Contains(t, err.Error(), "retry in 30 seconds")
+				assert.Empty(t, token)
+			},
+		},
 		{
 			name: "login with special characters in credentials",
 			setupFunc: func(t *testing.T) (*Client, context.Context, func()) {
@@ -264,9 +311,9 @@ func TestDataVault_Login(t *testing.T) {
 			client, ctx, cleanup := tt.setupFunc(t)
 			defer cleanup()
 
-			token, err := client.Login(ctx, tt.user)
+			session, err := client.Login(ctx, tt.user)
 
-			tt.validateResult(t, token, err)
+			tt.validateResult(t, session.JWT, err)
 		})
 	}
 }
@@ -283,7 +330,8 @@ func TestDataVault_Login_ServerFailure(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	token, err := client.Login(ctx, user)
+	session, err := client.Login(ctx, user)
+	token := session.JWT
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrorLogin, err)
@@ -309,7 +357,8 @@ func TestDataVault_Login_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	token, err := client.Login(ctx, user)
+	session, err := client.Login(ctx, user)
+	token := session.JWT
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -336,7 +385,8 @@ func TestDataVault_Login_ContextTimeout(t *testing.T) {
 
 	time.Sleep(1 * time.Millisecond)
 
-	token, err := client.Login(ctx, user)
+	session, err := client.Login(ctx, user)
+	token := session.JWT
 
 	assert.Error(t, err)
 	assert.Empty(t, token)
@@ -358,7 +408,8 @@ func TestDataVault_Login_WithJWTValidation(t *testing.T) {
 	_, err := client.Register(context.Background(), user)
 	require.NoError(t, err)
 
-	jwt, err := client.Login(context.Background(), user)
+	session, err := client.Login(context.Background(), user)
+	jwt := session.JWT
 	assert.NoError(t, err, "Login should succeed")
 	assert.NotEmpty(t, jwt, "JWT should not be empty")
 	assert.Contains(t, jwt, ".", "JWT should contain dots")
@@ -387,11 +438,13 @@ func TestDataVault_Login_JWTConsistency(t *testing.T) {
 		Password: "consistentpass",
 	}
 
-	registerJWT, err := client.Register(context.Background(), user)
+	registered, err := client.Register(context.Background(), user)
+	registerJWT := registered.JWT
 	require.NoError(t, err)
 
-	loginJWT, err := client.Login(context.Background(), user)
+	session, err := client.Login(context.Background(), user)
 	require.NoError(t, err)
+	loginJWT := session.JWT
 
 	mockServer := &MockVaultServer{
 		validateJWT: true,
@@ -408,3 +461,44 @@ func TestDataVault_Login_JWTConsistency(t *testing.T) {
 
 	assert.Equal(t, regLogin, loginLogin, "Both JWTs should contain same login")
 }
+
+func TestDataVault_Login_ReturnsKdfSalt(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "salt-test-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+
+	user := models.User{
+		Login:    "saltuser",
+		Password: "saltpassword",
+		Salt:     []byte("0123456789abcdef"),
+	}
+	_, err := client.Register(context.Background(), user)
+	require.NoError(t, err)
+
+	session, err := client.Login(context.Background(), models.User{Login: user.Login, Password: user.Password})
+	require.NoError(t, err)
+	assert.Equal(t, expectedToken, session.JWT)
+	assert.Equal(t, user.Salt, session.Salt, "Login should return the salt stored at registration")
+}
+
+func TestDataVault_Login_ReturnsRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	_, lis, cleanup := SetupMockServer(true, "refresh-test-token")
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+
+	user := models.User{Login: "refreshuser", Password: "refreshpassword"}
+	registered, err := client.Register(context.Background(), user)
+	require.NoError(t, err)
+
+	session, err := client.Login(context.Background(), user)
+	require.NoError(t, err)
+	assert.NotEmpty(t, session.RefreshToken)
+	assert.NotEqual(t, registered.RefreshToken, session.RefreshToken, "Each login should start its own session")
+}
@@ -0,0 +1,34 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Logout revokes the session of the access token on the server via gRPC
+func (c *Client) Logout(ctx context.Context, jwt string) error {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" {
+		return ErrorSession
+	}
+
+	grpcResp, err := c.ClientConn.Logout(ctx, &proto.LogoutRequest{})
+	if err != nil {
+		if unavailable(err) {
+			return ErrorUnavailable
+		}
+		return ErrorSession
+	}
+	if !grpcResp.Success {
+		return ErrorSession
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Logout implements the mock Logout method
+func (m *MockVaultServer) Logout(ctx context.Context, req *proto.LogoutRequest) (*proto.LogoutResponse, error) {
+	fmt.Printf("DEBUG MockServer: Logout called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	return &proto.LogoutResponse{
+		Success: true,
+	}, nil
+}
+
+func TestDataVault_Logout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		expectedErr   error
+	}{
+		{
+			name:          "successful logout",
+			shouldSucceed: true,
+			jwt:           "logout-token",
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "logout-token",
+			expectedErr:   ErrorSession,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			expectedErr:   ErrorSession,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			err := client.Logout(context.Background(), tt.jwt)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDataVault_Logout_WithJWTIntegration(t *testing.T) {
+	t.Parallel()
+
+	jwtSecret := "test-secret-for-logout"
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, jwtSecret)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	registered, err := client.Register(ctx, models.User{Login: "logoutuser", Password: "logoutpassword"})
+	require.NoError(t, err)
+
+	err = client.Logout(ctx, registered.JWT)
+	require.NoError(t, err, "Logout should succeed with valid JWT")
+
+	err = client.Logout(ctx, "invalid.jwt.token")
+	assert.Error(t, err, "Logout should fail with invalid JWT")
+}
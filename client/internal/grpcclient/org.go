@@ -0,0 +1,35 @@
+package grpcclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// orgHeader is the metadata header selecting the organization vault a call operates on
+const orgHeader = "x-org"
+
+// SetOrg makes the data calls of the client operate on an organization vault instead of the
+// user's own vault, an empty name switches back
+func (c *Client) SetOrg(org string) {
+	c.org = org
+}
+
+// orgUnary adds the selected organization to the metadata of a unary call
+func (c *Client) orgUnary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(c.withOrg(ctx), method, req, reply, cc, opts...)
+}
+
+// orgStream adds the selected organization to the metadata of a streaming call
+func (c *Client) orgStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(c.withOrg(ctx), desc, cc, method, opts...)
+}
+
+// withOrg returns the context with the organization header set, if an organization is selected
+func (c *Client) withOrg(ctx context.Context) context.Context {
+	if c.org == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, orgHeader, c.org)
+}
@@ -0,0 +1,30 @@
+package grpcclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestDataVault_WithOrg(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{}
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+		"authorization": "Bearer org-token",
+	}))
+
+	md, _ := metadata.FromOutgoingContext(client.withOrg(ctx))
+	assert.Empty(t, md.Get(orgHeader), "Own vault calls must not select an organization")
+
+	client.SetOrg("acme")
+	md, _ = metadata.FromOutgoingContext(client.withOrg(ctx))
+	assert.Equal(t, []string{"acme"}, md.Get(orgHeader))
+	assert.Equal(t, []string{"Bearer org-token"}, md.Get("authorization"))
+
+	client.SetOrg("")
+	md, _ = metadata.FromOutgoingContext(client.withOrg(ctx))
+	assert.Empty(t, md.Get(orgHeader))
+}
@@ -2,17 +2,34 @@ package grpcclient
 
 import (
 	"context"
+	"data-vault/client/internal/models"
 	"data-vault/client/internal/proto"
+	"time"
 )
 
 // PingServer checks server connectivity via gRPC
 func (c *Client) PingServer(ctx context.Context) bool {
-	req := &proto.PingDBRequest{}
+	_, err := c.Ping(ctx)
+	return err == nil
+}
+
+// Ping checks the server and its database via gRPC and reports the server version and latencies
+func (c *Client) Ping(ctx context.Context) (models.ServerStatus, error) {
+	start := time.Now()
 
-	grpcResp, err := c.ClientConn.PingDB(ctx, req)
-	if err != nil || !grpcResp.Success {
-		return false
+	grpcResp, err := c.ClientConn.PingDB(ctx, &proto.PingDBRequest{})
+	if err != nil {
+		return models.ServerStatus{}, ErrorUnavailable
+	}
+
+	res := models.ServerStatus{
+		Version:   grpcResp.Version,
+		Latency:   time.Since(start),
+		DBLatency: time.Duration(grpcResp.DbLatencyUs) * time.Microsecond,
+	}
+	if !grpcResp.Success {
+		return res, ErrorDatabase
 	}
 
-	return true
+	return res, nil
 }
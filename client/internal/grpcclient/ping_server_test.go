@@ -10,6 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// mockServerVersion is the build version the mock server reports
+const mockServerVersion = "1.2.3"
+
 // PingDB implements the mock PingDB method
 func (m *MockVaultServer) PingDB(ctx context.Context, req *proto.PingDBRequest) (*proto.PingDBResponse, error) {
 	fmt.Printf("DEBUG MockServer: PingDB called, shouldSucceed: %t\n", m.shouldSucceed)
@@ -18,12 +21,15 @@ func (m *MockVaultServer) PingDB(ctx context.Context, req *proto.PingDBRequest)
 		fmt.Printf("DEBUG MockServer: shouldSucceed is false, returning failure\n")
 		return &proto.PingDBResponse{
 			Success: false,
+			Version: mockServerVersion,
 		}, nil
 	}
 
 	fmt.Printf("DEBUG MockServer: PingDB successful\n")
 	return &proto.PingDBResponse{
-		Success: true,
+		Success:     true,
+		Version:     mockServerVersion,
+		DbLatencyUs: 1500,
 	}, nil
 }
 
@@ -178,3 +184,61 @@ func TestDataVault_PingServer_EmptyContext(t *testing.T) {
 	result := client.PingServer(context.Background())
 	assert.True(t, result, "Expected ping with background context to succeed")
 }
+
+func TestDataVault_Ping(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		expectedErr   error
+	}{
+		{
+			name:          "healthy server",
+			shouldSucceed: true,
+		},
+		{
+			name:          "server without database",
+			shouldSucceed: false,
+			expectedErr:   ErrorDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, "")
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			res, err := client.Ping(context.Background())
+
+			assert.Equal(t, mockServerVersion, res.Version)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Positive(t, res.Latency)
+			assert.Equal(t, 1500*time.Microsecond, res.DBLatency)
+		})
+	}
+}
+
+func TestDataVault_Ping_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	_, lis, cleanup := SetupMockServer(true, "")
+	client := SetupTestClient(t, lis)
+	cleanup()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.Ping(ctx)
+	assert.ErrorIs(t, err, ErrorUnavailable)
+}
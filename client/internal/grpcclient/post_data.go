@@ -8,8 +8,8 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
-// PostData stores encrypted data in the vault via gRPC
-func (c *Client) PostData(ctx context.Context, jwt, dataType string, data []byte) error {
+// PostData stores encrypted data with its plaintext metadata in the vault via gRPC
+func (c *Client) PostData(ctx context.Context, jwt, dataType string, data []byte, meta map[string]string) error {
 	md := metadata.New(map[string]string{
 		"authorization": "Bearer " + jwt,
 	})
@@ -20,11 +20,21 @@ func (c *Client) PostData(ctx context.Context, jwt, dataType string, data []byte
 	}
 
 	req := &proto.PostDataRequest{
-		Type: dataType,
-		Data: data,
+		Type:     dataType,
+		Data:     data,
+		Metadata: meta,
 	}
 
 	grpcResp, err := c.ClientConn.PostData(ctx, req)
+	if unavailable(err) {
+		return ErrorUnavailable
+	}
+	if forbidden(err) {
+		return ErrorForbidden
+	}
+	if rejected(err) {
+		return rejection(err)
+	}
 	if err != nil || !grpcResp.Success {
 		return errors.New("failed to post data")
 	}
@@ -61,6 +61,10 @@ func (m *MockVaultServer) PostData(ctx context.Context, req *proto.PostDataReque
 		return nil, status.Error(codes.InvalidArgument, "Data not provided")
 	}
 
+	if _, ok := req.Metadata[""]; ok {
+		return nil, status.Error(codes.InvalidArgument, "Metadata is malformed")
+	}
+
 	fmt.Printf("DEBUG MockServer: PostData successful\n")
 	return &proto.PostDataResponse{
 		Success: true,
@@ -82,12 +86,13 @@ func TestDataVault_PostData_WithJWTIntegration(t *testing.T) {
 		Password: "securepassword123",
 	}
 
-	jwt, err := client.Register(ctx, user)
+	registered, err := client.Register(ctx, user)
+	jwt := registered.JWT
 	assert.NoError(t, err, "Registration should succeed")
 	assert.NotEmpty(t, jwt, "JWT should not be empty")
 
 	testData := "This is some test data to be stored securely"
-	err = client.PostData(ctx, jwt, "text", []byte(testData))
+	err = client.PostData(ctx, jwt, "text", []byte(testData), nil)
 	assert.NoError(t, err, "PostData should succeed with valid JWT")
 }
 
@@ -104,7 +109,7 @@ func TestDataVault_PostData_WithInvalidJWT(t *testing.T) {
 	invalidJWT := "invalid.jwt.token"
 	testData := "This is some test data"
 
-	err := client.PostData(ctx, invalidJWT, "text", []byte(testData))
+	err := client.PostData(ctx, invalidJWT, "text", []byte(testData), nil)
 	assert.Error(t, err, "PostData should fail with invalid JWT")
 }
 
@@ -121,7 +126,7 @@ func TestDataVault_PostData_WithExpiredJWT(t *testing.T) {
 	expiredJWT := "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJsb2dpbiI6InRlc3R1c2VyIiwiZXhwIjoxNjAwMDAwMDAwLCJpYXQiOjE2MDAwMDAwMDB9.invalid"
 
 	testData := "This is some test data"
-	err := client.PostData(ctx, expiredJWT, "text", []byte(testData))
+	err := client.PostData(ctx, expiredJWT, "text", []byte(testData), nil)
 	assert.Error(t, err, "PostData should fail with expired JWT")
 }
 
@@ -143,11 +148,12 @@ func TestDataVault_PostData_MultipleUsersWithDifferentJWTs(t *testing.T) {
 
 	for i, user := range users {
 		t.Run(fmt.Sprintf("user_%d", i+1), func(t *testing.T) {
-			jwt, err := client.Register(ctx, user)
+			registered, err := client.Register(ctx, user)
+			jwt := registered.JWT
 			require.NoError(t, err)
 			require.NotEmpty(t, jwt)
 
-			err = client.PostData(ctx, jwt, "text", []byte("test data for "+user.Login))
+			err = client.PostData(ctx, jwt, "text", []byte("test data for "+user.Login), nil)
 			require.NoError(t, err)
 		})
 	}
@@ -167,10 +173,11 @@ func TestDataVault_PostData_CrossSecretValidation(t *testing.T) {
 	ctx := context.Background()
 
 	user := models.User{Login: "testuser", Password: "password123"}
-	jwt, err := client1.Register(ctx, user)
+	registered, err := client1.Register(ctx, user)
+	jwt := registered.JWT
 	require.NoError(t, err)
 
-	err = client2.PostData(ctx, jwt, "text", []byte("test data"))
+	err = client2.PostData(ctx, jwt, "text", []byte("test data"), nil)
 	require.Error(t, err, "Should fail to use JWT from server1 with server2")
 }
 
@@ -191,11 +198,12 @@ func TestDataVault_PostData_JWTCrossValidation(t *testing.T) {
 	ctx := context.Background()
 	user := models.User{Login: "testuser", Password: "testpass"}
 
-	jwt1, err := client1.Register(ctx, user)
+	registered, err := client1.Register(ctx, user)
+	jwt1 := registered.JWT
 	assert.NoError(t, err, "Registration should succeed with server1")
 
 	testData := "Cross-validation test data"
-	err = client2.PostData(ctx, jwt1, "text", []byte(testData))
+	err = client2.PostData(ctx, jwt1, "text", []byte(testData), nil)
 	assert.Error(t, err, "PostData should fail when using JWT from different server")
 }
 
@@ -310,7 +318,7 @@ func TestDataVault_PostData(t *testing.T) {
 			client, ctx, cleanup := tt.setupFunc(t)
 			defer cleanup()
 
-			err := client.PostData(ctx, tt.jwt, "text", []byte(tt.data))
+			err := client.PostData(ctx, tt.jwt, "text", []byte(tt.data), nil)
 			tt.validateResult(t, err)
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -333,7 +341,7 @@ func TestDataVault_PostData_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
-	err := client.PostData(ctx, expectedToken, "text", []byte("test data"))
+	err := client.PostData(ctx, expectedToken, "text", []byte("test data"), nil)
 
 	assert.Error(t, err)
 }
@@ -352,7 +360,7 @@ func TestDataVault_PostData_ContextTimeout(t *testing.T) {
 
 	time.Sleep(1 * time.Millisecond)
 
-	err := client.PostData(ctx, expectedToken, "text", []byte("test data"))
+	err := client.PostData(ctx, expectedToken, "text", []byte("test data"), nil)
 
 	assert.Error(t, err)
 }
@@ -369,7 +377,24 @@ func TestDataVault_PostData_MultipleConsecutive(t *testing.T) {
 
 	for i := 0; i < 3; i++ {
 		data := fmt.Sprintf("test data item %d", i+1)
-		err := client.PostData(ctx, expectedToken, "text", []byte(data))
+		err := client.PostData(ctx, expectedToken, "text", []byte(data), nil)
 		assert.NoError(t, err, "Expected post data %d to succeed", i+1)
 	}
 }
+
+func TestDataVault_PostData_Metadata(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "metadata-post-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	err := client.PostData(ctx, expectedToken, "password", []byte("encrypted"), map[string]string{"website": "example.com"})
+	assert.NoError(t, err)
+
+	err = client.PostData(ctx, expectedToken, "password", []byte("encrypted"), map[string]string{"": "no key"})
+	assert.Error(t, err)
+}
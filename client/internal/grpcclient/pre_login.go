@@ -0,0 +1,35 @@
+package grpcclient
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// PreLogin fetches the key derivation parameters of an account via gRPC, which the client needs
+// to derive the authentication key before it can log in
+func (c *Client) PreLogin(ctx context.Context, login string) (models.KDFParams, error) {
+	if login == "" {
+		return models.KDFParams{}, ErrorLogin
+	}
+
+	var header metadata.MD
+	grpcResp, err := c.ClientConn.PreLogin(ctx, &proto.PreLoginRequest{Login: login}, grpc.Header(&header))
+	if rateLimited(err) {
+		return models.KDFParams{}, retryLater(header)
+	}
+	if err != nil {
+		if unavailable(err) {
+			return models.KDFParams{}, ErrorUnavailable
+		}
+		return models.KDFParams{}, ErrorLogin
+	}
+
+	return models.KDFParams{
+		Salt:        grpcResp.KdfSalt,
+		RawPassword: grpcResp.RawPassword,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mockRawLogin is a login the mock server registers as an account created before the
+// authentication key was derived, which still logs in with the master password
+const mockRawLogin = "raw-user"
+
+// mockUnknownSalt is the salt the mock server hands out for logins that aren't registered
+var mockUnknownSalt = []byte("unknown-kdf-salt")
+
+// PreLogin implements the mock PreLogin method
+func (m *MockVaultServer) PreLogin(ctx context.Context, req *proto.PreLoginRequest) (*proto.PreLoginResponse, error) {
+	fmt.Printf("DEBUG MockServer: PreLogin called with login: %s, shouldSucceed: %t\n", req.Login, m.shouldSucceed)
+
+	if req.Login == mockLockedLogin {
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", "30"))
+		return nil, status.Error(codes.ResourceExhausted, "too many attempts, retry in 30 seconds")
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	salt, ok := m.registeredSalts[req.Login]
+	if !ok {
+		salt = mockUnknownSalt
+	}
+
+	return &proto.PreLoginResponse{
+		KdfSalt:     salt,
+		RawPassword: m.rawPasswords[req.Login],
+	}, nil
+}
+
+func TestDataVault_PreLogin(t *testing.T) {
+	t.Parallel()
+
+	salt := []byte("0123456789abcdef")
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		login         string
+		register      bool
+		expected      models.KDFParams
+		expectedErr   error
+	}{
+		{
+			name:          "registered account",
+			shouldSucceed: true,
+			login:         "kdfuser",
+			register:      true,
+			expected:      models.KDFParams{Salt: salt},
+		},
+		{
+			name:          "account still on the master password",
+			shouldSucceed: true,
+			login:         mockRawLogin,
+			register:      true,
+			expected:      models.KDFParams{Salt: salt, RawPassword: true},
+		},
+		{
+			name:          "unknown account gets a salt too",
+			shouldSucceed: true,
+			login:         "nobody",
+			expected:      models.KDFParams{Salt: mockUnknownSalt},
+		},
+		{
+			name:          "empty login",
+			shouldSucceed: true,
+			expectedErr:   ErrorLogin,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			login:         "kdfuser",
+			expectedErr:   ErrorLogin,
+		},
+		{
+			name:          "rate limited",
+			shouldSucceed: true,
+			login:         mockLockedLogin,
+			expectedErr:   ErrorTooMany,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(true, "prelogin-token")
+			defer cleanup()
+			client := SetupTestClient(t, lis)
+
+			if tt.register {
+				_, err := client.Register(context.Background(), models.User{Login: tt.login, Password: "master-password", Salt: salt})
+				require.NoError(t, err)
+			}
+
+			if !tt.shouldSucceed {
+				_, lis, cleanup = SetupMockServer(false, "")
+				defer cleanup()
+				client = SetupTestClient(t, lis)
+			}
+
+			params, err := client.PreLogin(context.Background(), tt.login)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, params)
+		})
+	}
+}
+
+func TestDataVault_Login_SwitchesToAuthKey(t *testing.T) {
+	t.Parallel()
+
+	_, lis, cleanup := SetupMockServer(true, "upgrade-token")
+	defer cleanup()
+	client := SetupTestClient(t, lis)
+
+	user := models.User{Login: mockRawLogin, Password: "master-password", Salt: []byte("0123456789abcdef")}
+	_, err := client.Register(context.Background(), user)
+	require.NoError(t, err)
+
+	_, err = client.Login(context.Background(), models.User{Login: user.Login, Password: user.Password, AuthKey: "derived-auth-key"})
+	require.NoError(t, err)
+
+	params, err := client.PreLogin(context.Background(), user.Login)
+	require.NoError(t, err)
+	assert.False(t, params.RawPassword, "The account should be switched by the login")
+
+	_, err = client.Login(context.Background(), models.User{Login: user.Login, Password: "derived-auth-key"})
+	assert.NoError(t, err, "The derived key replaces the master password")
+
+	_, err = client.Login(context.Background(), models.User{Login: user.Login, Password: user.Password})
+	assert.ErrorIs(t, err, ErrorLogin, "The master password is no longer accepted")
+}
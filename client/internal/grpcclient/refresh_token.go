@@ -0,0 +1,32 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+)
+
+// RefreshToken exchanges a refresh token for a new access and refresh token pair via gRPC.
+// The old refresh token can't be used again.
+func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (models.Session, error) {
+	if refreshToken == "" {
+		return models.Session{}, ErrorRefresh
+	}
+
+	grpcResp, err := c.ClientConn.RefreshToken(ctx, &proto.RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		if unavailable(err) {
+			return models.Session{}, ErrorUnavailable
+		}
+		return models.Session{}, ErrorRefresh
+	}
+	if !grpcResp.Success {
+		return models.Session{}, ErrorRefresh
+	}
+
+	return models.Session{
+		JWT:          grpcResp.JwtToken,
+		RefreshToken: grpcResp.RefreshToken,
+	}, nil
+}
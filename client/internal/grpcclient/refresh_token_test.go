@@ -0,0 +1,123 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// issueRefreshToken creates a single use mock refresh token for a user
+func (m *MockVaultServer) issueRefreshToken(login string) string {
+	if m.refreshTokens == nil {
+		m.refreshTokens = make(map[string]string)
+	}
+
+	token := fmt.Sprintf("refresh-%s-%d", login, len(m.refreshTokens)+1)
+	m.refreshTokens[token] = login
+	return token
+}
+
+// RefreshToken implements the mock RefreshToken method, rotating the presented refresh token
+func (m *MockVaultServer) RefreshToken(ctx context.Context, req *proto.RefreshTokenRequest) (*proto.RefreshTokenResponse, error) {
+	fmt.Printf("DEBUG MockServer: RefreshToken called, shouldSucceed: %t\n", m.shouldSucceed)
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	login, ok := m.refreshTokens[req.RefreshToken]
+	if !ok || login == "" {
+		return nil, status.Error(codes.Unauthenticated, "session expired or revoked")
+	}
+	m.refreshTokens[req.RefreshToken] = ""
+
+	return &proto.RefreshTokenResponse{
+		Success:      true,
+		JwtToken:     m.GenerateTestJWT(login),
+		RefreshToken: m.issueRefreshToken(login),
+	}, nil
+}
+
+func TestDataVault_RefreshToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		useToken      func(registered models.Session) string
+		expectedErr   error
+	}{
+		{
+			name:          "successful refresh",
+			shouldSucceed: true,
+			useToken:      func(registered models.Session) string { return registered.RefreshToken },
+		},
+		{
+			name:          "unknown refresh token",
+			shouldSucceed: true,
+			useToken:      func(models.Session) string { return "refresh-unknown" },
+			expectedErr:   ErrorRefresh,
+		},
+		{
+			name:          "empty refresh token",
+			shouldSucceed: true,
+			useToken:      func(models.Session) string { return "" },
+			expectedErr:   ErrorRefresh,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, "refresh-jwt")
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+			ctx := context.Background()
+
+			registered, err := client.Register(ctx, models.User{Login: "refreshuser", Password: "refreshpassword"})
+			require.NoError(t, err)
+
+			session, err := client.RefreshToken(ctx, tt.useToken(registered))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, session.JWT)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "refresh-jwt", session.JWT)
+				assert.NotEmpty(t, session.RefreshToken)
+				assert.NotEqual(t, registered.RefreshToken, session.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestDataVault_RefreshToken_SingleUse(t *testing.T) {
+	t.Parallel()
+
+	_, lis, cleanup := SetupMockServer(true, "refresh-jwt")
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	registered, err := client.Register(ctx, models.User{Login: "rotateuser", Password: "rotatepassword"})
+	require.NoError(t, err)
+
+	_, err = client.RefreshToken(ctx, registered.RefreshToken)
+	require.NoError(t, err)
+
+	_, err = client.RefreshToken(ctx, registered.RefreshToken)
+	assert.ErrorIs(t, err, ErrorRefresh, "A rotated refresh token must not be accepted again")
+}
@@ -6,25 +6,31 @@ import (
 	"data-vault/client/internal/proto"
 )
 
-// Register creates a new user account via gRPC and returns a JWT token
-func (c *Client) Register(ctx context.Context, user models.User) (string, error) {
+// Register creates a new user account via gRPC and returns a session with the access and refresh tokens
+func (c *Client) Register(ctx context.Context, user models.User) (models.Session, error) {
 	req := &proto.RegisterRequest{
 		User: &proto.User{
 			Login:    user.Login,
 			Password: user.Password,
 		},
+		KdfSalt: user.Salt,
+		Device:  deviceName(),
 	}
 
 	if user.Login == "" || user.Password == "" {
-		return "", ErrorRegister
+		return models.Session{}, ErrorRegister
 	}
 
 	grpcResp, err := c.ClientConn.Register(ctx, req)
 	if err != nil || !grpcResp.Success {
-		return "", ErrorRegister
+		return models.Session{}, ErrorRegister
 	}
 
-	jwt := grpcResp.JwtToken
+	session := models.Session{
+		JWT:          grpcResp.JwtToken,
+		RefreshToken: grpcResp.RefreshToken,
+		Salt:         user.Salt,
+	}
 
-	return jwt, nil
+	return session, nil
 }
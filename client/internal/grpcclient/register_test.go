@@ -18,6 +18,12 @@ func (m *MockVaultServer) Register(ctx context.Context, req *proto.RegisterReque
 	if m.registeredUsers == nil {
 		m.registeredUsers = make(map[string]string)
 	}
+	if m.registeredSalts == nil {
+		m.registeredSalts = make(map[string][]byte)
+	}
+	if m.rawPasswords == nil {
+		m.rawPasswords = make(map[string]bool)
+	}
 
 	if _, exists := m.registeredUsers[req.User.Login]; exists {
 		fmt.Printf("DEBUG MockServer: Duplicate user detected: %s\n", req.User.Login)
@@ -37,6 +43,8 @@ func (m *MockVaultServer) Register(ctx context.Context, req *proto.RegisterReque
 
 	fmt.Printf("DEBUG MockServer: Registration successful for: %s\n", req.User.Login)
 	m.registeredUsers[req.User.Login] = req.User.Password
+	m.registeredSalts[req.User.Login] = req.KdfSalt
+	m.rawPasswords[req.User.Login] = req.User.Login == mockRawLogin
 
 	var jwtToken string
 	if m.validateJWT {
@@ -47,8 +55,9 @@ func (m *MockVaultServer) Register(ctx context.Context, req *proto.RegisterReque
 	}
 
 	return &proto.RegisterResponse{
-		Success:  true,
-		JwtToken: jwtToken,
+		Success:      true,
+		JwtToken:     jwtToken,
+		RefreshToken: m.issueRefreshToken(req.User.Login),
 	}, nil
 }
 
@@ -134,17 +143,19 @@ func TestDataVault_Register(t *testing.T) {
 	ctx := context.Background()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := client.Register(ctx, tt.user)
+			session, err := client.Register(ctx, tt.user)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				if tt.errCheck != nil {
 					assert.True(t, tt.errCheck(err))
 				}
-				assert.Empty(t, token)
+				assert.Empty(t, session.JWT)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, expectedToken, token)
+				assert.Equal(t, expectedToken, session.JWT)
+				assert.NotEmpty(t, session.RefreshToken)
+				assert.Equal(t, tt.user.Salt, session.Salt)
 			}
 		})
 	}
@@ -164,7 +175,8 @@ func TestDataVault_Register_WithJWTValidation(t *testing.T) {
 		Password: "jwtpassword123",
 	}
 
-	jwt, err := client.Register(context.Background(), user)
+	session, err := client.Register(context.Background(), user)
+	jwt := session.JWT
 	assert.NoError(t, err, "Registration should succeed")
 	assert.NotEmpty(t, jwt, "JWT should not be empty")
 	assert.Contains(t, jwt, ".", "JWT should contain dots")
@@ -220,7 +232,8 @@ func TestDataVault_Register_JWTIntegrity(t *testing.T) {
 
 			client := SetupTestClient(t, lis)
 
-			jwt, err := client.Register(context.Background(), tt.user)
+			session, err := client.Register(context.Background(), tt.user)
+			jwt := session.JWT
 			assert.NoError(t, err, "Registration should succeed")
 			assert.NotEmpty(t, jwt, "JWT should not be empty")
 
@@ -250,14 +263,14 @@ func TestDataVault_Register_DuplicateUser(t *testing.T) {
 
 	ctx := context.Background()
 
-	token, err := client.Register(ctx, baseUser)
+	session, err := client.Register(ctx, baseUser)
 	require.NoError(t, err)
-	require.Equal(t, expectedToken, token)
+	require.Equal(t, expectedToken, session.JWT)
 
-	token, err = client.Register(ctx, baseUser)
+	session, err = client.Register(ctx, baseUser)
 	assert.Error(t, err)
 	assert.Equal(t, ErrorRegister, err)
-	assert.Empty(t, token)
+	assert.Empty(t, session.JWT)
 }
 
 func TestDataVault_Register_ServerFailure(t *testing.T) {
@@ -272,9 +285,9 @@ func TestDataVault_Register_ServerFailure(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	token, err := client.Register(ctx, user)
+	session, err := client.Register(ctx, user)
 
 	assert.Error(t, err)
 	assert.Equal(t, ErrorRegister, err)
-	assert.Empty(t, token)
+	assert.Empty(t, session.JWT)
 }
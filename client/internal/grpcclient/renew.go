@@ -0,0 +1,134 @@
+package grpcclient
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// renewLeeway renews access tokens that expire this soon so they don't run out in flight
+const renewLeeway = 30 * time.Second
+
+// TokenStore keeps the current access and refresh token pair and receives the renewed one
+type TokenStore interface {
+	LoadTokens() (jwt, refreshToken string, err error)
+	SaveTokens(jwt, refreshToken string) error
+}
+
+// SetTokenStore enables the transparent renewal of expired access tokens
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.tokens = store
+}
+
+// renewUnary replaces an expired access token of a unary call with a renewed one
+func (c *Client) renewUnary(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if method != proto.VaultService_RefreshToken_FullMethodName {
+		ctx = c.renewAuth(ctx)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// renewStream replaces an expired access token of a streaming call with a renewed one
+func (c *Client) renewStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(c.renewAuth(ctx), desc, cc, method, opts...)
+}
+
+// renewAuth swaps the bearer token in the outgoing metadata if it has expired. On failure the
+// context is returned unchanged and the server rejects the call as unauthenticated.
+func (c *Client) renewAuth(ctx context.Context) context.Context {
+	if c.tokens == nil {
+		return ctx
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	auth := md.Get("authorization")
+	if len(auth) == 0 || !strings.HasPrefix(auth[0], "Bearer ") {
+		return ctx
+	}
+
+	jwt := strings.TrimPrefix(auth[0], "Bearer ")
+	if !tokenExpired(jwt) {
+		return ctx
+	}
+
+	fresh, err := c.renew(ctx, jwt)
+	if err != nil {
+		return ctx
+	}
+
+	md = md.Copy()
+	md.Set("authorization", "Bearer "+fresh)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// renew exchanges the stored refresh token for a new token pair. Callers may keep using the token
+// they started with, so a stored token that was already renewed and is still valid is reused, as
+// the refresh token can only be exchanged once.
+func (c *Client) renew(ctx context.Context, stale string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	jwt, refreshToken, err := c.tokens.LoadTokens()
+	if err != nil {
+		return "", err
+	}
+
+	if jwt != "" && jwt != stale && !tokenExpired(jwt) {
+		return jwt, nil
+	}
+
+	session, err := c.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.tokens.SaveTokens(session.JWT, session.RefreshToken); err != nil {
+		return "", err
+	}
+
+	return session.JWT, nil
+}
+
+// tokenExpired reports whether a JWT expires within renewLeeway. The signature isn't checked,
+// the server does that, and tokens that can't be decoded are left for the server to reject.
+func tokenExpired(jwt string) bool {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return false
+	}
+
+	var claims struct {
+		ExpiresAt int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.ExpiresAt == 0 {
+		return false
+	}
+
+	return time.Now().Add(renewLeeway).Unix() >= claims.ExpiresAt
+}
+
+// deviceName returns the name the session of this client is listed under
+func deviceName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "unknown"
+	}
+	return host
+}
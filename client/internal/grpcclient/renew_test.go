@@ -0,0 +1,156 @@
+package grpcclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// memoryTokenStore keeps the token pair in memory for testing
+type memoryTokenStore struct {
+	jwt          string
+	refreshToken string
+	saves        int
+}
+
+func (s *memoryTokenStore) LoadTokens() (string, string, error) {
+	return s.jwt, s.refreshToken, nil
+}
+
+func (s *memoryTokenStore) SaveTokens(jwt, refreshToken string) error {
+	s.jwt = jwt
+	s.refreshToken = refreshToken
+	s.saves++
+	return nil
+}
+
+// setupRenewingClient creates a gRPC client for testing that renews expired access tokens like New does
+func setupRenewingClient(t *testing.T, lis *bufconn.Listener) *Client {
+	client := &Client{}
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(BufDialer(lis)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(client.renewUnary),
+		grpc.WithChainStreamInterceptor(client.renewStream),
+	)
+	require.NoError(t, err, "Failed to create gRPC connection")
+	t.Cleanup(func() { conn.Close() })
+
+	client.ClientConn = proto.NewVaultServiceClient(conn)
+	return client
+}
+
+func TestDataVault_RenewExpiredToken(t *testing.T) {
+	t.Parallel()
+
+	jwtSecret := "test-secret-for-renew"
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, jwtSecret)
+	defer cleanup()
+
+	client := setupRenewingClient(t, lis)
+	ctx := context.Background()
+
+	user := models.User{Login: "renewuser", Password: "renewpassword"}
+	registered, err := client.Register(ctx, user)
+	require.NoError(t, err)
+
+	signer := &MockVaultServer{validateJWT: true, jwtSecret: jwtSecret}
+	expired := signer.GenerateTestJWTWithExpiry(user.Login, time.Now().Add(-time.Minute))
+
+	err = client.PostData(ctx, expired, "text", []byte("before renewal"), nil)
+	require.Error(t, err, "Expired token should be rejected without a token store")
+
+	store := &memoryTokenStore{jwt: expired, refreshToken: registered.RefreshToken}
+	client.SetTokenStore(store)
+
+	err = client.PostData(ctx, expired, "text", []byte("renewed"), nil)
+	require.NoError(t, err, "Expired token should be renewed transparently")
+	assert.Equal(t, 1, store.saves)
+	assert.NotEqual(t, registered.RefreshToken, store.refreshToken, "Refresh token should be rotated")
+
+	login, valid := signer.ValidateTestJWT(store.jwt)
+	assert.True(t, valid, "Renewed JWT should be valid")
+	assert.Equal(t, user.Login, login)
+
+	err = client.PostData(ctx, expired, "text", []byte("reused"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.saves, "Renewed token should be reused by callers still holding the expired one")
+}
+
+func TestDataVault_RenewRevokedSession(t *testing.T) {
+	t.Parallel()
+
+	jwtSecret := "test-secret-for-revoked"
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, jwtSecret)
+	defer cleanup()
+
+	client := setupRenewingClient(t, lis)
+	ctx := context.Background()
+
+	user := models.User{Login: "revokeduser", Password: "revokedpassword"}
+	_, err := client.Register(ctx, user)
+	require.NoError(t, err)
+
+	store := &memoryTokenStore{refreshToken: "refresh-revoked"}
+	client.SetTokenStore(store)
+
+	signer := &MockVaultServer{validateJWT: true, jwtSecret: jwtSecret}
+	expired := signer.GenerateTestJWTWithExpiry(user.Login, time.Now().Add(-time.Minute))
+
+	err = client.PostData(ctx, expired, "text", []byte("data"), nil)
+	assert.Error(t, err, "Call should fail when the session can't be renewed")
+	assert.Zero(t, store.saves)
+}
+
+func TestTokenExpired(t *testing.T) {
+	signer := &MockVaultServer{validateJWT: true}
+
+	tests := []struct {
+		name     string
+		token    string
+		expected bool
+	}{
+		{
+			name:     "valid token",
+			token:    signer.GenerateTestJWTWithExpiry("user", time.Now().Add(time.Hour)),
+			expected: false,
+		},
+		{
+			name:     "expired token",
+			token:    signer.GenerateTestJWTWithExpiry("user", time.Now().Add(-time.Minute)),
+			expected: true,
+		},
+		{
+			name:     "token expiring within leeway",
+			token:    signer.GenerateTestJWTWithExpiry("user", time.Now().Add(renewLeeway/2)),
+			expected: true,
+		},
+		{
+			name:     "opaque token",
+			token:    "test-jwt-token",
+			expected: false,
+		},
+		{
+			name:     "malformed payload",
+			token:    "header.!!!.signature",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tokenExpired(tt.token))
+		})
+	}
+}
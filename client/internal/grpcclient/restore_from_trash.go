@@ -0,0 +1,41 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreFromTrash moves a trashed data entry back to the vault via gRPC and returns its version
+func (c *Client) RestoreFromTrash(ctx context.Context, jwt, id string) (int64, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if id == "" || jwt == "" {
+		return 0, ErrorTrash
+	}
+
+	grpcResp, err := c.ClientConn.RestoreFromTrash(ctx, &proto.RestoreFromTrashRequest{Id: id})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return 0, ErrorNotInTrash
+		case unavailable(err):
+			return 0, ErrorUnavailable
+		case forbidden(err):
+			return 0, ErrorForbidden
+		}
+		return 0, ErrorTrash
+	}
+	if !grpcResp.Success {
+		return 0, ErrorTrash
+	}
+
+	return grpcResp.Version, nil
+}
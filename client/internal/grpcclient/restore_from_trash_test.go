@@ -0,0 +1,105 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreFromTrash implements the mock RestoreFromTrash method
+func (m *MockVaultServer) RestoreFromTrash(ctx context.Context, req *proto.RestoreFromTrashRequest) (*proto.RestoreFromTrashResponse, error) {
+	fmt.Printf("DEBUG MockServer: RestoreFromTrash called with ID: %s, shouldSucceed: %t\n", req.Id, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Id == "missing-id" {
+		return nil, status.Error(codes.NotFound, "Data not found in trash")
+	}
+
+	return &proto.RestoreFromTrashResponse{
+		Success: true,
+		Version: mockCurrentVersion,
+	}, nil
+}
+
+func TestDataVault_RestoreFromTrash(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		id            string
+		expectedErr   error
+	}{
+		{
+			name:          "successful restore",
+			shouldSucceed: true,
+			jwt:           "trash-token",
+			id:            "trashed-1",
+		},
+		{
+			name:          "not in trash",
+			shouldSucceed: true,
+			jwt:           "trash-token",
+			id:            "missing-id",
+			expectedErr:   ErrorNotInTrash,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "trash-token",
+			id:            "trashed-1",
+			expectedErr:   ErrorTrash,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			id:            "trashed-1",
+			expectedErr:   ErrorTrash,
+		},
+		{
+			name:          "empty ID",
+			shouldSucceed: true,
+			jwt:           "trash-token",
+			id:            "",
+			expectedErr:   ErrorTrash,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			version, err := client.RestoreFromTrash(context.Background(), tt.jwt, tt.id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Zero(t, version)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(mockCurrentVersion), version)
+			}
+		})
+	}
+}
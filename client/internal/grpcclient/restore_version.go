@@ -0,0 +1,45 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreVersion makes a prior version the current state of a data entry via gRPC
+// and returns the entry's new version
+func (c *Client) RestoreVersion(ctx context.Context, jwt, id string, version int64) (int64, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if id == "" || jwt == "" || version <= 0 {
+		return 0, ErrorRestore
+	}
+
+	grpcResp, err := c.ClientConn.RestoreVersion(ctx, &proto.RestoreVersionRequest{
+		Id:      id,
+		Version: version,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return 0, ErrorNoVersion
+		case unavailable(err):
+			return 0, ErrorUnavailable
+		case forbidden(err):
+			return 0, ErrorForbidden
+		}
+		return 0, ErrorRestore
+	}
+	if !grpcResp.Success {
+		return 0, ErrorRestore
+	}
+
+	return grpcResp.Version, nil
+}
@@ -0,0 +1,159 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreVersion implements the mock RestoreVersion method
+func (m *MockVaultServer) RestoreVersion(ctx context.Context, req *proto.RestoreVersionRequest) (*proto.RestoreVersionResponse, error) {
+	fmt.Printf("DEBUG MockServer: RestoreVersion called with ID: %s, version: %d, shouldSucceed: %t\n", req.Id, req.Version, m.shouldSucceed)
+
+	if m.validateJWT {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "no metadata found")
+		}
+
+		authHeaders := md.Get("authorization")
+		if len(authHeaders) == 0 || !strings.HasPrefix(authHeaders[0], "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "no authorization header")
+		}
+
+		if _, valid := m.ValidateTestJWT(authHeaders[0][7:]); !valid {
+			return nil, status.Error(codes.Unauthenticated, "invalid JWT token")
+		}
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Id == "missing-id" || req.Version > mockHistoryVersions {
+		return nil, status.Error(codes.NotFound, "Version not found")
+	}
+
+	// The current version follows the archived ones, the restore creates the next one
+	return &proto.RestoreVersionResponse{
+		Success: true,
+		Version: mockHistoryVersions + 2,
+	}, nil
+}
+
+func TestDataVault_RestoreVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		shouldSucceed   bool
+		jwt             string
+		id              string
+		version         int64
+		expectedErr     error
+		expectedVersion int64
+	}{
+		{
+			name:            "restore archived version",
+			shouldSucceed:   true,
+			jwt:             "restore-token",
+			id:              "data-1",
+			version:         1,
+			expectedVersion: mockHistoryVersions + 2,
+		},
+		{
+			name:          "unknown version",
+			shouldSucceed: true,
+			jwt:           "restore-token",
+			id:            "data-1",
+			version:       mockHistoryVersions + 1,
+			expectedErr:   ErrorNoVersion,
+		},
+		{
+			name:          "unknown record",
+			shouldSucceed: true,
+			jwt:           "restore-token",
+			id:            "missing-id",
+			version:       1,
+			expectedErr:   ErrorNoVersion,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "restore-token",
+			id:            "data-1",
+			version:       1,
+			expectedErr:   ErrorRestore,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			id:            "data-1",
+			version:       1,
+			expectedErr:   ErrorRestore,
+		},
+		{
+			name:          "missing version",
+			shouldSucceed: true,
+			jwt:           "restore-token",
+			id:            "data-1",
+			version:       0,
+			expectedErr:   ErrorRestore,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			version, err := client.RestoreVersion(context.Background(), tt.jwt, tt.id, tt.version)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Zero(t, version)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestDataVault_RestoreVersion_WithJWTIntegration(t *testing.T) {
+	t.Parallel()
+
+	jwtSecret := "test-secret-for-restore"
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, jwtSecret)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	registered, err := client.Register(ctx, models.User{Login: "restoreuser", Password: "restorepassword"})
+	jwt := registered.JWT
+	require.NoError(t, err)
+
+	version, err := client.RestoreVersion(ctx, jwt, "data-1", 1)
+	require.NoError(t, err, "RestoreVersion should succeed with valid JWT")
+	assert.Equal(t, int64(mockHistoryVersions+2), version)
+
+	_, err = client.RestoreVersion(ctx, "invalid.jwt.token", "data-1", 1)
+	assert.Error(t, err, "RestoreVersion should fail with invalid JWT")
+}
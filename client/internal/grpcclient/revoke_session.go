@@ -0,0 +1,39 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSession ends one of the user's sessions on the server via gRPC, e.g. of a lost device
+func (c *Client) RevokeSession(ctx context.Context, jwt, id string) error {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if id == "" || jwt == "" {
+		return ErrorSession
+	}
+
+	grpcResp, err := c.ClientConn.RevokeSession(ctx, &proto.RevokeSessionRequest{Id: id})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return ErrorNoSession
+		case unavailable(err):
+			return ErrorUnavailable
+		}
+		return ErrorSession
+	}
+	if !grpcResp.Success {
+		return ErrorSession
+	}
+
+	return nil
+}
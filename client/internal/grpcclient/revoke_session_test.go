@@ -0,0 +1,102 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSession implements the mock RevokeSession method
+func (m *MockVaultServer) RevokeSession(ctx context.Context, req *proto.RevokeSessionRequest) (*proto.RevokeSessionResponse, error) {
+	fmt.Printf("DEBUG MockServer: RevokeSession called with id: %s, shouldSucceed: %t\n", req.Id, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "Session not found")
+	}
+
+	return &proto.RevokeSessionResponse{
+		Success: true,
+	}, nil
+}
+
+func TestDataVault_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		id            string
+		expectedErr   error
+	}{
+		{
+			name:          "successful revoke",
+			shouldSucceed: true,
+			jwt:           "revoke-token",
+			id:            "session-2",
+		},
+		{
+			name:          "session not found",
+			shouldSucceed: true,
+			jwt:           "revoke-token",
+			id:            "missing",
+			expectedErr:   ErrorNoSession,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "revoke-token",
+			id:            "session-2",
+			expectedErr:   ErrorSession,
+		},
+		{
+			name:          "empty session ID",
+			shouldSucceed: true,
+			jwt:           "revoke-token",
+			id:            "",
+			expectedErr:   ErrorSession,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			id:            "session-2",
+			expectedErr:   ErrorSession,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			err := client.RevokeSession(context.Background(), tt.jwt, tt.id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
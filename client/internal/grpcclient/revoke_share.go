@@ -0,0 +1,39 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeShare removes the access of another user to a data entry via gRPC
+func (c *Client) RevokeShare(ctx context.Context, jwt, id, login string) error {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || id == "" || login == "" {
+		return ErrorShare
+	}
+
+	grpcResp, err := c.ClientConn.RevokeShare(ctx, &proto.RevokeShareRequest{Id: id, Login: login})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return ErrorNoShare
+		case unavailable(err):
+			return ErrorUnavailable
+		}
+		return ErrorShare
+	}
+	if !grpcResp.Success {
+		return ErrorShare
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeShare implements the mock RevokeShare method
+func (m *MockVaultServer) RevokeShare(ctx context.Context, req *proto.RevokeShareRequest) (*proto.RevokeShareResponse, error) {
+	fmt.Printf("DEBUG MockServer: RevokeShare called with id: %s, login: %s, shouldSucceed: %t\n", req.Id, req.Login, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Id == "missing" {
+		return nil, status.Error(codes.NotFound, "Share not found")
+	}
+
+	return &proto.RevokeShareResponse{
+		Success: true,
+	}, nil
+}
+
+func TestDataVault_RevokeShare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		id            string
+		login         string
+		expectedErr   error
+	}{
+		{
+			name:          "successful revoke",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "data-1",
+			login:         "alice",
+		},
+		{
+			name:          "share not found",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "missing",
+			login:         "alice",
+			expectedErr:   ErrorNoShare,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "share-token",
+			id:            "data-1",
+			login:         "alice",
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty login",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "data-1",
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			id:            "data-1",
+			login:         "alice",
+			expectedErr:   ErrorShare,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			err := client.RevokeShare(context.Background(), tt.jwt, tt.id, tt.login)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
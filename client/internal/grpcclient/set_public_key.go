@@ -0,0 +1,34 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// SetPublicKey publishes the user's sharing public key on the server via gRPC
+func (c *Client) SetPublicKey(ctx context.Context, jwt string, key []byte) error {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || len(key) == 0 {
+		return ErrorShare
+	}
+
+	grpcResp, err := c.ClientConn.SetPublicKey(ctx, &proto.SetPublicKeyRequest{PublicKey: key})
+	if err != nil {
+		if unavailable(err) {
+			return ErrorUnavailable
+		}
+		return ErrorShare
+	}
+	if !grpcResp.Success {
+		return ErrorShare
+	}
+
+	return nil
+}
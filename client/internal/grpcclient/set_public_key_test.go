@@ -0,0 +1,105 @@
+package grpcclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockPublicKey is the sharing key every mock user has published
+var mockPublicKey = bytes.Repeat([]byte{7}, 32)
+
+// SetPublicKey implements the mock SetPublicKey method
+func (m *MockVaultServer) SetPublicKey(ctx context.Context, req *proto.SetPublicKeyRequest) (*proto.SetPublicKeyResponse, error) {
+	fmt.Printf("DEBUG MockServer: SetPublicKey called with %d bytes, shouldSucceed: %t\n", len(req.PublicKey), m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if len(req.PublicKey) != len(mockPublicKey) {
+		return nil, status.Error(codes.InvalidArgument, "Public key must be 32 bytes")
+	}
+
+	return &proto.SetPublicKeyResponse{
+		Success: true,
+	}, nil
+}
+
+func TestDataVault_SetPublicKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		key           []byte
+		expectedErr   error
+	}{
+		{
+			name:          "successful publish",
+			shouldSucceed: true,
+			jwt:           "key-token",
+			key:           mockPublicKey,
+		},
+		{
+			name:          "invalid key",
+			shouldSucceed: true,
+			jwt:           "key-token",
+			key:           []byte("short"),
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "key-token",
+			key:           mockPublicKey,
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty key",
+			shouldSucceed: true,
+			jwt:           "key-token",
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			key:           mockPublicKey,
+			expectedErr:   ErrorShare,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			err := client.SetPublicKey(context.Background(), tt.jwt, tt.key)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ShareData grants another user access to a data entry via gRPC. The wrapped key is the entry's
+// record key sealed to the recipient's public key.
+func (c *Client) ShareData(ctx context.Context, jwt, id, login, permission string, wrappedKey []byte) error {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || id == "" || login == "" || len(wrappedKey) == 0 {
+		return ErrorShare
+	}
+
+	grpcResp, err := c.ClientConn.ShareData(ctx, &proto.ShareDataRequest{
+		Id:         id,
+		Login:      login,
+		Permission: permission,
+		WrappedKey: wrappedKey,
+	})
+	if err != nil {
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return ErrorNotFound
+		case status.Code(err) == codes.FailedPrecondition:
+			return ErrorNoKey
+		case unavailable(err):
+			return ErrorUnavailable
+		}
+		return ErrorShare
+	}
+	if !grpcResp.Success {
+		return ErrorShare
+	}
+
+	return nil
+}
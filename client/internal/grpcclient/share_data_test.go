@@ -0,0 +1,146 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ShareData implements the mock ShareData method
+func (m *MockVaultServer) ShareData(ctx context.Context, req *proto.ShareDataRequest) (*proto.ShareDataResponse, error) {
+	fmt.Printf("DEBUG MockServer: ShareData called with id: %s, login: %s, shouldSucceed: %t\n", req.Id, req.Login, m.shouldSucceed)
+
+	if err := m.checkStreamJWT(ctx); err != nil {
+		return nil, err
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	switch {
+	case req.Permission != "read" && req.Permission != "write":
+		return nil, status.Error(codes.InvalidArgument, "Permission must be read or write")
+	case req.Id == "missing":
+		return nil, status.Error(codes.NotFound, "Data not found")
+	case req.Login == "nokey":
+		return nil, status.Error(codes.FailedPrecondition, "User has not published a public key yet")
+	}
+
+	return &proto.ShareDataResponse{
+		Success: true,
+	}, nil
+}
+
+func TestDataVault_ShareData(t *testing.T) {
+	t.Parallel()
+
+	wrapped := []byte("wrapped record key")
+
+	tests := []struct {
+		name          string
+		shouldSucceed bool
+		jwt           string
+		id            string
+		login         string
+		permission    string
+		wrapped       []byte
+		expectedErr   error
+	}{
+		{
+			name:          "successful share",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "data-1",
+			login:         "alice",
+			permission:    "write",
+			wrapped:       wrapped,
+		},
+		{
+			name:          "data not found",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "missing",
+			login:         "alice",
+			permission:    "read",
+			wrapped:       wrapped,
+			expectedErr:   ErrorNotFound,
+		},
+		{
+			name:          "recipient without key",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "data-1",
+			login:         "nokey",
+			permission:    "read",
+			wrapped:       wrapped,
+			expectedErr:   ErrorNoKey,
+		},
+		{
+			name:          "invalid permission",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "data-1",
+			login:         "alice",
+			permission:    "admin",
+			wrapped:       wrapped,
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "share-token",
+			id:            "data-1",
+			login:         "alice",
+			permission:    "read",
+			wrapped:       wrapped,
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty wrapped key",
+			shouldSucceed: true,
+			jwt:           "share-token",
+			id:            "data-1",
+			login:         "alice",
+			permission:    "read",
+			expectedErr:   ErrorShare,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			id:            "data-1",
+			login:         "alice",
+			permission:    "read",
+			wrapped:       wrapped,
+			expectedErr:   ErrorShare,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			err := client.ShareData(context.Background(), tt.jwt, tt.id, tt.login, tt.permission, tt.wrapped)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
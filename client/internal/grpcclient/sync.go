@@ -0,0 +1,73 @@
+package grpcclient
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Sync pushes local changes and fetches everything changed on the server after the cursor via gRPC
+func (c *Client) Sync(ctx context.Context, jwt string, cursor int64, changes []models.SyncChange) (models.SyncResult, error) {
+	var res models.SyncResult
+
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || cursor < 0 {
+		return res, ErrorSync
+	}
+
+	req := &proto.SyncRequest{
+		Cursor:  cursor,
+		Changes: make([]*proto.SyncChange, 0, len(changes)),
+	}
+	for _, ch := range changes {
+		req.Changes = append(req.Changes, &proto.SyncChange{
+			Id:      ch.ID,
+			Type:    ch.Type,
+			Data:    ch.Data,
+			Version: ch.Version,
+			Deleted: ch.Deleted,
+		})
+	}
+
+	grpcResp, err := c.ClientConn.Sync(ctx, req)
+	if forbidden(err) {
+		return res, ErrorForbidden
+	}
+	if err != nil {
+		return res, ErrorSync
+	}
+
+	res.Cursor = grpcResp.Cursor
+	for _, d := range grpcResp.Created {
+		res.Created = append(res.Created, dataFromProto(d))
+	}
+	for _, d := range grpcResp.Updated {
+		res.Updated = append(res.Updated, dataFromProto(d))
+	}
+	for _, t := range grpcResp.Deleted {
+		res.Deleted = append(res.Deleted, models.Tombstone{
+			ID:        t.Id,
+			Revision:  t.Revision,
+			DeletedAt: t.DeletedAt,
+		})
+	}
+	for _, cf := range grpcResp.Conflicts {
+		conflict := models.SyncConflict{
+			ID:            cf.Id,
+			ClientVersion: cf.ClientVersion,
+		}
+		if cf.Current != nil {
+			current := dataFromProto(cf.Current)
+			conflict.Current = &current
+		}
+		res.Conflicts = append(res.Conflicts, conflict)
+	}
+
+	return res, nil
+}
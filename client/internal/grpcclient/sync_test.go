@@ -0,0 +1,133 @@
+package grpcclient
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Sync implements the mock Sync method
+func (m *MockVaultServer) Sync(ctx context.Context, req *proto.SyncRequest) (*proto.SyncResponse, error) {
+	fmt.Printf("DEBUG MockServer: Sync called with cursor: %d, changes: %d, shouldSucceed: %t\n", req.Cursor, len(req.Changes), m.shouldSucceed)
+
+	if m.validateJWT {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "no metadata found")
+		}
+
+		authHeaders := md.Get("authorization")
+		if len(authHeaders) == 0 || !strings.HasPrefix(authHeaders[0], "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "no authorization header")
+		}
+
+		if _, valid := m.ValidateTestJWT(authHeaders[0][7:]); !valid {
+			return nil, status.Error(codes.Unauthenticated, "invalid JWT token")
+		}
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	resp := &proto.SyncResponse{Cursor: req.Cursor}
+	if req.Cursor < 10 {
+		resp.Cursor = 12
+		resp.Created = []*proto.Data{{Id: "data-3", Type: "text", Data: []byte("created"), Version: 1, Revision: 10}}
+		resp.Updated = []*proto.Data{{Id: "data-1", Type: "text", Data: []byte("updated"), Version: 2, Revision: 11}}
+		resp.Deleted = []*proto.Tombstone{{Id: "data-2", Revision: 12, DeletedAt: "2025-08-24T12:00:00Z"}}
+	}
+
+	for _, c := range req.Changes {
+		if c.Id != "" && c.Version != mockCurrentVersion {
+			resp.Conflicts = append(resp.Conflicts, &proto.SyncConflict{
+				Id:            c.Id,
+				ClientVersion: c.Version,
+				Current:       &proto.Data{Id: c.Id, Type: "text", Data: []byte("server copy"), Version: mockCurrentVersion},
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func TestDataVault_Sync(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "sync-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	res, err := client.Sync(ctx, expectedToken, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), res.Cursor)
+	require.Len(t, res.Created, 1)
+	assert.Equal(t, "data-3", res.Created[0].ID)
+	assert.Equal(t, int64(10), res.Created[0].Revision)
+	require.Len(t, res.Updated, 1)
+	assert.Equal(t, int64(2), res.Updated[0].Version)
+	require.Len(t, res.Deleted, 1)
+	assert.Equal(t, "data-2", res.Deleted[0].ID)
+	assert.Empty(t, res.Conflicts)
+
+	res, err = client.Sync(ctx, expectedToken, 12, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), res.Cursor, "Cursor should not move when nothing changed")
+	assert.Empty(t, res.Created)
+	assert.Empty(t, res.Updated)
+	assert.Empty(t, res.Deleted)
+}
+
+func TestDataVault_Sync_Conflicts(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "sync-conflict-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+
+	changes := []models.SyncChange{
+		{ID: "data-1", Type: "text", Data: []byte("fresh edit"), Version: mockCurrentVersion},
+		{ID: "data-2", Type: "text", Data: []byte("stale edit"), Version: mockCurrentVersion + 3},
+	}
+
+	res, err := client.Sync(context.Background(), expectedToken, 12, changes)
+	require.NoError(t, err)
+	require.Len(t, res.Conflicts, 1)
+	assert.Equal(t, "data-2", res.Conflicts[0].ID)
+	assert.Equal(t, int64(mockCurrentVersion+3), res.Conflicts[0].ClientVersion)
+	require.NotNil(t, res.Conflicts[0].Current)
+	assert.Equal(t, "server copy", string(res.Conflicts[0].Current.Data))
+}
+
+func TestDataVault_Sync_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, lis, cleanup := SetupMockServer(false, "")
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	_, err := client.Sync(ctx, "any-token", 0, nil)
+	assert.ErrorIs(t, err, ErrorSync, "Server failure should be reported")
+
+	_, err = client.Sync(ctx, "", 0, nil)
+	assert.ErrorIs(t, err, ErrorSync, "Empty JWT should be rejected")
+
+	_, err = client.Sync(ctx, "any-token", -1, nil)
+	assert.ErrorIs(t, err, ErrorSync, "Negative cursor should be rejected")
+}
@@ -0,0 +1,48 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateData replaces a data entry in the vault via gRPC if it is still at the given version
+func (c *Client) UpdateData(ctx context.Context, jwt, id, dataType string, data []byte, version int64) (int64, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if id == "" || jwt == "" || dataType == "" || len(data) == 0 || version <= 0 {
+		return 0, ErrorUpdate
+	}
+
+	req := &proto.UpdateDataRequest{
+		Id:      id,
+		Type:    dataType,
+		Data:    data,
+		Version: version,
+	}
+
+	grpcResp, err := c.ClientConn.UpdateData(ctx, req)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Aborted:
+			return 0, ErrorConflict
+		case codes.NotFound:
+			return 0, ErrorNotFound
+		case codes.PermissionDenied:
+			return 0, ErrorForbidden
+		}
+		return 0, ErrorUpdate
+	}
+	if !grpcResp.Success {
+		return 0, ErrorUpdate
+	}
+
+	return grpcResp.Version, nil
+}
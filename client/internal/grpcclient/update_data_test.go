@@ -0,0 +1,181 @@
+package grpcclient
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mockCurrentVersion is the version every mock record is stored at
+const mockCurrentVersion = 1
+
+// UpdateData implements the mock UpdateData method
+func (m *MockVaultServer) UpdateData(ctx context.Context, req *proto.UpdateDataRequest) (*proto.UpdateDataResponse, error) {
+	fmt.Printf("DEBUG MockServer: UpdateData called with ID: %s, version: %d, shouldSucceed: %t\n", req.Id, req.Version, m.shouldSucceed)
+
+	if m.validateJWT {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "no metadata found")
+		}
+
+		authHeaders := md.Get("authorization")
+		if len(authHeaders) == 0 || !strings.HasPrefix(authHeaders[0], "Bearer ") {
+			return nil, status.Error(codes.Unauthenticated, "no authorization header")
+		}
+
+		if _, valid := m.ValidateTestJWT(authHeaders[0][7:]); !valid {
+			return nil, status.Error(codes.Unauthenticated, "invalid JWT token")
+		}
+	}
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	if req.Id == "missing-id" {
+		return nil, status.Error(codes.NotFound, "Data not found")
+	}
+
+	if req.Version != mockCurrentVersion {
+		fmt.Printf("DEBUG MockServer: UpdateData version conflict for ID: %s\n", req.Id)
+		return nil, status.Error(codes.Aborted, "Data was modified since it was last read")
+	}
+
+	return &proto.UpdateDataResponse{
+		Success: true,
+		Version: req.Version + 1,
+	}, nil
+}
+
+func TestDataVault_UpdateData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		shouldSucceed   bool
+		jwt             string
+		id              string
+		data            []byte
+		version         int64
+		expectedErr     error
+		expectedVersion int64
+	}{
+		{
+			name:            "successful update at current version",
+			shouldSucceed:   true,
+			jwt:             "update-token",
+			id:              "data-1",
+			data:            []byte("updated payload"),
+			version:         mockCurrentVersion,
+			expectedVersion: mockCurrentVersion + 1,
+		},
+		{
+			name:          "stale version is rejected",
+			shouldSucceed: true,
+			jwt:           "update-token",
+			id:            "data-1",
+			data:          []byte("updated payload"),
+			version:       mockCurrentVersion + 5,
+			expectedErr:   ErrorConflict,
+		},
+		{
+			name:          "unknown record",
+			shouldSucceed: true,
+			jwt:           "update-token",
+			id:            "missing-id",
+			data:          []byte("updated payload"),
+			version:       mockCurrentVersion,
+			expectedErr:   ErrorNotFound,
+		},
+		{
+			name:          "server failure",
+			shouldSucceed: false,
+			jwt:           "update-token",
+			id:            "data-1",
+			data:          []byte("updated payload"),
+			version:       mockCurrentVersion,
+			expectedErr:   ErrorUpdate,
+		},
+		{
+			name:          "empty JWT",
+			shouldSucceed: true,
+			jwt:           "",
+			id:            "data-1",
+			data:          []byte("updated payload"),
+			version:       mockCurrentVersion,
+			expectedErr:   ErrorUpdate,
+		},
+		{
+			name:          "missing version",
+			shouldSucceed: true,
+			jwt:           "update-token",
+			id:            "data-1",
+			data:          []byte("updated payload"),
+			version:       0,
+			expectedErr:   ErrorUpdate,
+		},
+		{
+			name:          "empty data",
+			shouldSucceed: true,
+			jwt:           "update-token",
+			id:            "data-1",
+			data:          nil,
+			version:       mockCurrentVersion,
+			expectedErr:   ErrorUpdate,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServer(tt.shouldSucceed, tt.jwt)
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			version, err := client.UpdateData(context.Background(), tt.jwt, tt.id, "text", tt.data, tt.version)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Zero(t, version)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestDataVault_UpdateData_WithJWTIntegration(t *testing.T) {
+	t.Parallel()
+
+	jwtSecret := "test-secret-for-update"
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, jwtSecret)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	registered, err := client.Register(ctx, models.User{Login: "updateuser", Password: "updatepassword"})
+	jwt := registered.JWT
+	require.NoError(t, err)
+
+	version, err := client.UpdateData(ctx, jwt, "data-1", "password", []byte("rotated"), mockCurrentVersion)
+	require.NoError(t, err, "UpdateData should succeed with valid JWT")
+	assert.Equal(t, int64(mockCurrentVersion+1), version)
+
+	_, err = client.UpdateData(ctx, "invalid.jwt.token", "data-1", "password", []byte("rotated"), mockCurrentVersion)
+	assert.Error(t, err, "UpdateData should fail with invalid JWT")
+}
@@ -0,0 +1,97 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UploadFile streams a file to the server via gRPC. The file info is sent first: without an ID it creates
+// a new file, with an ID it continues the upload at info.Offset. Chunks are taken from next until it
+// returns io.EOF; a nil next only sends the info, which creates a file or reports its upload state.
+func (c *Client) UploadFile(ctx context.Context, jwt string, info models.FileInfo, next func() ([]byte, error)) (models.FileInfo, error) {
+	md := metadata.New(map[string]string{
+		"authorization": "Bearer " + jwt,
+	})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if jwt == "" || (info.ID == "" && len(info.Meta) == 0) {
+		return models.FileInfo{}, ErrorUpload
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.ClientConn.UploadFile(ctx)
+	if err != nil {
+		return models.FileInfo{}, ErrorUpload
+	}
+
+	err = stream.Send(&proto.UploadFileRequest{
+		Payload: &proto.UploadFileRequest_Info{Info: &proto.FileInfo{
+			Id:        info.ID,
+			Meta:      info.Meta,
+			Size:      info.Size,
+			ChunkSize: info.ChunkSize,
+			Offset:    info.Offset,
+		}},
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return models.FileInfo{}, ErrorUpload
+	}
+
+	for next != nil && err == nil {
+		var chunk []byte
+		chunk, err = next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return models.FileInfo{}, err
+		}
+
+		err = stream.Send(&proto.UploadFileRequest{
+			Payload: &proto.UploadFileRequest_Chunk{Chunk: chunk},
+		})
+	}
+
+	// A failed Send only reports io.EOF, the actual status is returned by CloseAndRecv
+	grpcResp, err := stream.CloseAndRecv()
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			return models.FileInfo{}, ErrorNotFound
+		case codes.FailedPrecondition, codes.Aborted:
+			return models.FileInfo{}, ErrorOffset
+		case codes.PermissionDenied:
+			return models.FileInfo{}, ErrorForbidden
+		}
+		return models.FileInfo{}, ErrorUpload
+	}
+
+	res := fileInfoFromProto(grpcResp.Info)
+	if res.ID == "" || res.ChunkSize <= 0 {
+		return models.FileInfo{}, ErrorUpload
+	}
+
+	return res, nil
+}
+
+// fileInfoFromProto converts a protobuf file info into the client model
+func fileInfoFromProto(f *proto.FileInfo) models.FileInfo {
+	return models.FileInfo{
+		ID:        f.GetId(),
+		Meta:      f.GetMeta(),
+		Size:      f.GetSize(),
+		ChunkSize: f.GetChunkSize(),
+		Offset:    f.GetOffset(),
+		Complete:  f.GetComplete(),
+	}
+}
@@ -0,0 +1,200 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// mockFile holds the chunks of a file uploaded to the mock server
+type mockFile struct {
+	mu        sync.Mutex
+	meta      []byte
+	size      int64
+	chunkSize int64
+	chunks    [][]byte
+}
+
+// info returns the upload state of the mock file
+func (f *mockFile) info(id string) *proto.FileInfo {
+	expected := (f.size + f.chunkSize - 1) / f.chunkSize
+	return &proto.FileInfo{
+		Id:        id,
+		Meta:      f.meta,
+		Size:      f.size,
+		ChunkSize: f.chunkSize,
+		Offset:    min(int64(len(f.chunks))*f.chunkSize, f.size),
+		Complete:  int64(len(f.chunks)) == expected,
+	}
+}
+
+// checkStreamJWT validates the bearer token of a streaming call
+func (m *MockVaultServer) checkStreamJWT(ctx context.Context) error {
+	if !m.validateJWT {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "no metadata found")
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 || !strings.HasPrefix(authHeaders[0], "Bearer ") {
+		return status.Error(codes.Unauthenticated, "no authorization header")
+	}
+
+	if _, valid := m.ValidateTestJWT(authHeaders[0][7:]); !valid {
+		return status.Error(codes.Unauthenticated, "invalid JWT token")
+	}
+
+	return nil
+}
+
+// UploadFile implements the mock UploadFile method
+func (m *MockVaultServer) UploadFile(stream grpc.ClientStreamingServer[proto.UploadFileRequest, proto.UploadFileResponse]) error {
+	if err := m.checkStreamJWT(stream.Context()); err != nil {
+		return err
+	}
+
+	if !m.shouldSucceed {
+		return status.Error(codes.Internal, "server failure")
+	}
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "File info not provided")
+	}
+
+	id := info.Id
+	file, ok := m.files[id]
+	if id == "" {
+		id = fmt.Sprintf("file-%d", len(m.files)+1)
+		file = &mockFile{meta: info.Meta, size: info.Size, chunkSize: info.ChunkSize}
+		m.files[id] = file
+	} else if !ok {
+		return status.Error(codes.NotFound, "File not found")
+	}
+
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	first := true
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first && info.Offset != file.info(id).Offset {
+			return status.Error(codes.FailedPrecondition, "Upload must resume at the stored offset")
+		}
+		first = false
+
+		file.chunks = append(file.chunks, req.GetChunk())
+	}
+
+	return stream.SendAndClose(&proto.UploadFileResponse{Info: file.info(id)})
+}
+
+func TestDataVault_UploadFile(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "upload-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	chunks := [][]byte{[]byte("aaaa"), []byte("bbbb"), []byte("cc")}
+	sendChunks := func(chunks ...[]byte) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			if len(chunks) == 0 {
+				return nil, io.EOF
+			}
+			c := chunks[0]
+			chunks = chunks[1:]
+			return c, nil
+		}
+	}
+
+	created, err := client.UploadFile(ctx, expectedToken, models.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: 4}, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+	assert.Equal(t, int64(0), created.Offset)
+	assert.False(t, created.Complete)
+
+	partial, err := client.UploadFile(ctx, expectedToken, models.FileInfo{ID: created.ID}, sendChunks(chunks[:2]...))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), partial.Offset)
+	assert.False(t, partial.Complete)
+
+	probe, err := client.UploadFile(ctx, expectedToken, models.FileInfo{ID: created.ID}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), probe.Offset, "Probe should report the stored offset")
+	assert.Equal(t, []byte("meta"), probe.Meta)
+
+	_, err = client.UploadFile(ctx, expectedToken, models.FileInfo{ID: created.ID}, sendChunks(chunks[2]))
+	assert.ErrorIs(t, err, ErrorOffset, "Resuming at the wrong offset should be rejected")
+
+	done, err := client.UploadFile(ctx, expectedToken, models.FileInfo{ID: created.ID, Offset: probe.Offset}, sendChunks(chunks[2]))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), done.Offset)
+	assert.True(t, done.Complete)
+}
+
+func TestDataVault_UploadFile_Errors(t *testing.T) {
+	t.Parallel()
+
+	expectedToken := "upload-error-token"
+	_, lis, cleanup := SetupMockServer(true, expectedToken)
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+	ctx := context.Background()
+
+	_, err := client.UploadFile(ctx, "", models.FileInfo{Meta: []byte("meta"), Size: 1, ChunkSize: 4}, nil)
+	assert.ErrorIs(t, err, ErrorUpload, "Empty JWT should be rejected")
+
+	_, err = client.UploadFile(ctx, expectedToken, models.FileInfo{Size: 1, ChunkSize: 4}, nil)
+	assert.ErrorIs(t, err, ErrorUpload, "New file without meta should be rejected")
+
+	_, err = client.UploadFile(ctx, expectedToken, models.FileInfo{ID: "missing"}, nil)
+	assert.ErrorIs(t, err, ErrorNotFound)
+
+	readErr := errors.New("disk read failed")
+	_, err = client.UploadFile(ctx, expectedToken, models.FileInfo{Meta: []byte("meta"), Size: 4, ChunkSize: 4}, func() ([]byte, error) {
+		return nil, readErr
+	})
+	assert.ErrorIs(t, err, readErr, "Local read errors should be passed through")
+
+	_, failLis, failCleanup := SetupMockServer(false, "")
+	defer failCleanup()
+
+	failClient := SetupTestClient(t, failLis)
+	_, err = failClient.UploadFile(ctx, "any-token", models.FileInfo{Meta: []byte("meta"), Size: 4, ChunkSize: 4}, nil)
+	assert.ErrorIs(t, err, ErrorUpload, "Server failure should be reported")
+}
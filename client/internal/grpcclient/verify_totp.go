@@ -0,0 +1,48 @@
+package grpcclient
+
+import (
+	"context"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyTOTP completes a login that requires a second factor via gRPC. The code is either a
+// current code of the authenticator app or one of the recovery codes.
+func (c *Client) VerifyTOTP(ctx context.Context, challenge, code string) (models.Session, error) {
+	if challenge == "" || code == "" {
+		return models.Session{}, ErrorLogin
+	}
+
+	var header metadata.MD
+	grpcResp, err := c.ClientConn.VerifyTOTP(ctx, &proto.VerifyTOTPRequest{
+		ChallengeToken: challenge,
+		Code:           code,
+		Device:         deviceName(),
+	}, grpc.Header(&header))
+	if err != nil {
+		switch {
+		case rateLimited(err):
+			return models.Session{}, retryLater(header)
+		case status.Code(err) == codes.Unauthenticated:
+			return models.Session{}, ErrorTOTPCode
+		case unavailable(err):
+			return models.Session{}, ErrorUnavailable
+		}
+		return models.Session{}, ErrorLogin
+	}
+	if !grpcResp.Success {
+		return models.Session{}, ErrorLogin
+	}
+
+	return models.Session{
+		JWT:          grpcResp.JwtToken,
+		RefreshToken: grpcResp.RefreshToken,
+		Salt:         grpcResp.KdfSalt,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package grpcclient
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyTOTP implements the mock VerifyTOTP method
+func (m *MockVaultServer) VerifyTOTP(ctx context.Context, req *proto.VerifyTOTPRequest) (*proto.VerifyTOTPResponse, error) {
+	fmt.Printf("DEBUG MockServer: VerifyTOTP called with code: %s, shouldSucceed: %t\n", req.Code, m.shouldSucceed)
+
+	if !m.shouldSucceed {
+		return nil, status.Error(codes.Internal, "server failure")
+	}
+
+	login, ok := strings.CutPrefix(req.ChallengeToken, "challenge-")
+	if !ok || m.totp[login] != totpEnabled {
+		return nil, status.Error(codes.Unauthenticated, "Challenge expired or invalid, please login again")
+	}
+
+	if req.Code != mockTOTPCode && !slices.Contains(mockRecoveryCodes, req.Code) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid two-factor code")
+	}
+
+	return &proto.VerifyTOTPResponse{
+		Success:      true,
+		JwtToken:     m.GenerateTestJWT(login),
+		KdfSalt:      m.registeredSalts[login],
+		RefreshToken: m.issueRefreshToken(login),
+	}, nil
+}
+
+// setupTOTPUser registers a user with two-factor authentication enabled
+func setupTOTPUser(t *testing.T, client *Client, user models.User) {
+	registered, err := client.Register(context.Background(), user)
+	require.NoError(t, err)
+
+	_, err = client.EnableTOTP(context.Background(), registered.JWT)
+	require.NoError(t, err)
+
+	_, err = client.ConfirmTOTP(context.Background(), registered.JWT, mockTOTPCode)
+	require.NoError(t, err)
+}
+
+func TestDataVault_Login_TOTPRequired(t *testing.T) {
+	t.Parallel()
+
+	_, lis, cleanup := SetupMockServerWithJWT(true, "", true, "totp-secret")
+	defer cleanup()
+
+	client := SetupTestClient(t, lis)
+
+	user := models.User{Login: "totpuser", Password: "totppassword"}
+	setupTOTPUser(t, client, user)
+
+	session, err := client.Login(context.Background(), user)
+	require.NoError(t, err)
+	assert.True(t, session.TOTPRequired)
+	assert.NotEmpty(t, session.ChallengeToken)
+	assert.Empty(t, session.JWT, "No access token before the second factor")
+	assert.Empty(t, session.RefreshToken)
+}
+
+func TestDataVault_VerifyTOTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		challenge   string
+		code        string
+		expectedErr error
+	}{
+		{
+			name:      "authenticator code",
+			challenge: "challenge-totpuser",
+			code:      mockTOTPCode,
+		},
+		{
+			name:      "recovery code",
+			challenge: "challenge-totpuser",
+			code:      mockRecoveryCodes[0],
+		},
+		{
+			name:        "wrong code",
+			challenge:   "challenge-totpuser",
+			code:        "000000",
+			expectedErr: ErrorTOTPCode,
+		},
+		{
+			name:        "invalid challenge",
+			challenge:   "challenge-nobody",
+			code:        mockTOTPCode,
+			expectedErr: ErrorTOTPCode,
+		},
+		{
+			name:        "empty code",
+			challenge:   "challenge-totpuser",
+			code:        "",
+			expectedErr: ErrorLogin,
+		},
+		{
+			name:        "empty challenge",
+			challenge:   "",
+			code:        mockTOTPCode,
+			expectedErr: ErrorLogin,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, lis, cleanup := SetupMockServerWithJWT(true, "", true, "totp-secret")
+			defer cleanup()
+
+			client := SetupTestClient(t, lis)
+
+			user := models.User{Login: "totpuser", Password: "totppassword", Salt: []byte("totp-kdf-salt-16")}
+			setupTOTPUser(t, client, user)
+
+			session, err := client.VerifyTOTP(context.Background(), tt.challenge, tt.code)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Empty(t, session.JWT)
+			} else {
+				require.NoError(t, err)
+				assert.NotEmpty(t, session.JWT)
+				assert.NotEmpty(t, session.RefreshToken)
+				assert.Equal(t, user.Salt, session.Salt)
+			}
+		})
+	}
+}
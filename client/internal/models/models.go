@@ -1,9 +1,81 @@
 package models
 
-// User represents a user with login credentials
+import "time"
+
+// Data type constants for the vault
+const (
+	DataTypeText     = "text"
+	DataTypePassword = "password"
+	DataTypeBinary   = "binary"
+	DataTypeCard     = "card"
+	DataTypeOTP      = "otp"
+)
+
+// Permissions of a record shared with another user
+const (
+	PermissionRead  = "read"
+	PermissionWrite = "write"
+)
+
+// Roles of organization members, from the most to the least privileged
+const (
+	RoleOwner    = "owner"
+	RoleAdmin    = "admin"
+	RoleMember   = "member"
+	RoleReadOnly = "read-only"
+)
+
+// One-time password generator types
+const (
+	OTPTypeTOTP = "totp"
+	OTPTypeHOTP = "hotp"
+)
+
+// User represents a user with login credentials. Password is sent to the server as is, so the
+// service layer replaces the master password with the derived authentication key first. AuthKey
+// carries that key when an account still logging in with the master password is switched to it.
 type User struct {
 	Login    string `json:"login"`
 	Password string `json:"password"`
+	Salt     []byte `json:"salt,omitempty"`
+	AuthKey  string `json:"-"`
+}
+
+// KDFParams are the key derivation parameters of an account, fetched before logging in.
+// RawPassword is set while the account still logs in with the master password itself.
+type KDFParams struct {
+	Salt        []byte `json:"salt"`
+	RawPassword bool   `json:"raw_password"`
+}
+
+// Session holds the credentials returned by the server after a successful login. JWT is a
+// short-lived access token, RefreshToken is exchanged for a new pair once it expires.
+// When the account has two-factor authentication, the login only returns TOTPRequired and a
+// ChallengeToken that is exchanged for the credentials together with a code.
+type Session struct {
+	JWT            string `json:"jwt"`
+	RefreshToken   string `json:"refresh_token"`
+	Salt           []byte `json:"salt"`
+	TOTPRequired   bool   `json:"totp_required,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+// TOTPSetup holds the secret of a two-factor authentication setup that still has to be
+// confirmed with a code. URI is the otpauth:// form of the secret for authenticator apps.
+type TOTPSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// DeviceSession describes a login of the user on one of their devices. Current marks the
+// session of the access token used to list them.
+type DeviceSession struct {
+	ID         string `json:"id"`
+	Device     string `json:"device"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at"`
+	Current    bool   `json:"current"`
 }
 
 // Data represents a data entry in the vault
@@ -14,4 +86,217 @@ type Data struct {
 	Type       string `json:"type"`
 	Data       []byte `json:"data"`
 	UploadedAt string `json:"uploaded_at"`
+	Version    int64  `json:"version"`
+	Revision   int64  `json:"revision"`
+	// DeletedAt is the time the entry was moved to the trash, empty for live entries
+	DeletedAt string `json:"deleted_at,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// SharedData is a record another user shared with the vault user. WrappedKey is the record key
+// sealed to the user's sharing key; once unwrapped into Key it decrypts the record and, with
+// write permission, seals updates to it.
+type SharedData struct {
+	Data       Data   `json:"data"`
+	Owner      string `json:"owner"`
+	Permission string `json:"permission"`
+	SharedAt   string `json:"shared_at"`
+	WrappedKey []byte `json:"-"`
+	Key        []byte `json:"-"`
+}
+
+// Member is a user's membership in an organization. WrappedKey is the organization key sealed
+// to the member's sharing key, it is only returned for the memberships of the vault user.
+type Member struct {
+	Org        string `json:"org"`
+	Login      string `json:"login"`
+	Role       string `json:"role"`
+	JoinedAt   string `json:"joined_at"`
+	WrappedKey []byte `json:"-"`
+}
+
+// ServerStatus is the result of a server check: the server's build version, the round trip of
+// the check and how long the server's database took to answer
+type ServerStatus struct {
+	Version   string        `json:"version"`
+	Latency   time.Duration `json:"latency"`
+	DBLatency time.Duration `json:"db_latency"`
+}
+
+// AuditEntry records a call made by or for the user on the server. Entries are numbered by Seq
+// and chained by Hash, which covers PrevHash, the hash of the user's previous entry.
+type AuditEntry struct {
+	User      string `json:"user"`
+	Seq       int64  `json:"seq"`
+	At        string `json:"at"`
+	Action    string `json:"action"`
+	RecordID  string `json:"record_id,omitempty"`
+	Org       string `json:"org,omitempty"`
+	ClientIP  string `json:"client_ip"`
+	UserAgent string `json:"user_agent"`
+	Result    string `json:"result"`
+	PrevHash  []byte `json:"prev_hash"`
+	Hash      []byte `json:"hash"`
+	Authentic bool   `json:"authentic"`
+}
+
+// Version is a prior state of a data entry kept in the vault's history. ArchivedAt is the time
+// the state was replaced by an update, a delete or a restore.
+type Version struct {
+	DataID     string            `json:"data_id"`
+	Version    int64             `json:"version"`
+	Type       string            `json:"type"`
+	Data       []byte            `json:"data"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	UploadedAt string            `json:"uploaded_at"`
+	ArchivedAt string            `json:"archived_at"`
+}
+
+// DataQuery selects a page of data entries, newest first. Empty fields don't filter.
+// UploadedFrom and UploadedTo are RFC 3339 timestamps, the range includes From and excludes To.
+type DataQuery struct {
+	Type         string            `json:"type"`
+	UploadedFrom string            `json:"uploaded_from"`
+	UploadedTo   string            `json:"uploaded_to"`
+	Metadata     map[string]string `json:"metadata"`
+	PageSize     int32             `json:"page_size"`
+	PageToken    string            `json:"page_token"`
+}
+
+// DataPage is a page of data entries with the token of the next page, empty on the last page
+type DataPage struct {
+	Data          []Data `json:"data"`
+	NextPageToken string `json:"next_page_token"`
+}
+
+// Tombstone marks a data entry deleted on the server
+type Tombstone struct {
+	ID        string `json:"id"`
+	Revision  int64  `json:"revision"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+// SyncChange represents a local modification pushed to the server during synchronization.
+// An empty ID creates a new entry, Deleted removes the entry at Version.
+type SyncChange struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Data    []byte `json:"data"`
+	Version int64  `json:"version"`
+	Deleted bool   `json:"deleted"`
+}
+
+// SyncConflict reports a pushed change that was based on a stale version.
+// Current is the server's copy of the entry, nil if it was deleted.
+type SyncConflict struct {
+	ID            string `json:"id"`
+	ClientVersion int64  `json:"client_version"`
+	Current       *Data  `json:"current"`
+}
+
+// SyncResult holds the changes made on the server since the client's cursor
+type SyncResult struct {
+	Cursor    int64          `json:"cursor"`
+	Created   []Data         `json:"created"`
+	Updated   []Data         `json:"updated"`
+	Deleted   []Tombstone    `json:"deleted"`
+	Conflicts []SyncConflict `json:"conflicts"`
+}
+
+// FileInfo describes a chunked file upload on the server. Meta is the encrypted FileMeta,
+// Offset is the number of plaintext bytes already stored.
+type FileInfo struct {
+	ID        string `json:"id"`
+	Meta      []byte `json:"meta"`
+	Size      int64  `json:"size"`
+	ChunkSize int64  `json:"chunk_size"`
+	Offset    int64  `json:"offset"`
+	Complete  bool   `json:"complete"`
+}
+
+// FileMeta is the description of an uploaded file, stored encrypted as the entry's data
+type FileMeta struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// PendingOp is a change made while the server was unreachable, waiting to be replayed.
+// Data holds the plaintext payload of a queued post.
+type PendingOp struct {
+	Kind     string `json:"kind"`
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Data     []byte `json:"data,omitempty"`
+	QueuedAt string `json:"queued_at"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// RejectedOp is a queued change the server refused for good. It is kept out of the queue
+// until the user has been told about it.
+type RejectedOp struct {
+	PendingOp
+	Reason     string `json:"reason"`
+	RejectedAt string `json:"rejected_at"`
+}
+
+// LoginPasswordData represents login/password pair data
+type LoginPasswordData struct {
+	Website  string `json:"website"`
+	Login    string `json:"login"`
+	Password string `json:"password"`
+	Notes    string `json:"notes"`
+}
+
+// BankCardData represents banking card data
+type BankCardData struct {
+	Bank     string `json:"bank"`
+	Number   string `json:"number"`
+	Holder   string `json:"holder"`
+	CVV      string `json:"cvv"`
+	ExpMonth int    `json:"exp_month"`
+	ExpYear  int    `json:"exp_year"`
+	Notes    string `json:"notes"`
+}
+
+// TextData represents arbitrary text data
+type TextData struct {
+	Content string `json:"content"`
+	Notes   string `json:"notes"`
+}
+
+// BinaryData represents arbitrary binary data. Files streamed with UploadFile
+// leave Content empty and describe the stored file with Size and SHA256.
+type BinaryData struct {
+	Filename string `json:"filename"`
+	Content  []byte `json:"content"`
+	Notes    string `json:"notes"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// OTPData represents the shared secret of a one-time password generator, e.g. the 2FA seed
+// of a service account. Secret is base32 encoded as in otpauth:// URIs. TOTP codes change
+// every Period seconds, HOTP codes are derived from Counter, which is advanced on each use.
+type OTPData struct {
+	Type      string `json:"type"`
+	Issuer    string `json:"issuer"`
+	Account   string `json:"account"`
+	Secret    string `json:"secret"`
+	Algorithm string `json:"algorithm"`
+	Digits    int    `json:"digits"`
+	Period    int    `json:"period"`
+	Counter   uint64 `json:"counter"`
+	Notes     string `json:"notes"`
+}
+
+// Record is the typed plaintext of a vault entry, exactly one of its fields is set
+type Record struct {
+	Password *LoginPasswordData `json:"password,omitempty"`
+	Card     *BankCardData      `json:"card,omitempty"`
+	Text     *TextData          `json:"text,omitempty"`
+	Binary   *BinaryData        `json:"binary,omitempty"`
+	OTP      *OTPData           `json:"otp,omitempty"`
 }
@@ -0,0 +1,25 @@
+package records
+
+import (
+	"errors"
+)
+
+// Package level errors for typed record validation
+var (
+	ErrEmptyRecord      = errors.New("record has no payload")
+	ErrEmptyLogin       = errors.New("login is required")
+	ErrEmptyText        = errors.New("text content is required")
+	ErrNoFilename       = errors.New("filename is required")
+	ErrCardNumber       = errors.New("card number is invalid")
+	ErrCardExpiry       = errors.New("card expiry month must be between 1 and 12")
+	ErrCardExpired      = errors.New("card has expired")
+	ErrCardCVV          = errors.New("card CVV must be 3 or 4 digits")
+	ErrOTPType          = errors.New("OTP type must be totp or hotp")
+	ErrOTPSecret        = errors.New("OTP secret must be a non-empty base32 string")
+	ErrOTPAlgorithm     = errors.New("OTP algorithm must be SHA1, SHA256 or SHA512")
+	ErrOTPDigits        = errors.New("OTP codes must have 6 to 8 digits")
+	ErrOTPPeriod        = errors.New("TOTP period must be positive")
+	ErrOTPURI           = errors.New("invalid otpauth:// URI")
+	ErrUnknownType      = errors.New("unknown record type")
+	ErrMultiplePayloads = errors.New("record must hold exactly one payload")
+)
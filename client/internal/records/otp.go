@@ -0,0 +1,190 @@
+package records
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"data-vault/client/internal/models"
+)
+
+// Defaults of otpauth:// URIs for parameters that aren't given
+const (
+	defaultOTPAlgorithm = "SHA1"
+	defaultOTPDigits    = 6
+	defaultOTPPeriod    = 30
+)
+
+// otpSecretEncoding decodes base32 secrets, which are usually written without padding
+var otpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NormalizeOTP fills in the default type, algorithm, digits and period of an OTP record and
+// brings the secret to the canonical unpadded upper case form authenticator apps show
+func NormalizeOTP(otp *models.OTPData) {
+	otp.Type = strings.ToLower(strings.TrimSpace(otp.Type))
+	if otp.Type == "" {
+		otp.Type = models.OTPTypeTOTP
+	}
+
+	otp.Secret = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '=' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(otp.Secret))
+
+	otp.Algorithm = strings.ToUpper(strings.TrimSpace(otp.Algorithm))
+	if otp.Algorithm == "" {
+		otp.Algorithm = defaultOTPAlgorithm
+	}
+	if otp.Digits == 0 {
+		otp.Digits = defaultOTPDigits
+	}
+	switch otp.Type {
+	case models.OTPTypeTOTP:
+		if otp.Period == 0 {
+			otp.Period = defaultOTPPeriod
+		}
+	case models.OTPTypeHOTP:
+		otp.Period = 0
+	}
+}
+
+// ParseOTPURI imports an otpauth://totp/... or otpauth://hotp/... URI as exported by
+// authenticator apps and shown in QR codes
+func ParseOTPURI(uri string) (*models.OTPData, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil || u.Scheme != "otpauth" {
+		return nil, ErrOTPURI
+	}
+
+	query := u.Query()
+	otp := &models.OTPData{
+		Type:      u.Host,
+		Secret:    query.Get("secret"),
+		Algorithm: query.Get("algorithm"),
+	}
+
+	// The label is "issuer:account" or just "account", the issuer parameter takes precedence
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		otp.Issuer = strings.TrimSpace(issuer)
+		otp.Account = strings.TrimSpace(account)
+	} else {
+		otp.Account = strings.TrimSpace(label)
+	}
+	if issuer := query.Get("issuer"); issuer != "" {
+		otp.Issuer = issuer
+	}
+
+	for name, field := range map[string]*int{"digits": &otp.Digits, "period": &otp.Period} {
+		if value := query.Get(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s %q", ErrOTPURI, name, value)
+			}
+			*field = n
+		}
+	}
+
+	if value := query.Get("counter"); value != "" {
+		counter, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: counter %q", ErrOTPURI, value)
+		}
+		otp.Counter = counter
+	}
+
+	NormalizeOTP(otp)
+	if err := validateOTP(otp); err != nil {
+		return nil, err
+	}
+
+	return otp, nil
+}
+
+// OTPCode computes the current code of an OTP record. For TOTP it also returns how long
+// the code stays valid; HOTP codes are valid until the counter is advanced, so it is zero.
+func OTPCode(otp *models.OTPData, now time.Time) (string, time.Duration, error) {
+	if err := validateOTP(otp); err != nil {
+		return "", 0, err
+	}
+
+	key, _ := otpSecretEncoding.DecodeString(otp.Secret)
+
+	if otp.Type == models.OTPTypeHOTP {
+		return hotp(key, otp.Counter, otp.Algorithm, otp.Digits), 0, nil
+	}
+
+	period := int64(otp.Period)
+	step := now.Unix() / period
+	remaining := time.Duration(period-now.Unix()%period) * time.Second
+
+	return hotp(key, uint64(step), otp.Algorithm, otp.Digits), remaining, nil
+}
+
+// validateOTP checks the type, secret and code parameters of an OTP record
+func validateOTP(otp *models.OTPData) error {
+	if otp.Type != models.OTPTypeTOTP && otp.Type != models.OTPTypeHOTP {
+		return ErrOTPType
+	}
+
+	key, err := otpSecretEncoding.DecodeString(otp.Secret)
+	if err != nil || len(key) == 0 {
+		return ErrOTPSecret
+	}
+
+	if otpHash(otp.Algorithm) == nil {
+		return ErrOTPAlgorithm
+	}
+	if otp.Digits < 6 || otp.Digits > 8 {
+		return ErrOTPDigits
+	}
+	if otp.Type == models.OTPTypeTOTP && otp.Period <= 0 {
+		return ErrOTPPeriod
+	}
+
+	return nil
+}
+
+// otpHash returns the HMAC hash of an OTP algorithm name, nil if it isn't supported
+func otpHash(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case "SHA1":
+		return sha1.New
+	case "SHA256":
+		return sha256.New
+	case "SHA512":
+		return sha512.New
+	}
+	return nil
+}
+
+// hotp computes the RFC 4226 code of a counter value, TOTP uses the time step as the counter
+func hotp(key []byte, counter uint64, algorithm string, digits int) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(otpHash(algorithm), key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
@@ -0,0 +1,140 @@
+package records
+
+import (
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// Encode serializes a record into the plaintext stored encrypted in the vault
+func Encode(rec models.Record) ([]byte, error) {
+	msg := &proto.Record{}
+
+	switch {
+	case rec.Password != nil:
+		msg.Payload = &proto.Record_Password{Password: &proto.LoginPassword{
+			Website:  rec.Password.Website,
+			Login:    rec.Password.Login,
+			Password: rec.Password.Password,
+			Notes:    rec.Password.Notes,
+		}}
+	case rec.Card != nil:
+		msg.Payload = &proto.Record_Card{Card: &proto.BankCard{
+			Bank:     rec.Card.Bank,
+			Number:   rec.Card.Number,
+			Holder:   rec.Card.Holder,
+			Cvv:      rec.Card.CVV,
+			ExpMonth: int32(rec.Card.ExpMonth),
+			ExpYear:  int32(rec.Card.ExpYear),
+			Notes:    rec.Card.Notes,
+		}}
+	case rec.Text != nil:
+		msg.Payload = &proto.Record_Text{Text: &proto.Text{
+			Content: rec.Text.Content,
+			Notes:   rec.Text.Notes,
+		}}
+	case rec.Binary != nil:
+		msg.Payload = &proto.Record_Binary{Binary: &proto.Binary{
+			Filename: rec.Binary.Filename,
+			Content:  rec.Binary.Content,
+			Notes:    rec.Binary.Notes,
+			Size:     rec.Binary.Size,
+			Sha256:   rec.Binary.SHA256,
+		}}
+	case rec.OTP != nil:
+		msg.Payload = &proto.Record_Otp{Otp: &proto.OneTimePassword{
+			Type:      rec.OTP.Type,
+			Issuer:    rec.OTP.Issuer,
+			Account:   rec.OTP.Account,
+			Secret:    rec.OTP.Secret,
+			Algorithm: rec.OTP.Algorithm,
+			Digits:    int32(rec.OTP.Digits),
+			Period:    int32(rec.OTP.Period),
+			Counter:   rec.OTP.Counter,
+			Notes:     rec.OTP.Notes,
+		}}
+	default:
+		return nil, ErrEmptyRecord
+	}
+
+	return protobuf.Marshal(msg)
+}
+
+// Decode parses the plaintext of a vault entry. Entries stored before typed records
+// existed hold raw bytes and are returned as a text record.
+func Decode(data []byte) models.Record {
+	msg := &proto.Record{}
+	if err := protobuf.Unmarshal(data, msg); err != nil {
+		return legacy(data)
+	}
+
+	switch p := msg.Payload.(type) {
+	case *proto.Record_Password:
+		return models.Record{Password: &models.LoginPasswordData{
+			Website:  p.Password.GetWebsite(),
+			Login:    p.Password.GetLogin(),
+			Password: p.Password.GetPassword(),
+			Notes:    p.Password.GetNotes(),
+		}}
+	case *proto.Record_Card:
+		return models.Record{Card: &models.BankCardData{
+			Bank:     p.Card.GetBank(),
+			Number:   p.Card.GetNumber(),
+			Holder:   p.Card.GetHolder(),
+			CVV:      p.Card.GetCvv(),
+			ExpMonth: int(p.Card.GetExpMonth()),
+			ExpYear:  int(p.Card.GetExpYear()),
+			Notes:    p.Card.GetNotes(),
+		}}
+	case *proto.Record_Text:
+		return models.Record{Text: &models.TextData{
+			Content: p.Text.GetContent(),
+			Notes:   p.Text.GetNotes(),
+		}}
+	case *proto.Record_Binary:
+		return models.Record{Binary: &models.BinaryData{
+			Filename: p.Binary.GetFilename(),
+			Content:  p.Binary.GetContent(),
+			Notes:    p.Binary.GetNotes(),
+			Size:     p.Binary.GetSize(),
+			SHA256:   p.Binary.GetSha256(),
+		}}
+	case *proto.Record_Otp:
+		return models.Record{OTP: &models.OTPData{
+			Type:      p.Otp.GetType(),
+			Issuer:    p.Otp.GetIssuer(),
+			Account:   p.Otp.GetAccount(),
+			Secret:    p.Otp.GetSecret(),
+			Algorithm: p.Otp.GetAlgorithm(),
+			Digits:    int(p.Otp.GetDigits()),
+			Period:    int(p.Otp.GetPeriod()),
+			Counter:   p.Otp.GetCounter(),
+			Notes:     p.Otp.GetNotes(),
+		}}
+	}
+
+	return legacy(data)
+}
+
+// TypeOf returns the vault data type of a record, empty if the record has no payload
+func TypeOf(rec models.Record) string {
+	switch {
+	case rec.Password != nil:
+		return models.DataTypePassword
+	case rec.Card != nil:
+		return models.DataTypeCard
+	case rec.Text != nil:
+		return models.DataTypeText
+	case rec.Binary != nil:
+		return models.DataTypeBinary
+	case rec.OTP != nil:
+		return models.DataTypeOTP
+	}
+	return ""
+}
+
+// legacy wraps an untyped payload into a text record
+func legacy(data []byte) models.Record {
+	return models.Record{Text: &models.TextData{Content: string(data)}}
+}
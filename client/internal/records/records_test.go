@@ -0,0 +1,323 @@
+package records
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"data-vault/client/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   models.Record
+		dataType string
+	}{
+		{
+			name:     "password",
+			record:   models.Record{Password: &models.LoginPasswordData{Website: "example.com", Login: "alice", Password: "s3cret", Notes: "work"}},
+			dataType: models.DataTypePassword,
+		},
+		{
+			name:     "card",
+			record:   models.Record{Card: &models.BankCardData{Bank: "Bank", Number: "4111111111111111", Holder: "ALICE", CVV: "123", ExpMonth: 12, ExpYear: 2030}},
+			dataType: models.DataTypeCard,
+		},
+		{
+			name:     "text",
+			record:   models.Record{Text: &models.TextData{Content: "hello", Notes: "greeting"}},
+			dataType: models.DataTypeText,
+		},
+		{
+			name:     "binary",
+			record:   models.Record{Binary: &models.BinaryData{Filename: "photo.jpg", Size: 2048, SHA256: "abc"}},
+			dataType: models.DataTypeBinary,
+		},
+		{
+			name:     "otp",
+			record:   models.Record{OTP: &models.OTPData{Type: models.OTPTypeHOTP, Issuer: "GitHub", Account: "ci-bot", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Counter: 7}},
+			dataType: models.DataTypeOTP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Encode(tt.record)
+			require.NoError(t, err)
+
+			decoded := Decode(data)
+			assert.Equal(t, tt.record, decoded)
+			assert.Equal(t, tt.dataType, TypeOf(decoded))
+		})
+	}
+
+	_, err := Encode(models.Record{})
+	assert.ErrorIs(t, err, ErrEmptyRecord)
+}
+
+func TestDecode_Legacy(t *testing.T) {
+	for _, raw := range []string{"plain text note", "{\"filename\":\"a.txt\"}", ""} {
+		rec := Decode([]byte(raw))
+		require.NotNil(t, rec.Text)
+		assert.Equal(t, raw, rec.Text.Content)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		record models.Record
+		err    error
+	}{
+		{
+			name:   "valid password",
+			record: models.Record{Password: &models.LoginPasswordData{Login: "alice"}},
+		},
+		{
+			name:   "password without login",
+			record: models.Record{Password: &models.LoginPasswordData{Login: "  ", Password: "s3cret"}},
+			err:    ErrEmptyLogin,
+		},
+		{
+			name:   "valid card with spaces",
+			record: models.Record{Card: &models.BankCardData{Number: "4111 1111 1111 1111", CVV: "123", ExpMonth: 12, ExpYear: 99}},
+		},
+		{
+			name:   "card failing luhn",
+			record: models.Record{Card: &models.BankCardData{Number: "4111111111111112", ExpMonth: 12, ExpYear: 2099}},
+			err:    ErrCardNumber,
+		},
+		{
+			name:   "card number with letters",
+			record: models.Record{Card: &models.BankCardData{Number: "4111abcd11111111", ExpMonth: 12, ExpYear: 2099}},
+			err:    ErrCardNumber,
+		},
+		{
+			name:   "card with bad month",
+			record: models.Record{Card: &models.BankCardData{Number: "4111111111111111", ExpMonth: 13, ExpYear: 2099}},
+			err:    ErrCardExpiry,
+		},
+		{
+			name:   "expired card",
+			record: models.Record{Card: &models.BankCardData{Number: "4111111111111111", ExpMonth: 1, ExpYear: 2001}},
+			err:    ErrCardExpired,
+		},
+		{
+			name:   "card with bad cvv",
+			record: models.Record{Card: &models.BankCardData{Number: "4111111111111111", CVV: "12a", ExpMonth: 12, ExpYear: 2099}},
+			err:    ErrCardCVV,
+		},
+		{
+			name:   "empty text",
+			record: models.Record{Text: &models.TextData{Notes: "only notes"}},
+			err:    ErrEmptyText,
+		},
+		{
+			name:   "binary without filename",
+			record: models.Record{Binary: &models.BinaryData{Content: []byte{1, 2, 3}}},
+			err:    ErrNoFilename,
+		},
+		{
+			name:   "valid otp",
+			record: models.Record{OTP: &models.OTPData{Type: models.OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30}},
+		},
+		{
+			name:   "otp with bad secret",
+			record: models.Record{OTP: &models.OTPData{Type: models.OTPTypeTOTP, Secret: "not base32!", Algorithm: "SHA1", Digits: 6, Period: 30}},
+			err:    ErrOTPSecret,
+		},
+		{
+			name:   "otp with unknown algorithm",
+			record: models.Record{OTP: &models.OTPData{Type: models.OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Algorithm: "MD5", Digits: 6, Period: 30}},
+			err:    ErrOTPAlgorithm,
+		},
+		{
+			name:   "otp with too many digits",
+			record: models.Record{OTP: &models.OTPData{Type: models.OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 10, Period: 30}},
+			err:    ErrOTPDigits,
+		},
+		{
+			name:   "totp without period",
+			record: models.Record{OTP: &models.OTPData{Type: models.OTPTypeTOTP, Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6}},
+			err:    ErrOTPPeriod,
+		},
+		{
+			name:   "otp with unknown type",
+			record: models.Record{OTP: &models.OTPData{Type: "motp", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6}},
+			err:    ErrOTPType,
+		},
+		{
+			name:   "empty record",
+			record: models.Record{},
+			err:    ErrEmptyRecord,
+		},
+		{
+			name:   "multiple payloads",
+			record: models.Record{Text: &models.TextData{Content: "a"}, Password: &models.LoginPasswordData{Login: "b"}},
+			err:    ErrMultiplePayloads,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.record)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateCard_ExpiryMonth(t *testing.T) {
+	card := &models.BankCardData{Number: "4111111111111111", ExpMonth: 3, ExpYear: 2025}
+
+	assert.NoError(t, validateCard(card, time.Date(2025, time.March, 31, 23, 0, 0, 0, time.UTC)))
+	assert.ErrorIs(t, validateCard(card, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)), ErrCardExpired)
+}
+
+func TestRender(t *testing.T) {
+	password := models.Record{Password: &models.LoginPasswordData{Website: "example.com", Login: "alice", Password: "s3cret"}}
+	assert.Equal(t, "Website: example.com\nLogin: alice\nPassword: ********", Render(password, false))
+	assert.Contains(t, Render(password, true), "Password: s3cret")
+	assert.Equal(t, "alice @ example.com", Summary(password))
+
+	card := models.Record{Card: &models.BankCardData{Number: "4111111111111111", Holder: "ALICE", CVV: "123", ExpMonth: 3, ExpYear: 2030}}
+	masked := Render(card, false)
+	assert.Contains(t, masked, "Number: •••• 1111")
+	assert.Contains(t, masked, "Expires: 03/30")
+	assert.Contains(t, masked, "CVV: ********")
+	assert.NotContains(t, masked, "123")
+	assert.Contains(t, Render(card, true), "Number: 4111 1111 1111 1111")
+
+	binary := models.Record{Binary: &models.BinaryData{Filename: "photo.jpg", Size: 3 * 1024 * 1024}}
+	assert.Equal(t, "File: photo.jpg\nSize: 3.0 MiB", Render(binary, false))
+
+	otp := models.Record{OTP: &models.OTPData{Type: models.OTPTypeTOTP, Issuer: "GitHub", Account: "ci-bot", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30}}
+	assert.Equal(t, "Issuer: GitHub\nAccount: ci-bot\nSecret: ********\nGenerator: TOTP, SHA1, 6 digits, 30s", Render(otp, false))
+	assert.Contains(t, Render(otp, true), "Secret: JBSWY3DPEHPK3PXP")
+	assert.Equal(t, "GitHub ci-bot", Summary(otp))
+
+	text := models.Record{Text: &models.TextData{Content: "line one\nline two"}}
+	assert.Equal(t, "line one line two", Summary(text))
+}
+
+func TestOTPCode_TOTP(t *testing.T) {
+	// RFC 6238 test vectors
+	secrets := map[string]string{
+		"SHA1":   "12345678901234567890",
+		"SHA256": "12345678901234567890123456789012",
+		"SHA512": "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+
+	tests := []struct {
+		algorithm string
+		unix      int64
+		code      string
+	}{
+		{"SHA1", 59, "94287082"},
+		{"SHA256", 59, "46119246"},
+		{"SHA512", 59, "90693936"},
+		{"SHA1", 1111111109, "07081804"},
+		{"SHA256", 1111111109, "68084774"},
+		{"SHA512", 1111111109, "25091201"},
+		{"SHA1", 2000000000, "69279037"},
+		{"SHA256", 2000000000, "90698825"},
+		{"SHA512", 2000000000, "38618901"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s at %d", tt.algorithm, tt.unix), func(t *testing.T) {
+			otp := &models.OTPData{
+				Type:      models.OTPTypeTOTP,
+				Secret:    otpSecretEncoding.EncodeToString([]byte(secrets[tt.algorithm])),
+				Algorithm: tt.algorithm,
+				Digits:    8,
+				Period:    30,
+			}
+
+			code, remaining, err := OTPCode(otp, time.Unix(tt.unix, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, time.Duration(30-tt.unix%30)*time.Second, remaining)
+		})
+	}
+}
+
+func TestOTPCode_HOTP(t *testing.T) {
+	// RFC 4226 test vectors
+	otp := &models.OTPData{
+		Type:      models.OTPTypeHOTP,
+		Secret:    otpSecretEncoding.EncodeToString([]byte("12345678901234567890")),
+		Algorithm: "SHA1",
+		Digits:    6,
+	}
+
+	for counter, want := range []string{"755224", "287082", "359152", "969429", "338314"} {
+		otp.Counter = uint64(counter)
+		code, remaining, err := OTPCode(otp, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, want, code)
+		assert.Zero(t, remaining)
+	}
+
+	_, _, err := OTPCode(&models.OTPData{Type: models.OTPTypeTOTP}, time.Now())
+	assert.ErrorIs(t, err, ErrOTPSecret)
+}
+
+func TestParseOTPURI(t *testing.T) {
+	tests := []struct {
+		name string
+		uri  string
+		want *models.OTPData
+		err  error
+	}{
+		{
+			name: "defaults",
+			uri:  "otpauth://totp/ACME%20Co:john@example.com?secret=jbsw y3dp ehpk 3pxp",
+			want: &models.OTPData{Type: models.OTPTypeTOTP, Issuer: "ACME Co", Account: "john@example.com", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30},
+		},
+		{
+			name: "issuer parameter and custom generator",
+			uri:  "otpauth://totp/Old:ci-bot?secret=JBSWY3DPEHPK3PXP&issuer=GitHub&algorithm=sha256&digits=8&period=60",
+			want: &models.OTPData{Type: models.OTPTypeTOTP, Issuer: "GitHub", Account: "ci-bot", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA256", Digits: 8, Period: 60},
+		},
+		{
+			name: "hotp with counter",
+			uri:  "otpauth://hotp/deploy?secret=JBSWY3DPEHPK3PXP&counter=42",
+			want: &models.OTPData{Type: models.OTPTypeHOTP, Account: "deploy", Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Counter: 42},
+		},
+		{
+			name: "other scheme",
+			uri:  "https://example.com/?secret=JBSWY3DPEHPK3PXP",
+			err:  ErrOTPURI,
+		},
+		{
+			name: "bad digits",
+			uri:  "otpauth://totp/x?secret=JBSWY3DPEHPK3PXP&digits=six",
+			err:  ErrOTPURI,
+		},
+		{
+			name: "missing secret",
+			uri:  "otpauth://totp/x?issuer=ACME",
+			err:  ErrOTPSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otp, err := ParseOTPURI(tt.uri)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, otp)
+		})
+	}
+}
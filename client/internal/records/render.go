@@ -0,0 +1,132 @@
+package records
+
+import (
+	"fmt"
+	"strings"
+
+	"data-vault/client/internal/models"
+)
+
+// mask replaces secret values in rendered records
+const mask = "********"
+
+// Render formats a record as "Field: value" lines. Passwords, card numbers and CVVs
+// are masked unless reveal is set; empty optional fields are skipped.
+func Render(rec models.Record, reveal bool) string {
+	var lines []string
+	add := func(name, value string) {
+		if value != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", name, value))
+		}
+	}
+
+	switch {
+	case rec.Password != nil:
+		add("Website", rec.Password.Website)
+		add("Login", rec.Password.Login)
+		add("Password", secret(rec.Password.Password, reveal))
+		add("Notes", rec.Password.Notes)
+	case rec.Card != nil:
+		add("Bank", rec.Card.Bank)
+		add("Number", cardNumber(rec.Card.Number, reveal))
+		add("Holder", rec.Card.Holder)
+		add("Expires", fmt.Sprintf("%02d/%02d", rec.Card.ExpMonth, rec.Card.ExpYear%100))
+		add("CVV", secret(rec.Card.CVV, reveal))
+		add("Notes", rec.Card.Notes)
+	case rec.Text != nil:
+		add("Text", rec.Text.Content)
+		add("Notes", rec.Text.Notes)
+	case rec.Binary != nil:
+		add("File", rec.Binary.Filename)
+		size := rec.Binary.Size
+		if size == 0 {
+			size = int64(len(rec.Binary.Content))
+		}
+		add("Size", formatSize(size))
+		add("SHA-256", rec.Binary.SHA256)
+		add("Notes", rec.Binary.Notes)
+	case rec.OTP != nil:
+		add("Issuer", rec.OTP.Issuer)
+		add("Account", rec.OTP.Account)
+		add("Secret", secret(rec.OTP.Secret, reveal))
+		add("Generator", otpGenerator(rec.OTP))
+		add("Notes", rec.OTP.Notes)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Summary formats a record as a single line suitable for lists
+func Summary(rec models.Record) string {
+	switch {
+	case rec.Password != nil:
+		if rec.Password.Website == "" {
+			return rec.Password.Login
+		}
+		return fmt.Sprintf("%s @ %s", rec.Password.Login, rec.Password.Website)
+	case rec.Card != nil:
+		return strings.TrimSpace(fmt.Sprintf("%s %s", rec.Card.Bank, cardNumber(rec.Card.Number, false)))
+	case rec.Text != nil:
+		content := strings.ReplaceAll(rec.Text.Content, "\n", " ")
+		if len([]rune(content)) > 40 {
+			content = string([]rune(content)[:40]) + "…"
+		}
+		return content
+	case rec.Binary != nil:
+		return rec.Binary.Filename
+	case rec.OTP != nil:
+		if rec.OTP.Issuer == "" {
+			return rec.OTP.Account
+		}
+		return strings.TrimSpace(fmt.Sprintf("%s %s", rec.OTP.Issuer, rec.OTP.Account))
+	}
+	return ""
+}
+
+// secret masks a value unless reveal is set
+func secret(value string, reveal bool) string {
+	if reveal || value == "" {
+		return value
+	}
+	return mask
+}
+
+// cardNumber formats a card number in groups of four, masking all but the last four digits unless reveal is set
+func cardNumber(number string, reveal bool) string {
+	number = NormalizeCardNumber(number)
+	if !reveal {
+		if len(number) <= 4 {
+			return number
+		}
+		return "•••• " + number[len(number)-4:]
+	}
+
+	var groups []string
+	for len(number) > 4 {
+		groups = append(groups, number[:4])
+		number = number[4:]
+	}
+	return strings.Join(append(groups, number), " ")
+}
+
+// otpGenerator describes the code parameters of an OTP record, e.g. "TOTP, SHA1, 6 digits, 30s"
+func otpGenerator(otp *models.OTPData) string {
+	if otp.Type == models.OTPTypeHOTP {
+		return fmt.Sprintf("HOTP, %s, %d digits, counter %d", otp.Algorithm, otp.Digits, otp.Counter)
+	}
+	return fmt.Sprintf("TOTP, %s, %d digits, %ds", otp.Algorithm, otp.Digits, otp.Period)
+}
+
+// formatSize formats a byte count with a binary unit
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
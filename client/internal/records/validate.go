@@ -0,0 +1,111 @@
+package records
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"data-vault/client/internal/models"
+)
+
+// Validate checks that a record holds exactly one payload and that its fields are well formed.
+// The server only sees the encrypted record, so this is the only place its contents are checked.
+func Validate(rec models.Record) error {
+	set := 0
+	for _, ok := range []bool{rec.Password != nil, rec.Card != nil, rec.Text != nil, rec.Binary != nil, rec.OTP != nil} {
+		if ok {
+			set++
+		}
+	}
+	if set == 0 {
+		return ErrEmptyRecord
+	}
+	if set > 1 {
+		return ErrMultiplePayloads
+	}
+
+	switch {
+	case rec.Password != nil:
+		if strings.TrimSpace(rec.Password.Login) == "" {
+			return ErrEmptyLogin
+		}
+	case rec.Card != nil:
+		return validateCard(rec.Card, time.Now())
+	case rec.Text != nil:
+		if rec.Text.Content == "" {
+			return ErrEmptyText
+		}
+	case rec.Binary != nil:
+		if strings.TrimSpace(rec.Binary.Filename) == "" {
+			return ErrNoFilename
+		}
+	case rec.OTP != nil:
+		return validateOTP(rec.OTP)
+	}
+
+	return nil
+}
+
+// NormalizeCardNumber strips the spaces and dashes card numbers are usually written with
+func NormalizeCardNumber(number string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, number)
+}
+
+// validateCard checks the card number checksum, the expiry date against now and the CVV
+func validateCard(card *models.BankCardData, now time.Time) error {
+	number := NormalizeCardNumber(card.Number)
+	if len(number) < 12 || len(number) > 19 || !digits(number) || !luhn(number) {
+		return ErrCardNumber
+	}
+
+	if card.ExpMonth < 1 || card.ExpMonth > 12 {
+		return ErrCardExpiry
+	}
+	year := card.ExpYear
+	if year < 100 {
+		year += 2000
+	}
+	// A card is valid through the last day of its expiry month
+	if !now.Before(time.Date(year, time.Month(card.ExpMonth)+1, 1, 0, 0, 0, 0, time.UTC)) {
+		return ErrCardExpired
+	}
+
+	if card.CVV != "" && (len(card.CVV) < 3 || len(card.CVV) > 4 || !digits(card.CVV)) {
+		return ErrCardCVV
+	}
+
+	return nil
+}
+
+// luhn reports whether a string of digits passes the Luhn checksum
+func luhn(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// digits reports whether s consists of ASCII digits only
+func digits(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII || !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+)
+
+// ConfirmTOTP turns on two-factor authentication and returns the recovery codes
+func (v *Vault) ConfirmTOTP(ctx context.Context, jwt, code string) ([]string, error) {
+	return v.grpcclient.ConfirmTOTP(ctx, jwt, code)
+}
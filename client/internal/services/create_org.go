@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+)
+
+// CreateOrg creates an organization vault owned by the user. Its records are encrypted with a
+// random organization key, which the server only stores wrapped for the sharing key of each member.
+func (v *Vault) CreateOrg(ctx context.Context, jwt, name string) error {
+	if len(v.key) == 0 {
+		return ErrorNoKey
+	}
+
+	private, err := encryption.SharingKey(v.key)
+	if err != nil {
+		return err
+	}
+
+	orgKey, err := encryption.NewKey()
+	if err != nil {
+		return err
+	}
+
+	wrapped, err := encryption.WrapKey(private.PublicKey().Bytes(), orgKey)
+	if err != nil {
+		return err
+	}
+
+	return v.grpcclient.CreateOrg(ctx, jwt, name, wrapped)
+}
@@ -2,9 +2,26 @@ package services
 
 import (
 	"context"
+	"data-vault/client/internal/cache"
+	"data-vault/client/internal/models"
 )
 
-// DeleteData removes a specific data entry from the vault
+// DeleteData moves a specific data entry of the vault to the trash.
+// While the server is unreachable the delete is queued in the local cache instead.
 func (v *Vault) DeleteData(ctx context.Context, jwt, id string) error {
-	return v.grpcclient.DeleteData(ctx, jwt, id)
+	if _, err := v.Flush(ctx, jwt); err != nil {
+		return err
+	}
+
+	if !v.offline {
+		err := v.grpcclient.DeleteData(ctx, jwt, id)
+		if !v.queueOffline(err) {
+			return err
+		}
+	}
+
+	return v.cache.Enqueue(models.PendingOp{
+		Kind: cache.OpDelete,
+		ID:   id,
+	})
 }
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+// DownloadTarget is the local file a download is written to, *os.File satisfies it
+type DownloadTarget interface {
+	io.ReadWriteSeeker
+	Truncate(size int64) error
+}
+
+// DownloadFile streams a file from the vault into dst, decrypting it chunk by chunk and verifying
+// its digest. A non-zero offset resumes a partial download: dst is cut back to the chunk boundary
+// the server continues from and its existing content is included in the digest check.
+func (v *Vault) DownloadFile(ctx context.Context, jwt, id string, dst DownloadTarget, offset int64, progress func(done, total int64)) (models.FileMeta, error) {
+	if len(v.key) == 0 {
+		return models.FileMeta{}, ErrorNoKey
+	}
+
+	info, next, err := v.grpcclient.DownloadFile(ctx, jwt, id, offset)
+	if err != nil {
+		return models.FileMeta{}, err
+	}
+
+	meta, err := v.fileMeta(info)
+	if err != nil {
+		return meta, err
+	}
+
+	if err := dst.Truncate(info.Offset); err != nil {
+		return meta, err
+	}
+	if _, err := dst.Seek(0, io.SeekStart); err != nil {
+		return meta, err
+	}
+
+	hash := sha256.New()
+	if _, err := io.CopyN(hash, dst, info.Offset); err != nil {
+		return meta, err
+	}
+
+	seq := info.Offset / info.ChunkSize
+	done := info.Offset
+	for {
+		chunk, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return meta, err
+		}
+
+		plain, err := encryption.DecryptChunk(v.key, seq, chunk)
+		if err != nil {
+			return meta, err
+		}
+		seq++
+
+		if _, err := dst.Write(plain); err != nil {
+			return meta, err
+		}
+		hash.Write(plain)
+
+		done += int64(len(plain))
+		if progress != nil {
+			progress(done, meta.Size)
+		}
+	}
+
+	if done != meta.Size || hex.EncodeToString(hash.Sum(nil)) != meta.SHA256 {
+		return meta, ErrorDigestMismatch
+	}
+
+	return meta, nil
+}
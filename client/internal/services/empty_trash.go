@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+)
+
+// EmptyTrash permanently removes the trashed data entries and returns how many were removed
+func (v *Vault) EmptyTrash(ctx context.Context, jwt string) (int64, error) {
+	return v.grpcclient.EmptyTrash(ctx, jwt)
+}
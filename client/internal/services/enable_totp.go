@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+)
+
+// EnableTOTP starts the two-factor authentication setup and returns the secret for the authenticator app
+func (v *Vault) EnableTOTP(ctx context.Context, jwt string) (models.TOTPSetup, error) {
+	return v.grpcclient.EnableTOTP(ctx, jwt)
+}
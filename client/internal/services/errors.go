@@ -8,4 +8,15 @@ import (
 var (
 	ErrorNotFound = errors.New("error finding data")
 	ErrorNoDB     = errors.New("error connecting DB")
+	ErrorNoKey    = errors.New("encryption key not available, please login again")
+	ErrorNoSalt   = errors.New("server returned no key derivation salt for this account")
+
+	ErrorShareFile = errors.New("streamed files can't be shared, only their description would be readable")
+	ErrorReadOnly  = errors.New("entry was shared read-only")
+
+	ErrorOrgSelected = errors.New("an organization vault is already selected")
+
+	ErrorIncompleteUpload = errors.New("file upload was interrupted before all chunks were stored")
+	ErrorFileChanged      = errors.New("local file differs from the one being uploaded")
+	ErrorDigestMismatch   = errors.New("downloaded file doesn't match its digest")
 )
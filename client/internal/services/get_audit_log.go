@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+	"slices"
+)
+
+// auditPageSize is the number of audit entries fetched per request, the server's maximum
+const auditPageSize = 500
+
+// GetAuditLog retrieves the latest limit entries of the user's audit log, or the whole log if
+// limit is 0. The entries are returned oldest first, ready for chain verification.
+func (v *Vault) GetAuditLog(ctx context.Context, jwt string, limit int) ([]models.AuditEntry, error) {
+	var res []models.AuditEntry
+	var before int64
+
+	for limit == 0 || len(res) < limit {
+		size := auditPageSize
+		if limit > 0 {
+			size = min(size, limit-len(res))
+		}
+
+		page, err := v.grpcclient.GetAuditLog(ctx, jwt, before, int32(size))
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, page...)
+		if len(page) < size || page[len(page)-1].Seq <= 1 {
+			break
+		}
+		before = page[len(page)-1].Seq
+	}
+
+	slices.Reverse(res)
+	return res, nil
+}
@@ -2,21 +2,154 @@ package services
 
 import (
 	"context"
+	"data-vault/client/internal/encryption"
 	"data-vault/client/internal/models"
+	"time"
 )
 
-// GetData retrieves all user data from the vault
-func (v *Vault) GetData(ctx context.Context, jwt string) ([]models.Data, error) {
+// GetData retrieves and decrypts the user data matching the metadata filter, from the local cache while offline
+func (v *Vault) GetData(ctx context.Context, jwt string, filter map[string]string) ([]models.Data, error) {
 	var res []models.Data
 
-	data, err := v.grpcclient.GetData(ctx, jwt)
+	if len(v.key) == 0 {
+		return nil, ErrorNoKey
+	}
+
+	query := models.DataQuery{Metadata: filter}
+
+	if _, err := v.Flush(ctx, jwt); err != nil {
+		return nil, err
+	}
+	if v.offline {
+		return filterData(v.cache.Records(), query), nil
+	}
+
+	sealedFilter, err := encryption.MetadataFilter(v.key, filter)
 	if err != nil {
-		return res, nil
+		return nil, err
+	}
+
+	data, err := v.grpcclient.GetData(ctx, jwt, sealedFilter)
+	if err != nil {
+		if v.queueOffline(err) {
+			return filterData(v.cache.Records(), query), nil
+		}
+		return nil, err
 	}
 
 	for _, d := range data {
+		if err := v.decryptData(&d); err != nil {
+			return nil, err
+		}
 		res = append(res, d)
 	}
 
+	// A filtered result is only part of the vault and must not replace the cached copy
+	if v.cache != nil && len(filter) == 0 {
+		if err := v.cache.Replace(res); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// GetDataPage retrieves a single page of user data matching the query and decrypts it.
+// The page is merged into the local cache; while the server is unreachable every cached
+// record matching the query is returned as one page.
+func (v *Vault) GetDataPage(ctx context.Context, jwt string, query models.DataQuery) (models.DataPage, error) {
+	var res models.DataPage
+
+	if len(v.key) == 0 {
+		return res, ErrorNoKey
+	}
+
+	if _, err := v.Flush(ctx, jwt); err != nil {
+		return res, err
+	}
+	if v.offline {
+		res.Data = filterData(v.cache.Records(), query)
+		return res, nil
+	}
+
+	sealedFilter, err := encryption.MetadataFilter(v.key, query.Metadata)
+	if err != nil {
+		return res, err
+	}
+	sealedQuery := query
+	sealedQuery.Metadata = sealedFilter
+
+	page, err := v.grpcclient.GetDataPage(ctx, jwt, sealedQuery)
+	if err != nil {
+		if v.queueOffline(err) {
+			res.Data = filterData(v.cache.Records(), query)
+			return res, nil
+		}
+		return res, err
+	}
+
+	for _, d := range page.Data {
+		if err := v.decryptData(&d); err != nil {
+			return res, err
+		}
+		res.Data = append(res.Data, d)
+	}
+	res.NextPageToken = page.NextPageToken
+
+	if v.cache == nil {
+		return res, nil
+	}
+
+	// A single unfiltered page holding every record is the whole vault
+	whole := query.Type == "" && query.UploadedFrom == "" && query.UploadedTo == "" &&
+		len(query.Metadata) == 0 && query.PageToken == "" && res.NextPageToken == ""
+	if whole {
+		err = v.cache.Replace(res.Data)
+	} else {
+		err = v.cache.Merge(res.Data)
+	}
+	if err != nil {
+		return res, err
+	}
+
 	return res, nil
 }
+
+// filterData returns the entries matching the filters of a query. Metadata pairs with
+// an empty value match any value of the key.
+func filterData(data []models.Data, query models.DataQuery) []models.Data {
+	res := make([]models.Data, 0, len(data))
+	for _, d := range data {
+		if matchQuery(d, query) {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// matchQuery reports whether an entry passes the type, upload date and metadata filters of a query
+func matchQuery(d models.Data, query models.DataQuery) bool {
+	if query.Type != "" && d.Type != query.Type {
+		return false
+	}
+
+	if query.UploadedFrom != "" || query.UploadedTo != "" {
+		uploaded, err := time.Parse(time.RFC3339, d.UploadedAt)
+		if err != nil {
+			return false
+		}
+		if from, err := time.Parse(time.RFC3339, query.UploadedFrom); err == nil && uploaded.Before(from) {
+			return false
+		}
+		if to, err := time.Parse(time.RFC3339, query.UploadedTo); err == nil && !uploaded.Before(to) {
+			return false
+		}
+	}
+
+	for k, v := range query.Metadata {
+		if value, ok := d.Metadata[k]; !ok || (v != "" && value != v) {
+			return false
+		}
+	}
+	return true
+}
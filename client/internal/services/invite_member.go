@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+)
+
+// InviteMember adds a user to an organization with a role, or changes the role of a member.
+// The organization key is wrapped for the invitee's public key. It returns the fingerprint of
+// the invitee's key to compare out of band.
+func (v *Vault) InviteMember(ctx context.Context, jwt, org, login, role string) (string, error) {
+	orgKey, err := v.orgKey(ctx, jwt, org)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, err := v.grpcclient.GetPublicKey(ctx, jwt, login)
+	if err != nil {
+		return "", err
+	}
+
+	wrapped, err := encryption.WrapKey(publicKey, orgKey)
+	if err != nil {
+		return "", err
+	}
+
+	if err := v.grpcclient.InviteMember(ctx, jwt, org, login, role, wrapped); err != nil {
+		return "", err
+	}
+
+	return encryption.Fingerprint(publicKey), nil
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+)
+
+// ListMembers retrieves the members of an organization the user belongs to
+func (v *Vault) ListMembers(ctx context.Context, jwt, org string) ([]models.Member, error) {
+	return v.grpcclient.ListMembers(ctx, jwt, org)
+}
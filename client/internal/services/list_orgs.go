@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+)
+
+// ListOrgs retrieves the organizations the user belongs to with the user's role in each
+func (v *Vault) ListOrgs(ctx context.Context, jwt string) ([]models.Member, error) {
+	return v.grpcclient.ListOrgs(ctx, jwt)
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+)
+
+// ListSessions retrieves the devices the user is logged in on
+func (v *Vault) ListSessions(ctx context.Context, jwt string) ([]models.DeviceSession, error) {
+	return v.grpcclient.ListSessions(ctx, jwt)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+)
+
+// ListSharedWithMe retrieves the entries other users shared with the user and decrypts them with
+// their record keys, unwrapped with the user's sharing key. Entries that can't be opened, e.g.
+// because the owner restored a version from before sharing, are skipped with a warning.
+func (v *Vault) ListSharedWithMe(ctx context.Context, jwt string) ([]models.SharedData, error) {
+	if len(v.key) == 0 {
+		return nil, ErrorNoKey
+	}
+
+	private, err := encryption.SharingKey(v.key)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := v.grpcclient.ListSharedWithMe(ctx, jwt)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.SharedData, 0, len(shared))
+	for _, s := range shared {
+		recordKey, err := encryption.UnwrapKey(private, s.WrappedKey)
+		if err != nil {
+			v.Log.Warn("can't unwrap shared record key", "id", s.Data.ID, "owner", s.Owner, "error", err)
+			continue
+		}
+
+		plaintext, err := encryption.OpenRecord(recordKey, s.Data.Data)
+		if err != nil {
+			v.Log.Warn("can't decrypt shared entry", "id", s.Data.ID, "owner", s.Owner, "error", err)
+			continue
+		}
+
+		s.Data.Data = plaintext
+		// Metadata is sealed with the owner's vault key, only pairs stored before that are readable
+		s.Data.Metadata = encryption.OpenMetadata(v.key, s.Data.Metadata)
+		s.Key = recordKey
+		res = append(res, s)
+	}
+
+	return res, nil
+}
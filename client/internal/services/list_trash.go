@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+)
+
+// ListTrash retrieves the trashed data entries, most recently deleted first, and decrypts them
+// with the client-side key. The trash is kept on the server only, so it needs a connection.
+func (v *Vault) ListTrash(ctx context.Context, jwt string) ([]models.Data, error) {
+	if len(v.key) == 0 {
+		return nil, ErrorNoKey
+	}
+
+	data, err := v.grpcclient.ListTrash(ctx, jwt)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range data {
+		if err := v.decryptData(&data[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+)
+
+// ListVersions retrieves the prior versions of a data entry, newest first, and decrypts them
+// with the client-side key. The history is kept on the server only, so it needs a connection.
+func (v *Vault) ListVersions(ctx context.Context, jwt, id string) ([]models.Version, error) {
+	if len(v.key) == 0 {
+		return nil, ErrorNoKey
+	}
+
+	versions, err := v.grpcclient.ListVersions(ctx, jwt, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range versions {
+		plaintext, err := encryption.DecryptRecord(v.key, id, versions[i].Data)
+		if err != nil {
+			return nil, err
+		}
+		versions[i].Data = plaintext
+		versions[i].Metadata = encryption.OpenMetadata(v.key, versions[i].Metadata)
+	}
+
+	return versions, nil
+}
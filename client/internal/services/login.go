@@ -2,15 +2,48 @@ package services
 
 import (
 	"context"
+	"data-vault/client/internal/encryption"
 	"data-vault/client/internal/models"
 )
 
-// Login authenticates a user and returns a JWT token
-func (v *Vault) Login(ctx context.Context, user models.User) (string, error) {
-	jwt, err := v.grpcclient.Login(ctx, user)
+// Login fetches the account's key derivation salt, derives the vault key and the authentication
+// key from the master password and logs in with the authentication key. The master password and
+// the vault key never reach the server; an account that still logs in with the master password
+// is switched to the authentication key by this login. If the account requires a second factor,
+// the session only holds the challenge for VerifyTOTP.
+func (v *Vault) Login(ctx context.Context, user models.User) (models.Session, error) {
+	params, err := v.grpcclient.PreLogin(ctx, user.Login)
 	if err != nil {
-		return "", err
+		return models.Session{}, err
 	}
 
-	return jwt, nil
+	if len(params.Salt) == 0 {
+		return models.Session{}, ErrorNoSalt
+	}
+
+	key, authKey, err := encryption.DeriveKeys(user.Password, params.Salt)
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	if params.RawPassword {
+		user.AuthKey = encryption.AuthSecret(authKey)
+	} else {
+		user.Password = encryption.AuthSecret(authKey)
+	}
+
+	session, err := v.grpcclient.Login(ctx, user)
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	if session.TOTPRequired {
+		return session, nil
+	}
+
+	session.Salt = params.Salt
+	v.key = key
+	v.publishSharingKey(ctx, session.JWT)
+
+	return session, nil
 }
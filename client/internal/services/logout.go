@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+)
+
+// Logout revokes the current session on the server so its tokens stop working
+func (v *Vault) Logout(ctx context.Context, jwt string) error {
+	return v.grpcclient.Logout(ctx, jwt)
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/cache"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/grpcclient"
+	"data-vault/client/internal/models"
+	"errors"
+)
+
+// SetCache enables offline mode backed by the given local cache
+func (v *Vault) SetCache(c *cache.Cache) {
+	v.cache = c
+}
+
+// Offline reports whether the last server call failed because the server was unreachable
+func (v *Vault) Offline() bool {
+	return v.offline
+}
+
+// PendingCount returns the number of operations waiting to be replayed on the server
+func (v *Vault) PendingCount() int {
+	if v.cache == nil {
+		return 0
+	}
+	return len(v.cache.Pending())
+}
+
+// Flush replays the operations queued while offline in their original order and returns
+// how many were applied. It stops without error as soon as the server is unreachable again.
+// Operations the server refuses for good are moved out of the queue so that they don't block
+// the ones after them; TakeRejected returns them for the user.
+func (v *Vault) Flush(ctx context.Context, jwt string) (int, error) {
+	if v.cache == nil {
+		return 0, nil
+	}
+
+	replayed := 0
+	for _, op := range v.cache.Pending() {
+		var err error
+		switch op.Kind {
+		case cache.OpPost:
+			var cipherData []byte
+			cipherData, err = encryption.Encrypt(v.key, op.Data)
+			if err != nil {
+				return replayed, err
+			}
+			var sealedMeta map[string]string
+			sealedMeta, err = encryption.SealMetadata(v.key, op.Metadata)
+			if err != nil {
+				return replayed, err
+			}
+			err = v.grpcclient.PostData(ctx, jwt, op.Type, cipherData, sealedMeta)
+		case cache.OpDelete:
+			err = v.grpcclient.DeleteData(ctx, jwt, op.ID)
+		}
+
+		if v.queueOffline(err) {
+			return replayed, nil
+		}
+		if rejected(err) {
+			v.Log.Warn("Server rejected a queued operation", "kind", op.Kind, "id", op.ID, "queued_at", op.QueuedAt, "error", err)
+			if err := v.cache.Reject(err.Error()); err != nil {
+				return replayed, err
+			}
+			continue
+		}
+		if err != nil {
+			return replayed, err
+		}
+
+		if err := v.cache.Done(); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+
+	v.offline = false
+	return replayed, nil
+}
+
+// TakeRejected returns the queued operations the server refused for good since the last call
+func (v *Vault) TakeRejected() ([]models.RejectedOp, error) {
+	if v.cache == nil {
+		return nil, nil
+	}
+	return v.cache.TakeRejected()
+}
+
+// rejected reports whether err means the server refused an operation for good, so that
+// replaying it again can't succeed
+func rejected(err error) bool {
+	return errors.Is(err, grpcclient.ErrorRejected) || errors.Is(err, grpcclient.ErrorForbidden)
+}
+
+// queueOffline reports whether err means the server is unreachable and the cache can take over
+func (v *Vault) queueOffline(err error) bool {
+	if v.cache == nil || !errors.Is(err, grpcclient.ErrorUnavailable) {
+		return false
+	}
+
+	v.offline = true
+	return true
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"data-vault/client/internal/cache"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/grpcclient"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// replayServer answers the replayed operations with the error configured for their type or ID.
+// The calls the tests don't make are left to the nil embedded client.
+type replayServer struct {
+	proto.VaultServiceClient
+	errs    map[string]error
+	posted  []string
+	deleted []string
+}
+
+// PostData records the type of an accepted post
+func (s *replayServer) PostData(ctx context.Context, in *proto.PostDataRequest, opts ...grpc.CallOption) (*proto.PostDataResponse, error) {
+	if err := s.errs[in.Type]; err != nil {
+		return nil, err
+	}
+	s.posted = append(s.posted, in.Type)
+	return &proto.PostDataResponse{Success: true}, nil
+}
+
+// DeleteData records the ID of an accepted delete
+func (s *replayServer) DeleteData(ctx context.Context, in *proto.DeleteDataRequest, opts ...grpc.CallOption) (*proto.DeleteDataResponse, error) {
+	if err := s.errs[in.Id]; err != nil {
+		return nil, err
+	}
+	s.deleted = append(s.deleted, in.Id)
+	return &proto.DeleteDataResponse{Success: true}, nil
+}
+
+// newOfflineVault creates a vault with a cache holding the queued operations, talking to server
+func newOfflineVault(t *testing.T, server *replayServer, queue []models.PendingOp) *Vault {
+	t.Helper()
+
+	salt, err := encryption.NewSalt()
+	require.NoError(t, err)
+	key, _, err := encryption.DeriveKeys("master-password", salt)
+	require.NoError(t, err)
+
+	c, err := cache.Open(filepath.Join(t.TempDir(), "cache.bin"), "alice", key)
+	require.NoError(t, err)
+	for _, op := range queue {
+		require.NoError(t, c.Enqueue(op))
+	}
+
+	v := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), &grpcclient.Client{ClientConn: server})
+	v.SetKey(key)
+	v.SetCache(c)
+	return v
+}
+
+func TestFlush(t *testing.T) {
+	queue := []models.PendingOp{
+		{Kind: cache.OpPost, Type: "text", Data: []byte("first")},
+		{Kind: cache.OpDelete, ID: "gone"},
+		{Kind: cache.OpPost, Type: "card", Data: []byte("second")},
+		{Kind: cache.OpPost, Type: "password", Data: []byte("third")},
+	}
+
+	tests := []struct {
+		name           string
+		errs           map[string]error
+		expectReplayed int
+		expectErr      bool
+		expectPosted   []string
+		expectDeleted  []string
+		expectPending  int
+		expectRejected []string
+		expectOffline  bool
+	}{
+		{
+			name:           "all applied",
+			expectReplayed: 4,
+			expectPosted:   []string{"text", "card", "password"},
+			expectDeleted:  []string{"gone"},
+		},
+		{
+			name: "rejected in the middle",
+			errs: map[string]error{
+				"gone": status.Error(codes.NotFound, "Data not found"),
+				"card": status.Error(codes.InvalidArgument, "Metadata is malformed"),
+			},
+			expectReplayed: 2,
+			expectPosted:   []string{"text", "password"},
+			expectRejected: []string{"Data not found", "Metadata is malformed"},
+		},
+		{
+			name:           "forbidden by the organization role",
+			errs:           map[string]error{"card": status.Error(codes.PermissionDenied, "Role can't write")},
+			expectReplayed: 3,
+			expectPosted:   []string{"text", "password"},
+			expectDeleted:  []string{"gone"},
+			expectRejected: []string{grpcclient.ErrorForbidden.Error()},
+		},
+		{
+			name:           "server unreachable again",
+			errs:           map[string]error{"gone": status.Error(codes.Unavailable, "connection refused")},
+			expectReplayed: 1,
+			expectPosted:   []string{"text"},
+			expectPending:  3,
+			expectOffline:  true,
+		},
+		{
+			name:           "server failure is kept for a retry",
+			errs:           map[string]error{"card": status.Error(codes.Internal, "Failed to post data")},
+			expectReplayed: 2,
+			expectErr:      true,
+			expectPosted:   []string{"text"},
+			expectDeleted:  []string{"gone"},
+			expectPending:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &replayServer{errs: tt.errs}
+			v := newOfflineVault(t, server, queue)
+
+			replayed, err := v.Flush(context.Background(), "jwt")
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectReplayed, replayed)
+			assert.Equal(t, tt.expectPosted, server.posted)
+			assert.Equal(t, tt.expectDeleted, server.deleted)
+			assert.Equal(t, tt.expectPending, v.PendingCount())
+			assert.Equal(t, tt.expectOffline, v.Offline())
+
+			rejected, err := v.TakeRejected()
+			require.NoError(t, err)
+			require.Len(t, rejected, len(tt.expectRejected))
+			for i, reason := range tt.expectRejected {
+				assert.Contains(t, rejected[i].Reason, reason)
+			}
+		})
+	}
+}
@@ -1,8 +1,16 @@
 package services
 
-import "context"
+import (
+	"context"
+	"data-vault/client/internal/models"
+)
 
 // PingServer checks if the server connection is alive
 func (v *Vault) PingServer(ctx context.Context) bool {
 	return v.grpcclient.PingServer(ctx)
 }
+
+// Ping checks the server and its database and reports the server version and latencies
+func (v *Vault) Ping(ctx context.Context) (models.ServerStatus, error) {
+	return v.grpcclient.Ping(ctx)
+}
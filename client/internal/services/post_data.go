@@ -2,9 +2,58 @@ package services
 
 import (
 	"context"
+	"data-vault/client/internal/cache"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
 )
 
-// PostData stores encrypted data in the vault
-func (v *Vault) PostData(ctx context.Context, jwt, dataType string, data []byte) error {
-	return v.grpcclient.PostData(ctx, jwt, dataType, data)
+// PostRecord validates a typed record and stores it in the vault under its data type
+func (v *Vault) PostRecord(ctx context.Context, jwt string, rec models.Record, meta map[string]string) error {
+	if err := records.Validate(rec); err != nil {
+		return err
+	}
+
+	data, err := records.Encode(rec)
+	if err != nil {
+		return err
+	}
+
+	return v.PostData(ctx, jwt, records.TypeOf(rec), data, meta)
+}
+
+// PostData encrypts data and its metadata with the client-side key and stores them in the vault.
+// While the server is unreachable the post is queued in the local cache instead.
+func (v *Vault) PostData(ctx context.Context, jwt, dataType string, data []byte, meta map[string]string) error {
+	if len(v.key) == 0 {
+		return ErrorNoKey
+	}
+
+	if _, err := v.Flush(ctx, jwt); err != nil {
+		return err
+	}
+
+	if !v.offline {
+		cipherData, err := encryption.Encrypt(v.key, data)
+		if err != nil {
+			return err
+		}
+
+		sealedMeta, err := encryption.SealMetadata(v.key, meta)
+		if err != nil {
+			return err
+		}
+
+		err = v.grpcclient.PostData(ctx, jwt, dataType, cipherData, sealedMeta)
+		if !v.queueOffline(err) {
+			return err
+		}
+	}
+
+	return v.cache.Enqueue(models.PendingOp{
+		Kind:     cache.OpPost,
+		Type:     dataType,
+		Data:     data,
+		Metadata: meta,
+	})
 }
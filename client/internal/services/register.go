@@ -2,15 +2,32 @@ package services
 
 import (
 	"context"
+	"data-vault/client/internal/encryption"
 	"data-vault/client/internal/models"
 )
 
-// Register creates a new user account and returns a JWT token
-func (v *Vault) Register(ctx context.Context, user models.User) (string, error) {
-	jwt, err := v.grpcclient.Register(ctx, user)
+// Register creates a new user account, derives the vault key and the authentication key from the
+// master password and returns the session tokens. Only the authentication key is sent to the server.
+func (v *Vault) Register(ctx context.Context, user models.User) (models.Session, error) {
+	salt, err := encryption.NewSalt()
 	if err != nil {
-		return "", err
+		return models.Session{}, err
 	}
+	user.Salt = salt
 
-	return jwt, nil
+	key, authKey, err := encryption.DeriveKeys(user.Password, salt)
+	if err != nil {
+		return models.Session{}, err
+	}
+	user.Password = encryption.AuthSecret(authKey)
+
+	session, err := v.grpcclient.Register(ctx, user)
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	v.key = key
+	v.publishSharingKey(ctx, session.JWT)
+
+	return session, nil
 }
@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+)
+
+// RestoreFromTrash moves a trashed data entry back to the vault and returns its version
+func (v *Vault) RestoreFromTrash(ctx context.Context, jwt, id string) (int64, error) {
+	return v.grpcclient.RestoreFromTrash(ctx, jwt, id)
+}
@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+)
+
+// RestoreVersion makes a prior version the current state of a data entry and returns the entry's new version
+func (v *Vault) RestoreVersion(ctx context.Context, jwt, id string, version int64) (int64, error) {
+	return v.grpcclient.RestoreVersion(ctx, jwt, id, version)
+}
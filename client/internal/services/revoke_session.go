@@ -0,0 +1,10 @@
+package services
+
+import (
+	"context"
+)
+
+// RevokeSession logs the user out on another device
+func (v *Vault) RevokeSession(ctx context.Context, jwt, id string) error {
+	return v.grpcclient.RevokeSession(ctx, jwt, id)
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"context"
+)
+
+// RevokeShare removes the access of another user to an entry. The user keeps what they already
+// read, so secrets they saw should be changed as well.
+func (v *Vault) RevokeShare(ctx context.Context, jwt, id, login string) error {
+	return v.grpcclient.RevokeShare(ctx, jwt, id, login)
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/grpcclient"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+)
+
+// ShareData grants another user read or write access to a single entry. The entry's record key
+// is wrapped for the recipient's public key, so the server never learns it and the recipient
+// can't read any other entry. Entries still sealed with the vault key are re-sealed with their
+// record key first. It returns the fingerprint of the recipient's key to compare out of band.
+func (v *Vault) ShareData(ctx context.Context, jwt, id, login, permission string) (string, error) {
+	if len(v.key) == 0 {
+		return "", ErrorNoKey
+	}
+
+	publicKey, err := v.grpcclient.GetPublicKey(ctx, jwt, login)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := v.grpcclient.GetData(ctx, jwt, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var entry *models.Data
+	for i := range data {
+		if data[i].ID == id {
+			entry = &data[i]
+			break
+		}
+	}
+	if entry == nil {
+		return "", grpcclient.ErrorNotFound
+	}
+
+	recordKey, err := encryption.RecordKey(v.key, id)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := encryption.OpenRecord(recordKey, entry.Data); err != nil {
+		plaintext, err := encryption.Decrypt(v.key, entry.Data)
+		if err != nil {
+			return "", err
+		}
+
+		if rec := records.Decode(plaintext); rec.Binary != nil && rec.Binary.Content == nil && rec.Binary.Size > 0 {
+			return "", ErrorShareFile
+		}
+
+		if _, err := v.UpdateData(ctx, jwt, id, entry.Type, plaintext, entry.Version); err != nil {
+			return "", err
+		}
+	}
+
+	wrapped, err := encryption.WrapKey(publicKey, recordKey)
+	if err != nil {
+		return "", err
+	}
+
+	if err := v.grpcclient.ShareData(ctx, jwt, id, login, permission, wrapped); err != nil {
+		return "", err
+	}
+
+	return encryption.Fingerprint(publicKey), nil
+}
+
+// SharingFingerprint returns the fingerprint of the user's own sharing key, which users sharing
+// with them see when they share
+func (v *Vault) SharingFingerprint() (string, error) {
+	private, err := encryption.SharingKey(v.key)
+	if err != nil {
+		return "", err
+	}
+	return encryption.Fingerprint(private.PublicKey().Bytes()), nil
+}
+
+// publishSharingKey publishes the public half of the sharing key derived from the vault key,
+// so that other users can share entries with this one. Sharing works without it for the user's
+// own entries, so failures are only logged.
+func (v *Vault) publishSharingKey(ctx context.Context, jwt string) {
+	private, err := encryption.SharingKey(v.key)
+	if err != nil {
+		v.Log.Warn("can't derive sharing key", "error", err)
+		return
+	}
+
+	if err := v.grpcclient.SetPublicKey(ctx, jwt, private.PublicKey().Bytes()); err != nil {
+		v.Log.Warn("can't publish sharing key", "error", err)
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+)
+
+// Sync encrypts and pushes local changes, then returns the decrypted server changes since the cursor
+func (v *Vault) Sync(ctx context.Context, jwt string, cursor int64, changes []models.SyncChange) (models.SyncResult, error) {
+	var res models.SyncResult
+
+	if len(v.key) == 0 {
+		return res, ErrorNoKey
+	}
+
+	pushed := make([]models.SyncChange, 0, len(changes))
+	for _, c := range changes {
+		if !c.Deleted {
+			cipherData, err := v.encryptData(c.ID, c.Data)
+			if err != nil {
+				return res, err
+			}
+			c.Data = cipherData
+		}
+		pushed = append(pushed, c)
+	}
+
+	res, err := v.grpcclient.Sync(ctx, jwt, cursor, pushed)
+	if err != nil {
+		return res, err
+	}
+
+	for i := range res.Created {
+		if err := v.decryptData(&res.Created[i]); err != nil {
+			return res, err
+		}
+	}
+	for i := range res.Updated {
+		if err := v.decryptData(&res.Updated[i]); err != nil {
+			return res, err
+		}
+	}
+	for _, c := range res.Conflicts {
+		if c.Current != nil {
+			if err := v.decryptData(c.Current); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// encryptData seals the payload of an entry. Existing entries are sealed with their record key,
+// so that users the entry is shared with can keep reading it; new entries get no ID until the
+// server stores them and are sealed with the vault key.
+func (v *Vault) encryptData(id string, data []byte) ([]byte, error) {
+	if id == "" {
+		return encryption.Encrypt(v.key, data)
+	}
+
+	recordKey, err := encryption.RecordKey(v.key, id)
+	if err != nil {
+		return nil, err
+	}
+	return encryption.EncryptRecord(recordKey, data)
+}
+
+// decryptData replaces an entry's payload and metadata with their plaintext
+func (v *Vault) decryptData(d *models.Data) error {
+	plaintext, err := encryption.DecryptRecord(v.key, d.ID, d.Data)
+	if err != nil {
+		return err
+	}
+	d.Data = plaintext
+	d.Metadata = encryption.OpenMetadata(v.key, d.Metadata)
+	return nil
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+)
+
+// UpdateRecord validates a typed record and replaces a data entry with it, returning the entry's new version
+func (v *Vault) UpdateRecord(ctx context.Context, jwt, id string, rec models.Record, version int64) (int64, error) {
+	if err := records.Validate(rec); err != nil {
+		return 0, err
+	}
+
+	data, err := records.Encode(rec)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.UpdateData(ctx, jwt, id, records.TypeOf(rec), data, version)
+}
+
+// UpdateData encrypts the new payload with the entry's record key and replaces the entry, returning its new version
+func (v *Vault) UpdateData(ctx context.Context, jwt, id, dataType string, data []byte, version int64) (int64, error) {
+	if len(v.key) == 0 {
+		return 0, ErrorNoKey
+	}
+
+	cipherData, err := v.encryptData(id, data)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.grpcclient.UpdateData(ctx, jwt, id, dataType, cipherData, version)
+}
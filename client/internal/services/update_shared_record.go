@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+)
+
+// UpdateSharedRecord replaces an entry shared with write permission, sealing it with the entry's
+// record key so that its owner and everyone else it is shared with can keep reading it
+func (v *Vault) UpdateSharedRecord(ctx context.Context, jwt string, shared models.SharedData, rec models.Record) (int64, error) {
+	if shared.Permission != models.PermissionWrite {
+		return 0, ErrorReadOnly
+	}
+
+	if len(shared.Key) == 0 {
+		return 0, ErrorNoKey
+	}
+
+	if err := records.Validate(rec); err != nil {
+		return 0, err
+	}
+
+	data, err := records.Encode(rec)
+	if err != nil {
+		return 0, err
+	}
+
+	cipherData, err := encryption.EncryptRecord(shared.Key, data)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.grpcclient.UpdateData(ctx, jwt, shared.Data.ID, records.TypeOf(rec), cipherData, shared.Data.Version)
+}
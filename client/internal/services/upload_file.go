@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+	"data-vault/client/internal/records"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// FileChunkSize is the plaintext size of the chunks files are uploaded in
+const FileChunkSize = 64 * 1024
+
+// UploadFile encrypts a file chunk by chunk and streams it to the vault. An empty resumeID starts
+// a new upload, otherwise the upload with that ID continues where the server stopped receiving.
+// The returned info carries the file ID even on failure, so the upload can be resumed later.
+func (v *Vault) UploadFile(ctx context.Context, jwt, name string, r io.ReadSeeker, size int64, resumeID string, progress func(done, total int64)) (models.FileInfo, error) {
+	if len(v.key) == 0 {
+		return models.FileInfo{}, ErrorNoKey
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return models.FileInfo{}, err
+	}
+	digest := hex.EncodeToString(hash.Sum(nil))
+
+	var info models.FileInfo
+	var err error
+	if resumeID == "" {
+		info, err = v.createFile(ctx, jwt, models.FileMeta{Filename: name, Size: size, SHA256: digest})
+	} else {
+		info, err = v.resumeFile(ctx, jwt, resumeID, size, digest)
+	}
+	if err != nil || info.Complete {
+		return info, err
+	}
+
+	if _, err := r.Seek(info.Offset, io.SeekStart); err != nil {
+		return info, err
+	}
+
+	seq := info.Offset / info.ChunkSize
+	done := info.Offset
+	buf := make([]byte, info.ChunkSize)
+	next := func() ([]byte, error) {
+		n, err := io.ReadFull(r, buf)
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+
+		chunk, err := encryption.EncryptChunk(v.key, seq, buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		seq++
+
+		done += int64(n)
+		if progress != nil {
+			progress(done, size)
+		}
+		return chunk, nil
+	}
+
+	res, err := v.grpcclient.UploadFile(ctx, jwt, models.FileInfo{ID: info.ID, Offset: info.Offset}, next)
+	if err != nil {
+		return info, err
+	}
+	if !res.Complete {
+		return res, ErrorIncompleteUpload
+	}
+
+	return res, nil
+}
+
+// createFile registers a new file on the server with its description encrypted as a binary record
+func (v *Vault) createFile(ctx context.Context, jwt string, meta models.FileMeta) (models.FileInfo, error) {
+	plainMeta, err := records.Encode(models.Record{Binary: &models.BinaryData{
+		Filename: meta.Filename,
+		Size:     meta.Size,
+		SHA256:   meta.SHA256,
+	}})
+	if err != nil {
+		return models.FileInfo{}, err
+	}
+
+	cipherMeta, err := encryption.Encrypt(v.key, plainMeta)
+	if err != nil {
+		return models.FileInfo{}, err
+	}
+
+	return v.grpcclient.UploadFile(ctx, jwt, models.FileInfo{
+		Meta:      cipherMeta,
+		Size:      meta.Size,
+		ChunkSize: FileChunkSize,
+	}, nil)
+}
+
+// resumeFile fetches the upload state of a file and checks that the local file is the one being uploaded
+func (v *Vault) resumeFile(ctx context.Context, jwt, id string, size int64, digest string) (models.FileInfo, error) {
+	info, err := v.grpcclient.UploadFile(ctx, jwt, models.FileInfo{ID: id}, nil)
+	if err != nil {
+		return info, err
+	}
+
+	meta, err := v.fileMeta(info)
+	if err != nil {
+		return info, err
+	}
+
+	if meta.Size != size || meta.SHA256 != digest {
+		return info, ErrorFileChanged
+	}
+
+	return info, nil
+}
+
+// fileMeta decrypts the description of a file
+func (v *Vault) fileMeta(info models.FileInfo) (models.FileMeta, error) {
+	var meta models.FileMeta
+
+	plainMeta, err := encryption.DecryptRecord(v.key, info.ID, info.Meta)
+	if err != nil {
+		return meta, err
+	}
+
+	rec := records.Decode(plainMeta)
+	if rec.Binary == nil {
+		// Files uploaded before typed records stored their description as JSON
+		err = json.Unmarshal(plainMeta, &meta)
+		return meta, err
+	}
+
+	return models.FileMeta{
+		Filename: rec.Binary.Filename,
+		Size:     rec.Binary.Size,
+		SHA256:   rec.Binary.SHA256,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/grpcclient"
+)
+
+// UseOrg switches the service to an organization vault: data calls carry the organization and
+// records are sealed with keys derived from the organization key instead of the vault key.
+// The local cache only holds the user's own vault, so organization vaults have no offline mode.
+func (v *Vault) UseOrg(ctx context.Context, jwt, org string) error {
+	orgKey, err := v.orgKey(ctx, jwt, org)
+	if err != nil {
+		return err
+	}
+
+	v.key = orgKey
+	v.org = org
+	v.cache = nil
+	v.grpcclient.SetOrg(org)
+	return nil
+}
+
+// orgKey unwraps the key of an organization the user belongs to with the user's sharing key
+func (v *Vault) orgKey(ctx context.Context, jwt, org string) ([]byte, error) {
+	if len(v.key) == 0 {
+		return nil, ErrorNoKey
+	}
+
+	if v.org != "" {
+		return nil, ErrorOrgSelected
+	}
+
+	private, err := encryption.SharingKey(v.key)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := v.grpcclient.ListOrgs(ctx, jwt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range memberships {
+		if m.Org == org {
+			return encryption.UnwrapKey(private, m.WrappedKey)
+		}
+	}
+	return nil, grpcclient.ErrorNoOrg
+}
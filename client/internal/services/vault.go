@@ -2,19 +2,52 @@ package services
 
 import (
 	"context"
+	"data-vault/client/internal/cache"
 	"data-vault/client/internal/grpcclient"
 	"data-vault/client/internal/models"
+	"io"
 	"log/slog"
 )
 
 // Service defines the interface for vault operations
 type Service interface {
-	Register(ctx context.Context, user models.User) (string, error)
-	Login(ctx context.Context, user models.User) (string, error)
-	PostData(ctx context.Context, jwt, dataType string, data []byte) error
-	GetData(ctx context.Context, jwt string) ([]models.Data, error)
+	Register(ctx context.Context, user models.User) (models.Session, error)
+	Login(ctx context.Context, user models.User) (models.Session, error)
+	VerifyTOTP(ctx context.Context, user models.User, challenge, code string) (models.Session, error)
+	EnableTOTP(ctx context.Context, jwt string) (models.TOTPSetup, error)
+	ConfirmTOTP(ctx context.Context, jwt, code string) ([]string, error)
+	Logout(ctx context.Context, jwt string) error
+	ListSessions(ctx context.Context, jwt string) ([]models.DeviceSession, error)
+	RevokeSession(ctx context.Context, jwt, id string) error
+	PostData(ctx context.Context, jwt, dataType string, data []byte, meta map[string]string) error
+	PostRecord(ctx context.Context, jwt string, rec models.Record, meta map[string]string) error
+	GetData(ctx context.Context, jwt string, filter map[string]string) ([]models.Data, error)
+	GetDataPage(ctx context.Context, jwt string, query models.DataQuery) (models.DataPage, error)
+	UpdateData(ctx context.Context, jwt, id, dataType string, data []byte, version int64) (int64, error)
+	UpdateRecord(ctx context.Context, jwt, id string, rec models.Record, version int64) (int64, error)
 	DeleteData(ctx context.Context, jwt, id string) error
+	ListVersions(ctx context.Context, jwt, id string) ([]models.Version, error)
+	RestoreVersion(ctx context.Context, jwt, id string, version int64) (int64, error)
+	ListTrash(ctx context.Context, jwt string) ([]models.Data, error)
+	RestoreFromTrash(ctx context.Context, jwt, id string) (int64, error)
+	EmptyTrash(ctx context.Context, jwt string) (int64, error)
+	ShareData(ctx context.Context, jwt, id, login, permission string) (string, error)
+	ListSharedWithMe(ctx context.Context, jwt string) ([]models.SharedData, error)
+	UpdateSharedRecord(ctx context.Context, jwt string, shared models.SharedData, rec models.Record) (int64, error)
+	RevokeShare(ctx context.Context, jwt, id, login string) error
+	SharingFingerprint() (string, error)
+	CreateOrg(ctx context.Context, jwt, name string) error
+	InviteMember(ctx context.Context, jwt, org, login, role string) (string, error)
+	ListMembers(ctx context.Context, jwt, org string) ([]models.Member, error)
+	ListOrgs(ctx context.Context, jwt string) ([]models.Member, error)
+	UseOrg(ctx context.Context, jwt, org string) error
+	GetAuditLog(ctx context.Context, jwt string, limit int) ([]models.AuditEntry, error)
+	Sync(ctx context.Context, jwt string, cursor int64, changes []models.SyncChange) (models.SyncResult, error)
+	UploadFile(ctx context.Context, jwt, name string, r io.ReadSeeker, size int64, resumeID string, progress func(done, total int64)) (models.FileInfo, error)
+	DownloadFile(ctx context.Context, jwt, id string, dst DownloadTarget, offset int64, progress func(done, total int64)) (models.FileMeta, error)
+	Flush(ctx context.Context, jwt string) (int, error)
 	PingServer(ctx context.Context) bool
+	Ping(ctx context.Context) (models.ServerStatus, error)
 }
 
 // Vault implements the Service interface and manages vault operations
@@ -22,6 +55,10 @@ type Vault struct {
 	ctx        context.Context
 	Log        *slog.Logger
 	grpcclient *grpcclient.Client
+	key        []byte
+	cache      *cache.Cache
+	offline    bool
+	org        string
 }
 
 // New creates and initializes a new Vault service instance
@@ -33,3 +70,18 @@ func New(ctx context.Context, log *slog.Logger, grpcclient *grpcclient.Client) *
 	}
 	return &service
 }
+
+// SetKey sets the client-side encryption key used for vault payloads
+func (v *Vault) SetKey(key []byte) {
+	v.key = key
+}
+
+// SetTokenStore lets the service renew expired access tokens with the stored refresh token
+func (v *Vault) SetTokenStore(store grpcclient.TokenStore) {
+	v.grpcclient.SetTokenStore(store)
+}
+
+// Key returns the client-side encryption key derived at login or registration
+func (v *Vault) Key() []byte {
+	return v.key
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"data-vault/client/internal/encryption"
+	"data-vault/client/internal/models"
+)
+
+// VerifyTOTP completes a login challenged for a second factor, derives the vault key from the
+// master password and returns the session tokens
+func (v *Vault) VerifyTOTP(ctx context.Context, user models.User, challenge, code string) (models.Session, error) {
+	session, err := v.grpcclient.VerifyTOTP(ctx, challenge, code)
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	if len(session.Salt) == 0 {
+		return models.Session{}, ErrorNoSalt
+	}
+
+	key, _, err := encryption.DeriveKeys(user.Password, session.Salt)
+	if err != nil {
+		return models.Session{}, err
+	}
+	v.key = key
+	v.publishSharingKey(ctx, session.JWT)
+
+	return session, nil
+}
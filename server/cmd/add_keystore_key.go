@@ -0,0 +1,25 @@
+package main
+
+import (
+	"log/slog"
+
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/service"
+)
+
+// addKeystoreKey runs the add-keystore-key admin command, which generates a new key-encryption
+// key in KEYSTORE_FILE and makes it the active one. The keystore is created if it doesn't exist.
+// Restart the server and run rotate-keys afterwards to re-wrap existing values.
+func addKeystoreKey(log *slog.Logger, cfg config.Config) error {
+	if cfg.KeystoreFile == "" {
+		return service.ErrNoKeyProvider
+	}
+
+	id, err := service.AddKeystoreKey(cfg.KeystoreFile, cfg.KeystorePassphrase)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Key-encryption key added to keystore", "file", cfg.KeystoreFile, "key", id)
+	return nil
+}
@@ -2,8 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
 	_ "net/http/pprof"
+	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 
 	"data-vault/server/internal/config"
@@ -14,17 +20,62 @@ import (
 	"data-vault/server/internal/transport"
 
 	"net"
+)
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+// Build-time variables for version information
+var (
+	buildVersion string = "1.0.0"
 )
 
+// errUnknownCommand is returned for arguments that don't name an admin command
+var errUnknownCommand = errors.New("unknown command, expected add-keystore-key, migrate or rotate-keys")
+
 // main is the entry point for the Data Vault server application
 func main() {
-	log := logger.New()
-
-	cfg, err := config.New()
+	cfg, args, err := config.New(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		log.Error("Error loading configuration: %v\n", err)
+		logger.New("info", config.LogText).Error("Error loading configuration", "error", err)
+		os.Exit(1)
+	}
+	cfg.Version = buildVersion
+
+	log := logger.New(cfg.LogLevel, cfg.LogFormat)
+	if err := run(log, cfg, args); err != nil {
+		log.Error("Server failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the server, or runs the admin command named by the arguments left after the
+// configuration flags, and returns when it is done. Failing to start returns an error, so that
+// the process exits with a non-zero status. On a signal the server stops in order: it reports
+// itself as not serving, stops accepting connections, drains the calls in flight for up to
+// SHUTDOWN_TIMEOUT, stops the background jobs and closes the database last.
+func run(log *slog.Logger, cfg config.Config, args []string) error {
+	command := ""
+	if len(args) > 0 {
+		command = args[0]
+	}
+	switch command {
+	case "":
+		// Only serving needs the TLS files, and rotate-keys must still read values sealed by a
+		// published legacy key to move them off it
+		if err := cfg.ValidateServing(); err != nil {
+			return fmt.Errorf("loading configuration: %w", err)
+		}
+	case "add-keystore-key", "migrate", "rotate-keys":
+	default:
+		return fmt.Errorf("%w: %s", errUnknownCommand, command)
+	}
+
+	if command == "add-keystore-key" {
+		if err := addKeystoreKey(log, cfg); err != nil {
+			return fmt.Errorf("adding keystore key: %w", err)
+		}
+		return nil
 	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
@@ -32,38 +83,106 @@ func main() {
 
 	store, err := storage.New(ctx, &cfg)
 	if err != nil {
-		log.Error("Error creating new storage: %v\n", err)
+		return fmt.Errorf("opening storage: %w", err)
 	}
 	defer store.DB.Close()
 
-	s := service.New(log, cfg, store)
+	if command == "migrate" {
+		if err := migrate(ctx, log, store, args[1:]); err != nil {
+			return fmt.Errorf("migrating database: %w", err)
+		}
+		return nil
+	}
+
+	s, err := service.New(log, cfg, store)
+	if err != nil {
+		return fmt.Errorf("loading encryption keys: %w", err)
+	}
+
+	if command == "rotate-keys" {
+		if err := store.Migrate(ctx); err != nil {
+			return fmt.Errorf("migrating database: %w", err)
+		}
+		if err := rotateKeys(ctx, log, s, args[1:]); err != nil {
+			return fmt.Errorf("rotating encryption keys: %w", err)
+		}
+		return nil
+	}
 
 	h := handler.New(ctx, s, cfg, log)
-	grpcErrCh := make(chan error, 1)
 
-	server := transport.New(h, cfg, log)
+	var limits transport.LimitStore = transport.NewMemoryLimitStore()
+	if cfg.RateLimitStore == config.RateLimitDatabase {
+		limits = store
+	}
+	limiter := transport.NewRateLimiter(limits, cfg, log)
+
+	server := transport.New(h, limiter, cfg, log)
 	g, err := transport.NewRouter(server)
 	if err != nil {
-		log.Error("Error creating gRPC server: %v\n", err)
+		return fmt.Errorf("creating gRPC server: %w", err)
+	}
+
+	lis, err := net.Listen("tcp", cfg.ServerAddr)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", cfg.ServerAddr, err)
 	}
 
+	grpcErrCh := make(chan error, 1)
 	go func() {
-		grpcAddr := cfg.ServerAddr
-		lis, err := net.Listen("tcp", grpcAddr)
-		if err != nil {
-			grpcErrCh <- err
-			return
-		}
-		log.Info("Starting gRPC server", "address", grpcAddr)
+		log.Info("Starting gRPC server", "address", cfg.ServerAddr)
 		if err := g.Serve(lis); err != nil {
 			grpcErrCh <- err
 		}
 	}()
 
+	// The background jobs stop with the server, before the deferred close of the database
+	jobsCtx, cancelJobs := context.WithCancel(ctx)
+	var jobs sync.WaitGroup
+	defer func() {
+		cancelJobs()
+		server.GracefulStop(g, cfg.ShutdownTimeout)
+		jobs.Wait()
+		log.Info("Server shut down")
+	}()
+
+	// The server answers health checks as not ready until the database is migrated
+	if err := store.Migrate(ctx); err != nil {
+		return fmt.Errorf("migrating database: %w", err)
+	}
+
+	if cfg.EncryptionKey != "" {
+		migrated, err := s.MigratePasswords(ctx)
+		if err != nil {
+			log.Error("Error migrating legacy passwords", "error", err)
+		} else if migrated > 0 {
+			log.Info("Migrated legacy passwords to Argon2id hashes", "count", migrated)
+		}
+	}
+
+	jobs.Add(3)
+	go func() {
+		defer jobs.Done()
+		s.RunPurger(jobsCtx)
+	}()
+	go func() {
+		defer jobs.Done()
+		limiter.Run(jobsCtx)
+	}()
+
+	server.SetReady(true)
+	go func() {
+		defer jobs.Done()
+		server.RunHealthChecks(jobsCtx, h)
+	}()
+
 	select {
 	case err := <-grpcErrCh:
-		log.Error("gRPC server error", "error", err)
+		return fmt.Errorf("serving gRPC: %w", err)
 	case <-ctx.Done():
-		log.Info("Servers shut down successfully")
+		// A second signal kills the server without waiting for the calls in flight
+		stop()
+		log.Info("Shutting down, draining calls in flight", "timeout", cfg.ShutdownTimeout.String())
+		return nil
 	}
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"data-vault/server/internal/storage"
+)
+
+// errMigrateUsage is returned when the migrate command is called without a known action
+var errMigrateUsage = errors.New("usage: migrate up [-steps N] | down [-steps N] | status")
+
+// migrate runs the migrate admin command. up applies the pending schema migrations, all of them
+// unless -steps is given, down reverts the latest -steps migrations, one by default, and status
+// lists the migrations with the time each was applied.
+func migrate(ctx context.Context, log *slog.Logger, store *storage.Storage, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	steps := flags.Int("steps", 0, "Number of migrations to apply or revert")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *steps < 0 {
+		return errMigrateUsage
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := store.MigrateUp(ctx, *steps)
+		for _, m := range applied {
+			log.Info("Migration applied", "version", m.Version, "name", m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		log.Info("Database schema is up to date", "applied", len(applied))
+
+	case "down":
+		if *steps == 0 {
+			*steps = 1
+		}
+		reverted, err := store.MigrateDown(ctx, *steps)
+		for _, m := range reverted {
+			log.Info("Migration reverted", "version", m.Version, "name", m.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+	case "status":
+		migrations, err := store.ListMigrations(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			state := "pending"
+			if m.AppliedAt != "" {
+				state = "applied " + m.AppliedAt
+			}
+			fmt.Printf("%04d %-24s %s\n", m.Version, m.Name, state)
+		}
+
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
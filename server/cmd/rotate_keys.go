@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"data-vault/server/internal/service"
+)
+
+// defaultRotateBatch is the number of rows re-encrypted per query by rotate-keys
+const defaultRotateBatch = 500
+
+// rotateKeys runs the rotate-keys admin command, which re-seals all stored values in envelopes
+// wrapped by the active key of KEY_PROVIDER. It can run while the server is serving with the same keys.
+func rotateKeys(ctx context.Context, log *slog.Logger, s *service.Vault, args []string) error {
+	flags := flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+	batch := flags.Uint64("batch-size", defaultRotateBatch, "Number of rows re-encrypted per batch")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	log.Info("Rotating encryption keys", "batch_size", *batch)
+
+	rotated, err := s.RotateKeys(ctx, *batch)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Encryption keys rotated", "values", rotated)
+	return nil
+}
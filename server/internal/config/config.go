@@ -1,43 +1,230 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	env "github.com/joho/godotenv"
 )
 
-// Config holds server configuration settings
+// Defaults for settings that aren't required to be set
+const (
+	// DefaultHistoryVersions is the number of prior versions kept per entry
+	DefaultHistoryVersions = 10
+	// DefaultTrashRetention is how long deleted entries stay in the trash
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	// DefaultTrashPurgeInterval is how often the trash is checked for expired entries
+	DefaultTrashPurgeInterval = time.Hour
+	// DefaultUploadTimeout is how long an upload may stay unfinished
+	DefaultUploadTimeout = 24 * time.Hour
+	// DefaultAccessTokenTTL is how long an access token is accepted
+	DefaultAccessTokenTTL = 15 * time.Minute
+	// DefaultRefreshTokenTTL is how long a session can go unused before it expires
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+	// DefaultRateLimitWindow is the window the authentication calls are counted in
+	DefaultRateLimitWindow = time.Minute
+	// DefaultRateLimitPerLogin is the number of authentication calls allowed per login and window
+	DefaultRateLimitPerLogin = 10
+	// DefaultRateLimitPerPeer is the number of authentication calls allowed per client address and window
+	DefaultRateLimitPerPeer = 30
+	// DefaultLockoutThreshold is the number of consecutive failed logins that locks a login
+	DefaultLockoutThreshold = 5
+	// DefaultLockoutBase is the first lockout, every further failure doubles it
+	DefaultLockoutBase = 30 * time.Second
+	// DefaultLockoutMax is the longest lockout, failures older than it are forgotten
+	DefaultLockoutMax = time.Hour
+	// DefaultShutdownTimeout is how long the calls in flight may take to finish on shutdown
+	DefaultShutdownTimeout = 30 * time.Second
+	// MinJWTSecretLength is the shortest JWT_SECRET the server starts with
+	MinJWTSecretLength = 32
+)
+
+// Rate limiter stores selectable with RATE_LIMIT_STORE
+const (
+	RateLimitMemory   = "memory"
+	RateLimitDatabase = "database"
+	// RateLimitPostgres is the former name of RateLimitDatabase, from when Postgres was the
+	// only database
+	RateLimitPostgres = "postgres"
+)
+
+// Log formats selectable with LOG_FORMAT
+const (
+	LogText = "text"
+	LogJSON = "json"
+)
+
+// Package level errors for the configuration
+var (
+	// ErrWeakJWTSecret is returned when JWT_SECRET is too short or a known default
+	ErrWeakJWTSecret = errors.New("JWT_SECRET must be at least 32 random characters, e.g. from 'openssl rand -base64 32'")
+	// ErrNoDatabaseURI is returned when DATABASE_URI isn't set
+	ErrNoDatabaseURI = errors.New("DATABASE_URI must be set")
+	// ErrKeyLength is returned for a legacy encryption key that isn't an AES key
+	ErrKeyLength = errors.New("legacy encryption keys must be 16, 24 or 32 bytes long for AES")
+	// ErrDefaultKey is returned for a legacy encryption key that is a published example value
+	ErrDefaultKey = errors.New("legacy encryption key is a published example, run rotate-keys and remove it")
+	// ErrConfigFormat is returned for a config file that is neither YAML nor TOML
+	ErrConfigFormat = errors.New("config file must be .yaml, .yml or .toml")
+)
+
+// defaultSecrets are example secrets published with the project, which must never guard real data
+var defaultSecrets = []string{
+	"123",
+	"847392615038",
+	"1234567890abcdef",
+	"your-secret-key-here",
+	"32-byte-encryption-key",
+	"another-32-byte-encryption-key",
+	"change-me",
+}
+
+// Config holds server configuration settings. Every setting is read from the variable named by
+// its env tag, defaulting to envDefault; the config file and the command-line flags set it under
+// the same name in lower case, with dashes for the flags. Settings tagged secret have no flag and are
+// only read from the environment and the config file.
 type Config struct {
 	ServerAddr    string `env:"RUN_ADDRESS" envDefault:"localhost:8080"`
 	DatabaseURI   string `env:"DATABASE_URI"`
-	JWTSecret     string `env:"JWT_SECRET" envDefault:"123"`
-	EncryptionKey string `env:"ENCRYPTION_KEY" envDefault:"123"`
+	JWTSecret     string `env:"JWT_SECRET" secret:"true"`
+	EncryptionKey string `env:"ENCRYPTION_KEY" secret:"true"`
+
+	// TLSCertFile and TLSKeyFile are the PEM files of the server's TLS certificate and key
+	TLSCertFile string `env:"TLS_CERT_FILE" envDefault:"server.crt"`
+	TLSKeyFile  string `env:"TLS_KEY_FILE" envDefault:"server.key"`
+
+	// LogLevel is the lowest level logged: debug, info, warn or error
+	LogLevel string `env:"LOG_LEVEL" envDefault:"info"`
+	// LogFormat is the format of the log lines: text or json
+	LogFormat string `env:"LOG_FORMAT" envDefault:"text"`
+
+	// EncryptionKeys holds the versioned legacy encryption keys, ENCRYPTION_KEY is version 0.
+	// They only decrypt values written before envelope encryption.
+	EncryptionKeys map[uint32]string `env:"ENCRYPTION_KEYS" secret:"true"`
+
+	// KeyProvider selects where the key-encryption keys live: keyfile, keystore or kms
+	KeyProvider string `env:"KEY_PROVIDER"`
+	// KeyFiles are the keyfile provider's files, the first one holds the active key
+	KeyFiles []string `env:"KEY_FILE"`
+	// KeystoreFile is the keystore provider's file, unlocked with KeystorePassphrase
+	KeystoreFile       string `env:"KEYSTORE_FILE"`
+	KeystorePassphrase string `env:"KEYSTORE_PASSPHRASE" secret:"true"`
+	// KMSURL, KMSKeyID and KMSToken address the key of the kms provider
+	KMSURL   string `env:"KMS_URL"`
+	KMSKeyID string `env:"KMS_KEY_ID"`
+	KMSToken string `env:"KMS_TOKEN" secret:"true"`
+
+	// HistoryVersions limits the prior versions kept per entry, 0 keeps all of them
+	HistoryVersions int `env:"HISTORY_VERSIONS" envDefault:"10"`
+	// HistoryMaxAge drops prior versions archived longer ago than this, 0 keeps them forever
+	HistoryMaxAge time.Duration `env:"HISTORY_MAX_AGE"`
+
+	// TrashRetention is how long deleted entries can be restored before they are purged, 0 keeps them until emptied
+	TrashRetention time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+	// TrashPurgeInterval is how often the background purger runs
+	TrashPurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+	// UploadTimeout is how long after its start an unfinished upload is removed with its chunks, 0 keeps it
+	UploadTimeout time.Duration `env:"UPLOAD_TIMEOUT" envDefault:"24h"`
+
+	// AccessTokenTTL is the lifetime of the JWT access tokens
+	AccessTokenTTL time.Duration `env:"ACCESS_TOKEN_TTL" envDefault:"15m"`
+	// RefreshTokenTTL is the lifetime of a refresh token, every refresh starts it anew
+	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL" envDefault:"720h"`
+
+	// RateLimitStore keeps the rate limiter's counters: memory for a single instance, database
+	// to share them between instances
+	RateLimitStore string `env:"RATE_LIMIT_STORE" envDefault:"memory"`
+	// RateLimitWindow is the fixed window the authentication calls are counted in
+	RateLimitWindow time.Duration `env:"RATE_LIMIT_WINDOW" envDefault:"1m"`
+	// RateLimitPerLogin and RateLimitPerPeer limit the authentication calls per window made for
+	// a login and from a client address, 0 disables the limit
+	RateLimitPerLogin int `env:"RATE_LIMIT_PER_LOGIN" envDefault:"10"`
+	RateLimitPerPeer  int `env:"RATE_LIMIT_PER_PEER" envDefault:"30"`
+	// LockoutThreshold is the number of consecutive failed logins after which a login is locked
+	// for LockoutBase, doubled with every further failure up to LockoutMax. 0 disables lockouts.
+	LockoutThreshold int           `env:"LOCKOUT_THRESHOLD" envDefault:"5"`
+	LockoutBase      time.Duration `env:"LOCKOUT_BASE" envDefault:"30s"`
+	LockoutMax       time.Duration `env:"LOCKOUT_MAX" envDefault:"1h"`
+
+	// ShutdownTimeout is how long the server waits for the calls in flight to finish on shutdown
+	// before it cancels them
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"30s"`
+
+	// Version is the build version of the server, set by main rather than the environment
+	Version string
 }
 
-// New creates and loads a new configuration instance
-func New() (Config, error) {
+// New loads the configuration from its defaults, the config file, the environment and the
+// command-line flags in args, each overriding the ones before, and validates it. The config file
+// is named by the -config flag or CONFIG_FILE. It returns the arguments after the flags.
+func New(args []string) (Config, []string, error) {
 	cfg := Config{}
 
 	err := env.Load()
 	if err != nil && !os.IsNotExist(err) {
-		return cfg, err
+		return cfg, nil, err
 	}
 
-	if cfg.ServerAddr == "" {
-		cfg.ServerAddr = os.Getenv("RUN_ADDRESS")
+	flags, file, err := parseFlags(args)
+	if err != nil {
+		return cfg, nil, err
 	}
 
-	if cfg.DatabaseURI == "" {
-		cfg.DatabaseURI = os.Getenv("DATABASE_URI")
+	if err := cfg.load(file, flags); err != nil {
+		return cfg, nil, err
 	}
 
-	if cfg.JWTSecret == "" {
-		cfg.JWTSecret = os.Getenv("JWT_SECRET")
+	if cfg.EncryptionKeys == nil {
+		cfg.EncryptionKeys = make(map[uint32]string)
+	}
+	if cfg.EncryptionKey != "" {
+		if _, ok := cfg.EncryptionKeys[0]; !ok {
+			cfg.EncryptionKeys[0] = cfg.EncryptionKey
+		}
+	}
+	if cfg.RateLimitStore == RateLimitPostgres {
+		cfg.RateLimitStore = RateLimitDatabase
 	}
 
-	if cfg.EncryptionKey == "" {
-		cfg.EncryptionKey = os.Getenv("ENCRYPTION_KEY")
+	if err := cfg.Validate(); err != nil {
+		return cfg, nil, err
 	}
 
-	return cfg, nil
+	return cfg, flags.Args(), nil
+}
+
+// parseKeys parses versioned keys written as comma separated version:key pairs
+func parseKeys(text string) (map[uint32]string, error) {
+	keys := make(map[uint32]string)
+	for _, pair := range strings.Split(text, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		version, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("ENCRYPTION_KEYS entry %q must be written as version:key", pair)
+		}
+
+		v, err := strconv.ParseUint(version, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("ENCRYPTION_KEYS entry %q has an invalid version", pair)
+		}
+		keys[uint32(v)] = key
+	}
+	return keys, nil
+}
+
+// DefaultSecret reports whether a secret is one of the example values published with the project
+func DefaultSecret(secret string) bool {
+	for _, d := range defaultSecrets {
+		if secret == d {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testJWTSecret is a JWT_SECRET long enough to pass validation
+const testJWTSecret = "test-jwt-secret-key-for-testing-0123456789"
+
+// clearEnv unsets every setting's variable for the test, so that the environment the tests run in
+// doesn't leak into the configuration
+func clearEnv(t *testing.T) {
+	t.Helper()
+
+	for _, s := range (&Config{}).settings() {
+		t.Setenv(s.name, "")
+		require.NoError(t, os.Unsetenv(s.name))
+	}
+	t.Setenv(configFileVar, "")
+	require.NoError(t, os.Unsetenv(configFileVar))
+}
+
+// writeFile writes a config file into a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNew_Precedence(t *testing.T) {
+	yamlFile := `
+database_uri: memory://
+jwt_secret: ` + testJWTSecret + `
+run_address: file:8080
+history_versions: 3
+trash_retention: 48h
+key_file: [file-2.key, file-1.key]
+encryption_keys:
+  1: file-key-0123456
+log_format: json
+`
+
+	tests := []struct {
+		name   string
+		file   string
+		env    map[string]string
+		args   []string
+		expect func(t *testing.T, cfg Config, rest []string)
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"DATABASE_URI": "memory://", "JWT_SECRET": testJWTSecret},
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Equal(t, "localhost:8080", cfg.ServerAddr)
+				assert.Equal(t, DefaultHistoryVersions, cfg.HistoryVersions)
+				assert.Equal(t, DefaultTrashRetention, cfg.TrashRetention)
+				assert.Equal(t, DefaultShutdownTimeout, cfg.ShutdownTimeout)
+				assert.Equal(t, LogText, cfg.LogFormat)
+				assert.Equal(t, RateLimitMemory, cfg.RateLimitStore)
+				assert.Empty(t, cfg.KeyFiles)
+				assert.Empty(t, cfg.EncryptionKeys)
+			},
+		},
+		{
+			name: "file over defaults",
+			file: yamlFile,
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Equal(t, "file:8080", cfg.ServerAddr)
+				assert.Equal(t, 3, cfg.HistoryVersions)
+				assert.Equal(t, 48*time.Hour, cfg.TrashRetention)
+				assert.Equal(t, []string{"file-2.key", "file-1.key"}, cfg.KeyFiles)
+				assert.Equal(t, map[uint32]string{1: "file-key-0123456"}, cfg.EncryptionKeys)
+				assert.Equal(t, LogJSON, cfg.LogFormat)
+				assert.Equal(t, DefaultRefreshTokenTTL, cfg.RefreshTokenTTL, "Settings missing from the file keep their defaults")
+			},
+		},
+		{
+			name: "environment over file",
+			file: yamlFile,
+			env:  map[string]string{"RUN_ADDRESS": "env:8080", "HISTORY_VERSIONS": "5", "KEY_FILE": "env.key"},
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Equal(t, "env:8080", cfg.ServerAddr)
+				assert.Equal(t, 5, cfg.HistoryVersions)
+				assert.Equal(t, []string{"env.key"}, cfg.KeyFiles)
+				assert.Equal(t, 48*time.Hour, cfg.TrashRetention)
+			},
+		},
+		{
+			name: "flags over environment",
+			file: yamlFile,
+			env:  map[string]string{"RUN_ADDRESS": "env:8080", "HISTORY_VERSIONS": "5"},
+			args: []string{"-run-address", "flag:8080", "-key-file", "flag-2.key,flag-1.key"},
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Equal(t, "flag:8080", cfg.ServerAddr)
+				assert.Equal(t, []string{"flag-2.key", "flag-1.key"}, cfg.KeyFiles)
+				assert.Equal(t, 5, cfg.HistoryVersions)
+			},
+		},
+		{
+			name: "empty variable clears the file",
+			file: yamlFile,
+			env:  map[string]string{"KEY_FILE": "", "HISTORY_VERSIONS": "", "ENCRYPTION_KEYS": ""},
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Empty(t, cfg.KeyFiles)
+				assert.Zero(t, cfg.HistoryVersions)
+				assert.Empty(t, cfg.EncryptionKeys)
+				assert.Equal(t, "file:8080", cfg.ServerAddr)
+			},
+		},
+		{
+			name: "empty flag clears the environment",
+			env:  map[string]string{"DATABASE_URI": "memory://", "JWT_SECRET": testJWTSecret, "TRASH_RETENTION": "1h"},
+			args: []string{"-trash-retention="},
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Zero(t, cfg.TrashRetention)
+			},
+		},
+		{
+			name: "legacy key and store names",
+			env: map[string]string{
+				"DATABASE_URI":     "memory://",
+				"JWT_SECRET":       testJWTSecret,
+				"ENCRYPTION_KEY":   "legacy-key-01234",
+				"RATE_LIMIT_STORE": RateLimitPostgres,
+			},
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Equal(t, map[uint32]string{0: "legacy-key-01234"}, cfg.EncryptionKeys)
+				assert.Equal(t, RateLimitDatabase, cfg.RateLimitStore)
+			},
+		},
+		{
+			name: "arguments after the flags",
+			env:  map[string]string{"DATABASE_URI": "memory://", "JWT_SECRET": testJWTSecret},
+			args: []string{"-log-level", "debug", "migrate", "up"},
+			expect: func(t *testing.T, cfg Config, rest []string) {
+				assert.Equal(t, "debug", cfg.LogLevel)
+				assert.Equal(t, []string{"migrate", "up"}, rest)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeFile(t, "vault.yaml", tt.file)}, args...)
+			}
+
+			cfg, rest, err := New(args)
+			require.NoError(t, err)
+			tt.expect(t, cfg, rest)
+		})
+	}
+}
+
+func TestNew_ConfigFile(t *testing.T) {
+	clearEnv(t)
+
+	tomlFile := writeFile(t, "vault.toml", `
+database_uri = "memory://"
+jwt_secret = "`+testJWTSecret+`"
+access_token_ttl = "5m"
+key_file = ["a.key", "b.key"]
+`)
+	t.Setenv(configFileVar, tomlFile)
+
+	cfg, _, err := New(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, cfg.AccessTokenTTL)
+	assert.Equal(t, []string{"a.key", "b.key"}, cfg.KeyFiles)
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		fileName  string
+		env       map[string]string
+		args      []string
+		expectErr error
+		expectMsg []string
+	}{
+		{
+			name:      "unknown file key",
+			fileName:  "vault.yaml",
+			file:      "database_uri: memory://\njwt_secret: " + testJWTSecret + "\nrun_adress: typo:8080\n",
+			expectMsg: []string{`unknown setting "run_adress"`},
+		},
+		{
+			name:      "unsupported file format",
+			fileName:  "vault.json",
+			file:      "{}",
+			expectErr: ErrConfigFormat,
+		},
+		{
+			name:      "malformed file",
+			fileName:  "vault.yaml",
+			file:      "database_uri: [",
+			expectMsg: []string{"vault.yaml"},
+		},
+		{
+			name:      "values that don't parse",
+			env:       map[string]string{"DATABASE_URI": "memory://", "JWT_SECRET": testJWTSecret, "HISTORY_VERSIONS": "ten", "LOCKOUT_BASE": "soon"},
+			expectMsg: []string{"HISTORY_VERSIONS", "LOCKOUT_BASE"},
+		},
+		{
+			name:      "invalid settings",
+			env:       map[string]string{"JWT_SECRET": "change-me"},
+			expectErr: ErrNoDatabaseURI,
+			expectMsg: []string{"JWT_SECRET must be at least"},
+		},
+		{
+			name:      "unknown flag",
+			env:       map[string]string{"DATABASE_URI": "memory://", "JWT_SECRET": testJWTSecret},
+			args:      []string{"-no-such-flag"},
+			expectMsg: []string{"no-such-flag"},
+		},
+		{
+			name:      "secret flag",
+			env:       map[string]string{"DATABASE_URI": "memory://", "JWT_SECRET": testJWTSecret},
+			args:      []string{"-jwt-secret", testJWTSecret},
+			expectMsg: []string{"jwt-secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			args := tt.args
+			if tt.fileName != "" {
+				args = append([]string{"-config", writeFile(t, tt.fileName, tt.file)}, args...)
+			}
+
+			_, _, err := New(args)
+			require.Error(t, err)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			}
+			for _, msg := range tt.expectMsg {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileVar names the config file when the -config flag isn't given
+const configFileVar = "CONFIG_FILE"
+
+// setting is a field of the configuration together with the variable it is read from
+type setting struct {
+	name   string
+	value  reflect.Value
+	def    string
+	hasDef bool
+	// secret settings have no flag, since the arguments of a process are visible to other users
+	secret bool
+}
+
+// settings returns the fields of the configuration that have an env tag
+func (c *Config) settings() []setting {
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+
+	var res []setting
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := t.Field(i).Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+		def, hasDef := t.Field(i).Tag.Lookup("envDefault")
+		secret := t.Field(i).Tag.Get("secret") == "true"
+		res = append(res, setting{name: name, value: v.Field(i), def: def, hasDef: hasDef, secret: secret})
+	}
+	return res
+}
+
+// flagName returns the command-line flag of a setting, its variable name in lower case with dashes
+func flagName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// fileKey returns the config file key of a setting, its variable name in lower case
+func fileKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// parseFlags parses the command-line flags, one per setting and -config, and returns them with
+// the config file to read
+func parseFlags(args []string) (*flag.FlagSet, string, error) {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	file := flags.String("config", os.Getenv(configFileVar), "YAML or TOML config file, "+configFileVar)
+	for _, s := range (&Config{}).settings() {
+		if s.secret {
+			continue
+		}
+		flags.String(flagName(s.name), s.def, s.name)
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return nil, "", err
+	}
+	return flags, *file, nil
+}
+
+// load sets every setting from the last of its default, the config file, the environment and the
+// flags that has it. A variable or flag set to an empty value clears the setting. Unknown config
+// file keys and values that don't parse are reported together.
+func (c *Config) load(file string, flags *flag.FlagSet) error {
+	fileValues, err := readFile(file)
+	if err != nil {
+		return err
+	}
+
+	flagValues := make(map[string]string)
+	flags.Visit(func(f *flag.Flag) {
+		flagValues[f.Name] = f.Value.String()
+	})
+
+	var errs []error
+	for _, s := range c.settings() {
+		raw, ok := s.def, s.hasDef
+		if v, found := fileValues[fileKey(s.name)]; found {
+			raw, ok = v, true
+			delete(fileValues, fileKey(s.name))
+		}
+		if v, found := os.LookupEnv(s.name); found {
+			raw, ok = v, true
+		}
+		if v, found := flagValues[flagName(s.name)]; found {
+			raw, ok = v, true
+		}
+		if !ok {
+			continue
+		}
+
+		if err := set(s.value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+		}
+	}
+
+	unknown := make([]string, 0, len(fileValues))
+	for key := range fileValues {
+		unknown = append(unknown, key)
+	}
+	slices.Sort(unknown)
+	for _, key := range unknown {
+		errs = append(errs, fmt.Errorf("%s: unknown setting %q", file, key))
+	}
+
+	return errors.Join(errs...)
+}
+
+// set parses a setting's text into its field. Lists are separated by commas, empty text is the
+// zero value.
+func set(v reflect.Value, raw string) error {
+	if raw == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Interface().(type) {
+	case string:
+		v.SetString(raw)
+	case int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case []string:
+		var list []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		v.Set(reflect.ValueOf(list))
+	case map[uint32]string:
+		keys, err := parseKeys(raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(keys))
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
+
+// readFile reads the settings of a YAML or TOML config file as text, keyed by their lower case
+// names. Lists are joined with commas and maps written as key:value pairs, as in the environment.
+func readFile(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrConfigFormat, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		if value != nil {
+			values[strings.ToLower(key)] = fileValue(value)
+		}
+	}
+	return values, nil
+}
+
+// fileValue writes a config file value as the text of the environment variable
+func fileValue(value any) string {
+	switch value := value.(type) {
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, fileValue(item))
+		}
+		return strings.Join(items, ",")
+	case map[string]any:
+		pairs := make([]string, 0, len(value))
+		for key, item := range value {
+			pairs = append(pairs, key+":"+fileValue(item))
+		}
+		slices.Sort(pairs)
+		return strings.Join(pairs, ",")
+	case map[any]any:
+		pairs := make([]string, 0, len(value))
+		for key, item := range value {
+			pairs = append(pairs, fmt.Sprint(key)+":"+fileValue(item))
+		}
+		slices.Sort(pairs)
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Validate checks the settings the server and its admin commands can't run without and reports
+// all problems at once. The TLS files and the published legacy keys are checked by
+// ValidateServing, the admin commands run without the former and rotate-keys needs the latter.
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.DatabaseURI == "" {
+		errs = append(errs, ErrNoDatabaseURI)
+	}
+
+	if len(c.JWTSecret) < MinJWTSecretLength || DefaultSecret(c.JWTSecret) {
+		errs = append(errs, ErrWeakJWTSecret)
+	}
+
+	versions := make([]uint32, 0, len(c.EncryptionKeys))
+	for version := range c.EncryptionKeys {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	for _, version := range versions {
+		switch len(c.EncryptionKeys[version]) {
+		case 16, 24, 32:
+		default:
+			errs = append(errs, fmt.Errorf("ENCRYPTION_KEYS version %d: %w, not %d", version, ErrKeyLength, len(c.EncryptionKeys[version])))
+		}
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error, not %q", c.LogLevel))
+	}
+	if c.LogFormat != LogText && c.LogFormat != LogJSON {
+		errs = append(errs, fmt.Errorf("LOG_FORMAT must be %s or %s, not %q", LogText, LogJSON, c.LogFormat))
+	}
+
+	if c.RateLimitStore != RateLimitMemory && c.RateLimitStore != RateLimitDatabase {
+		errs = append(errs, fmt.Errorf("RATE_LIMIT_STORE must be %s or %s, not %q", RateLimitMemory, RateLimitDatabase, c.RateLimitStore))
+	}
+
+	if c.AccessTokenTTL <= 0 || c.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("ACCESS_TOKEN_TTL and REFRESH_TOKEN_TTL must be positive"))
+	}
+
+	// Zero turns the counted and timed features off, negative values are mistakes
+	for _, s := range (&c).settings() {
+		switch value := s.value.Interface().(type) {
+		case int:
+			if value < 0 {
+				errs = append(errs, fmt.Errorf("%s must not be negative", s.name))
+			}
+		case time.Duration:
+			if value < 0 {
+				errs = append(errs, fmt.Errorf("%s must not be negative", s.name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateServing checks the settings only serving needs: that the TLS certificate and key can be
+// read and belong together, and that no legacy key is a published example, since anyone could
+// decrypt what it sealed
+func (c Config) ValidateServing() error {
+	var errs []error
+
+	if _, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile); err != nil {
+		errs = append(errs, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE: %w", err))
+	}
+
+	versions := make([]uint32, 0, len(c.EncryptionKeys))
+	for version, key := range c.EncryptionKeys {
+		if DefaultSecret(strings.TrimSpace(key)) {
+			versions = append(versions, version)
+		}
+	}
+	slices.Sort(versions)
+	for _, version := range versions {
+		errs = append(errs, fmt.Errorf("ENCRYPTION_KEYS version %d: %w", version, ErrDefaultKey))
+	}
+
+	return errors.Join(errs...)
+}
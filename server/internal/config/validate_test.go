@@ -0,0 +1,211 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig returns a configuration that passes Validate
+func validConfig() Config {
+	return Config{
+		DatabaseURI:     "memory://",
+		JWTSecret:       testJWTSecret,
+		LogLevel:        "info",
+		LogFormat:       LogText,
+		RateLimitStore:  RateLimitMemory,
+		AccessTokenTTL:  DefaultAccessTokenTTL,
+		RefreshTokenTTL: DefaultRefreshTokenTTL,
+		EncryptionKeys:  map[uint32]string{0: "legacy-key-01234"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		modify    func(c *Config)
+		expectErr error
+		expectMsg []string
+	}{
+		{
+			name:   "valid",
+			modify: func(c *Config) {},
+		},
+		{
+			name:      "no database",
+			modify:    func(c *Config) { c.DatabaseURI = "" },
+			expectErr: ErrNoDatabaseURI,
+		},
+		{
+			name:      "short JWT secret",
+			modify:    func(c *Config) { c.JWTSecret = "short" },
+			expectErr: ErrWeakJWTSecret,
+		},
+		{
+			name:      "published JWT secret",
+			modify:    func(c *Config) { c.JWTSecret = "your-secret-key-here" },
+			expectErr: ErrWeakJWTSecret,
+		},
+		{
+			name:      "legacy key length",
+			modify:    func(c *Config) { c.EncryptionKeys[2] = "too-short" },
+			expectErr: ErrKeyLength,
+			expectMsg: []string{"version 2"},
+		},
+		{
+			name:      "log level",
+			modify:    func(c *Config) { c.LogLevel = "verbose" },
+			expectMsg: []string{"LOG_LEVEL"},
+		},
+		{
+			name:      "log format",
+			modify:    func(c *Config) { c.LogFormat = "xml" },
+			expectMsg: []string{"LOG_FORMAT"},
+		},
+		{
+			name:      "rate limit store",
+			modify:    func(c *Config) { c.RateLimitStore = "redis" },
+			expectMsg: []string{"RATE_LIMIT_STORE"},
+		},
+		{
+			name:      "token lifetime",
+			modify:    func(c *Config) { c.AccessTokenTTL = 0 },
+			expectMsg: []string{"ACCESS_TOKEN_TTL"},
+		},
+		{
+			name: "negative limits",
+			modify: func(c *Config) {
+				c.HistoryVersions = -1
+				c.LockoutBase = -time.Second
+			},
+			expectMsg: []string{"HISTORY_VERSIONS must not be negative", "LOCKOUT_BASE must not be negative"},
+		},
+		{
+			name: "all problems at once",
+			modify: func(c *Config) {
+				c.DatabaseURI = ""
+				c.JWTSecret = ""
+				c.LogFormat = ""
+			},
+			expectErr: ErrNoDatabaseURI,
+			expectMsg: []string{"JWT_SECRET", "LOG_FORMAT"},
+		},
+		{
+			name:   "TLS files are not checked",
+			modify: func(c *Config) { c.TLSCertFile = "missing.crt" },
+		},
+		{
+			name:   "published legacy keys are not checked",
+			modify: func(c *Config) { c.EncryptionKeys[0] = "1234567890abcdef" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+			if tt.expectErr == nil && tt.expectMsg == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			}
+			for _, msg := range tt.expectMsg {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+// writeTestCert writes a self-signed certificate and its key and returns their files
+func writeTestCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "data-vault test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestValidateServing(t *testing.T) {
+	certFile, keyFile := writeTestCert(t)
+	otherCert, otherKey := writeTestCert(t)
+
+	tests := []struct {
+		name      string
+		certFile  string
+		keyFile   string
+		keys      map[uint32]string
+		expectErr error
+		expectMsg []string
+	}{
+		{name: "matching pair", certFile: certFile, keyFile: keyFile},
+		{name: "missing files", certFile: "missing.crt", keyFile: "missing.key", expectMsg: []string{"TLS_CERT_FILE and TLS_KEY_FILE"}},
+		{name: "key of another certificate", certFile: certFile, keyFile: otherKey, expectMsg: []string{"TLS_CERT_FILE and TLS_KEY_FILE"}},
+		{name: "swapped files", certFile: otherKey, keyFile: otherCert, expectMsg: []string{"TLS_CERT_FILE and TLS_KEY_FILE"}},
+		{
+			name:      "published legacy key",
+			certFile:  certFile,
+			keyFile:   keyFile,
+			keys:      map[uint32]string{0: "legacy-key-01234", 3: "1234567890abcdef"},
+			expectErr: ErrDefaultKey,
+			expectMsg: []string{"version 3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.TLSCertFile = tt.certFile
+			cfg.TLSKeyFile = tt.keyFile
+			if tt.keys != nil {
+				cfg.EncryptionKeys = tt.keys
+			}
+
+			err := cfg.ValidateServing()
+			if tt.expectErr == nil && tt.expectMsg == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			}
+			for _, msg := range tt.expectMsg {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+)
+
+// AuditSubject returns the user whose audit log a call belongs to: the authenticated caller, or
+// for the login calls the user the credentials were presented for. It is empty when the call
+// can't be attributed to a user.
+func (g *Handler) AuditSubject(ctx context.Context, req any) string {
+	if login, ok := ctx.Value(userIDKey).(string); ok && login != "" {
+		return login
+	}
+
+	switch r := req.(type) {
+	case *proto.LoginRequest:
+		return r.GetUser().GetLogin()
+	case *proto.VerifyTOTPRequest:
+		login, _ := g.parseChallenge(r.GetChallengeToken())
+		return login
+	}
+
+	return ""
+}
+
+// KnownUser reports whether a login belongs to a registered user
+func (g *Handler) KnownUser(ctx context.Context, login string) (bool, error) {
+	return g.service.UserExists(ctx, login)
+}
+
+// RecordAudit appends an entry to the audit log of the entry's user
+func (g *Handler) RecordAudit(ctx context.Context, entry models.AuditEntry) error {
+	return g.service.RecordAudit(ctx, entry)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConfirmTOTP handles requests to turn on two-factor authentication with a code from the provisioned secret
+func (g *Handler) ConfirmTOTP(ctx context.Context, in *proto.ConfirmTOTPRequest) (*proto.ConfirmTOTPResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Code) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Code not provided")
+	}
+
+	recoveryCodes, err := g.service.ConfirmTOTP(ctx, login, in.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTOTP):
+			return nil, status.Error(codes.InvalidArgument, "Invalid two-factor code")
+		case errors.Is(err, service.ErrTOTPEnabled):
+			return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication already enabled")
+		case errors.Is(err, service.ErrTOTPNotPending):
+			return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication setup not started")
+		}
+		return nil, status.Error(codes.Internal, "Failed to confirm two-factor authentication")
+	}
+
+	return &proto.ConfirmTOTPResponse{
+		Success:       true,
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
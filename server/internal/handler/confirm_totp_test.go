@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestConfirmTOTP(t *testing.T) {
+	recoveryCodes := []string{"abcde-fghij", "klmno-pqrst"}
+
+	tests := []struct {
+		name         string
+		request      *proto.ConfirmTOTPRequest
+		userID       interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			request:    &proto.ConfirmTOTPRequest{Code: "123456"},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "invalid code",
+			request:      &proto.ConfirmTOTPRequest{Code: "000000"},
+			userID:       "testuser",
+			mockError:    service.ErrInvalidTOTP,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Invalid two-factor code",
+		},
+		{
+			name:         "setup not started",
+			request:      &proto.ConfirmTOTPRequest{Code: "123456"},
+			userID:       "testuser",
+			mockError:    service.ErrTOTPNotPending,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "Two-factor authentication setup not started",
+		},
+		{
+			name:         "already enabled",
+			request:      &proto.ConfirmTOTPRequest{Code: "123456"},
+			userID:       "testuser",
+			mockError:    service.ErrTOTPEnabled,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "Two-factor authentication already enabled",
+		},
+		{
+			name:         "service error",
+			request:      &proto.ConfirmTOTPRequest{Code: "123456"},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to confirm two-factor authentication",
+		},
+		{
+			name:         "empty code",
+			request:      &proto.ConfirmTOTPRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Code not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.ConfirmTOTPRequest{Code: "123456"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				if tt.mockError != nil {
+					mockService.On("ConfirmTOTP", mock.Anything, "testuser", tt.request.Code).Return(nil, tt.mockError)
+				} else {
+					mockService.On("ConfirmTOTP", mock.Anything, "testuser", tt.request.Code).Return(recoveryCodes, nil)
+				}
+			}
+
+			response, err := handler.ConfirmTOTP(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, recoveryCodes, response.RecoveryCodes)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateOrg handles requests to create an organization vault owned by the user
+func (g *Handler) CreateOrg(ctx context.Context, in *proto.CreateOrgRequest) (*proto.CreateOrgResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Name) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Organization name not provided")
+	}
+
+	if len(in.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Wrapped key not provided")
+	}
+
+	err = g.service.CreateOrg(ctx, login, in.Name, in.WrappedKey)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidOrgName) {
+			return nil, status.Error(codes.InvalidArgument, "Organization name must be 1-64 letters, digits, dots, dashes or underscores")
+		}
+		if errors.Is(err, storage.ErrDuplicateOrg) {
+			return nil, status.Error(codes.AlreadyExists, "Organization already exists")
+		}
+		return nil, status.Error(codes.Internal, "Failed to create organization")
+	}
+
+	return &proto.CreateOrgResponse{
+		Success: true,
+	}, nil
+}
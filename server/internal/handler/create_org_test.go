@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateOrg(t *testing.T) {
+	wrapped := []byte("wrapped org key")
+
+	tests := []struct {
+		name         string
+		request      *proto.CreateOrgRequest
+		userID       interface{}
+		org          string
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			request:    &proto.CreateOrgRequest{Name: "acme", WrappedKey: wrapped},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "name taken",
+			request:      &proto.CreateOrgRequest{Name: "acme", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    storage.ErrDuplicateOrg,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.AlreadyExists,
+			expectedMsg:  "Organization already exists",
+		},
+		{
+			name:         "invalid name",
+			request:      &proto.CreateOrgRequest{Name: "acme inc", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    service.ErrInvalidOrgName,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Organization name must be",
+		},
+		{
+			name:         "service error",
+			request:      &proto.CreateOrgRequest{Name: "acme", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to create organization",
+		},
+		{
+			name:         "empty name",
+			request:      &proto.CreateOrgRequest{WrappedKey: wrapped},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Organization name not provided",
+		},
+		{
+			name:         "empty wrapped key",
+			request:      &proto.CreateOrgRequest{Name: "acme"},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Wrapped key not provided",
+		},
+		{
+			name:         "called on organization vault",
+			request:      &proto.CreateOrgRequest{Name: "acme", WrappedKey: wrapped},
+			userID:       "testuser",
+			org:          "other",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "not available on organization vaults",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.CreateOrgRequest{Name: "acme", WrappedKey: wrapped},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+			if tt.org != "" {
+				ctx = WithOrg(ctx, tt.org, "owner")
+			}
+
+			if tt.expectMock {
+				mockService.On("CreateOrg", mock.Anything, "testuser", tt.request.Name, tt.request.WrappedKey).
+					Return(tt.mockError)
+			}
+
+			response, err := handler.CreateOrg(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"data-vault/server/internal/models"
 	"data-vault/server/internal/proto"
 
 	"google.golang.org/grpc/codes"
@@ -11,9 +12,9 @@ import (
 // DeleteData handles data deletion requests
 func (g *Handler) DeleteData(ctx context.Context, in *proto.DeleteDataRequest) (*proto.DeleteDataResponse, error) {
 	var response *proto.DeleteDataResponse
-	userID, ok := ctx.Value(userIDKey).(string)
-	if !ok || userID == "" {
-		return nil, status.Error(codes.Unauthenticated, "User ID not found in context")
+	userID, err := authorize(ctx, models.ActionWrite)
+	if err != nil {
+		return nil, err
 	}
 
 	dataID := in.Id
@@ -21,7 +22,7 @@ func (g *Handler) DeleteData(ctx context.Context, in *proto.DeleteDataRequest) (
 		return nil, status.Error(codes.InvalidArgument, "Data ID not provided")
 	}
 
-	err := g.service.DeleteData(ctx, userID, dataID)
+	err = g.service.DeleteData(ctx, userID, dataID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Failed to delete data")
 	}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DownloadFile handles streamed file downloads. The requested offset is rounded down
+// to a chunk boundary; the file info carrying the actual start offset is sent first,
+// followed by the encrypted chunks.
+func (g *Handler) DownloadFile(in *proto.DownloadFileRequest, stream proto.VaultService_DownloadFileServer) error {
+	ctx := stream.Context()
+
+	login, err := authorize(ctx, models.ActionRead)
+	if err != nil {
+		return err
+	}
+
+	if len(in.Id) == 0 {
+		return status.Error(codes.InvalidArgument, "File ID not provided")
+	}
+
+	file, err := g.service.GetFile(ctx, login, in.Id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNoDataFound) {
+			return status.Error(codes.NotFound, "File not found")
+		}
+		return status.Error(codes.Internal, "Failed to get file")
+	}
+
+	if !file.Complete {
+		return status.Error(codes.FailedPrecondition, "File upload is not complete")
+	}
+
+	if in.Offset < 0 || in.Offset > file.Size {
+		return status.Error(codes.InvalidArgument, "Offset is outside of the file")
+	}
+
+	from := in.Offset / file.ChunkSize
+	info := fileToProto(file)
+	info.Offset = from * file.ChunkSize
+
+	err = stream.Send(&proto.DownloadFileResponse{
+		Payload: &proto.DownloadFileResponse_Info{Info: info},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = g.service.ReadChunks(ctx, file, from, func(chunk []byte) error {
+		return stream.Send(&proto.DownloadFileResponse{
+			Payload: &proto.DownloadFileResponse_Chunk{Chunk: chunk},
+		})
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, "Failed to read file")
+	}
+
+	return nil
+}
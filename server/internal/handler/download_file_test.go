@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockDownloadStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*proto.DownloadFileResponse
+}
+
+func (s *mockDownloadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockDownloadStream) Send(resp *proto.DownloadFileResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func TestDownloadFile(t *testing.T) {
+	completeFile := models.File{ID: "file1", User: "testuser", Meta: []byte("meta"), Size: 10, ChunkSize: 4, Chunks: 3, Complete: true}
+	partialFile := completeFile
+	partialFile.Chunks = 1
+	partialFile.Complete = false
+
+	sendChunks := func(chunks ...string) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			fn := args.Get(3).(func([]byte) error)
+			for _, c := range chunks {
+				_ = fn([]byte(c))
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		request        *proto.DownloadFileRequest
+		userID         interface{}
+		setupMock      func(m *MockService)
+		expectError    bool
+		expectedCode   codes.Code
+		expectedMsg    string
+		expectedChunks []string
+		expectedOffset int64
+	}{
+		{
+			name:    "full download",
+			request: &proto.DownloadFileRequest{Id: "file1"},
+			userID:  "testuser",
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(completeFile, nil)
+				m.On("ReadChunks", mock.Anything, completeFile, int64(0), mock.Anything).
+					Run(sendChunks("aaaa", "bbbb", "cc")).Return(nil)
+			},
+			expectedChunks: []string{"aaaa", "bbbb", "cc"},
+		},
+		{
+			name:    "resume download",
+			request: &proto.DownloadFileRequest{Id: "file1", Offset: 8},
+			userID:  "testuser",
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(completeFile, nil)
+				m.On("ReadChunks", mock.Anything, completeFile, int64(2), mock.Anything).
+					Run(sendChunks("cc")).Return(nil)
+			},
+			expectedChunks: []string{"cc"},
+			expectedOffset: 8,
+		},
+		{
+			name:    "offset rounded down to chunk",
+			request: &proto.DownloadFileRequest{Id: "file1", Offset: 7},
+			userID:  "testuser",
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(completeFile, nil)
+				m.On("ReadChunks", mock.Anything, completeFile, int64(1), mock.Anything).
+					Run(sendChunks("bbbb", "cc")).Return(nil)
+			},
+			expectedChunks: []string{"bbbb", "cc"},
+			expectedOffset: 4,
+		},
+		{
+			name:    "offset beyond file",
+			request: &proto.DownloadFileRequest{Id: "file1", Offset: 11},
+			userID:  "testuser",
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(completeFile, nil)
+			},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Offset is outside of the file",
+		},
+		{
+			name:    "incomplete upload",
+			request: &proto.DownloadFileRequest{Id: "file1"},
+			userID:  "testuser",
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(partialFile, nil)
+			},
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "File upload is not complete",
+		},
+		{
+			name:    "file not found",
+			request: &proto.DownloadFileRequest{Id: "missing"},
+			userID:  "testuser",
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "missing").Return(models.File{}, storage.ErrNoDataFound)
+			},
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "File not found",
+		},
+		{
+			name:    "read error",
+			request: &proto.DownloadFileRequest{Id: "file1"},
+			userID:  "testuser",
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(completeFile, nil)
+				m.On("ReadChunks", mock.Anything, completeFile, int64(0), mock.Anything).Return(errors.New("database error"))
+			},
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to read file",
+		},
+		{
+			name:         "empty file ID",
+			request:      &proto.DownloadFileRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "File ID not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.DownloadFileRequest{Id: "file1"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			ctx := context.Background()
+			if tt.userID != nil {
+				ctx = context.WithValue(ctx, userIDKey, tt.userID)
+			}
+
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+
+			stream := &mockDownloadStream{ctx: ctx}
+			err := handler.DownloadFile(tt.request, stream)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, stream.responses, len(tt.expectedChunks)+1)
+
+				info := stream.responses[0].GetInfo()
+				require.NotNil(t, info)
+				assert.Equal(t, "file1", info.Id)
+				assert.True(t, info.Complete)
+				assert.Equal(t, tt.expectedOffset, info.Offset)
+
+				for i, c := range tt.expectedChunks {
+					assert.Equal(t, c, string(stream.responses[i+1].GetChunk()))
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
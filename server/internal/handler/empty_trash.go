@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EmptyTrash handles requests to permanently remove the trashed data entries of a user
+func (g *Handler) EmptyTrash(ctx context.Context, in *proto.EmptyTrashRequest) (*proto.EmptyTrashResponse, error) {
+	login, err := authorize(ctx, models.ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	purged, err := g.service.EmptyTrash(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to empty trash")
+	}
+
+	return &proto.EmptyTrashResponse{
+		Success: true,
+		Purged:  purged,
+	}, nil
+}
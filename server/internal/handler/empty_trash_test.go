@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEmptyTrash(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         interface{}
+		mockPurged     int64
+		mockError      error
+		expectMock     bool
+		expectError    bool
+		expectedCode   codes.Code
+		expectedMsg    string
+		expectedPurged int64
+	}{
+		{
+			name:           "success",
+			userID:         "testuser",
+			mockPurged:     4,
+			expectMock:     true,
+			expectedPurged: 4,
+		},
+		{
+			name:       "nothing to purge",
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "service error",
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to empty trash",
+		},
+		{
+			name:         "missing user ID in context",
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("EmptyTrash", mock.Anything, "testuser").Return(tt.mockPurged, tt.mockError)
+			}
+
+			response, err := handler.EmptyTrash(ctx, &proto.EmptyTrashRequest{})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, tt.expectedPurged, response.Purged)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EnableTOTP handles requests to provision a two-factor secret for the user
+func (g *Handler) EnableTOTP(ctx context.Context, in *proto.EnableTOTPRequest) (*proto.EnableTOTPResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, uri, err := g.service.EnableTOTP(ctx, login)
+	if err != nil {
+		if errors.Is(err, service.ErrTOTPEnabled) {
+			return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication already enabled")
+		}
+		return nil, status.Error(codes.Internal, "Failed to enable two-factor authentication")
+	}
+
+	return &proto.EnableTOTPResponse{
+		Success: true,
+		Secret:  secret,
+		Uri:     uri,
+	}, nil
+}
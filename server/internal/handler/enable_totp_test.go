@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEnableTOTP(t *testing.T) {
+	const (
+		secret = "JBSWY3DPEHPK3PXP"
+		uri    = "otpauth://totp/DataVault:testuser?secret=JBSWY3DPEHPK3PXP"
+	)
+
+	tests := []struct {
+		name         string
+		userID       interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "already enabled",
+			userID:       "testuser",
+			mockError:    service.ErrTOTPEnabled,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "Two-factor authentication already enabled",
+		},
+		{
+			name:         "service error",
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to enable two-factor authentication",
+		},
+		{
+			name:         "missing user ID in context",
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				if tt.mockError != nil {
+					mockService.On("EnableTOTP", mock.Anything, "testuser").Return("", "", tt.mockError)
+				} else {
+					mockService.On("EnableTOTP", mock.Anything, "testuser").Return(secret, uri, nil)
+				}
+			}
+
+			response, err := handler.EnableTOTP(ctx, &proto.EnableTOTPRequest{})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, secret, response.Secret)
+				assert.Equal(t, uri, response.Uri)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
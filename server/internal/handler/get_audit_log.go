@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetAuditLog handles requests for a page of the user's audit log
+func (g *Handler) GetAuditLog(ctx context.Context, in *proto.GetAuditLogRequest) (*proto.GetAuditLogResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Page size must not be negative")
+	}
+
+	if in.BeforeSeq < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Sequence number must not be negative")
+	}
+
+	entries, err := g.service.GetAuditLog(ctx, login, in.BeforeSeq, int(in.PageSize))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to get audit log")
+	}
+
+	response := &proto.GetAuditLogResponse{
+		Entries: make([]*proto.AuditEntry, 0, len(entries)),
+	}
+	for _, e := range entries {
+		response.Entries = append(response.Entries, auditEntryToProto(e))
+	}
+
+	return response, nil
+}
+
+// auditEntryToProto converts an audit entry to its protobuf form
+func auditEntryToProto(e models.AuditEntry) *proto.AuditEntry {
+	return &proto.AuditEntry{
+		User:      e.User,
+		Seq:       e.Seq,
+		At:        e.At,
+		Action:    e.Action,
+		RecordId:  e.RecordID,
+		Org:       e.Org,
+		ClientIp:  e.ClientIP,
+		UserAgent: e.UserAgent,
+		Result:    e.Result,
+		PrevHash:  e.PrevHash,
+		Hash:      e.Hash,
+		Authentic: e.Authentic,
+	}
+}
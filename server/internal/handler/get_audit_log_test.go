@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetAuditLog(t *testing.T) {
+	entries := []models.AuditEntry{
+		{User: "testuser", Seq: 2, At: "2024-01-01T10:01:00Z", Action: "GetData", Result: "OK", PrevHash: []byte("hash1"), Hash: []byte("hash2")},
+		{User: "testuser", Seq: 1, At: "2024-01-01T10:00:00Z", Action: "Login", ClientIP: "10.0.0.1", UserAgent: "grpc-go", Result: "OK", Hash: []byte("hash1")},
+	}
+
+	tests := []struct {
+		name          string
+		request       *proto.GetAuditLogRequest
+		userID        interface{}
+		org           string
+		mockEntries   []models.AuditEntry
+		mockError     error
+		expectMock    bool
+		expectError   bool
+		expectedCode  codes.Code
+		expectedMsg   string
+		expectedCount int
+	}{
+		{
+			name:          "success",
+			request:       &proto.GetAuditLogRequest{PageSize: 10},
+			userID:        "testuser",
+			mockEntries:   entries,
+			expectMock:    true,
+			expectedCount: 2,
+		},
+		{
+			name:          "next page",
+			request:       &proto.GetAuditLogRequest{PageSize: 10, BeforeSeq: 2},
+			userID:        "testuser",
+			mockEntries:   entries[1:],
+			expectMock:    true,
+			expectedCount: 1,
+		},
+		{
+			name:          "empty log",
+			request:       &proto.GetAuditLogRequest{},
+			userID:        "testuser",
+			mockEntries:   []models.AuditEntry{},
+			expectMock:    true,
+			expectedCount: 0,
+		},
+		{
+			name:         "service error",
+			request:      &proto.GetAuditLogRequest{},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to get audit log",
+		},
+		{
+			name:         "negative page size",
+			request:      &proto.GetAuditLogRequest{PageSize: -1},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Page size must not be negative",
+		},
+		{
+			name:         "negative sequence number",
+			request:      &proto.GetAuditLogRequest{BeforeSeq: -1},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Sequence number must not be negative",
+		},
+		{
+			name:         "organization vault",
+			request:      &proto.GetAuditLogRequest{},
+			userID:       "testuser",
+			org:          "acme",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Operation is not available on organization vaults",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.GetAuditLogRequest{},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+			if tt.org != "" {
+				ctx = WithOrg(ctx, tt.org, models.RoleOwner)
+			}
+
+			if tt.expectMock {
+				mockService.On("GetAuditLog", mock.Anything, "testuser", tt.request.BeforeSeq, int(tt.request.PageSize)).
+					Return(tt.mockEntries, tt.mockError)
+			}
+
+			response, err := handler.GetAuditLog(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.Len(t, response.Entries, tt.expectedCount)
+				for i, e := range response.Entries {
+					assert.Equal(t, tt.mockEntries[i].Seq, e.Seq)
+					assert.Equal(t, tt.mockEntries[i].Action, e.Action)
+					assert.Equal(t, tt.mockEntries[i].Hash, e.Hash)
+					assert.Equal(t, tt.mockEntries[i].PrevHash, e.PrevHash)
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
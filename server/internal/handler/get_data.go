@@ -2,7 +2,12 @@ package handler
 
 import (
 	"context"
+	"data-vault/server/internal/models"
 	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+	"errors"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -10,30 +15,85 @@ import (
 
 // GetData handles data retrieval requests
 func (g *Handler) GetData(ctx context.Context, in *proto.GetDataRequest) (*proto.GetDataResponse, error) {
-	userID, ok := ctx.Value(userIDKey).(string)
-	if !ok || userID == "" {
-		return nil, status.Error(codes.Unauthenticated, "User ID not found in context")
+	userID, err := authorize(ctx, models.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Metadata) > maxMetadataEntries {
+		return nil, status.Error(codes.InvalidArgument, "Metadata filter is too large")
 	}
 
-	data, err := g.service.GetData(ctx, userID)
+	if in.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Page size must not be negative")
+	}
+
+	if in.Type != "" && !models.ValidDataType(in.Type) {
+		return nil, status.Error(codes.InvalidArgument, "Unknown data type")
+	}
+
+	from, err := normalizeTimestamp(in.UploadedFrom)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid uploaded_from timestamp")
+	}
+	to, err := normalizeTimestamp(in.UploadedTo)
 	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid uploaded_to timestamp")
+	}
+
+	page, err := g.service.GetData(ctx, userID, models.DataQuery{
+		Type:         in.Type,
+		UploadedFrom: from,
+		UploadedTo:   to,
+		Metadata:     in.Metadata,
+		PageSize:     int(in.PageSize),
+		PageToken:    in.PageToken,
+	})
+	if errors.Is(err, service.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid page token")
+	}
+	if err != nil && !errors.Is(err, storage.ErrNoDataFound) {
 		return nil, status.Error(codes.Internal, "Failed to get data")
 	}
 
 	response := &proto.GetDataResponse{
-		Data: make([]*proto.Data, 0, len(data)),
+		Data:          make([]*proto.Data, 0, len(page.Data)),
+		NextPageToken: page.NextPageToken,
 	}
 
-	for _, d := range data {
-		response.Data = append(response.Data, &proto.Data{
-			Id:         d.ID,
-			User:       d.User,
-			Status:     d.Status,
-			Type:       d.Type,
-			Data:       d.Data,
-			UploadedAt: d.UploadedAt,
-		})
+	for _, d := range page.Data {
+		response.Data = append(response.Data, dataToProto(d))
 	}
 
 	return response, nil
 }
+
+// normalizeTimestamp converts an RFC 3339 timestamp into the UTC form uploaded_at is stored in,
+// so that the stored values compare correctly as text
+func normalizeTimestamp(ts string) (string, error) {
+	if ts == "" {
+		return "", nil
+	}
+
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// dataToProto converts a data entry into its protobuf representation
+func dataToProto(d models.Data) *proto.Data {
+	return &proto.Data{
+		Id:         d.ID,
+		User:       d.User,
+		Status:     d.Status,
+		Type:       d.Type,
+		Data:       d.Data,
+		UploadedAt: d.UploadedAt,
+		Version:    d.Version,
+		Revision:   d.Revision,
+		Metadata:   d.Metadata,
+		DeletedAt:  d.DeletedAt,
+	}
+}
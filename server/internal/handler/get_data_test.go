@@ -7,6 +7,8 @@ import (
 
 	"data-vault/server/internal/models"
 	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +26,7 @@ func TestGetData(t *testing.T) {
 			Type:       "text",
 			Data:       []byte("encrypted data 1"),
 			UploadedAt: "2024-01-01T00:00:00Z",
+			Metadata:   map[string]string{"env": "prod"},
 		},
 		{
 			ID:         "data2",
@@ -36,14 +39,17 @@ func TestGetData(t *testing.T) {
 	}
 
 	tests := []struct {
-		name         string
-		userID       interface{}
-		mockData     []models.Data
-		mockError    error
-		expectError  bool
-		expectedCode codes.Code
-		expectedMsg  string
-		expectedLen  int
+		name          string
+		userID        interface{}
+		request       *proto.GetDataRequest
+		expectedQuery models.DataQuery
+		mockData      []models.Data
+		mockToken     string
+		mockError     error
+		expectError   bool
+		expectedCode  codes.Code
+		expectedMsg   string
+		expectedLen   int
 	}{
 		{
 			name:        "success",
@@ -61,6 +67,79 @@ func TestGetData(t *testing.T) {
 			expectError: false,
 			expectedLen: 0,
 		},
+		{
+			name:          "metadata filter",
+			userID:        "testuser",
+			request:       &proto.GetDataRequest{Metadata: map[string]string{"env": "prod"}},
+			expectedQuery: models.DataQuery{Metadata: map[string]string{"env": "prod"}},
+			mockData:      mockData[:1],
+			expectError:   false,
+			expectedLen:   1,
+		},
+		{
+			name:   "filters and paging",
+			userID: "testuser",
+			request: &proto.GetDataRequest{
+				Type:         "password",
+				UploadedFrom: "2024-01-01T03:00:00+03:00",
+				UploadedTo:   "2024-02-01T00:00:00Z",
+				PageSize:     1,
+				PageToken:    "token-1",
+			},
+			expectedQuery: models.DataQuery{
+				Type:         "password",
+				UploadedFrom: "2024-01-01T00:00:00Z",
+				UploadedTo:   "2024-02-01T00:00:00Z",
+				PageSize:     1,
+				PageToken:    "token-1",
+			},
+			mockData:    mockData[:1],
+			mockToken:   "token-2",
+			expectError: false,
+			expectedLen: 1,
+		},
+		{
+			name:          "invalid page token",
+			userID:        "testuser",
+			request:       &proto.GetDataRequest{PageToken: "garbage"},
+			expectedQuery: models.DataQuery{PageToken: "garbage"},
+			mockError:     service.ErrInvalidPageToken,
+			expectError:   true,
+			expectedCode:  codes.InvalidArgument,
+			expectedMsg:   "Invalid page token",
+		},
+		{
+			name:         "metadata filter too large",
+			userID:       "testuser",
+			request:      &proto.GetDataRequest{Metadata: largeMetadata()},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Metadata filter is too large",
+		},
+		{
+			name:         "negative page size",
+			userID:       "testuser",
+			request:      &proto.GetDataRequest{PageSize: -1},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Page size must not be negative",
+		},
+		{
+			name:         "unknown type filter",
+			userID:       "testuser",
+			request:      &proto.GetDataRequest{Type: "note"},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Unknown data type",
+		},
+		{
+			name:         "malformed date",
+			userID:       "testuser",
+			request:      &proto.GetDataRequest{UploadedFrom: "2024-01-01"},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Invalid uploaded_from timestamp",
+		},
 		{
 			name:         "missing user ID in context",
 			userID:       nil,
@@ -91,6 +170,14 @@ func TestGetData(t *testing.T) {
 			expectedCode: codes.Internal,
 			expectedMsg:  "Failed to get data",
 		},
+		{
+			name:        "no data stored",
+			userID:      "testuser",
+			mockData:    nil,
+			mockError:   storage.ErrNoDataFound,
+			expectError: false,
+			expectedLen: 0,
+		},
 		{
 			name:        "nil data from service",
 			userID:      "testuser",
@@ -105,7 +192,10 @@ func TestGetData(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			handler, mockService := setupTestHandler()
 
-			request := &proto.GetDataRequest{}
+			request := tt.request
+			if request == nil {
+				request = &proto.GetDataRequest{}
+			}
 
 			var ctx context.Context
 			if tt.userID != nil {
@@ -115,8 +205,9 @@ func TestGetData(t *testing.T) {
 			}
 
 			// Only set up mock for valid requests
-			if tt.userID == "testuser" {
-				mockService.On("GetData", mock.Anything, "testuser").Return(tt.mockData, tt.mockError)
+			if tt.userID == "testuser" && (tt.expectedCode != codes.InvalidArgument || tt.mockError != nil) {
+				mockService.On("GetData", mock.Anything, "testuser", tt.expectedQuery).
+					Return(models.DataPage{Data: tt.mockData, NextPageToken: tt.mockToken}, tt.mockError)
 			}
 
 			response, err := handler.GetData(ctx, request)
@@ -132,6 +223,7 @@ func TestGetData(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, response)
 				assert.Len(t, response.Data, tt.expectedLen)
+				assert.Equal(t, tt.mockToken, response.NextPageToken)
 
 				if tt.expectedLen > 0 && tt.mockData != nil {
 					for i, expectedItem := range tt.mockData {
@@ -139,6 +231,7 @@ func TestGetData(t *testing.T) {
 						assert.Equal(t, expectedItem.Data, response.Data[i].Data)
 						assert.Equal(t, expectedItem.Status, response.Data[i].Status)
 						assert.Equal(t, expectedItem.UploadedAt, response.Data[i].UploadedAt)
+						assert.Equal(t, expectedItem.Metadata, response.Data[i].Metadata)
 					}
 				}
 			}
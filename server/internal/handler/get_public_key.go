@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPublicKey handles requests for the public key of a user to share records with
+func (g *Handler) GetPublicKey(ctx context.Context, in *proto.GetPublicKeyRequest) (*proto.GetPublicKeyResponse, error) {
+	if _, err := caller(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(in.Login) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Login not provided")
+	}
+
+	key, err := g.service.GetPublicKey(ctx, in.Login)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "User not found")
+		}
+		if errors.Is(err, storage.ErrNoPublicKey) {
+			return nil, status.Error(codes.FailedPrecondition, "User has not published a public key yet")
+		}
+		return nil, status.Error(codes.Internal, "Failed to get public key")
+	}
+
+	return &proto.GetPublicKeyResponse{
+		Success:   true,
+		PublicKey: key,
+	}, nil
+}
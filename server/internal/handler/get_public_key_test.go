@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetPublicKey(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+
+	tests := []struct {
+		name         string
+		request      *proto.GetPublicKeyRequest
+		userID       interface{}
+		mockKey      []byte
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			request:    &proto.GetPublicKeyRequest{Login: "alice"},
+			userID:     "testuser",
+			mockKey:    key,
+			expectMock: true,
+		},
+		{
+			name:         "user not found",
+			request:      &proto.GetPublicKeyRequest{Login: "nobody"},
+			userID:       "testuser",
+			mockError:    storage.ErrUserNotFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "User not found",
+		},
+		{
+			name:         "no public key",
+			request:      &proto.GetPublicKeyRequest{Login: "alice"},
+			userID:       "testuser",
+			mockError:    storage.ErrNoPublicKey,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "User has not published a public key yet",
+		},
+		{
+			name:         "service error",
+			request:      &proto.GetPublicKeyRequest{Login: "alice"},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to get public key",
+		},
+		{
+			name:         "empty login",
+			request:      &proto.GetPublicKeyRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Login not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.GetPublicKeyRequest{Login: "alice"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("GetPublicKey", mock.Anything, tt.request.Login).Return(tt.mockKey, tt.mockError)
+			}
+
+			response, err := handler.GetPublicKey(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, tt.mockKey, response.PublicKey)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"time"
@@ -9,24 +10,72 @@ import (
 	"data-vault/server/internal/config"
 	"data-vault/server/internal/models"
 	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
 
 	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type contextKey string
 
 const (
-	tokenExpiryHours            = 24
-	userIDKey        contextKey = "user_id"
+	userIDKey    contextKey = "user_id"
+	sessionIDKey contextKey = "session_id"
+	orgKey       contextKey = "org"
+	roleKey      contextKey = "role"
+
+	challengePurpose = "totp"
+	challengeTTL     = 5 * time.Minute
+
+	maxMetadataEntries = 32
+	maxMetadataKey     = 64
+	maxMetadataValue   = 1024
 )
 
 // Service defines the interface for vault operations
 type Service interface {
 	Register(ctx context.Context, user models.User) error
-	Login(ctx context.Context, user models.User) error
-	PostData(ctx context.Context, login, dataType string, data []byte) error
-	GetData(ctx context.Context, shortURL string) ([]models.Data, error)
+	PreLogin(ctx context.Context, login string) ([]byte, bool, error)
+	Login(ctx context.Context, user models.User) ([]byte, error)
+	CreateSession(ctx context.Context, login, device string) (models.Session, string, error)
+	RefreshSession(ctx context.Context, refreshToken string) (models.Session, string, error)
+	SessionActive(ctx context.Context, login, id string) (bool, error)
+	ListSessions(ctx context.Context, login string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, login, id string) error
+	TOTPEnabled(ctx context.Context, login string) (bool, error)
+	EnableTOTP(ctx context.Context, login string) (string, string, error)
+	ConfirmTOTP(ctx context.Context, login, code string) ([]string, error)
+	VerifyTOTP(ctx context.Context, login, code string) ([]byte, error)
+	PostData(ctx context.Context, login, dataType string, data []byte, metadata map[string]string) error
+	GetData(ctx context.Context, login string, query models.DataQuery) (models.DataPage, error)
+	UpdateData(ctx context.Context, login, id, dataType string, data []byte, version int64) (int64, error)
 	DeleteData(ctx context.Context, login, id string) error
+	ListVersions(ctx context.Context, login, id string) ([]models.Version, error)
+	RestoreVersion(ctx context.Context, login, id string, version int64) (int64, error)
+	ListTrash(ctx context.Context, login string) ([]models.Data, error)
+	RestoreFromTrash(ctx context.Context, login, id string) (int64, error)
+	EmptyTrash(ctx context.Context, login string) (int64, error)
+	SetPublicKey(ctx context.Context, login string, key []byte) error
+	GetPublicKey(ctx context.Context, login string) ([]byte, error)
+	ShareData(ctx context.Context, login, id, recipient, permission string, wrappedKey []byte) error
+	ListSharedWithMe(ctx context.Context, login string) ([]models.SharedData, error)
+	RevokeShare(ctx context.Context, login, id, recipient string) error
+	CreateOrg(ctx context.Context, login, name string, wrappedKey []byte) error
+	InviteMember(ctx context.Context, login, org, invitee, role string, wrappedKey []byte) error
+	ListMembers(ctx context.Context, login, org string) ([]models.Member, error)
+	ListOrgs(ctx context.Context, login string) ([]models.Member, error)
+	MemberRole(ctx context.Context, org, login string) (string, error)
+	UserExists(ctx context.Context, login string) (bool, error)
+	RecordAudit(ctx context.Context, entry models.AuditEntry) error
+	GetAuditLog(ctx context.Context, login string, beforeSeq int64, pageSize int) ([]models.AuditEntry, error)
+	PingDB(ctx context.Context) (time.Duration, error)
+	Sync(ctx context.Context, login string, cursor int64, changes []models.SyncChange) (models.SyncResult, error)
+	StartUpload(ctx context.Context, login string, meta []byte, size, chunkSize int64) (models.File, error)
+	GetFile(ctx context.Context, login, id string) (models.File, error)
+	WriteChunk(ctx context.Context, file models.File, seq int64, chunk []byte) error
+	FinishUpload(ctx context.Context, file models.File) error
+	ReadChunks(ctx context.Context, file models.File, from int64, fn func(chunk []byte) error) error
 }
 
 // Handler manages GRPC request handling for vault service
@@ -40,7 +89,16 @@ type Handler struct {
 // Claim represents JWT token claims
 type Claim struct {
 	jwt.RegisteredClaims
-	Login string
+	Login     string
+	SessionID string
+}
+
+// challengeClaim represents the claims of the partial token handed out by Login while the second
+// factor is pending. It carries no session, so the auth interceptor never accepts it.
+type challengeClaim struct {
+	jwt.RegisteredClaims
+	Login   string
+	Purpose string
 }
 
 // New creates a new Handler instance
@@ -52,14 +110,93 @@ func New(ctx context.Context, s Service, cfg config.Config, log *slog.Logger) *H
 	}
 }
 
-// IssueJWT generates a JWT token for a user
-func (g *Handler) IssueJWT(user models.User) (string, error) {
+// WithSession returns a context carrying the authenticated user and session for the handlers
+func WithSession(ctx context.Context, login, sessionID string) context.Context {
+	ctx = context.WithValue(ctx, userIDKey, login)
+	return context.WithValue(ctx, sessionIDKey, sessionID)
+}
+
+// WithOrg returns a context selecting the organization vault the data handlers operate on,
+// along with the caller's role in it
+func WithOrg(ctx context.Context, org, role string) context.Context {
+	ctx = context.WithValue(ctx, orgKey, org)
+	return context.WithValue(ctx, roleKey, role)
+}
+
+// MemberRole returns the role of a user in an organization, empty if the user isn't a member.
+// It is used to authorize calls on organization vaults.
+func (g *Handler) MemberRole(ctx context.Context, org, login string) (string, error) {
+	role, err := g.service.MemberRole(ctx, org, login)
+	if errors.Is(err, storage.ErrMemberNotFound) {
+		return "", nil
+	}
+	return role, err
+}
+
+// authorize returns the account a data call operates on: the organization selected by the
+// caller, if the caller's role in it allows the action, or else the caller's own account
+func authorize(ctx context.Context, action string) (string, error) {
+	login, ok := ctx.Value(userIDKey).(string)
+	if !ok || len(login) == 0 {
+		return "", status.Error(codes.Unauthenticated, "User ID not found in context")
+	}
+
+	org, _ := ctx.Value(orgKey).(string)
+	if org == "" {
+		return login, nil
+	}
+
+	role, _ := ctx.Value(roleKey).(string)
+	if !models.RoleAllows(role, action) {
+		return "", status.Error(codes.PermissionDenied, "Organization role does not allow this operation")
+	}
+
+	return models.OrgAccount(org), nil
+}
+
+// caller returns the user of a call that only operates on the user's own account and rejects
+// calls made on an organization vault
+func caller(ctx context.Context) (string, error) {
+	login, ok := ctx.Value(userIDKey).(string)
+	if !ok || len(login) == 0 {
+		return "", status.Error(codes.Unauthenticated, "User ID not found in context")
+	}
+
+	if org, _ := ctx.Value(orgKey).(string); org != "" {
+		return "", status.Error(codes.InvalidArgument, "Operation is not available on organization vaults")
+	}
+
+	return login, nil
+}
+
+// SessionActive reports whether a session of the user is still live, used to reject revoked access tokens
+func (g *Handler) SessionActive(ctx context.Context, login, sessionID string) (bool, error) {
+	return g.service.SessionActive(ctx, login, sessionID)
+}
+
+// CheckDatabase reports whether the database answers, used to decide the readiness of the server
+func (g *Handler) CheckDatabase(ctx context.Context) error {
+	_, err := g.service.PingDB(ctx)
+	return err
+}
+
+// accessTokenTTL returns the configured lifetime of access tokens
+func (g *Handler) accessTokenTTL() time.Duration {
+	if g.cfg.AccessTokenTTL > 0 {
+		return g.cfg.AccessTokenTTL
+	}
+	return config.DefaultAccessTokenTTL
+}
+
+// IssueJWT generates a short-lived access token for a user's session
+func (g *Handler) IssueJWT(user models.User, sessionID string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claim{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(tokenExpiryHours * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(g.accessTokenTTL())),
 			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
 		},
-		Login: user.Login,
+		Login:     user.Login,
+		SessionID: sessionID,
 	})
 
 	signedToken, err := token.SignedString([]byte(g.cfg.JWTSecret))
@@ -69,3 +206,34 @@ func (g *Handler) IssueJWT(user models.User) (string, error) {
 
 	return signedToken, nil
 }
+
+// issueChallenge generates the partial token that lets a user who passed the password check
+// complete the login with a two-factor code
+func (g *Handler) issueChallenge(login string) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, challengeClaim{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(challengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
+		},
+		Login:   login,
+		Purpose: challengePurpose,
+	})
+
+	return token.SignedString([]byte(g.cfg.JWTSecret))
+}
+
+// parseChallenge validates a partial token and returns the login it was issued for
+func (g *Handler) parseChallenge(tokenString string) (string, bool) {
+	claims := &challengeClaim{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(g.cfg.JWTSecret), nil
+	})
+	if err != nil || !token.Valid || claims.Purpose != challengePurpose || claims.Login == "" {
+		return "", false
+	}
+
+	return claims.Login, true
+}
@@ -10,11 +10,14 @@ import (
 
 	"data-vault/server/internal/config"
 	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type MockService struct {
@@ -26,22 +29,119 @@ func (m *MockService) Register(ctx context.Context, user models.User) error {
 	return args.Error(0)
 }
 
-func (m *MockService) Login(ctx context.Context, user models.User) error {
+func (m *MockService) PreLogin(ctx context.Context, login string) ([]byte, bool, error) {
+	args := m.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).([]byte), args.Bool(1), args.Error(2)
+}
+
+func (m *MockService) Login(ctx context.Context, user models.User) ([]byte, error) {
 	args := m.Called(ctx, user)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockService) CreateSession(ctx context.Context, login, device string) (models.Session, string, error) {
+	args := m.Called(ctx, login, device)
+	return args.Get(0).(models.Session), args.String(1), args.Error(2)
+}
+
+func (m *MockService) RefreshSession(ctx context.Context, refreshToken string) (models.Session, string, error) {
+	args := m.Called(ctx, refreshToken)
+	return args.Get(0).(models.Session), args.String(1), args.Error(2)
+}
+
+func (m *MockService) SessionActive(ctx context.Context, login, id string) (bool, error) {
+	args := m.Called(ctx, login, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockService) ListSessions(ctx context.Context, login string) ([]models.Session, error) {
+	args := m.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Session), args.Error(1)
 }
 
-func (m *MockService) PostData(ctx context.Context, login, dataType string, data []byte) error {
-	args := m.Called(ctx, login, dataType, data)
+func (m *MockService) RevokeSession(ctx context.Context, login, id string) error {
+	args := m.Called(ctx, login, id)
 	return args.Error(0)
 }
 
-func (m *MockService) GetData(ctx context.Context, login string) ([]models.Data, error) {
+func (m *MockService) TOTPEnabled(ctx context.Context, login string) (bool, error) {
+	args := m.Called(ctx, login)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockService) EnableTOTP(ctx context.Context, login string) (string, string, error) {
 	args := m.Called(ctx, login)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *MockService) ConfirmTOTP(ctx context.Context, login, code string) ([]string, error) {
+	args := m.Called(ctx, login, code)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Data), args.Error(1)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockService) VerifyTOTP(ctx context.Context, login, code string) ([]byte, error) {
+	args := m.Called(ctx, login, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockService) PostData(ctx context.Context, login, dataType string, data []byte, metadata map[string]string) error {
+	args := m.Called(ctx, login, dataType, data, metadata)
+	return args.Error(0)
+}
+
+func (m *MockService) GetData(ctx context.Context, login string, query models.DataQuery) (models.DataPage, error) {
+	args := m.Called(ctx, login, query)
+	return args.Get(0).(models.DataPage), args.Error(1)
+}
+
+func (m *MockService) UpdateData(ctx context.Context, login, id, dataType string, data []byte, version int64) (int64, error) {
+	args := m.Called(ctx, login, id, dataType, data, version)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockService) Sync(ctx context.Context, login string, cursor int64, changes []models.SyncChange) (models.SyncResult, error) {
+	args := m.Called(ctx, login, cursor, changes)
+	return args.Get(0).(models.SyncResult), args.Error(1)
+}
+
+func (m *MockService) StartUpload(ctx context.Context, login string, meta []byte, size, chunkSize int64) (models.File, error) {
+	args := m.Called(ctx, login, meta, size, chunkSize)
+	return args.Get(0).(models.File), args.Error(1)
+}
+
+func (m *MockService) GetFile(ctx context.Context, login, id string) (models.File, error) {
+	args := m.Called(ctx, login, id)
+	return args.Get(0).(models.File), args.Error(1)
+}
+
+func (m *MockService) WriteChunk(ctx context.Context, file models.File, seq int64, chunk []byte) error {
+	args := m.Called(ctx, file, seq, chunk)
+	return args.Error(0)
+}
+
+func (m *MockService) FinishUpload(ctx context.Context, file models.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockService) ReadChunks(ctx context.Context, file models.File, from int64, fn func(chunk []byte) error) error {
+	args := m.Called(ctx, file, from, fn)
+	return args.Error(0)
 }
 
 func (m *MockService) DeleteData(ctx context.Context, login, id string) error {
@@ -49,6 +149,122 @@ func (m *MockService) DeleteData(ctx context.Context, login, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockService) ListVersions(ctx context.Context, login, id string) ([]models.Version, error) {
+	args := m.Called(ctx, login, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Version), args.Error(1)
+}
+
+func (m *MockService) RestoreVersion(ctx context.Context, login, id string, version int64) (int64, error) {
+	args := m.Called(ctx, login, id, version)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockService) ListTrash(ctx context.Context, login string) ([]models.Data, error) {
+	args := m.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Data), args.Error(1)
+}
+
+func (m *MockService) SetPublicKey(ctx context.Context, login string, key []byte) error {
+	args := m.Called(ctx, login, key)
+	return args.Error(0)
+}
+
+func (m *MockService) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	args := m.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockService) ShareData(ctx context.Context, login, id, recipient, permission string, wrappedKey []byte) error {
+	args := m.Called(ctx, login, id, recipient, permission, wrappedKey)
+	return args.Error(0)
+}
+
+func (m *MockService) ListSharedWithMe(ctx context.Context, login string) ([]models.SharedData, error) {
+	args := m.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SharedData), args.Error(1)
+}
+
+func (m *MockService) RevokeShare(ctx context.Context, login, id, recipient string) error {
+	args := m.Called(ctx, login, id, recipient)
+	return args.Error(0)
+}
+
+func (m *MockService) CreateOrg(ctx context.Context, login, name string, wrappedKey []byte) error {
+	args := m.Called(ctx, login, name, wrappedKey)
+	return args.Error(0)
+}
+
+func (m *MockService) InviteMember(ctx context.Context, login, org, invitee, role string, wrappedKey []byte) error {
+	args := m.Called(ctx, login, org, invitee, role, wrappedKey)
+	return args.Error(0)
+}
+
+func (m *MockService) ListMembers(ctx context.Context, login, org string) ([]models.Member, error) {
+	args := m.Called(ctx, login, org)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Member), args.Error(1)
+}
+
+func (m *MockService) ListOrgs(ctx context.Context, login string) ([]models.Member, error) {
+	args := m.Called(ctx, login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Member), args.Error(1)
+}
+
+func (m *MockService) MemberRole(ctx context.Context, org, login string) (string, error) {
+	args := m.Called(ctx, org, login)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockService) UserExists(ctx context.Context, login string) (bool, error) {
+	args := m.Called(ctx, login)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockService) RecordAudit(ctx context.Context, entry models.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockService) GetAuditLog(ctx context.Context, login string, beforeSeq int64, pageSize int) ([]models.AuditEntry, error) {
+	args := m.Called(ctx, login, beforeSeq, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AuditEntry), args.Error(1)
+}
+
+func (m *MockService) PingDB(ctx context.Context) (time.Duration, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *MockService) RestoreFromTrash(ctx context.Context, login, id string) (int64, error) {
+	args := m.Called(ctx, login, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockService) EmptyTrash(ctx context.Context, login string) (int64, error) {
+	args := m.Called(ctx, login)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func setupTestHandler() (*Handler, *MockService) {
 	mockService := &MockService{}
 	cfg := config.Config{
@@ -75,6 +291,7 @@ func createTestUser(login, password string) models.User {
 	return models.User{
 		Login:    login,
 		Password: password,
+		Salt:     []byte("test-kdf-salt-16"),
 	}
 }
 
@@ -97,6 +314,17 @@ func createContextWithUser(userID string) context.Context {
 	return context.WithValue(context.Background(), userIDKey, userID)
 }
 
+func testSession(login string) models.Session {
+	return models.Session{
+		ID:         "session123",
+		User:       login,
+		Device:     "laptop",
+		CreatedAt:  "2026-01-01T00:00:00Z",
+		LastUsedAt: "2026-01-02T00:00:00Z",
+		ExpiresAt:  "2026-02-01T00:00:00Z",
+	}
+}
+
 func validateJWTToken(t *testing.T, tokenString, secret, expectedLogin string) *Claim {
 	token, err := jwt.ParseWithClaims(tokenString, &Claim{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(secret), nil
@@ -136,11 +364,12 @@ func TestJWTIssuance(t *testing.T) {
 
 	user := createTestUser("testuser", "testpass")
 
-	token, err := handler.IssueJWT(user)
+	token, err := handler.IssueJWT(user, "session123")
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 
-	validateJWTToken(t, token, handler.cfg.JWTSecret, user.Login)
+	claims := validateJWTToken(t, token, handler.cfg.JWTSecret, user.Login)
+	assert.Equal(t, "session123", claims.SessionID)
 }
 
 func TestJWTIssuance_EmptySecret(t *testing.T) {
@@ -154,7 +383,7 @@ func TestJWTIssuance_EmptySecret(t *testing.T) {
 
 	user := createTestUser("testuser", "testpass")
 
-	token, err := handler.IssueJWT(user)
+	token, err := handler.IssueJWT(user, "session123")
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 }
@@ -164,7 +393,7 @@ func TestJWTIssuance_SpecialCharacters(t *testing.T) {
 
 	user := createTestUser("test@user.com", "test-pass-123")
 
-	token, err := handler.IssueJWT(user)
+	token, err := handler.IssueJWT(user, "session123")
 	require.NoError(t, err)
 	assert.NotEmpty(t, token)
 
@@ -196,8 +425,66 @@ func TestContextKeyType(t *testing.T) {
 }
 
 func TestConstants(t *testing.T) {
-	assert.Equal(t, 24, tokenExpiryHours)
 	assert.Equal(t, contextKey("user_id"), userIDKey)
+	assert.Equal(t, contextKey("session_id"), sessionIDKey)
+	assert.Equal(t, contextKey("org"), orgKey)
+	assert.Equal(t, contextKey("role"), roleKey)
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name            string
+		userID          interface{}
+		org             string
+		role            string
+		action          string
+		expectedAccount string
+		expectedCode    codes.Code
+	}{
+		{name: "own vault", userID: "testuser", action: models.ActionManage, expectedAccount: "testuser"},
+		{name: "owner manages", userID: "testuser", org: "acme", role: models.RoleOwner, action: models.ActionManage, expectedAccount: "org:acme"},
+		{name: "admin manages", userID: "testuser", org: "acme", role: models.RoleAdmin, action: models.ActionManage, expectedAccount: "org:acme"},
+		{name: "member writes", userID: "testuser", org: "acme", role: models.RoleMember, action: models.ActionWrite, expectedAccount: "org:acme"},
+		{name: "member can't manage", userID: "testuser", org: "acme", role: models.RoleMember, action: models.ActionManage, expectedCode: codes.PermissionDenied},
+		{name: "read-only reads", userID: "testuser", org: "acme", role: models.RoleReadOnly, action: models.ActionRead, expectedAccount: "org:acme"},
+		{name: "read-only can't write", userID: "testuser", org: "acme", role: models.RoleReadOnly, action: models.ActionWrite, expectedCode: codes.PermissionDenied},
+		{name: "unknown role", userID: "testuser", org: "acme", role: "guest", action: models.ActionRead, expectedCode: codes.PermissionDenied},
+		{name: "missing user ID", userID: nil, action: models.ActionRead, expectedCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.userID != nil {
+				ctx = context.WithValue(ctx, userIDKey, tt.userID)
+			}
+			if tt.org != "" {
+				ctx = WithOrg(ctx, tt.org, tt.role)
+			}
+
+			account, err := authorize(ctx, tt.action)
+			if tt.expectedCode != codes.OK {
+				require.Error(t, err)
+				assert.Equal(t, tt.expectedCode, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAccount, account)
+		})
+	}
+}
+
+func TestCaller(t *testing.T) {
+	login, err := caller(createContextWithUser("testuser"))
+	require.NoError(t, err)
+	assert.Equal(t, "testuser", login)
+
+	_, err = caller(WithOrg(createContextWithUser("testuser"), "acme", models.RoleOwner))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = caller(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
 }
 
 func testUserValid() models.User {
@@ -222,4 +509,86 @@ func testDataLarge() []models.Data {
 
 func testDataEmpty() []models.Data {
 	return []models.Data{}
-}
\ No newline at end of file
+}
+
+func largeMetadata() map[string]string {
+	metadata := make(map[string]string, maxMetadataEntries+1)
+	for i := 0; i <= maxMetadataEntries; i++ {
+		metadata[fmt.Sprintf("key%d", i)] = "value"
+	}
+	return metadata
+}
+
+func TestChallengeToken(t *testing.T) {
+	handler, _ := setupTestHandler()
+
+	challenge, err := handler.issueChallenge("testuser")
+	require.NoError(t, err)
+
+	login, ok := handler.parseChallenge(challenge)
+	assert.True(t, ok)
+	assert.Equal(t, "testuser", login)
+
+	accessToken, err := handler.IssueJWT(createTestUser("testuser", "testpass"), "session123")
+	require.NoError(t, err)
+	_, ok = handler.parseChallenge(accessToken)
+	assert.False(t, ok, "Access tokens must not pass as challenge tokens")
+
+	other, _ := setupTestHandlerWithConfig(config.Config{JWTSecret: "another-secret"})
+	_, ok = other.parseChallenge(challenge)
+	assert.False(t, ok, "Challenge signed with another secret must be rejected")
+
+	_, ok = handler.parseChallenge("not.a.token")
+	assert.False(t, ok)
+}
+
+func TestAuditSubject(t *testing.T) {
+	handler, _ := setupTestHandler()
+
+	challenge, err := handler.issueChallenge("challenged")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      any
+		expected string
+	}{
+		{
+			name:     "authenticated caller",
+			ctx:      context.WithValue(context.Background(), userIDKey, "testuser"),
+			req:      &proto.GetDataRequest{},
+			expected: "testuser",
+		},
+		{
+			name:     "login attempt",
+			ctx:      context.Background(),
+			req:      &proto.LoginRequest{User: &proto.User{Login: "testuser", Password: "wrong"}},
+			expected: "testuser",
+		},
+		{
+			name:     "second factor with valid challenge",
+			ctx:      context.Background(),
+			req:      &proto.VerifyTOTPRequest{ChallengeToken: challenge, Code: "123456"},
+			expected: "challenged",
+		},
+		{
+			name:     "second factor with forged challenge",
+			ctx:      context.Background(),
+			req:      &proto.VerifyTOTPRequest{ChallengeToken: "not.a.token", Code: "123456"},
+			expected: "",
+		},
+		{
+			name:     "anonymous call",
+			ctx:      context.Background(),
+			req:      &proto.RegisterRequest{User: &proto.User{Login: "newuser", Password: "pass"}},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, handler.AuditSubject(tt.ctx, tt.req))
+		})
+	}
+}
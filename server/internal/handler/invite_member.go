@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InviteMember handles requests to add a user to an organization or change a member's role
+func (g *Handler) InviteMember(ctx context.Context, in *proto.InviteMemberRequest) (*proto.InviteMemberResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Org) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Organization not provided")
+	}
+
+	if len(in.Login) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Login not provided")
+	}
+
+	if !models.ValidRole(in.Role) {
+		return nil, status.Error(codes.InvalidArgument, "Role must be owner, admin, member or read-only")
+	}
+
+	if len(in.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Wrapped key not provided")
+	}
+
+	err = g.service.InviteMember(ctx, login, in.Org, in.Login, in.Role, in.WrappedKey)
+	if err != nil {
+		if errors.Is(err, service.ErrSelfRole) {
+			return nil, status.Error(codes.InvalidArgument, "You can't change your own role")
+		}
+		if errors.Is(err, service.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, "Organization role does not allow this operation")
+		}
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "User not found")
+		}
+		if errors.Is(err, storage.ErrNoPublicKey) {
+			return nil, status.Error(codes.FailedPrecondition, "User has not published a public key yet")
+		}
+		return nil, status.Error(codes.Internal, "Failed to invite member")
+	}
+
+	return &proto.InviteMemberResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInviteMember(t *testing.T) {
+	wrapped := []byte("wrapped org key")
+
+	tests := []struct {
+		name         string
+		request      *proto.InviteMemberRequest
+		userID       interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success member",
+			request:    &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "member", WrappedKey: wrapped},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:       "success read-only",
+			request:    &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "read-only", WrappedKey: wrapped},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "role does not allow inviting",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "member", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    service.ErrForbidden,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "Organization role does not allow this operation",
+		},
+		{
+			name:         "invitee not found",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "nobody", Role: "member", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    storage.ErrUserNotFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "User not found",
+		},
+		{
+			name:         "invitee without public key",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "member", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    storage.ErrNoPublicKey,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "public key",
+		},
+		{
+			name:         "change own role",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "testuser", Role: "member", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    service.ErrSelfRole,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "You can't change your own role",
+		},
+		{
+			name:         "service error",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "member", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to invite member",
+		},
+		{
+			name:         "empty organization",
+			request:      &proto.InviteMemberRequest{Login: "alice", Role: "member", WrappedKey: wrapped},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Organization not provided",
+		},
+		{
+			name:         "empty login",
+			request:      &proto.InviteMemberRequest{Org: "acme", Role: "member", WrappedKey: wrapped},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Login not provided",
+		},
+		{
+			name:         "unknown role",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "write", WrappedKey: wrapped},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Role must be owner, admin, member or read-only",
+		},
+		{
+			name:         "empty wrapped key",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "member"},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Wrapped key not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.InviteMemberRequest{Org: "acme", Login: "alice", Role: "member", WrappedKey: wrapped},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("InviteMember", mock.Anything, "testuser", tt.request.Org, tt.request.Login, tt.request.Role, tt.request.WrappedKey).
+					Return(tt.mockError)
+			}
+
+			response, err := handler.InviteMember(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListMembers handles requests for the members of an organization the user belongs to
+func (g *Handler) ListMembers(ctx context.Context, in *proto.ListMembersRequest) (*proto.ListMembersResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Org) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Organization not provided")
+	}
+
+	members, err := g.service.ListMembers(ctx, login, in.Org)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			return nil, status.Error(codes.PermissionDenied, "Organization role does not allow this operation")
+		}
+		return nil, status.Error(codes.Internal, "Failed to list members")
+	}
+
+	response := &proto.ListMembersResponse{
+		Members: make([]*proto.Member, 0, len(members)),
+	}
+	for _, m := range members {
+		response.Members = append(response.Members, memberToProto(m))
+	}
+
+	return response, nil
+}
+
+// memberToProto converts an organization membership into its protobuf representation
+func memberToProto(m models.Member) *proto.Member {
+	return &proto.Member{
+		Org:        m.Org,
+		Login:      m.Login,
+		Role:       m.Role,
+		WrappedKey: m.WrappedKey,
+		JoinedAt:   m.JoinedAt,
+	}
+}
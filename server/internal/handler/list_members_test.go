@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListMembers(t *testing.T) {
+	members := []models.Member{
+		{Org: "acme", Login: "testuser", Role: "owner", WrappedKey: []byte("wrapped"), JoinedAt: "2024-01-01T10:00:00Z"},
+		{Org: "acme", Login: "alice", Role: "read-only", JoinedAt: "2024-01-02T10:00:00Z"},
+	}
+
+	tests := []struct {
+		name          string
+		request       *proto.ListMembersRequest
+		userID        interface{}
+		mockMembers   []models.Member
+		mockError     error
+		expectMock    bool
+		expectError   bool
+		expectedCode  codes.Code
+		expectedMsg   string
+		expectedCount int
+	}{
+		{
+			name:          "success",
+			request:       &proto.ListMembersRequest{Org: "acme"},
+			userID:        "testuser",
+			mockMembers:   members,
+			expectMock:    true,
+			expectedCount: 2,
+		},
+		{
+			name:         "not a member",
+			request:      &proto.ListMembersRequest{Org: "acme"},
+			userID:       "testuser",
+			mockError:    service.ErrForbidden,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "Organization role does not allow this operation",
+		},
+		{
+			name:         "service error",
+			request:      &proto.ListMembersRequest{Org: "acme"},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to list members",
+		},
+		{
+			name:         "empty organization",
+			request:      &proto.ListMembersRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Organization not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.ListMembersRequest{Org: "acme"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("ListMembers", mock.Anything, "testuser", tt.request.Org).
+					Return(tt.mockMembers, tt.mockError)
+			}
+
+			response, err := handler.ListMembers(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.Len(t, response.Members, tt.expectedCount)
+				assert.Equal(t, "testuser", response.Members[0].Login)
+				assert.Equal(t, "owner", response.Members[0].Role)
+				assert.Equal(t, "read-only", response.Members[1].Role)
+				assert.Empty(t, response.Members[1].WrappedKey)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
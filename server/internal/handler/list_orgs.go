@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListOrgs handles requests for the organizations the user belongs to
+func (g *Handler) ListOrgs(ctx context.Context, in *proto.ListOrgsRequest) (*proto.ListOrgsResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := g.service.ListOrgs(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to list organizations")
+	}
+
+	response := &proto.ListOrgsResponse{
+		Memberships: make([]*proto.Member, 0, len(memberships)),
+	}
+	for _, m := range memberships {
+		response.Memberships = append(response.Memberships, memberToProto(m))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListOrgs(t *testing.T) {
+	memberships := []models.Member{
+		{Org: "acme", Login: "testuser", Role: "admin", WrappedKey: []byte("wrapped acme key"), JoinedAt: "2024-01-01T10:00:00Z"},
+		{Org: "globex", Login: "testuser", Role: "read-only", WrappedKey: []byte("wrapped globex key"), JoinedAt: "2024-01-02T10:00:00Z"},
+	}
+
+	tests := []struct {
+		name            string
+		userID          interface{}
+		mockMemberships []models.Member
+		mockError       error
+		expectMock      bool
+		expectError     bool
+		expectedCode    codes.Code
+		expectedMsg     string
+		expectedCount   int
+	}{
+		{
+			name:            "success",
+			userID:          "testuser",
+			mockMemberships: memberships,
+			expectMock:      true,
+			expectedCount:   2,
+		},
+		{
+			name:            "no organizations",
+			userID:          "testuser",
+			mockMemberships: []models.Member{},
+			expectMock:      true,
+			expectedCount:   0,
+		},
+		{
+			name:         "service error",
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to list organizations",
+		},
+		{
+			name:         "missing user ID in context",
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("ListOrgs", mock.Anything, "testuser").
+					Return(tt.mockMemberships, tt.mockError)
+			}
+
+			response, err := handler.ListOrgs(ctx, &proto.ListOrgsRequest{})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.Len(t, response.Memberships, tt.expectedCount)
+				for i, m := range response.Memberships {
+					assert.Equal(t, tt.mockMemberships[i].Org, m.Org)
+					assert.Equal(t, tt.mockMemberships[i].Role, m.Role)
+					assert.Equal(t, tt.mockMemberships[i].WrappedKey, m.WrappedKey)
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSessions handles requests for the live sessions of a user
+func (g *Handler) ListSessions(ctx context.Context, in *proto.ListSessionsRequest) (*proto.ListSessionsResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, _ := ctx.Value(sessionIDKey).(string)
+
+	sessions, err := g.service.ListSessions(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to list sessions")
+	}
+
+	response := &proto.ListSessionsResponse{
+		Sessions: make([]*proto.Session, 0, len(sessions)),
+	}
+	for _, s := range sessions {
+		response.Sessions = append(response.Sessions, &proto.Session{
+			Id:         s.ID,
+			Device:     s.Device,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == current,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListSessions(t *testing.T) {
+	other := testSession("testuser")
+	other.ID = "session456"
+	other.Device = "phone"
+
+	tests := []struct {
+		name          string
+		userID        interface{}
+		mockSessions  []models.Session
+		mockError     error
+		expectMock    bool
+		expectError   bool
+		expectedCode  codes.Code
+		expectedMsg   string
+		expectedCount int
+	}{
+		{
+			name:          "success",
+			userID:        "testuser",
+			mockSessions:  []models.Session{testSession("testuser"), other},
+			expectMock:    true,
+			expectedCount: 2,
+		},
+		{
+			name:          "no sessions",
+			userID:        "testuser",
+			mockSessions:  []models.Session{},
+			expectMock:    true,
+			expectedCount: 0,
+		},
+		{
+			name:         "service error",
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to list sessions",
+		},
+		{
+			name:         "missing user ID in context",
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			ctx := context.Background()
+			if tt.userID != nil {
+				ctx = context.WithValue(ctx, userIDKey, tt.userID)
+				ctx = context.WithValue(ctx, sessionIDKey, "session123")
+			}
+
+			if tt.expectMock {
+				if tt.mockError != nil {
+					mockService.On("ListSessions", mock.Anything, "testuser").Return(nil, tt.mockError)
+				} else {
+					mockService.On("ListSessions", mock.Anything, "testuser").Return(tt.mockSessions, nil)
+				}
+			}
+
+			response, err := handler.ListSessions(ctx, &proto.ListSessionsRequest{})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.Len(t, response.Sessions, tt.expectedCount)
+				for i, s := range response.Sessions {
+					assert.Equal(t, tt.mockSessions[i].ID, s.Id)
+					assert.Equal(t, tt.mockSessions[i].Device, s.Device)
+					assert.Equal(t, tt.mockSessions[i].ExpiresAt, s.ExpiresAt)
+					assert.Equal(t, s.Id == "session123", s.Current)
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListSharedWithMe handles requests for the data entries other users shared with the user
+func (g *Handler) ListSharedWithMe(ctx context.Context, in *proto.ListSharedWithMeRequest) (*proto.ListSharedWithMeResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := g.service.ListSharedWithMe(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to list shared data")
+	}
+
+	response := &proto.ListSharedWithMeResponse{
+		Shares: make([]*proto.SharedData, 0, len(shared)),
+	}
+	for _, sd := range shared {
+		response.Shares = append(response.Shares, &proto.SharedData{
+			Data:       dataToProto(sd.Data),
+			Owner:      sd.Share.Owner,
+			Permission: sd.Share.Permission,
+			WrappedKey: sd.Share.WrappedKey,
+			SharedAt:   sd.Share.SharedAt,
+		})
+	}
+
+	return response, nil
+}
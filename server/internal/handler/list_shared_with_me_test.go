@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListSharedWithMe(t *testing.T) {
+	shared := make([]models.SharedData, 0)
+	for _, d := range testDataSample() {
+		shared = append(shared, models.SharedData{
+			Data: d,
+			Share: models.Share{
+				DataID:     d.ID,
+				Owner:      d.User,
+				Recipient:  "testuser",
+				Permission: models.PermissionRead,
+				WrappedKey: []byte("wrapped-" + d.ID),
+				SharedAt:   "2026-01-01T00:00:00Z",
+			},
+		})
+	}
+
+	tests := []struct {
+		name         string
+		userID       interface{}
+		mockShared   []models.SharedData
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			userID:     "testuser",
+			mockShared: shared,
+			expectMock: true,
+		},
+		{
+			name:       "nothing shared",
+			userID:     "testuser",
+			mockShared: []models.SharedData{},
+			expectMock: true,
+		},
+		{
+			name:         "service error",
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to list shared data",
+		},
+		{
+			name:         "missing user ID in context",
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("ListSharedWithMe", mock.Anything, "testuser").Return(tt.mockShared, tt.mockError)
+			}
+
+			response, err := handler.ListSharedWithMe(ctx, &proto.ListSharedWithMeRequest{})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.Len(t, response.Shares, len(tt.mockShared))
+				for i, sd := range tt.mockShared {
+					assert.Equal(t, sd.Data.ID, response.Shares[i].Data.Id)
+					assert.Equal(t, sd.Data.Data, response.Shares[i].Data.Data)
+					assert.Equal(t, sd.Share.Owner, response.Shares[i].Owner)
+					assert.Equal(t, sd.Share.Permission, response.Shares[i].Permission)
+					assert.Equal(t, sd.Share.WrappedKey, response.Shares[i].WrappedKey)
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTrash handles requests for the trashed data entries of a user
+func (g *Handler) ListTrash(ctx context.Context, in *proto.ListTrashRequest) (*proto.ListTrashResponse, error) {
+	login, err := authorize(ctx, models.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := g.service.ListTrash(ctx, login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to list trash")
+	}
+
+	response := &proto.ListTrashResponse{
+		Data: make([]*proto.Data, 0, len(data)),
+	}
+	for _, d := range data {
+		response.Data = append(response.Data, dataToProto(d))
+	}
+
+	return response, nil
+}
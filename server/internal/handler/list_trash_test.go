@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListTrash(t *testing.T) {
+	trashed := testDataSample()
+	for i := range trashed {
+		trashed[i].Status = models.StatusDeleted
+		trashed[i].DeletedAt = "2026-01-01T00:00:00Z"
+	}
+
+	tests := []struct {
+		name         string
+		userID       interface{}
+		mockData     []models.Data
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			userID:     "testuser",
+			mockData:   trashed,
+			expectMock: true,
+		},
+		{
+			name:       "empty trash",
+			userID:     "testuser",
+			mockData:   testDataEmpty(),
+			expectMock: true,
+		},
+		{
+			name:         "service error",
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to list trash",
+		},
+		{
+			name:         "missing user ID in context",
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("ListTrash", mock.Anything, "testuser").Return(tt.mockData, tt.mockError)
+			}
+
+			response, err := handler.ListTrash(ctx, &proto.ListTrashRequest{})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.Len(t, response.Data, len(tt.mockData))
+				for i, d := range tt.mockData {
+					assert.Equal(t, d.ID, response.Data[i].Id)
+					assert.Equal(t, models.StatusDeleted, response.Data[i].Status)
+					assert.Equal(t, d.DeletedAt, response.Data[i].DeletedAt)
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
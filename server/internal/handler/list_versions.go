@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListVersions handles requests for the prior versions of a data entry
+func (g *Handler) ListVersions(ctx context.Context, in *proto.ListVersionsRequest) (*proto.ListVersionsResponse, error) {
+	login, err := authorize(ctx, models.ActionRead)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data ID not provided")
+	}
+
+	versions, err := g.service.ListVersions(ctx, login, in.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to list versions")
+	}
+
+	response := &proto.ListVersionsResponse{
+		Versions: make([]*proto.Version, 0, len(versions)),
+	}
+	for _, v := range versions {
+		response.Versions = append(response.Versions, versionToProto(v))
+	}
+
+	return response, nil
+}
+
+// versionToProto converts a prior version of a data entry into its protobuf representation
+func versionToProto(v models.Version) *proto.Version {
+	return &proto.Version{
+		DataId:     v.DataID,
+		Version:    v.Version,
+		Type:       v.Type,
+		Data:       v.Data,
+		Metadata:   v.Metadata,
+		UploadedAt: v.UploadedAt,
+		ArchivedAt: v.ArchivedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListVersions(t *testing.T) {
+	versions := []models.Version{
+		{DataID: "data123", Version: 2, Type: "text", Data: []byte("second"), ArchivedAt: "2026-01-02T00:00:00Z"},
+		{DataID: "data123", Version: 1, Type: "text", Data: []byte("first"), Metadata: map[string]string{"tag": "old"}, ArchivedAt: "2026-01-01T00:00:00Z"},
+	}
+
+	tests := []struct {
+		name         string
+		request      *proto.ListVersionsRequest
+		userID       interface{}
+		mockVersions []models.Version
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "success",
+			request:      &proto.ListVersionsRequest{Id: "data123"},
+			userID:       "testuser",
+			mockVersions: versions,
+			expectMock:   true,
+		},
+		{
+			name:         "no history",
+			request:      &proto.ListVersionsRequest{Id: "data123"},
+			userID:       "testuser",
+			mockVersions: []models.Version{},
+			expectMock:   true,
+		},
+		{
+			name:         "service error",
+			request:      &proto.ListVersionsRequest{Id: "data123"},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to list versions",
+		},
+		{
+			name:         "empty data ID",
+			request:      &proto.ListVersionsRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data ID not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.ListVersionsRequest{Id: "data123"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("ListVersions", mock.Anything, "testuser", tt.request.Id).
+					Return(tt.mockVersions, tt.mockError)
+			}
+
+			response, err := handler.ListVersions(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				require.Len(t, response.Versions, len(tt.mockVersions))
+				for i, v := range tt.mockVersions {
+					assert.Equal(t, v.Version, response.Versions[i].Version)
+					assert.Equal(t, v.Data, response.Versions[i].Data)
+					assert.Equal(t, v.Metadata, response.Versions[i].Metadata)
+					assert.Equal(t, v.ArchivedAt, response.Versions[i].ArchivedAt)
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
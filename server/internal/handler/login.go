@@ -11,20 +11,22 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// Login handles user authentication requests
+// Login handles user authentication requests. Users with two-factor authentication get a
+// challenge token instead of a session, to be completed with VerifyTOTP.
 func (g *Handler) Login(ctx context.Context, in *proto.LoginRequest) (*proto.LoginResponse, error) {
 	var response *proto.LoginResponse
 
 	user := models.User{
 		Login:    in.User.Login,
 		Password: in.User.Password,
+		AuthKey:  in.AuthKey,
 	}
 
 	if len(user.Login) == 0 || len(user.Password) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "User ID or Password not provided")
 	}
 
-	err := g.service.Login(ctx, user)
+	salt, err := g.service.Login(ctx, user)
 	if err != nil {
 		if errors.Is(err, storage.ErrWrongPassword) {
 			return nil, status.Error(codes.Unauthenticated, "Wrong password")
@@ -32,14 +34,39 @@ func (g *Handler) Login(ctx context.Context, in *proto.LoginRequest) (*proto.Log
 		return nil, status.Error(codes.Internal, "Failed to login user")
 	}
 
-	jwtToken, err := g.IssueJWT(user)
+	totpEnabled, err := g.service.TOTPEnabled(ctx, user.Login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to login user")
+	}
+
+	if totpEnabled {
+		challenge, err := g.issueChallenge(user.Login)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "Failed to issue challenge token")
+		}
+
+		return &proto.LoginResponse{
+			Success:        true,
+			TotpRequired:   true,
+			ChallengeToken: challenge,
+		}, nil
+	}
+
+	session, refreshToken, err := g.service.CreateSession(ctx, user.Login, in.Device)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to create session")
+	}
+
+	jwtToken, err := g.IssueJWT(user, session.ID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Failed to issue JWT token")
 	}
 
 	response = &proto.LoginResponse{
-		Success:  true,
-		JwtToken: jwtToken,
+		Success:      true,
+		JwtToken:     jwtToken,
+		KdfSalt:      salt,
+		RefreshToken: refreshToken,
 	}
 
 	return response, nil
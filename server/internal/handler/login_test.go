@@ -21,21 +21,37 @@ func TestLogin(t *testing.T) {
 	tests := []struct {
 		name          string
 		user          models.User
+		mockSalt      []byte
 		mockError     error
+		totpEnabled   bool
+		totpError     error
+		expectSession bool
+		sessionError  error
 		expectError   bool
 		expectedCode  codes.Code
 		expectedMsg   string
 		expectSuccess bool
 		expectJWT     bool
+		expectTOTP    bool
 	}{
 		{
 			name:          "success",
 			user:          models.User{Login: "testuser", Password: "testpass123"},
+			mockSalt:      []byte("test-kdf-salt-16"),
 			mockError:     nil,
+			expectSession: true,
 			expectError:   false,
 			expectSuccess: true,
 			expectJWT:     true,
 		},
+		{
+			name:          "switch to derived authentication key",
+			user:          models.User{Login: "testuser", Password: "testpass123", AuthKey: "derived-auth-key"},
+			mockSalt:      []byte("test-kdf-salt-16"),
+			expectSession: true,
+			expectSuccess: true,
+			expectJWT:     true,
+		},
 		{
 			name:         "empty login",
 			user:         models.User{Login: "", Password: "testpass123"},
@@ -66,6 +82,34 @@ func TestLogin(t *testing.T) {
 			expectedCode: codes.Internal,
 			expectedMsg:  "Failed to login user",
 		},
+		{
+			name:          "two-factor challenge",
+			user:          models.User{Login: "testuser", Password: "testpass123"},
+			mockSalt:      []byte("test-kdf-salt-16"),
+			totpEnabled:   true,
+			expectError:   false,
+			expectSuccess: true,
+			expectTOTP:    true,
+		},
+		{
+			name:         "two-factor check error",
+			user:         models.User{Login: "testuser", Password: "testpass123"},
+			mockSalt:     []byte("test-kdf-salt-16"),
+			totpError:    errors.New("database error"),
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to login user",
+		},
+		{
+			name:          "session error",
+			user:          models.User{Login: "testuser", Password: "testpass123"},
+			mockSalt:      []byte("test-kdf-salt-16"),
+			expectSession: true,
+			sessionError:  errors.New("database error"),
+			expectError:   true,
+			expectedCode:  codes.Internal,
+			expectedMsg:   "Failed to create session",
+		},
 	}
 
 	for _, tt := range tests {
@@ -77,10 +121,19 @@ func TestLogin(t *testing.T) {
 					Login:    tt.user.Login,
 					Password: tt.user.Password,
 				},
+				Device:  "laptop",
+				AuthKey: tt.user.AuthKey,
 			}
 
 			if tt.user.Login != "" && tt.user.Password != "" {
-				mockService.On("Login", mock.Anything, tt.user).Return(tt.mockError)
+				mockService.On("Login", mock.Anything, tt.user).Return(tt.mockSalt, tt.mockError)
+				if tt.mockError == nil {
+					mockService.On("TOTPEnabled", mock.Anything, tt.user.Login).Return(tt.totpEnabled, tt.totpError)
+				}
+			}
+			if tt.expectSession {
+				mockService.On("CreateSession", mock.Anything, tt.user.Login, "laptop").
+					Return(testSession(tt.user.Login), "session123.refresh", tt.sessionError)
 			}
 
 			response, err := handler.Login(context.Background(), request)
@@ -92,10 +145,23 @@ func TestLogin(t *testing.T) {
 				assert.Equal(t, tt.expectedCode, st.Code())
 				assert.Contains(t, st.Message(), tt.expectedMsg)
 				assert.Nil(t, response)
+			} else if tt.expectTOTP {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.Equal(t, tt.expectSuccess, response.Success)
+				assert.True(t, response.TotpRequired)
+				assert.Empty(t, response.JwtToken, "No session before the second factor")
+				assert.Empty(t, response.KdfSalt)
+				login, ok := handler.parseChallenge(response.ChallengeToken)
+				assert.True(t, ok)
+				assert.Equal(t, tt.user.Login, login)
 			} else {
 				require.NoError(t, err)
 				require.NotNil(t, response)
 				assert.Equal(t, tt.expectSuccess, response.Success)
+				assert.False(t, response.TotpRequired)
+				assert.Equal(t, tt.mockSalt, response.KdfSalt)
+				assert.Equal(t, "session123.refresh", response.RefreshToken)
 				if tt.expectJWT {
 					assert.NotEmpty(t, response.JwtToken)
 					token, err := jwt.ParseWithClaims(response.JwtToken, &Claim{}, func(token *jwt.Token) (interface{}, error) {
@@ -107,6 +173,7 @@ func TestLogin(t *testing.T) {
 					claims, ok := token.Claims.(*Claim)
 					require.True(t, ok)
 					assert.Equal(t, tt.user.Login, claims.Login)
+					assert.Equal(t, "session123", claims.SessionID)
 				}
 			}
 
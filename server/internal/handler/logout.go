@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Logout handles requests to revoke the caller's own session
+func (g *Handler) Logout(ctx context.Context, in *proto.LogoutRequest) (*proto.LogoutResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, ok := ctx.Value(sessionIDKey).(string)
+	if !ok || len(sessionID) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "Session not found in context")
+	}
+
+	err = g.service.RevokeSession(ctx, login, sessionID)
+	if err != nil && !errors.Is(err, storage.ErrSessionNotFound) {
+		return nil, status.Error(codes.Internal, "Failed to logout")
+	}
+
+	return &proto.LogoutResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLogout(t *testing.T) {
+	tests := []struct {
+		name         string
+		userID       interface{}
+		sessionID    interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			userID:     "testuser",
+			sessionID:  "session123",
+			expectMock: true,
+		},
+		{
+			name:       "session already revoked",
+			userID:     "testuser",
+			sessionID:  "session123",
+			mockError:  storage.ErrSessionNotFound,
+			expectMock: true,
+		},
+		{
+			name:         "service error",
+			userID:       "testuser",
+			sessionID:    "session123",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to logout",
+		},
+		{
+			name:         "missing session in context",
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "Session not found in context",
+		},
+		{
+			name:         "missing user ID in context",
+			sessionID:    "session123",
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			ctx := context.Background()
+			if tt.userID != nil {
+				ctx = context.WithValue(ctx, userIDKey, tt.userID)
+			}
+			if tt.sessionID != nil {
+				ctx = context.WithValue(ctx, sessionIDKey, tt.sessionID)
+			}
+
+			if tt.expectMock {
+				mockService.On("RevokeSession", mock.Anything, "testuser", "session123").Return(tt.mockError)
+			}
+
+			response, err := handler.Logout(ctx, &proto.LogoutRequest{})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
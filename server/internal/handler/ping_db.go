@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+)
+
+// PingDB handles checks of the server and its database, made without authentication. A server
+// that can't reach its database answers with success unset.
+func (g *Handler) PingDB(ctx context.Context, in *proto.PingDBRequest) (*proto.PingDBResponse, error) {
+	latency, err := g.service.PingDB(ctx)
+	if err != nil {
+		g.log.Error("Database is unreachable", "error", err)
+		return &proto.PingDBResponse{Version: g.cfg.Version}, nil
+	}
+
+	return &proto.PingDBResponse{
+		Success:     true,
+		Version:     g.cfg.Version,
+		DbLatencyUs: latency.Microseconds(),
+	}, nil
+}
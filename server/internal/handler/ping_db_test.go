@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPingDB(t *testing.T) {
+	tests := []struct {
+		name            string
+		mockLatency     time.Duration
+		mockError       error
+		expectedSuccess bool
+		expectedLatency int64
+	}{
+		{
+			name:            "database reachable",
+			mockLatency:     1500 * time.Microsecond,
+			expectedSuccess: true,
+			expectedLatency: 1500,
+		},
+		{
+			name:            "database unreachable",
+			mockError:       errors.New("connection refused"),
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandlerWithConfig(config.Config{JWTSecret: "test-jwt-secret-key-for-testing", Version: "1.2.3"})
+
+			mockService.On("PingDB", mock.Anything).Return(tt.mockLatency, tt.mockError)
+
+			response, err := handler.PingDB(context.Background(), &proto.PingDBRequest{})
+
+			require.NoError(t, err)
+			require.NotNil(t, response)
+			assert.Equal(t, tt.expectedSuccess, response.Success)
+			assert.Equal(t, "1.2.3", response.Version)
+			assert.Equal(t, tt.expectedLatency, response.DbLatencyUs)
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
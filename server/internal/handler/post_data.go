@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"data-vault/server/internal/models"
 	"data-vault/server/internal/proto"
 
 	"google.golang.org/grpc/codes"
@@ -18,12 +19,20 @@ func (g *Handler) PostData(ctx context.Context, in *proto.PostDataRequest) (*pro
 		return nil, status.Error(codes.InvalidArgument, "Data not provided")
 	}
 
-	login, ok := ctx.Value(userIDKey).(string)
-	if !ok || len(login) == 0 {
-		return nil, status.Error(codes.Unauthenticated, "User ID not found in context")
+	if !models.ValidDataType(dataType) {
+		return nil, status.Error(codes.InvalidArgument, "Unknown data type")
 	}
 
-	err := g.service.PostData(ctx, login, dataType, data)
+	if !validMetadata(in.Metadata) {
+		return nil, status.Error(codes.InvalidArgument, "Metadata is malformed")
+	}
+
+	login, err := authorize(ctx, models.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.service.PostData(ctx, login, dataType, data, in.Metadata)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Failed to post data")
 	}
@@ -34,3 +43,17 @@ func (g *Handler) PostData(ctx context.Context, in *proto.PostDataRequest) (*pro
 
 	return response, nil
 }
+
+// validMetadata reports whether entry metadata has non-empty keys and stays within the size limits
+func validMetadata(metadata map[string]string) bool {
+	if len(metadata) > maxMetadataEntries {
+		return false
+	}
+
+	for k, v := range metadata {
+		if k == "" || len(k) > maxMetadataKey || len(v) > maxMetadataValue {
+			return false
+		}
+	}
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"data-vault/server/internal/models"
 	"data-vault/server/internal/proto"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,7 @@ func TestPostData(t *testing.T) {
 		name          string
 		data          []byte
 		dataType      string
+		metadata      map[string]string
 		userID        interface{}
 		mockError     error
 		expectError   bool
@@ -90,6 +92,44 @@ func TestPostData(t *testing.T) {
 			expectedCode: codes.Internal,
 			expectedMsg:  "Failed to post data",
 		},
+		{
+			name:          "with metadata",
+			data:          []byte("sensitive data to store"),
+			dataType:      "password",
+			metadata:      map[string]string{"website": "example.com", "env": "prod"},
+			userID:        "testuser",
+			expectError:   false,
+			expectSuccess: true,
+		},
+		{
+			name:         "metadata with empty key",
+			data:         []byte("sensitive data to store"),
+			dataType:     "text",
+			metadata:     map[string]string{"": "value"},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Metadata is malformed",
+		},
+		{
+			name:         "too many metadata entries",
+			data:         []byte("sensitive data to store"),
+			dataType:     "text",
+			metadata:     largeMetadata(),
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Metadata is malformed",
+		},
+		{
+			name:         "unknown data type",
+			data:         []byte("sensitive data to store"),
+			dataType:     "note",
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Unknown data type",
+		},
 		{
 			name:          "large data",
 			data:          make([]byte, 10000),
@@ -106,8 +146,9 @@ func TestPostData(t *testing.T) {
 			handler, mockService := setupTestHandler()
 
 			request := &proto.PostDataRequest{
-				Type: tt.dataType,
-				Data: tt.data,
+				Type:     tt.dataType,
+				Data:     tt.data,
+				Metadata: tt.metadata,
 			}
 
 			var ctx context.Context
@@ -117,8 +158,8 @@ func TestPostData(t *testing.T) {
 				ctx = context.Background()
 			}
 
-			if len(tt.data) > 0 && tt.dataType != "" && tt.userID == "testuser" {
-				mockService.On("PostData", mock.Anything, "testuser", tt.dataType, tt.data).Return(tt.mockError)
+			if len(tt.data) > 0 && models.ValidDataType(tt.dataType) && validMetadata(tt.metadata) && tt.userID == "testuser" {
+				mockService.On("PostData", mock.Anything, "testuser", tt.dataType, tt.data, tt.metadata).Return(tt.mockError)
 			}
 
 			response, err := handler.PostData(ctx, request)
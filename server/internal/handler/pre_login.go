@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PreLogin handles requests for the key derivation parameters the client needs before it can
+// derive the authentication key to log in with
+func (g *Handler) PreLogin(ctx context.Context, in *proto.PreLoginRequest) (*proto.PreLoginResponse, error) {
+	if len(in.Login) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Login not provided")
+	}
+
+	salt, rawPassword, err := g.service.PreLogin(ctx, in.Login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to get key derivation parameters")
+	}
+
+	return &proto.PreLoginResponse{
+		KdfSalt:     salt,
+		RawPassword: rawPassword,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPreLogin(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         *proto.PreLoginRequest
+		mockSalt        []byte
+		mockRawPassword bool
+		mockError       error
+		expectMock      bool
+		expectError     bool
+		expectedCode    codes.Code
+		expectedMsg     string
+	}{
+		{
+			name:       "success",
+			request:    &proto.PreLoginRequest{Login: "testuser"},
+			mockSalt:   []byte("test-kdf-salt-16"),
+			expectMock: true,
+		},
+		{
+			name:            "account still on the master password",
+			request:         &proto.PreLoginRequest{Login: "olduser"},
+			mockSalt:        []byte("test-kdf-salt-16"),
+			mockRawPassword: true,
+			expectMock:      true,
+		},
+		{
+			name:         "empty login",
+			request:      &proto.PreLoginRequest{},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Login not provided",
+		},
+		{
+			name:         "service error",
+			request:      &proto.PreLoginRequest{Login: "testuser"},
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to get key derivation parameters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			if tt.expectMock {
+				mockService.On("PreLogin", mock.Anything, tt.request.Login).Return(tt.mockSalt, tt.mockRawPassword, tt.mockError)
+			}
+
+			response, err := handler.PreLogin(context.Background(), tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.Equal(t, tt.mockSalt, response.KdfSalt)
+				assert.Equal(t, tt.mockRawPassword, response.RawPassword)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
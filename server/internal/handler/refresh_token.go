@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RefreshToken handles requests to exchange a refresh token for a new access and refresh token pair
+func (g *Handler) RefreshToken(ctx context.Context, in *proto.RefreshTokenRequest) (*proto.RefreshTokenResponse, error) {
+	if len(in.RefreshToken) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Refresh token not provided")
+	}
+
+	session, refreshToken, err := g.service.RefreshSession(ctx, in.RefreshToken)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			return nil, status.Error(codes.Unauthenticated, "Session expired or revoked")
+		}
+		return nil, status.Error(codes.Internal, "Failed to refresh session")
+	}
+
+	jwtToken, err := g.IssueJWT(models.User{Login: session.User}, session.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to issue JWT token")
+	}
+
+	return &proto.RefreshTokenResponse{
+		Success:      true,
+		JwtToken:     jwtToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRefreshToken(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *proto.RefreshTokenRequest
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			request:    &proto.RefreshTokenRequest{RefreshToken: "session123.old"},
+			expectMock: true,
+		},
+		{
+			name:         "empty refresh token",
+			request:      &proto.RefreshTokenRequest{},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Refresh token not provided",
+		},
+		{
+			name:         "revoked or reused token",
+			request:      &proto.RefreshTokenRequest{RefreshToken: "session123.old"},
+			mockError:    service.ErrInvalidRefreshToken,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "Session expired or revoked",
+		},
+		{
+			name:         "service error",
+			request:      &proto.RefreshTokenRequest{RefreshToken: "session123.old"},
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to refresh session",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			if tt.expectMock {
+				session := testSession("testuser")
+				if tt.mockError != nil {
+					session = models.Session{}
+				}
+				mockService.On("RefreshSession", mock.Anything, tt.request.RefreshToken).
+					Return(session, "session123.new", tt.mockError)
+			}
+
+			response, err := handler.RefreshToken(context.Background(), tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, "session123.new", response.RefreshToken)
+				claims := validateJWTToken(t, response.JwtToken, handler.cfg.JWTSecret, "testuser")
+				assert.Equal(t, "session123", claims.SessionID)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
 	"data-vault/server/internal/storage"
 	"errors"
 
@@ -19,28 +20,42 @@ func (g *Handler) Register(ctx context.Context, in *proto.RegisterRequest) (*pro
 	user := models.User{
 		Login:    in.User.Login,
 		Password: in.User.Password,
+		Salt:     in.KdfSalt,
 	}
 
 	if len(user.Login) == 0 || len(user.Password) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "User ID or Password not provided")
 	}
 
+	if len(user.Salt) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Key derivation salt not provided")
+	}
+
 	err := g.service.Register(ctx, user)
 	if err != nil {
 		if errors.Is(err, storage.ErrDuplicateLogin) {
 			return nil, status.Error(codes.AlreadyExists, "User already exists")
 		}
+		if errors.Is(err, service.ErrReservedLogin) {
+			return nil, status.Error(codes.InvalidArgument, "Logins starting with org: are reserved")
+		}
 		return nil, status.Error(codes.Internal, "Failed to register user")
 	}
 
-	jwtToken, err := g.IssueJWT(user)
+	session, refreshToken, err := g.service.CreateSession(ctx, user.Login, in.Device)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to create session")
+	}
+
+	jwtToken, err := g.IssueJWT(user, session.ID)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Failed to issue JWT token")
 	}
 
 	response = &proto.RegisterResponse{
-		Success:  true,
-		JwtToken: jwtToken,
+		Success:      true,
+		JwtToken:     jwtToken,
+		RefreshToken: refreshToken,
 	}
 
 	return response, nil
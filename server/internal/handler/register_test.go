@@ -7,6 +7,7 @@ import (
 
 	"data-vault/server/internal/models"
 	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
 	"data-vault/server/internal/storage"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,8 @@ func TestRegister(t *testing.T) {
 		name          string
 		user          models.User
 		mockError     error
+		expectSession bool
+		sessionError  error
 		expectError   bool
 		expectedCode  codes.Code
 		expectedMsg   string
@@ -31,6 +34,7 @@ func TestRegister(t *testing.T) {
 			name:          "success",
 			user:          testUserValid(),
 			mockError:     nil,
+			expectSession: true,
 			expectError:   false,
 			expectSuccess: true,
 			expectJWT:     true,
@@ -49,22 +53,46 @@ func TestRegister(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			expectedMsg:  "User ID or Password not provided",
 		},
+		{
+			name:         "missing key derivation salt",
+			user:         models.User{Login: "testuser", Password: "testpass123"},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Key derivation salt not provided",
+		},
 		{
 			name:         "duplicate user",
-			user:         models.User{Login: "existinguser", Password: "testpass123"},
+			user:         models.User{Login: "existinguser", Password: "testpass123", Salt: []byte("test-kdf-salt-16")},
 			mockError:    storage.ErrDuplicateLogin,
 			expectError:  true,
 			expectedCode: codes.AlreadyExists,
 			expectedMsg:  "User already exists",
 		},
+		{
+			name:         "reserved login",
+			user:         models.User{Login: "org:acme", Password: "testpass123", Salt: []byte("test-kdf-salt-16")},
+			mockError:    service.ErrReservedLogin,
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Logins starting with org: are reserved",
+		},
 		{
 			name:         "service error",
-			user:         models.User{Login: "testuser", Password: "testpass123"},
+			user:         models.User{Login: "testuser", Password: "testpass123", Salt: []byte("test-kdf-salt-16")},
 			mockError:    errors.New("database error"),
 			expectError:  true,
 			expectedCode: codes.Internal,
 			expectedMsg:  "Failed to register user",
 		},
+		{
+			name:          "session error",
+			user:          testUserValid(),
+			expectSession: true,
+			sessionError:  errors.New("database error"),
+			expectError:   true,
+			expectedCode:  codes.Internal,
+			expectedMsg:   "Failed to create session",
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,11 +104,17 @@ func TestRegister(t *testing.T) {
 					Login:    tt.user.Login,
 					Password: tt.user.Password,
 				},
+				KdfSalt: tt.user.Salt,
+				Device:  "laptop",
 			}
 
-			if tt.user.Login != "" && tt.user.Password != "" {
+			if tt.user.Login != "" && tt.user.Password != "" && len(tt.user.Salt) > 0 {
 				mockService.On("Register", mock.Anything, tt.user).Return(tt.mockError)
 			}
+			if tt.expectSession {
+				mockService.On("CreateSession", mock.Anything, tt.user.Login, "laptop").
+					Return(testSession(tt.user.Login), "session123.refresh", tt.sessionError)
+			}
 
 			response, err := handler.Register(context.Background(), request)
 
@@ -95,6 +129,7 @@ func TestRegister(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, response)
 				assert.Equal(t, tt.expectSuccess, response.Success)
+				assert.Equal(t, "session123.refresh", response.RefreshToken)
 				if tt.expectJWT {
 					assert.NotEmpty(t, response.JwtToken)
 					validateJWTToken(t, response.JwtToken, handler.cfg.JWTSecret, tt.user.Login)
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreFromTrash handles requests to move a trashed data entry back to the live entries
+func (g *Handler) RestoreFromTrash(ctx context.Context, in *proto.RestoreFromTrashRequest) (*proto.RestoreFromTrashResponse, error) {
+	login, err := authorize(ctx, models.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data ID not provided")
+	}
+
+	version, err := g.service.RestoreFromTrash(ctx, login, in.Id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNoDataFound) {
+			return nil, status.Error(codes.NotFound, "Data not found in trash")
+		}
+		return nil, status.Error(codes.Internal, "Failed to restore data")
+	}
+
+	return &proto.RestoreFromTrashResponse{
+		Success: true,
+		Version: version,
+	}, nil
+}
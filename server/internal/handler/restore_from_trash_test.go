@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRestoreFromTrash(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         *proto.RestoreFromTrashRequest
+		userID          interface{}
+		mockVersion     int64
+		mockError       error
+		expectMock      bool
+		expectError     bool
+		expectedCode    codes.Code
+		expectedMsg     string
+		expectedVersion int64
+	}{
+		{
+			name:            "success",
+			request:         &proto.RestoreFromTrashRequest{Id: "data123"},
+			userID:          "testuser",
+			mockVersion:     3,
+			expectMock:      true,
+			expectedVersion: 3,
+		},
+		{
+			name:         "not in trash",
+			request:      &proto.RestoreFromTrashRequest{Id: "data123"},
+			userID:       "testuser",
+			mockError:    storage.ErrNoDataFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "Data not found in trash",
+		},
+		{
+			name:         "service error",
+			request:      &proto.RestoreFromTrashRequest{Id: "data123"},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to restore data",
+		},
+		{
+			name:         "empty data ID",
+			request:      &proto.RestoreFromTrashRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data ID not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.RestoreFromTrashRequest{Id: "data123"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("RestoreFromTrash", mock.Anything, "testuser", tt.request.Id).
+					Return(tt.mockVersion, tt.mockError)
+			}
+
+			response, err := handler.RestoreFromTrash(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, tt.expectedVersion, response.Version)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RestoreVersion handles requests to make a prior version the current state of a data entry
+func (g *Handler) RestoreVersion(ctx context.Context, in *proto.RestoreVersionRequest) (*proto.RestoreVersionResponse, error) {
+	login, err := authorize(ctx, models.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data ID not provided")
+	}
+
+	if in.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data version not provided")
+	}
+
+	version, err := g.service.RestoreVersion(ctx, login, in.Id, in.Version)
+	if err != nil {
+		if errors.Is(err, storage.ErrVersionNotFound) {
+			return nil, status.Error(codes.NotFound, "Version not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to restore version")
+	}
+
+	return &proto.RestoreVersionResponse{
+		Success: true,
+		Version: version,
+	}, nil
+}
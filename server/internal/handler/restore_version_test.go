@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRestoreVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         *proto.RestoreVersionRequest
+		userID          interface{}
+		mockVersion     int64
+		mockError       error
+		expectMock      bool
+		expectError     bool
+		expectedCode    codes.Code
+		expectedMsg     string
+		expectedVersion int64
+	}{
+		{
+			name:            "success",
+			request:         &proto.RestoreVersionRequest{Id: "data123", Version: 2},
+			userID:          "testuser",
+			mockVersion:     5,
+			expectMock:      true,
+			expectedVersion: 5,
+		},
+		{
+			name:         "version not found",
+			request:      &proto.RestoreVersionRequest{Id: "data123", Version: 9},
+			userID:       "testuser",
+			mockError:    storage.ErrVersionNotFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "Version not found",
+		},
+		{
+			name:         "service error",
+			request:      &proto.RestoreVersionRequest{Id: "data123", Version: 2},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to restore version",
+		},
+		{
+			name:         "empty data ID",
+			request:      &proto.RestoreVersionRequest{Version: 2},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data ID not provided",
+		},
+		{
+			name:         "missing version",
+			request:      &proto.RestoreVersionRequest{Id: "data123"},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data version not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.RestoreVersionRequest{Id: "data123", Version: 2},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("RestoreVersion", mock.Anything, "testuser", tt.request.Id, tt.request.Version).
+					Return(tt.mockVersion, tt.mockError)
+			}
+
+			response, err := handler.RestoreVersion(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, tt.expectedVersion, response.Version)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
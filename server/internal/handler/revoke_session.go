@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeSession handles requests to end one of the user's sessions, e.g. of a lost device
+func (g *Handler) RevokeSession(ctx context.Context, in *proto.RevokeSessionRequest) (*proto.RevokeSessionResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Session ID not provided")
+	}
+
+	err = g.service.RevokeSession(ctx, login, in.Id)
+	if err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			return nil, status.Error(codes.NotFound, "Session not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to revoke session")
+	}
+
+	return &proto.RevokeSessionResponse{
+		Success: true,
+	}, nil
+}
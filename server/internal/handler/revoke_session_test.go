@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRevokeSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *proto.RevokeSessionRequest
+		userID       interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			request:    &proto.RevokeSessionRequest{Id: "session456"},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "session not found",
+			request:      &proto.RevokeSessionRequest{Id: "missing"},
+			userID:       "testuser",
+			mockError:    storage.ErrSessionNotFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "Session not found",
+		},
+		{
+			name:         "service error",
+			request:      &proto.RevokeSessionRequest{Id: "session456"},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to revoke session",
+		},
+		{
+			name:         "empty session ID",
+			request:      &proto.RevokeSessionRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Session ID not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.RevokeSessionRequest{Id: "session456"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("RevokeSession", mock.Anything, "testuser", tt.request.Id).Return(tt.mockError)
+			}
+
+			response, err := handler.RevokeSession(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
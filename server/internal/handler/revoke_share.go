@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RevokeShare handles requests to remove the access of another user to one of the user's data entries
+func (g *Handler) RevokeShare(ctx context.Context, in *proto.RevokeShareRequest) (*proto.RevokeShareResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data ID not provided")
+	}
+
+	if len(in.Login) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Login not provided")
+	}
+
+	err = g.service.RevokeShare(ctx, login, in.Id, in.Login)
+	if err != nil {
+		if errors.Is(err, storage.ErrShareNotFound) {
+			return nil, status.Error(codes.NotFound, "Share not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to revoke share")
+	}
+
+	return &proto.RevokeShareResponse{
+		Success: true,
+	}, nil
+}
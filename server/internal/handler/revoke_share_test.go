@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRevokeShare(t *testing.T) {
+	tests := []struct {
+		name         string
+		request      *proto.RevokeShareRequest
+		userID       interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			request:    &proto.RevokeShareRequest{Id: "data123", Login: "alice"},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "share not found",
+			request:      &proto.RevokeShareRequest{Id: "data123", Login: "bob"},
+			userID:       "testuser",
+			mockError:    storage.ErrShareNotFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "Share not found",
+		},
+		{
+			name:         "service error",
+			request:      &proto.RevokeShareRequest{Id: "data123", Login: "alice"},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to revoke share",
+		},
+		{
+			name:         "empty data ID",
+			request:      &proto.RevokeShareRequest{Login: "alice"},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data ID not provided",
+		},
+		{
+			name:         "empty login",
+			request:      &proto.RevokeShareRequest{Id: "data123"},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Login not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.RevokeShareRequest{Id: "data123", Login: "alice"},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("RevokeShare", mock.Anything, "testuser", tt.request.Id, tt.request.Login).Return(tt.mockError)
+			}
+
+			response, err := handler.RevokeShare(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetPublicKey handles requests to publish the key other users share records with the user for
+func (g *Handler) SetPublicKey(ctx context.Context, in *proto.SetPublicKeyRequest) (*proto.SetPublicKeyResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.PublicKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Public key not provided")
+	}
+
+	err = g.service.SetPublicKey(ctx, login, in.PublicKey)
+	if err != nil {
+		if errors.Is(err, service.ErrMalformedRequest) {
+			return nil, status.Error(codes.InvalidArgument, "Public key must be 32 bytes")
+		}
+		return nil, status.Error(codes.Internal, "Failed to set public key")
+	}
+
+	return &proto.SetPublicKeyResponse{
+		Success: true,
+	}, nil
+}
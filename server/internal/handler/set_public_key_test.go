@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSetPublicKey(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+
+	tests := []struct {
+		name         string
+		request      *proto.SetPublicKeyRequest
+		userID       interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success",
+			request:    &proto.SetPublicKeyRequest{PublicKey: key},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "wrong key size",
+			request:      &proto.SetPublicKeyRequest{PublicKey: []byte("short")},
+			userID:       "testuser",
+			mockError:    service.ErrMalformedRequest,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Public key must be 32 bytes",
+		},
+		{
+			name:         "service error",
+			request:      &proto.SetPublicKeyRequest{PublicKey: key},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to set public key",
+		},
+		{
+			name:         "empty key",
+			request:      &proto.SetPublicKeyRequest{},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Public key not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.SetPublicKeyRequest{PublicKey: key},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("SetPublicKey", mock.Anything, "testuser", tt.request.PublicKey).Return(tt.mockError)
+			}
+
+			response, err := handler.SetPublicKey(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
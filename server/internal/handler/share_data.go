@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ShareData handles requests to grant another user access to one of the user's data entries
+func (g *Handler) ShareData(ctx context.Context, in *proto.ShareDataRequest) (*proto.ShareDataResponse, error) {
+	login, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data ID not provided")
+	}
+
+	if len(in.Login) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Login not provided")
+	}
+
+	if !models.ValidPermission(in.Permission) {
+		return nil, status.Error(codes.InvalidArgument, "Permission must be read or write")
+	}
+
+	if len(in.WrappedKey) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Wrapped key not provided")
+	}
+
+	err = g.service.ShareData(ctx, login, in.Id, in.Login, in.Permission, in.WrappedKey)
+	if err != nil {
+		if errors.Is(err, service.ErrSelfShare) {
+			return nil, status.Error(codes.InvalidArgument, "Data can't be shared with yourself")
+		}
+		if errors.Is(err, storage.ErrNoDataFound) {
+			return nil, status.Error(codes.NotFound, "Data not found")
+		}
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "User not found")
+		}
+		if errors.Is(err, storage.ErrNoPublicKey) {
+			return nil, status.Error(codes.FailedPrecondition, "User has not published a public key yet")
+		}
+		return nil, status.Error(codes.Internal, "Failed to share data")
+	}
+
+	return &proto.ShareDataResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShareData(t *testing.T) {
+	wrapped := []byte("wrapped record key")
+
+	tests := []struct {
+		name         string
+		request      *proto.ShareDataRequest
+		userID       interface{}
+		mockError    error
+		expectMock   bool
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:       "success read",
+			request:    &proto.ShareDataRequest{Id: "data123", Login: "alice", Permission: "read", WrappedKey: wrapped},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:       "success write",
+			request:    &proto.ShareDataRequest{Id: "data123", Login: "alice", Permission: "write", WrappedKey: wrapped},
+			userID:     "testuser",
+			expectMock: true,
+		},
+		{
+			name:         "data not found",
+			request:      &proto.ShareDataRequest{Id: "missing", Login: "alice", Permission: "read", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    storage.ErrNoDataFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "Data not found",
+		},
+		{
+			name:         "recipient not found",
+			request:      &proto.ShareDataRequest{Id: "data123", Login: "nobody", Permission: "read", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    storage.ErrUserNotFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "User not found",
+		},
+		{
+			name:         "recipient without public key",
+			request:      &proto.ShareDataRequest{Id: "data123", Login: "alice", Permission: "read", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    storage.ErrNoPublicKey,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "public key",
+		},
+		{
+			name:         "share with self",
+			request:      &proto.ShareDataRequest{Id: "data123", Login: "testuser", Permission: "read", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    service.ErrSelfShare,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data can't be shared with yourself",
+		},
+		{
+			name:         "service error",
+			request:      &proto.ShareDataRequest{Id: "data123", Login: "alice", Permission: "read", WrappedKey: wrapped},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to share data",
+		},
+		{
+			name:         "empty data ID",
+			request:      &proto.ShareDataRequest{Login: "alice", Permission: "read", WrappedKey: wrapped},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data ID not provided",
+		},
+		{
+			name:         "empty login",
+			request:      &proto.ShareDataRequest{Id: "data123", Permission: "read", WrappedKey: wrapped},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Login not provided",
+		},
+		{
+			name:         "unknown permission",
+			request:      &proto.ShareDataRequest{Id: "data123", Login: "alice", Permission: "admin", WrappedKey: wrapped},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Permission must be read or write",
+		},
+		{
+			name:         "empty wrapped key",
+			request:      &proto.ShareDataRequest{Id: "data123", Login: "alice", Permission: "read"},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Wrapped key not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.ShareDataRequest{Id: "data123", Login: "alice", Permission: "read", WrappedKey: wrapped},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("ShareData", mock.Anything, "testuser", tt.request.Id, tt.request.Login, tt.request.Permission, tt.request.WrappedKey).
+					Return(tt.mockError)
+			}
+
+			response, err := handler.ShareData(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
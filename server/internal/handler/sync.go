@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sync handles incremental synchronization requests from client devices
+func (g *Handler) Sync(ctx context.Context, in *proto.SyncRequest) (*proto.SyncResponse, error) {
+	userID, err := authorize(ctx, syncAction(in))
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Cursor < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Cursor must not be negative")
+	}
+
+	changes := make([]models.SyncChange, 0, len(in.Changes))
+	for i, c := range in.Changes {
+		change := models.SyncChange{
+			ID:      c.Id,
+			Type:    c.Type,
+			Data:    c.Data,
+			Version: c.Version,
+			Deleted: c.Deleted,
+		}
+
+		valid := change.ID != "" && change.Version > 0
+		if change.ID == "" {
+			valid = !change.Deleted && change.Type != "" && len(change.Data) > 0
+		} else if !change.Deleted {
+			valid = valid && change.Type != "" && len(change.Data) > 0
+		}
+		if !change.Deleted && !models.ValidDataType(change.Type) {
+			valid = false
+		}
+		if !valid {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Change %d is malformed", i))
+		}
+
+		changes = append(changes, change)
+	}
+
+	res, err := g.service.Sync(ctx, userID, in.Cursor, changes)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to sync data")
+	}
+
+	response := &proto.SyncResponse{
+		Cursor:    res.Cursor,
+		Created:   make([]*proto.Data, 0, len(res.Created)),
+		Updated:   make([]*proto.Data, 0, len(res.Updated)),
+		Deleted:   make([]*proto.Tombstone, 0, len(res.Deleted)),
+		Conflicts: make([]*proto.SyncConflict, 0, len(res.Conflicts)),
+	}
+
+	for _, d := range res.Created {
+		response.Created = append(response.Created, dataToProto(d))
+	}
+	for _, d := range res.Updated {
+		response.Updated = append(response.Updated, dataToProto(d))
+	}
+	for _, t := range res.Deleted {
+		response.Deleted = append(response.Deleted, &proto.Tombstone{
+			Id:        t.ID,
+			Revision:  t.Revision,
+			DeletedAt: t.DeletedAt,
+		})
+	}
+	for _, c := range res.Conflicts {
+		conflict := &proto.SyncConflict{
+			Id:            c.ID,
+			ClientVersion: c.ClientVersion,
+		}
+		if c.Current != nil {
+			conflict.Current = dataToProto(*c.Current)
+		}
+		response.Conflicts = append(response.Conflicts, conflict)
+	}
+
+	return response, nil
+}
+
+// syncAction returns the action a sync request performs: pulling changes only reads,
+// pushing changes writes
+func syncAction(in *proto.SyncRequest) string {
+	if len(in.Changes) == 0 {
+		return models.ActionRead
+	}
+	return models.ActionWrite
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSync(t *testing.T) {
+	current := models.Data{ID: "7", User: "testuser", Type: "password", Data: []byte("server copy"), Version: 3, Revision: 41}
+
+	syncResult := models.SyncResult{
+		Cursor:  42,
+		Created: []models.Data{{ID: "9", User: "testuser", Type: "text", Data: []byte("new"), Version: 1, Revision: 40}},
+		Updated: []models.Data{current},
+		Deleted: []models.Tombstone{{ID: "5", User: "testuser", Revision: 42, DeletedAt: "2025-01-01T00:00:00Z"}},
+		Conflicts: []models.SyncConflict{
+			{ID: "7", ClientVersion: 2, Current: &current},
+			{ID: "5", ClientVersion: 1},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		request      *proto.SyncRequest
+		userID       interface{}
+		expectMock   bool
+		mockChanges  []models.SyncChange
+		mockResult   models.SyncResult
+		mockError    error
+		expectError  bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:        "pull only",
+			request:     &proto.SyncRequest{Cursor: 10},
+			userID:      "testuser",
+			expectMock:  true,
+			mockChanges: []models.SyncChange{},
+			mockResult:  syncResult,
+		},
+		{
+			name: "push create, update and delete",
+			request: &proto.SyncRequest{
+				Cursor: 10,
+				Changes: []*proto.SyncChange{
+					{Type: "text", Data: []byte("new")},
+					{Id: "7", Type: "password", Data: []byte("client copy"), Version: 2},
+					{Id: "5", Version: 1, Deleted: true},
+				},
+			},
+			userID:     "testuser",
+			expectMock: true,
+			mockChanges: []models.SyncChange{
+				{Type: "text", Data: []byte("new")},
+				{ID: "7", Type: "password", Data: []byte("client copy"), Version: 2},
+				{ID: "5", Version: 1, Deleted: true},
+			},
+			mockResult: syncResult,
+		},
+		{
+			name:         "negative cursor",
+			request:      &proto.SyncRequest{Cursor: -1},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Cursor must not be negative",
+		},
+		{
+			name: "update without version",
+			request: &proto.SyncRequest{
+				Changes: []*proto.SyncChange{{Id: "7", Type: "password", Data: []byte("client copy")}},
+			},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Change 0 is malformed",
+		},
+		{
+			name: "delete without ID",
+			request: &proto.SyncRequest{
+				Changes: []*proto.SyncChange{{Deleted: true, Version: 1}},
+			},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Change 0 is malformed",
+		},
+		{
+			name: "unknown data type",
+			request: &proto.SyncRequest{
+				Changes: []*proto.SyncChange{{Type: "note", Data: []byte("new")}},
+			},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Change 0 is malformed",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.SyncRequest{},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+		{
+			name:         "service error",
+			request:      &proto.SyncRequest{Cursor: 10},
+			userID:       "testuser",
+			expectMock:   true,
+			mockChanges:  []models.SyncChange{},
+			mockError:    errors.New("database error"),
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to sync data",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("Sync", mock.Anything, "testuser", tt.request.Cursor, tt.mockChanges).Return(tt.mockResult, tt.mockError)
+			}
+
+			response, err := handler.Sync(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.Equal(t, int64(42), response.Cursor)
+				require.Len(t, response.Created, 1)
+				assert.Equal(t, "9", response.Created[0].Id)
+				require.Len(t, response.Updated, 1)
+				assert.Equal(t, int64(41), response.Updated[0].Revision)
+				require.Len(t, response.Deleted, 1)
+				assert.Equal(t, "5", response.Deleted[0].Id)
+				require.Len(t, response.Conflicts, 2)
+				assert.Equal(t, int64(3), response.Conflicts[0].Current.Version)
+				assert.Nil(t, response.Conflicts[1].Current)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UpdateData handles data update requests with optimistic concurrency control
+func (g *Handler) UpdateData(ctx context.Context, in *proto.UpdateDataRequest) (*proto.UpdateDataResponse, error) {
+	var response *proto.UpdateDataResponse
+
+	login, err := authorize(ctx, models.ActionWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Id) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data ID not provided")
+	}
+
+	if len(in.Data) == 0 || in.Type == "" {
+		return nil, status.Error(codes.InvalidArgument, "Data not provided")
+	}
+
+	if !models.ValidDataType(in.Type) {
+		return nil, status.Error(codes.InvalidArgument, "Unknown data type")
+	}
+
+	if in.Version <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Data version not provided")
+	}
+
+	version, err := g.service.UpdateData(ctx, login, in.Id, in.Type, in.Data, in.Version)
+	if err != nil {
+		if errors.Is(err, storage.ErrVersionConflict) {
+			return nil, status.Error(codes.Aborted, "Data was modified since it was last read")
+		}
+		if errors.Is(err, storage.ErrNoDataFound) {
+			return nil, status.Error(codes.NotFound, "Data not found")
+		}
+		return nil, status.Error(codes.Internal, "Failed to update data")
+	}
+
+	response = &proto.UpdateDataResponse{
+		Success: true,
+		Version: version,
+	}
+
+	return response, nil
+}
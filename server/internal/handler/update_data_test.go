@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateData(t *testing.T) {
+	tests := []struct {
+		name            string
+		request         *proto.UpdateDataRequest
+		userID          interface{}
+		mockVersion     int64
+		mockError       error
+		expectMock      bool
+		expectError     bool
+		expectedCode    codes.Code
+		expectedMsg     string
+		expectedVersion int64
+	}{
+		{
+			name:            "success",
+			request:         &proto.UpdateDataRequest{Id: "data123", Type: "password", Data: []byte("new secret"), Version: 3},
+			userID:          "testuser",
+			mockVersion:     4,
+			expectMock:      true,
+			expectError:     false,
+			expectedVersion: 4,
+		},
+		{
+			name:         "stale version",
+			request:      &proto.UpdateDataRequest{Id: "data123", Type: "password", Data: []byte("new secret"), Version: 2},
+			userID:       "testuser",
+			mockError:    storage.ErrVersionConflict,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Aborted,
+			expectedMsg:  "Data was modified since it was last read",
+		},
+		{
+			name:         "record not found",
+			request:      &proto.UpdateDataRequest{Id: "missing", Type: "text", Data: []byte("new secret"), Version: 1},
+			userID:       "testuser",
+			mockError:    storage.ErrNoDataFound,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "Data not found",
+		},
+		{
+			name:         "service error",
+			request:      &proto.UpdateDataRequest{Id: "data123", Type: "text", Data: []byte("new secret"), Version: 1},
+			userID:       "testuser",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to update data",
+		},
+		{
+			name:         "empty data ID",
+			request:      &proto.UpdateDataRequest{Id: "", Type: "text", Data: []byte("new secret"), Version: 1},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data ID not provided",
+		},
+		{
+			name:         "empty data",
+			request:      &proto.UpdateDataRequest{Id: "data123", Type: "text", Version: 1},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data not provided",
+		},
+		{
+			name:         "unknown data type",
+			request:      &proto.UpdateDataRequest{Id: "data123", Type: "note", Data: []byte("new secret"), Version: 1},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Unknown data type",
+		},
+		{
+			name:         "missing version",
+			request:      &proto.UpdateDataRequest{Id: "data123", Type: "text", Data: []byte("new secret")},
+			userID:       "testuser",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Data version not provided",
+		},
+		{
+			name:         "missing user ID in context",
+			request:      &proto.UpdateDataRequest{Id: "data123", Type: "text", Data: []byte("new secret"), Version: 1},
+			userID:       nil,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			var ctx context.Context
+			if tt.userID != nil {
+				ctx = context.WithValue(context.Background(), userIDKey, tt.userID)
+			} else {
+				ctx = context.Background()
+			}
+
+			if tt.expectMock {
+				mockService.On("UpdateData", mock.Anything, "testuser", tt.request.Id, tt.request.Type, tt.request.Data, tt.request.Version).
+					Return(tt.mockVersion, tt.mockError)
+			}
+
+			response, err := handler.UpdateData(ctx, tt.request)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, tt.expectedVersion, response.Version)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxChunkOverhead is the room left above the chunk size for the client-side encryption nonce and tag
+const maxChunkOverhead = 1024
+
+// UploadFile handles streamed file uploads. The first message carries the file info:
+// without an ID a new file is created, with an ID the upload continues at the stored offset.
+// Chunks are persisted as they arrive, so an interrupted upload can be resumed later.
+func (g *Handler) UploadFile(stream proto.VaultService_UploadFileServer) error {
+	ctx := stream.Context()
+
+	login, err := authorize(ctx, models.ActionWrite)
+	if err != nil {
+		return err
+	}
+
+	req, err := stream.Recv()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "File info not provided")
+	}
+
+	var file models.File
+	if info.Id == "" {
+		if len(info.Meta) == 0 || info.Size < 0 || info.ChunkSize <= 0 || info.ChunkSize > service.MaxChunkSize {
+			return status.Error(codes.InvalidArgument, "File info is malformed")
+		}
+
+		file, err = g.service.StartUpload(ctx, login, info.Meta, info.Size, info.ChunkSize)
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to start upload")
+		}
+	} else {
+		file, err = g.service.GetFile(ctx, login, info.Id)
+		if err != nil {
+			if errors.Is(err, storage.ErrNoDataFound) {
+				return status.Error(codes.NotFound, "File not found")
+			}
+			return status.Error(codes.Internal, "Failed to get file")
+		}
+	}
+
+	first := true
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			if file.Complete {
+				return status.Error(codes.FailedPrecondition, "File upload is already complete")
+			}
+			if info.Offset != file.Offset() {
+				return status.Error(codes.FailedPrecondition, fmt.Sprintf("Upload must resume at offset %d", file.Offset()))
+			}
+			first = false
+		}
+
+		chunk := req.GetChunk()
+		if len(chunk) == 0 {
+			return status.Error(codes.InvalidArgument, "Chunk not provided")
+		}
+		if int64(len(chunk)) > file.ChunkSize+maxChunkOverhead {
+			return status.Error(codes.InvalidArgument, "Chunk is too large")
+		}
+
+		err = g.service.WriteChunk(ctx, file, file.Chunks, chunk)
+		if err != nil {
+			if errors.Is(err, service.ErrTooManyChunks) {
+				return status.Error(codes.InvalidArgument, "File has more chunks than its size allows")
+			}
+			if errors.Is(err, storage.ErrChunkOutOfOrder) {
+				return status.Error(codes.Aborted, "File is being uploaded by another client")
+			}
+			return status.Error(codes.Internal, "Failed to store chunk")
+		}
+		file.Chunks++
+	}
+
+	if !file.Complete && file.Chunks == file.ExpectedChunks() {
+		if err := g.service.FinishUpload(ctx, file); err != nil {
+			return status.Error(codes.Internal, "Failed to finish upload")
+		}
+		file.Complete = true
+	}
+
+	return stream.SendAndClose(&proto.UploadFileResponse{
+		Info: fileToProto(file),
+	})
+}
+
+// fileToProto converts a file upload state into its protobuf representation
+func fileToProto(f models.File) *proto.FileInfo {
+	return &proto.FileInfo{
+		Id:        f.ID,
+		Meta:      f.Meta,
+		Size:      f.Size,
+		ChunkSize: f.ChunkSize,
+		Offset:    f.Offset(),
+		Complete:  f.Complete,
+	}
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockUploadStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*proto.UploadFileRequest
+	response *proto.UploadFileResponse
+}
+
+func (s *mockUploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockUploadStream) Recv() (*proto.UploadFileRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *mockUploadStream) SendAndClose(resp *proto.UploadFileResponse) error {
+	s.response = resp
+	return nil
+}
+
+func infoRequest(info *proto.FileInfo) *proto.UploadFileRequest {
+	return &proto.UploadFileRequest{Payload: &proto.UploadFileRequest_Info{Info: info}}
+}
+
+func chunkRequest(chunk string) *proto.UploadFileRequest {
+	return &proto.UploadFileRequest{Payload: &proto.UploadFileRequest_Chunk{Chunk: []byte(chunk)}}
+}
+
+func TestUploadFile(t *testing.T) {
+	newFile := models.File{ID: "file1", User: "testuser", Meta: []byte("meta"), Size: 10, ChunkSize: 4}
+	partialFile := newFile
+	partialFile.Chunks = 1
+
+	tests := []struct {
+		name           string
+		userID         interface{}
+		requests       []*proto.UploadFileRequest
+		setupMock      func(m *MockService)
+		expectError    bool
+		expectedCode   codes.Code
+		expectedMsg    string
+		expectedOffset int64
+		expectComplete bool
+	}{
+		{
+			name:   "full upload",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: 4}),
+				chunkRequest("aaaa"), chunkRequest("bbbb"), chunkRequest("cc"),
+			},
+			setupMock: func(m *MockService) {
+				m.On("StartUpload", mock.Anything, "testuser", []byte("meta"), int64(10), int64(4)).Return(newFile, nil)
+				m.On("WriteChunk", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(3)
+				m.On("FinishUpload", mock.Anything, mock.MatchedBy(func(f models.File) bool { return f.Chunks == 3 })).Return(nil)
+			},
+			expectedOffset: 10,
+			expectComplete: true,
+		},
+		{
+			name:   "interrupted upload",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: 4}),
+				chunkRequest("aaaa"),
+			},
+			setupMock: func(m *MockService) {
+				m.On("StartUpload", mock.Anything, "testuser", []byte("meta"), int64(10), int64(4)).Return(newFile, nil)
+				m.On("WriteChunk", mock.Anything, newFile, int64(0), []byte("aaaa")).Return(nil)
+			},
+			expectedOffset: 4,
+		},
+		{
+			name:   "resume upload",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Id: "file1", Offset: 4}),
+				chunkRequest("bbbb"), chunkRequest("cc"),
+			},
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(partialFile, nil)
+				m.On("WriteChunk", mock.Anything, mock.Anything, int64(1), []byte("bbbb")).Return(nil)
+				m.On("WriteChunk", mock.Anything, mock.Anything, int64(2), []byte("cc")).Return(nil)
+				m.On("FinishUpload", mock.Anything, mock.Anything).Return(nil)
+			},
+			expectedOffset: 10,
+			expectComplete: true,
+		},
+		{
+			name:   "status probe",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Id: "file1"}),
+			},
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(partialFile, nil)
+			},
+			expectedOffset: 4,
+		},
+		{
+			name:   "resume at wrong offset",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Id: "file1", Offset: 8}),
+				chunkRequest("cc"),
+			},
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "file1").Return(partialFile, nil)
+			},
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "Upload must resume at offset 4",
+		},
+		{
+			name:   "resume unknown file",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Id: "missing"}),
+			},
+			setupMock: func(m *MockService) {
+				m.On("GetFile", mock.Anything, "testuser", "missing").Return(models.File{}, storage.ErrNoDataFound)
+			},
+			expectError:  true,
+			expectedCode: codes.NotFound,
+			expectedMsg:  "File not found",
+		},
+		{
+			name:   "too many chunks",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: 4}),
+				chunkRequest("aaaa"),
+			},
+			setupMock: func(m *MockService) {
+				m.On("StartUpload", mock.Anything, "testuser", []byte("meta"), int64(10), int64(4)).Return(newFile, nil)
+				m.On("WriteChunk", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(service.ErrTooManyChunks)
+			},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "File has more chunks than its size allows",
+		},
+		{
+			name:   "start upload error",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: 4}),
+			},
+			setupMock: func(m *MockService) {
+				m.On("StartUpload", mock.Anything, "testuser", []byte("meta"), int64(10), int64(4)).Return(models.File{}, errors.New("database error"))
+			},
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to start upload",
+		},
+		{
+			name:         "missing file info",
+			userID:       "testuser",
+			requests:     []*proto.UploadFileRequest{chunkRequest("aaaa")},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "File info not provided",
+		},
+		{
+			name:   "chunk size too large",
+			userID: "testuser",
+			requests: []*proto.UploadFileRequest{
+				infoRequest(&proto.FileInfo{Meta: []byte("meta"), Size: 10, ChunkSize: service.MaxChunkSize + 1}),
+			},
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "File info is malformed",
+		},
+		{
+			name:         "missing user ID in context",
+			userID:       nil,
+			requests:     []*proto.UploadFileRequest{infoRequest(&proto.FileInfo{Id: "file1"})},
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "User ID not found in context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			ctx := context.Background()
+			if tt.userID != nil {
+				ctx = context.WithValue(ctx, userIDKey, tt.userID)
+			}
+
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+
+			stream := &mockUploadStream{ctx: ctx, requests: tt.requests}
+			err := handler.UploadFile(stream)
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, stream.response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, stream.response)
+				assert.Equal(t, "file1", stream.response.Info.Id)
+				assert.Equal(t, tt.expectedOffset, stream.response.Info.Offset)
+				assert.Equal(t, tt.expectComplete, stream.response.Info.Complete)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
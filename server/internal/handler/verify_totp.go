@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyTOTP handles requests to complete a login challenge with a two-factor or recovery code
+func (g *Handler) VerifyTOTP(ctx context.Context, in *proto.VerifyTOTPRequest) (*proto.VerifyTOTPResponse, error) {
+	if len(in.ChallengeToken) == 0 || len(in.Code) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Challenge token or code not provided")
+	}
+
+	login, ok := g.parseChallenge(in.ChallengeToken)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "Challenge expired or invalid, please login again")
+	}
+
+	salt, err := g.service.VerifyTOTP(ctx, login, in.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTOTP):
+			return nil, status.Error(codes.Unauthenticated, "Invalid two-factor code")
+		case errors.Is(err, service.ErrTOTPNotEnabled):
+			return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication not enabled")
+		}
+		return nil, status.Error(codes.Internal, "Failed to verify two-factor code")
+	}
+
+	session, refreshToken, err := g.service.CreateSession(ctx, login, in.Device)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to create session")
+	}
+
+	jwtToken, err := g.IssueJWT(models.User{Login: login}, session.ID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Failed to issue JWT token")
+	}
+
+	return &proto.VerifyTOTPResponse{
+		Success:      true,
+		JwtToken:     jwtToken,
+		KdfSalt:      salt,
+		RefreshToken: refreshToken,
+	}, nil
+}
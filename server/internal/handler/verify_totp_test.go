@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"data-vault/server/internal/proto"
+	"data-vault/server/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyTOTP(t *testing.T) {
+	tests := []struct {
+		name          string
+		challenge     string
+		code          string
+		mockError     error
+		expectMock    bool
+		expectSession bool
+		expectError   bool
+		expectedCode  codes.Code
+		expectedMsg   string
+	}{
+		{
+			name:          "success",
+			code:          "123456",
+			expectMock:    true,
+			expectSession: true,
+		},
+		{
+			name:          "recovery code",
+			code:          "abcde-fghij",
+			expectMock:    true,
+			expectSession: true,
+		},
+		{
+			name:         "invalid code",
+			code:         "000000",
+			mockError:    service.ErrInvalidTOTP,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "Invalid two-factor code",
+		},
+		{
+			name:         "two-factor not enabled",
+			code:         "123456",
+			mockError:    service.ErrTOTPNotEnabled,
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "Two-factor authentication not enabled",
+		},
+		{
+			name:         "service error",
+			code:         "123456",
+			mockError:    errors.New("database error"),
+			expectMock:   true,
+			expectError:  true,
+			expectedCode: codes.Internal,
+			expectedMsg:  "Failed to verify two-factor code",
+		},
+		{
+			name:         "invalid challenge",
+			challenge:    "not.a.token",
+			code:         "123456",
+			expectError:  true,
+			expectedCode: codes.Unauthenticated,
+			expectedMsg:  "Challenge expired or invalid",
+		},
+		{
+			name:         "empty code",
+			code:         "",
+			expectError:  true,
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "Challenge token or code not provided",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, mockService := setupTestHandler()
+
+			challenge := tt.challenge
+			if challenge == "" {
+				var err error
+				challenge, err = handler.issueChallenge("testuser")
+				require.NoError(t, err)
+			}
+
+			if tt.expectMock {
+				if tt.mockError != nil {
+					mockService.On("VerifyTOTP", mock.Anything, "testuser", tt.code).Return(nil, tt.mockError)
+				} else {
+					mockService.On("VerifyTOTP", mock.Anything, "testuser", tt.code).Return([]byte("test-kdf-salt-16"), nil)
+				}
+			}
+			if tt.expectSession {
+				mockService.On("CreateSession", mock.Anything, "testuser", "laptop").
+					Return(testSession("testuser"), "session123.refresh", nil)
+			}
+
+			response, err := handler.VerifyTOTP(context.Background(), &proto.VerifyTOTPRequest{
+				ChallengeToken: challenge,
+				Code:           tt.code,
+				Device:         "laptop",
+			})
+
+			if tt.expectError {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, st.Code())
+				assert.Contains(t, st.Message(), tt.expectedMsg)
+				assert.Nil(t, response)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response)
+				assert.True(t, response.Success)
+				assert.Equal(t, []byte("test-kdf-salt-16"), response.KdfSalt)
+				assert.Equal(t, "session123.refresh", response.RefreshToken)
+				claims := validateJWTToken(t, response.JwtToken, handler.cfg.JWTSecret, "testuser")
+				assert.Equal(t, "session123", claims.SessionID)
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
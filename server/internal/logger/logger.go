@@ -3,10 +3,21 @@ package logger
 import (
 	"log/slog"
 	"os"
+
+	"data-vault/server/internal/config"
 )
 
-// New creates a new structured logger with text output to stdout
-func New() *slog.Logger {
-	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
-	return log
+// New creates a new structured logger writing to stdout from the given level up, as text or as
+// JSON. An unknown level logs from info up.
+func New(level, format string) *slog.Logger {
+	var opts slog.HandlerOptions
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err == nil {
+		opts.Level = lvl
+	}
+
+	if format == config.LogJSON {
+		return slog.New(slog.NewJSONHandler(os.Stdout, &opts))
+	}
+	return slog.New(slog.NewTextHandler(os.Stdout, &opts))
 }
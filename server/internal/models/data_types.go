@@ -6,11 +6,22 @@ const (
 	DataTypePassword = "password"
 	DataTypeBinary   = "binary"
 	DataTypeCard     = "card"
+	DataTypeOTP      = "otp"
 )
 
 // DataType represents the type of data stored in the vault
 type DataType string
 
+// ValidDataType reports whether t is one of the record types the vault stores.
+// The typed record itself is encrypted by the client, so its fields are validated there.
+func ValidDataType(t string) bool {
+	switch t {
+	case DataTypeText, DataTypePassword, DataTypeBinary, DataTypeCard, DataTypeOTP:
+		return true
+	}
+	return false
+}
+
 // LoginPasswordData represents login/password pair data
 type LoginPasswordData struct {
 	Website  string `json:"website"`
@@ -42,3 +53,16 @@ type BinaryData struct {
 	Content  []byte `json:"content"`
 	Notes    string `json:"notes"`
 }
+
+// OTPData represents the shared secret of a TOTP or HOTP code generator
+type OTPData struct {
+	Type      string `json:"type"`
+	Issuer    string `json:"issuer"`
+	Account   string `json:"account"`
+	Secret    string `json:"secret"`
+	Algorithm string `json:"algorithm"`
+	Digits    int    `json:"digits"`
+	Period    int    `json:"period"`
+	Counter   uint64 `json:"counter"`
+	Notes     string `json:"notes"`
+}
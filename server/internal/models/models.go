@@ -1,9 +1,71 @@
 package models
 
-// User represents a user with login credentials
+import "strings"
+
+// Data entry status values
+const (
+	StatusNew       = "NEW"
+	StatusUploading = "UPLOADING"
+	StatusDeleted   = "DELETED"
+)
+
+// Share permission values
+const (
+	PermissionRead  = "read"
+	PermissionWrite = "write"
+)
+
+// Organization member roles, from the most to the least privileged
+const (
+	RoleOwner    = "owner"
+	RoleAdmin    = "admin"
+	RoleMember   = "member"
+	RoleReadOnly = "read-only"
+)
+
+// Actions checked against the role of an organization member
+const (
+	ActionRead   = "read"
+	ActionWrite  = "write"
+	ActionManage = "manage"
+)
+
+// orgAccountPrefix starts the account name the records of an organization are stored under
+const orgAccountPrefix = "org:"
+
+// User represents a user with login credentials. Password is the authentication key the
+// client derives from the master password, RawPassword marks accounts created before that
+// which still log in with the master password itself. AuthKey carries the derived key that
+// replaces the master password when such an account logs in.
 type User struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login       string `json:"login"`
+	Password    string `json:"password"`
+	Salt        []byte `json:"salt,omitempty"`
+	RawPassword bool   `json:"-"`
+	AuthKey     string `json:"-"`
+}
+
+// TOTP holds a user's two-factor authentication state. Secret is set once 2FA is enabled,
+// Pending holds a secret that was provisioned but not confirmed yet. LastStep is the time
+// step of the last accepted code, codes can't be used twice. The secrets are stored
+// encrypted if KEY_PROVIDER is configured.
+type TOTP struct {
+	Secret   []byte `json:"-"`
+	Pending  []byte `json:"-"`
+	LastStep int64  `json:"last_step"`
+}
+
+// Session is a login of a user on a device, kept alive by its rotating refresh token.
+// Timestamps are RFC 3339 UTC, RevokedAt is empty for sessions that weren't revoked.
+type Session struct {
+	ID          string `json:"id"`
+	User        string `json:"user"`
+	Device      string `json:"device"`
+	RefreshHash string `json:"-"`
+	CreatedAt   string `json:"created_at"`
+	LastUsedAt  string `json:"last_used_at"`
+	ExpiresAt   string `json:"expires_at"`
+	RevokedAt   string `json:"revoked_at,omitempty"`
 }
 
 // Data represents a data entry in the vault
@@ -14,4 +76,218 @@ type Data struct {
 	Type       string `json:"type"`
 	Data       []byte `json:"data"`
 	UploadedAt string `json:"uploaded_at"`
+	Version    int64  `json:"version"`
+	Revision   int64  `json:"revision"`
+	// DeletedAt is the time the entry was moved to the trash, empty for live entries
+	DeletedAt string `json:"deleted_at,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// RawMetadata is the metadata column as stored: JSON, encrypted with the server key
+	// when server-side encryption is enabled
+	RawMetadata []byte `json:"-"`
+}
+
+// DataQuery selects a page of a user's data entries, newest first. Empty fields don't filter.
+// UploadedFrom and UploadedTo are RFC 3339 UTC timestamps, the range includes From and excludes To.
+type DataQuery struct {
+	Type         string            `json:"type"`
+	UploadedFrom string            `json:"uploaded_from"`
+	UploadedTo   string            `json:"uploaded_to"`
+	Metadata     map[string]string `json:"metadata"`
+	PageSize     int               `json:"page_size"`
+	PageToken    string            `json:"page_token"`
+}
+
+// DataPage is a page of data entries with the token of the next page, empty on the last page
+type DataPage struct {
+	Data          []Data `json:"data"`
+	NextPageToken string `json:"next_page_token"`
+}
+
+// PageCursor is the position of the last entry read for a page in the newest-first order
+type PageCursor struct {
+	UploadedAt string `json:"u"`
+	ID         string `json:"i"`
+}
+
+// Version is a prior state of a data entry kept in its history. ArchivedAt is the time
+// the state was replaced by an update, a delete or a restore.
+type Version struct {
+	DataID     string            `json:"data_id"`
+	Version    int64             `json:"version"`
+	Type       string            `json:"type"`
+	Data       []byte            `json:"data"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	UploadedAt string            `json:"uploaded_at"`
+	ArchivedAt string            `json:"archived_at"`
+
+	// RawMetadata is the metadata column as stored, see Data
+	RawMetadata []byte `json:"-"`
+}
+
+// Tombstone marks a deleted data entry so that other devices can drop their copy
+type Tombstone struct {
+	ID        string `json:"id"`
+	User      string `json:"user"`
+	Revision  int64  `json:"revision"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+// SyncChange represents a modification pushed by a client during synchronization.
+// An empty ID creates a new entry, Deleted removes the entry at Version.
+type SyncChange struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Data    []byte `json:"data"`
+	Version int64  `json:"version"`
+	Deleted bool   `json:"deleted"`
+}
+
+// SyncConflict reports a pushed change that was based on a stale version.
+// Current is the server's copy of the entry, nil if it was deleted.
+type SyncConflict struct {
+	ID            string `json:"id"`
+	ClientVersion int64  `json:"client_version"`
+	Current       *Data  `json:"current"`
+}
+
+// SyncResult holds the changes made since a client's cursor
+type SyncResult struct {
+	Cursor    int64          `json:"cursor"`
+	Created   []Data         `json:"created"`
+	Updated   []Data         `json:"updated"`
+	Deleted   []Tombstone    `json:"deleted"`
+	Conflicts []SyncConflict `json:"conflicts"`
+}
+
+// File describes a binary entry whose content is stored as a sequence of encrypted chunks.
+// Meta is the client-encrypted file description kept in the entry's data column,
+// Size is the plaintext size and Chunks the number of chunks stored so far.
+type File struct {
+	ID        string `json:"id"`
+	User      string `json:"user"`
+	Meta      []byte `json:"meta"`
+	Size      int64  `json:"size"`
+	ChunkSize int64  `json:"chunk_size"`
+	Chunks    int64  `json:"chunks"`
+	Complete  bool   `json:"complete"`
+}
+
+// Offset returns the number of plaintext bytes already stored
+func (f File) Offset() int64 {
+	return min(f.Chunks*f.ChunkSize, f.Size)
+}
+
+// ExpectedChunks returns the number of chunks a complete file consists of
+func (f File) ExpectedChunks() int64 {
+	if f.ChunkSize <= 0 {
+		return 0
+	}
+	return (f.Size + f.ChunkSize - 1) / f.ChunkSize
+}
+
+// Share grants a user access to another user's data entry. WrappedKey is the entry's record
+// key sealed by the owner to the recipient's public key, the server can't unwrap it.
+type Share struct {
+	DataID     string `json:"data_id"`
+	Owner      string `json:"owner"`
+	Recipient  string `json:"recipient"`
+	Permission string `json:"permission"`
+	WrappedKey []byte `json:"wrapped_key"`
+	SharedAt   string `json:"shared_at"`
+}
+
+// SharedData is a data entry together with the share that grants a user access to it
+type SharedData struct {
+	Data  Data  `json:"data"`
+	Share Share `json:"share"`
+}
+
+// ValidPermission reports whether a share permission is known
+func ValidPermission(permission string) bool {
+	return permission == PermissionRead || permission == PermissionWrite
+}
+
+// Org is a vault shared by a group of users. Its records are stored under OrgAccount(Name).
+type Org struct {
+	Name      string `json:"name"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Member is a user's membership in an organization. WrappedKey is the organization key
+// sealed to the member's public key, the server can't unwrap it.
+type Member struct {
+	Org        string `json:"org"`
+	Login      string `json:"login"`
+	Role       string `json:"role"`
+	WrappedKey []byte `json:"wrapped_key"`
+	JoinedAt   string `json:"joined_at"`
+}
+
+// ValidRole reports whether an organization role is known
+func ValidRole(role string) bool {
+	switch role {
+	case RoleOwner, RoleAdmin, RoleMember, RoleReadOnly:
+		return true
+	}
+	return false
+}
+
+// RoleAllows reports whether a member with the role may perform the action on the organization:
+// every member reads, all but read-only members write and owners and admins manage members
+func RoleAllows(role, action string) bool {
+	switch action {
+	case ActionRead:
+		return ValidRole(role)
+	case ActionWrite:
+		return role == RoleOwner || role == RoleAdmin || role == RoleMember
+	case ActionManage:
+		return role == RoleOwner || role == RoleAdmin
+	}
+	return false
+}
+
+// OrgAccount returns the account name the records of an organization are stored under
+func OrgAccount(org string) string {
+	return orgAccountPrefix + org
+}
+
+// IsOrgAccount reports whether an account name belongs to an organization rather than a user
+func IsOrgAccount(account string) bool {
+	return strings.HasPrefix(account, orgAccountPrefix)
+}
+
+// Migration is a versioned change of the database schema. AppliedAt is empty while the migration
+// is pending.
+type Migration struct {
+	Version   int64  `json:"version"`
+	Name      string `json:"name"`
+	AppliedAt string `json:"applied_at,omitempty"`
+}
+
+// AuditEntry records a call made by or for a user. Org is set for calls on an organization
+// vault and Result is the gRPC status code name. Entries of a user are numbered by Seq and
+// chained by Hash, which covers PrevHash, the hash of the user's previous entry. MAC keys the
+// hash with a server secret, and Authentic reports whether it matched when the entry was read.
+type AuditEntry struct {
+	User      string `json:"user"`
+	Seq       int64  `json:"seq"`
+	At        string `json:"at"`
+	Action    string `json:"action"`
+	RecordID  string `json:"record_id,omitempty"`
+	Org       string `json:"org,omitempty"`
+	ClientIP  string `json:"client_ip"`
+	UserAgent string `json:"user_agent"`
+	Result    string `json:"result"`
+	PrevHash  []byte `json:"prev_hash"`
+	Hash      []byte `json:"hash"`
+	MAC       []byte `json:"-"`
+	Authentic bool   `json:"authentic"`
+}
+
+// Ciphertext is a server-side encrypted value of a column, Key holds the primary key of its row
+type Ciphertext struct {
+	Key   []any
+	Value []byte
 }
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"data-vault/server/internal/storage"
+	"errors"
+	"io"
+)
+
+// auditKeyName is the name the audit log key is stored under in server_keys
+const auditKeyName = "audit"
+
+// loadAuditKey returns the key the audit log hashes are MACed with. With a key provider it is a
+// random key sealed by the provider and stored in the database on first use, so that whoever can
+// rewrite the audit log can't forge it without the provider. Without one it is derived from the
+// JWT secret.
+func (s *Vault) loadAuditKey(ctx context.Context) ([]byte, error) {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	if s.auditKey != nil {
+		return s.auditKey, nil
+	}
+
+	if !s.serverEncryption() {
+		mac := hmac.New(sha256.New, []byte(s.cfg.JWTSecret))
+		mac.Write([]byte("audit-key"))
+		s.auditKey = mac.Sum(nil)
+		return s.auditKey, nil
+	}
+
+	sealed, err := s.Storage.GetServerKey(ctx, auditKeyName)
+	if errors.Is(err, storage.ErrServerKeyNotFound) {
+		sealed, err = s.createServerKey(ctx, auditKeyName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.decryptBytes(ctx, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditKey = key
+	return key, nil
+}
+
+// createServerKey generates a random key, stores it sealed under a name and returns the sealed
+// key stored, which is another server's if it stored one first
+func (s *Vault) createServerKey(ctx context.Context, name string) ([]byte, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+
+	sealed, err := s.encryptBytes(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.CreateServerKey(ctx, name, sealed); err != nil {
+		return nil, err
+	}
+
+	return s.Storage.GetServerKey(ctx, name)
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// ConfirmTOTP turns on two-factor authentication once the user proves their authenticator app
+// produces valid codes for the provisioned secret, and returns the new recovery codes
+func (s *Vault) ConfirmTOTP(ctx context.Context, login, code string) ([]string, error) {
+	totp, err := s.Storage.GetTOTP(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if len(totp.Secret) > 0 {
+		return nil, ErrTOTPEnabled
+	}
+	if len(totp.Pending) == 0 {
+		return nil, ErrTOTPNotPending
+	}
+
+	secret, err := s.openSecret(ctx, totp.Pending)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := matchTOTP(secret, normalizeCode(code), time.Now())
+	if !ok {
+		return nil, ErrInvalidTOTP
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.EnableTOTP(ctx, login, totp.Pending, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
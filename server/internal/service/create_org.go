@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"regexp"
+	"time"
+)
+
+// orgNamePattern restricts organization names to characters that are safe in the x-org header
+var orgNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// CreateOrg creates an organization vault with the user as its owner. The wrapped key is the
+// organization key generated by the owner's client and sealed to the owner's public key.
+func (s *Vault) CreateOrg(ctx context.Context, login, name string, wrappedKey []byte) error {
+	if login == "" || len(wrappedKey) == 0 {
+		return ErrMalformedRequest
+	}
+
+	if !orgNamePattern.MatchString(name) {
+		return ErrInvalidOrgName
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	return s.Storage.CreateOrg(ctx, models.Org{
+		Name:      name,
+		CreatedBy: login,
+		CreatedAt: now,
+	}, models.Member{
+		Org:        name,
+		Login:      login,
+		Role:       models.RoleOwner,
+		WrappedKey: wrappedKey,
+		JoinedAt:   now,
+	})
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 )
 
-// DeleteData removes a specific data entry for a user
+// DeleteData moves a specific data entry of a user to the trash
 func (s *Vault) DeleteData(ctx context.Context, login, id string) error {
-	err := s.Storage.DeleteData(ctx, login, id)
+	err := s.Storage.DeleteData(ctx, login, id, 0)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+)
+
+// GetFile retrieves the state of a user's chunked binary entry, removing the server-side encryption layer from its meta
+func (s *Vault) GetFile(ctx context.Context, login, id string) (models.File, error) {
+	if login == "" || id == "" {
+		return models.File{}, ErrMalformedRequest
+	}
+
+	file, err := s.Storage.GetFile(ctx, login, id)
+	if err != nil {
+		return file, err
+	}
+
+	if s.serverEncryption() {
+		meta, err := s.decryptBytes(ctx, file.Meta)
+		if err != nil {
+			return file, err
+		}
+		file.Meta = meta
+	}
+
+	return file, nil
+}
+
+// ReadChunks passes the chunks of a complete file starting at the given sequence number to fn.
+// The chunks are still encrypted with the user's client-side key.
+func (s *Vault) ReadChunks(ctx context.Context, file models.File, from int64, fn func(chunk []byte) error) error {
+	if !file.Complete {
+		return ErrIncompleteFile
+	}
+
+	return s.Storage.GetChunks(ctx, file.ID, from, func(seq int64, chunk []byte) error {
+		if s.serverEncryption() {
+			plain, err := s.decryptBytes(ctx, chunk)
+			if err != nil {
+				return err
+			}
+			chunk = plain
+		}
+		return fn(chunk)
+	})
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// EmptyTrash permanently removes all trashed data entries of a user and returns how many were removed
+func (s *Vault) EmptyTrash(ctx context.Context, login string) (int64, error) {
+	if login == "" {
+		return 0, ErrMalformedRequest
+	}
+
+	purged, err := s.Storage.EmptyTrash(ctx, login)
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+// PurgeTrash permanently removes the entries of all users that stayed in the trash longer than
+// the configured retention and returns how many were removed
+func (s *Vault) PurgeTrash(ctx context.Context) (int64, error) {
+	if s.cfg.TrashRetention <= 0 {
+		return 0, nil
+	}
+
+	before := time.Now().UTC().Add(-s.cfg.TrashRetention).Format(time.RFC3339)
+	return s.Storage.PurgeTrash(ctx, before)
+}
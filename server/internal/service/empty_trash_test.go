@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyTrash(t *testing.T) {
+	ctx := context.Background()
+	vault, _ := newTestVault(t, testConfig())
+
+	first := postTestData(t, vault, "alice", "first", nil)
+	second := postTestData(t, vault, "alice", "second", nil)
+	postTestData(t, vault, "alice", "kept", nil)
+	theirs := postTestData(t, vault, "bob", "theirs", nil)
+
+	_, err := vault.UpdateData(ctx, "alice", first, "text", []byte("first edited"), 1)
+	require.NoError(t, err)
+
+	for _, d := range []struct{ login, id string }{{"alice", first}, {"alice", second}, {"bob", theirs}} {
+		require.NoError(t, vault.DeleteData(ctx, d.login, d.id))
+	}
+
+	purged, err := vault.EmptyTrash(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	trash, err := vault.ListTrash(ctx, "alice")
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+
+	_, err = vault.RestoreFromTrash(ctx, "alice", first)
+	assert.ErrorIs(t, err, storage.ErrNoDataFound)
+
+	versions, err := vault.ListVersions(ctx, "alice", first)
+	require.NoError(t, err)
+	assert.Empty(t, versions, "The history should go with the entry")
+
+	trash, err = vault.ListTrash(ctx, "bob")
+	require.NoError(t, err)
+	assert.Len(t, trash, 1, "Other users' trash should be kept")
+
+	page, err := vault.GetData(ctx, "alice", models.DataQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kept"}, payloads(page.Data))
+
+	_, err = vault.EmptyTrash(ctx, "")
+	assert.ErrorIs(t, err, ErrMalformedRequest)
+}
+
+func TestPurgeTrash(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		retention    time.Duration
+		expectPurged int64
+	}{
+		{name: "expired", retention: time.Nanosecond, expectPurged: 1},
+		{name: "within retention", retention: time.Hour},
+		{name: "retention disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.TrashRetention = tt.retention
+			vault, _ := newTestVault(t, cfg)
+
+			id := postTestData(t, vault, "alice", "secret", nil)
+			require.NoError(t, vault.DeleteData(ctx, "alice", id))
+
+			// Deletion times have a resolution of a second
+			if tt.expectPurged > 0 {
+				time.Sleep(time.Second)
+			}
+
+			purged, err := vault.PurgeTrash(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectPurged, purged)
+
+			trash, err := vault.ListTrash(ctx, "alice")
+			require.NoError(t, err)
+			assert.Len(t, trash, 1-int(tt.expectPurged))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"net/url"
+	"strconv"
+)
+
+// EnableTOTP provisions a new two-factor secret for the user and returns it in base32 and as an
+// otpauth:// URI. Two-factor authentication is turned on once the secret is confirmed with a code.
+func (s *Vault) EnableTOTP(ctx context.Context, login string) (string, string, error) {
+	totp, err := s.Storage.GetTOTP(ctx, login)
+	if err != nil {
+		return "", "", err
+	}
+	if len(totp.Secret) > 0 {
+		return "", "", ErrTOTPEnabled
+	}
+
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	sealed, err := s.sealSecret(ctx, secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := s.Storage.SetPendingTOTP(ctx, login, sealed); err != nil {
+		return "", "", err
+	}
+
+	encoded := totpEncoding.EncodeToString(secret)
+	uri := url.URL{
+		Scheme: "otpauth",
+		Host:   "totp",
+		Path:   "/" + totpIssuer + ":" + login,
+		RawQuery: url.Values{
+			"secret":    {encoded},
+			"issuer":    {totpIssuer},
+			"algorithm": {"SHA1"},
+			"digits":    {strconv.Itoa(totpDigits)},
+			"period":    {strconv.Itoa(totpPeriod)},
+		}.Encode(),
+	}
+
+	return encoded, uri.String(), nil
+}
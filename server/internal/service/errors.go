@@ -9,4 +9,31 @@ var (
 	ErrWrongFormat      = errors.New("order number is in the wrong format")
 	ErrNoNewAddresses   = errors.New("no new addresses found")
 	ErrMalformedRequest = errors.New("malformed request")
+	ErrInvalidHash      = errors.New("stored password hash is malformed")
+	ErrIncompleteFile   = errors.New("file upload is not complete")
+	ErrTooManyChunks    = errors.New("file has more chunks than its size allows")
+	ErrInvalidPageToken = errors.New("page token is malformed")
+	ErrUnknownKey       = errors.New("encryption key version is not configured")
+	ErrCiphertext       = errors.New("ciphertext is malformed or was sealed with an unknown key")
+	ErrSelfShare        = errors.New("data can't be shared with its owner")
+	ErrReservedLogin    = errors.New("logins starting with org: are reserved for organizations")
+	ErrInvalidOrgName   = errors.New("organization name must be 1-64 letters, digits, dots, dashes or underscores")
+	ErrInvalidRole      = errors.New("role must be owner, admin, member or read-only")
+	ErrForbidden        = errors.New("role does not allow this operation")
+	ErrSelfRole         = errors.New("members can't change their own role")
+
+	ErrUnknownProvider    = errors.New("KEY_PROVIDER must be keyfile, keystore or kms")
+	ErrNoKeyProvider      = errors.New("server-side encryption needs a key provider, set KEY_PROVIDER")
+	ErrWeakKey            = errors.New("key-encryption key must be 32 random bytes, e.g. from 'openssl rand -base64 32'")
+	ErrWeakPassphrase     = errors.New("keystore passphrase is too short or a known default")
+	ErrKeyfilePermissions = errors.New("keyfile must not be accessible by group or others, chmod 600 it")
+	ErrKeystoreLocked     = errors.New("keystore passphrase is wrong or the keystore is corrupted")
+	ErrInsecureKMS        = errors.New("KMS_URL must use https unless the KMS runs on localhost")
+	ErrKMS                = errors.New("key management service request failed")
+
+	ErrInvalidRefreshToken = errors.New("refresh token is invalid, expired or revoked")
+	ErrInvalidTOTP         = errors.New("two-factor code is invalid or was already used")
+	ErrTOTPEnabled         = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrTOTPNotPending      = errors.New("two-factor authentication setup was not started")
 )
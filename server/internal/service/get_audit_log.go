@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+)
+
+// GetAuditLog returns a page of the user's audit log, newest first, with every entry checked
+// against its MAC. A positive beforeSeq continues after the last entry of the previous page.
+func (s *Vault) GetAuditLog(ctx context.Context, login string, beforeSeq int64, pageSize int) ([]models.AuditEntry, error) {
+	if login == "" || beforeSeq < 0 || pageSize < 0 {
+		return nil, ErrMalformedRequest
+	}
+
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	pageSize = min(pageSize, MaxPageSize)
+
+	key, err := s.loadAuditKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.Storage.ListAudit(ctx, login, beforeSeq, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		entries[i].Authentic = authenticAudit(key, entries[i])
+	}
+	return entries, nil
+}
@@ -3,34 +3,80 @@ package service
 import (
 	"context"
 	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"errors"
 )
 
-// GetData retrieves and decrypts all data for a specific user
-func (s *Vault) GetData(ctx context.Context, login string) ([]models.Data, error) {
-	var res []models.Data
+// GetData retrieves a page of a specific user's data matching the query, newest first, removing the
+// server-side encryption layer if enabled. The returned payloads are still encrypted with the user's
+// client-side key. Metadata is only readable after decryption, so entries are read in batches
+// and filtered here until the page is full.
+func (s *Vault) GetData(ctx context.Context, login string, query models.DataQuery) (models.DataPage, error) {
+	page := models.DataPage{Data: make([]models.Data, 0)}
 
-	if login == "" {
-		return nil, ErrMalformedRequest
+	if login == "" || query.PageSize < 0 {
+		return page, ErrMalformedRequest
 	}
 
-	data, err := s.Storage.GetData(ctx, login)
+	size := query.PageSize
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	size = min(size, MaxPageSize)
+
+	cursor, err := decodePageToken(query.PageToken)
 	if err != nil {
-		return nil, err
+		return page, err
 	}
 
-	for _, d := range data {
-		decryptedData, err := s.decryptBytes(ctx, d.Data)
-		if err != nil {
-			return nil, err
+	// One entry more than the page tells whether another page follows
+	batch := size + 1
+	for {
+		data, err := s.Storage.GetData(ctx, login, query, cursor, uint64(batch))
+		if err != nil && !errors.Is(err, storage.ErrNoDataFound) {
+			return page, err
+		}
+
+		for _, d := range data {
+			if len(page.Data) == size {
+				page.NextPageToken = encodePageToken(cursor)
+				return page, nil
+			}
+			cursor = models.PageCursor{UploadedAt: d.UploadedAt, ID: d.ID}
+
+			d, err = s.openData(ctx, d)
+			if err != nil {
+				return page, err
+			}
+			if matchMetadata(d.Metadata, query.Metadata) {
+				page.Data = append(page.Data, d)
+			}
+		}
+
+		if len(data) < batch {
+			return page, nil
 		}
-		res = append(res, models.Data{
-			ID:         d.ID,
-			User:       d.User,
-			Status:     d.Status,
-			Type:       d.Type,
-			Data:       decryptedData,
-			UploadedAt: d.UploadedAt,
-		})
 	}
-	return res, nil
+}
+
+// openData removes the server-side encryption layer from a stored entry if it is enabled and decodes its metadata
+func (s *Vault) openData(ctx context.Context, d models.Data) (models.Data, error) {
+	metadata, err := s.openMetadata(ctx, d.RawMetadata)
+	if err != nil {
+		return d, err
+	}
+	d.Metadata = metadata
+	d.RawMetadata = nil
+
+	if !s.serverEncryption() {
+		return d, nil
+	}
+
+	payload, err := s.decryptBytes(ctx, d.Data)
+	if err != nil {
+		return d, err
+	}
+	d.Data = payload
+
+	return d, nil
 }
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetData(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		query  models.DataQuery
+		expect []string
+	}{
+		{
+			name:   "all entries newest first",
+			expect: []string{"card", "work", "home"},
+		},
+		{
+			name:   "by type",
+			query:  models.DataQuery{Type: "card"},
+			expect: []string{"card"},
+		},
+		{
+			name:   "by metadata key",
+			query:  models.DataQuery{Metadata: map[string]string{"site": ""}},
+			expect: []string{"work", "home"},
+		},
+		{
+			name:   "by metadata pair",
+			query:  models.DataQuery{Metadata: map[string]string{"site": "work.example"}},
+			expect: []string{"work"},
+		},
+		{
+			name:  "no match",
+			query: models.DataQuery{Metadata: map[string]string{"site": "other.example"}},
+		},
+	}
+
+	for name, cfg := range testConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			vault, _ := newTestVault(t, cfg)
+
+			postTestData(t, vault, "alice", "home", map[string]string{"site": "home.example"})
+			postTestData(t, vault, "alice", "work", map[string]string{"site": "work.example"})
+			require.NoError(t, vault.PostData(ctx, "alice", "card", []byte("card"), nil))
+
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					page, err := vault.GetData(ctx, "alice", tt.query)
+					require.NoError(t, err)
+					assert.Empty(t, page.NextPageToken)
+
+					got := make([]string, 0, len(page.Data))
+					for _, d := range page.Data {
+						got = append(got, string(d.Data))
+					}
+					assert.Equal(t, append([]string{}, tt.expect...), got)
+				})
+			}
+		})
+	}
+}
+
+func TestGetData_Pages(t *testing.T) {
+	ctx := context.Background()
+	vault, _ := newTestVault(t, testConfig())
+
+	for _, payload := range []string{"one", "two", "three", "four", "five"} {
+		postTestData(t, vault, "alice", payload, map[string]string{"n": payload})
+	}
+
+	var got []string
+	query := models.DataQuery{PageSize: 2}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3, "Five entries should fit three pages")
+
+		page, err := vault.GetData(ctx, "alice", query)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(page.Data), 2)
+		for _, d := range page.Data {
+			got = append(got, string(d.Data))
+		}
+
+		if page.NextPageToken == "" {
+			break
+		}
+		query.PageToken = page.NextPageToken
+	}
+	assert.Equal(t, []string{"five", "four", "three", "two", "one"}, got)
+
+	_, err := vault.GetData(ctx, "alice", models.DataQuery{PageToken: "not a token"})
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	_, err = vault.GetData(ctx, "alice", models.DataQuery{PageSize: -1})
+	assert.ErrorIs(t, err, ErrMalformedRequest)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+)
+
+// GetPublicKey returns the public key a user published for sharing
+func (s *Vault) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	if login == "" {
+		return nil, ErrMalformedRequest
+	}
+
+	return s.Storage.GetPublicKey(ctx, login)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"errors"
+	"time"
+)
+
+// InviteMember adds a user to an organization with a role, or changes the role of a member.
+// Owners and admins manage members, but only owners grant or take away the owner role.
+// The wrapped key is the organization key sealed by the inviter's client to the invitee's public key.
+func (s *Vault) InviteMember(ctx context.Context, login, org, invitee, role string, wrappedKey []byte) error {
+	if login == "" || org == "" || invitee == "" || len(wrappedKey) == 0 {
+		return ErrMalformedRequest
+	}
+
+	if !models.ValidRole(role) {
+		return ErrInvalidRole
+	}
+
+	if invitee == login {
+		return ErrSelfRole
+	}
+
+	inviter, err := s.Storage.GetMember(ctx, org, login)
+	if errors.Is(err, storage.ErrMemberNotFound) {
+		return ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+
+	if !models.RoleAllows(inviter.Role, models.ActionManage) {
+		return ErrForbidden
+	}
+
+	joinedAt := time.Now().UTC().Format(time.RFC3339)
+	current, err := s.Storage.GetMember(ctx, org, invitee)
+	switch {
+	case err == nil:
+		joinedAt = current.JoinedAt
+		if current.Role == models.RoleOwner && inviter.Role != models.RoleOwner {
+			return ErrForbidden
+		}
+	case !errors.Is(err, storage.ErrMemberNotFound):
+		return err
+	}
+
+	if role == models.RoleOwner && inviter.Role != models.RoleOwner {
+		return ErrForbidden
+	}
+
+	// The invitee can only have been wrapped for if they published a key
+	if _, err := s.Storage.GetPublicKey(ctx, invitee); err != nil {
+		return err
+	}
+
+	return s.Storage.PutMember(ctx, models.Member{
+		Org:        org,
+		Login:      invitee,
+		Role:       role,
+		WrappedKey: wrappedKey,
+		JoinedAt:   joinedAt,
+	})
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"data-vault/server/internal/config"
+)
+
+// Key provider names accepted in KEY_PROVIDER
+const (
+	ProviderKeyfile  = "keyfile"
+	ProviderKeystore = "keystore"
+	ProviderKMS      = "kms"
+)
+
+// minDistinctKeyBytes is the least number of distinct byte values a 32 byte key-encryption key
+// must have; random keys have about 30, so fewer means the key was typed rather than generated
+const minDistinctKeyBytes = 16
+
+// KeyProvider keeps the key-encryption keys and wraps the per-value data keys with them.
+// The key-encryption keys never leave the provider, the server only stores wrapped data keys.
+type KeyProvider interface {
+	// KeyID identifies the key-encryption key new data keys are wrapped with
+	KeyID() string
+	// WrapKey encrypts a data key with the current key-encryption key
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped by the key-encryption key with the given ID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// NewKeyProvider creates the key provider selected by KEY_PROVIDER, nil if none is configured
+func NewKeyProvider(cfg config.Config) (KeyProvider, error) {
+	switch cfg.KeyProvider {
+	case "":
+		return nil, nil
+	case ProviderKeyfile:
+		return newKeyfileProvider(cfg.KeyFiles)
+	case ProviderKeystore:
+		return newKeystoreProvider(cfg.KeystoreFile, cfg.KeystorePassphrase)
+	case ProviderKMS:
+		return newKMSProvider(cfg.KMSURL, cfg.KMSKeyID, cfg.KMSToken)
+	}
+	return nil, ErrUnknownProvider
+}
+
+// localKeys wraps data keys with AES-256-GCM key-encryption keys held in memory, as loaded
+// by the keyfile and keystore providers
+type localKeys struct {
+	keys   map[string]cipher.AEAD
+	active string
+}
+
+// add registers a key-encryption key and returns its ID, rejecting weak keys
+func (l *localKeys) add(prefix string, kek []byte) (string, error) {
+	if weakKey(kek) {
+		return "", ErrWeakKey
+	}
+
+	gcm, err := newGCM(kek)
+	if err != nil {
+		return "", err
+	}
+
+	id := prefix + keyFingerprint(kek)
+	if l.keys == nil {
+		l.keys = make(map[string]cipher.AEAD)
+	}
+	l.keys[id] = gcm
+	return id, nil
+}
+
+// KeyID returns the ID of the key new data keys are wrapped with
+func (l *localKeys) KeyID() string {
+	return l.active
+}
+
+// WrapKey encrypts a data key with the active key-encryption key
+func (l *localKeys) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	return seal(l.keys[l.active], dataKey)
+}
+
+// UnwrapKey decrypts a data key with the key-encryption key it was wrapped by
+func (l *localKeys) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	gcm, ok := l.keys[keyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	return open(gcm, wrapped)
+}
+
+// weakKey reports whether a key-encryption key isn't a random 256-bit key
+func weakKey(key []byte) bool {
+	if len(key) != dataKeySize {
+		return true
+	}
+
+	distinct := make(map[byte]struct{})
+	for _, b := range key {
+		distinct[b] = struct{}{}
+	}
+	return len(distinct) < minDistinctKeyBytes
+}
+
+// keyFingerprint returns a short public identifier of a key
+func keyFingerprint(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// defaultKey reports whether a legacy key is one of the publicly known example values
+func defaultKey(key string) bool {
+	return config.DefaultSecret(strings.TrimSpace(key))
+}
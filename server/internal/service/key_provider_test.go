@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPassphrase unlocks the keystores of the tests
+const testPassphrase = "correct horse battery staple"
+
+// randomKey returns 32 random bytes
+func randomKey(t *testing.T) []byte {
+	t.Helper()
+
+	key := make([]byte, dataKeySize)
+	_, err := io.ReadFull(rand.Reader, key)
+	require.NoError(t, err)
+	return key
+}
+
+// writeKeyfile stores a key-encryption key in base64 the way 'openssl rand -base64 32' does
+func writeKeyfile(t *testing.T, kek []byte, perm os.FileMode) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "kek")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(kek)+"\n"), perm))
+	require.NoError(t, os.Chmod(path, perm))
+	return path
+}
+
+// testWrapUnwrap checks that a provider unwraps what it wrapped
+func testWrapUnwrap(t *testing.T, provider KeyProvider) {
+	t.Helper()
+	ctx := context.Background()
+
+	dataKey := randomKey(t)
+	wrapped, err := provider.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.NotContains(t, string(wrapped), string(dataKey))
+
+	unwrapped, err := provider.UnwrapKey(ctx, provider.KeyID(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = provider.UnwrapKey(ctx, "other:"+provider.KeyID(), wrapped)
+	assert.Error(t, err)
+}
+
+func TestKeyfileProvider(t *testing.T) {
+	ctx := context.Background()
+
+	current := randomKey(t)
+	retired := randomKey(t)
+
+	tests := []struct {
+		name      string
+		paths     func(t *testing.T) []string
+		expectErr error
+	}{
+		{
+			name: "single keyfile",
+			paths: func(t *testing.T) []string {
+				return []string{writeKeyfile(t, current, 0o600)}
+			},
+		},
+		{
+			name: "retired keyfile",
+			paths: func(t *testing.T) []string {
+				return []string{writeKeyfile(t, current, 0o600), writeKeyfile(t, retired, 0o400)}
+			},
+		},
+		{
+			name:      "no keyfiles",
+			paths:     func(t *testing.T) []string { return nil },
+			expectErr: ErrNoKeyProvider,
+		},
+		{
+			name: "readable by others",
+			paths: func(t *testing.T) []string {
+				return []string{writeKeyfile(t, current, 0o644)}
+			},
+			expectErr: ErrKeyfilePermissions,
+		},
+		{
+			name: "short key",
+			paths: func(t *testing.T) []string {
+				return []string{writeKeyfile(t, current[:16], 0o600)}
+			},
+			expectErr: ErrWeakKey,
+		},
+		{
+			name: "typed key",
+			paths: func(t *testing.T) []string {
+				return []string{writeKeyfile(t, []byte(strings.Repeat("ab", 16)), 0o600)}
+			},
+			expectErr: ErrWeakKey,
+		},
+		{
+			name: "not base64",
+			paths: func(t *testing.T) []string {
+				path := filepath.Join(t.TempDir(), "kek")
+				require.NoError(t, os.WriteFile(path, []byte("not a key!"), 0o600))
+				return []string{path}
+			},
+			expectErr: ErrWeakKey,
+		},
+		{
+			name: "missing keyfile",
+			paths: func(t *testing.T) []string {
+				return []string{filepath.Join(t.TempDir(), "missing")}
+			},
+			expectErr: os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := newKeyfileProvider(tt.paths(t))
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, keyfilePrefix+keyFingerprint(current), provider.KeyID(), "The first keyfile holds the active key")
+			testWrapUnwrap(t, provider)
+		})
+	}
+
+	old, err := newKeyfileProvider([]string{writeKeyfile(t, retired, 0o600)})
+	require.NoError(t, err)
+	wrapped, err := old.WrapKey(ctx, current)
+	require.NoError(t, err)
+
+	rotated, err := newKeyfileProvider([]string{writeKeyfile(t, current, 0o600), writeKeyfile(t, retired, 0o600)})
+	require.NoError(t, err)
+	unwrapped, err := rotated.UnwrapKey(ctx, old.KeyID(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, current, unwrapped, "Retired keys should still unwrap their data keys")
+}
+
+func TestKeystoreProvider(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "keystore.json")
+
+	_, err := newKeystoreProvider(path, testPassphrase)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = AddKeystoreKey(path, "short")
+	assert.ErrorIs(t, err, ErrWeakPassphrase)
+	assert.NoFileExists(t, path)
+
+	first, err := AddKeystoreKey(path, testPassphrase)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first, keystorePrefix))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Mode().Perm()&0o077, "The keystore must not be readable by others")
+
+	provider, err := newKeystoreProvider(path, testPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, first, provider.KeyID())
+	testWrapUnwrap(t, provider)
+
+	wrapped, err := provider.WrapKey(ctx, randomKey(t))
+	require.NoError(t, err)
+
+	before, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	_, err = AddKeystoreKey(path, "a different passphrase")
+	assert.ErrorIs(t, err, ErrKeystoreLocked)
+
+	after, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "A wrong passphrase must leave the keystore untouched")
+
+	_, err = newKeystoreProvider(path, "a different passphrase")
+	assert.ErrorIs(t, err, ErrKeystoreLocked)
+
+	second, err := AddKeystoreKey(path, testPassphrase)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "No temporary files should be left behind")
+
+	rotated, err := newKeystoreProvider(path, testPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, second, rotated.KeyID(), "The newest key should be the active one")
+
+	_, err = rotated.UnwrapKey(ctx, first, wrapped)
+	assert.NoError(t, err, "Older keys should still unwrap their data keys")
+}
+
+// fakeKMS is an in-memory KMS speaking the HTTP API of kmsProvider
+type fakeKMS struct {
+	kek     *localKeys
+	token   string
+	unwraps atomic.Int32
+	fail    atomic.Bool
+}
+
+func newFakeKMS(t *testing.T) (*fakeKMS, *httptest.Server) {
+	t.Helper()
+
+	kms := &fakeKMS{kek: &localKeys{}, token: "kms-token"}
+	id, err := kms.kek.add("", randomKey(t))
+	require.NoError(t, err)
+	kms.kek.active = id
+
+	server := httptest.NewServer(kms)
+	t.Cleanup(server.Close)
+	return kms, server
+}
+
+func (f *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+f.token || f.fail.Load() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var req kmsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var resp kmsRequest
+	var err error
+	switch r.URL.Path {
+	case "/v1/keys/vault-key/wrap":
+		resp.Ciphertext, err = f.kek.WrapKey(r.Context(), req.Plaintext)
+	case "/v1/keys/vault-key/unwrap":
+		f.unwraps.Add(1)
+		resp.Plaintext, err = f.kek.UnwrapKey(r.Context(), f.kek.active, req.Ciphertext)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestKMSProvider(t *testing.T) {
+	ctx := context.Background()
+	kms, server := newFakeKMS(t)
+
+	provider, err := newKMSProvider(server.URL+"/", "vault-key", kms.token)
+	require.NoError(t, err)
+	assert.Equal(t, kmsPrefix+"vault-key", provider.KeyID())
+	testWrapUnwrap(t, provider)
+
+	unauthorized, err := newKMSProvider(server.URL, "vault-key", "wrong-token")
+	require.NoError(t, err)
+	_, err = unauthorized.WrapKey(ctx, randomKey(t))
+	assert.ErrorIs(t, err, ErrKMS)
+
+	_, err = provider.UnwrapKey(ctx, kmsPrefix+"vault-key", []byte("garbage"))
+	assert.ErrorIs(t, err, ErrKMS)
+}
+
+func TestKMSProvider_Cache(t *testing.T) {
+	ctx := context.Background()
+	kms, server := newFakeKMS(t)
+
+	provider, err := newKMSProvider(server.URL, "vault-key", kms.token)
+	require.NoError(t, err)
+
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	dataKey := randomKey(t)
+	wrapped, err := provider.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		unwrapped, err := provider.UnwrapKey(ctx, provider.KeyID(), wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, dataKey, unwrapped)
+	}
+	assert.Equal(t, int32(1), kms.unwraps.Load(), "Repeated unwraps should be served from the cache")
+
+	kms.fail.Store(true)
+	_, err = provider.UnwrapKey(ctx, provider.KeyID(), wrapped)
+	assert.NoError(t, err, "A cached key doesn't need the KMS")
+
+	now = now.Add(kmsCacheTTL)
+	_, err = provider.UnwrapKey(ctx, provider.KeyID(), wrapped)
+	assert.ErrorIs(t, err, ErrKMS, "An expired key must be unwrapped again")
+
+	kms.fail.Store(false)
+	_, err = provider.UnwrapKey(ctx, provider.KeyID(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), kms.unwraps.Load())
+
+	other, err := provider.WrapKey(ctx, randomKey(t))
+	require.NoError(t, err)
+	_, err = provider.UnwrapKey(ctx, provider.KeyID(), other)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), kms.unwraps.Load(), "Each wrapped key is cached separately")
+}
+
+func TestNewKMSProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		keyID     string
+		expectErr error
+	}{
+		{name: "https", url: "https://kms.example.com", keyID: "vault-key"},
+		{name: "http on localhost", url: "http://localhost:8200", keyID: "vault-key"},
+		{name: "http on loopback", url: "http://127.0.0.1:8200", keyID: "vault-key"},
+		{name: "http on remote host", url: "http://kms.example.com", keyID: "vault-key", expectErr: ErrInsecureKMS},
+		{name: "no url", keyID: "vault-key", expectErr: ErrNoKeyProvider},
+		{name: "no key", url: "https://kms.example.com", expectErr: ErrNoKeyProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newKMSProvider(tt.url, tt.keyID, "")
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
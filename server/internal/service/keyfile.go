@@ -0,0 +1,51 @@
+package service
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+)
+
+// keyfilePrefix starts the IDs of key-encryption keys loaded from keyfiles
+const keyfilePrefix = "file:"
+
+// newKeyfileProvider loads key-encryption keys from keyfiles holding 32 random bytes in base64,
+// e.g. made with 'openssl rand -base64 32'. The first file holds the active key, the others
+// keep data keys wrapped by retired keys readable until rotate-keys re-wraps them.
+// Keyfiles must not be accessible to other users.
+func newKeyfileProvider(paths []string) (*localKeys, error) {
+	if len(paths) == 0 {
+		return nil, ErrNoKeyProvider
+	}
+
+	var keys localKeys
+	for i, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode().Perm()&0o077 != 0 {
+			return nil, ErrKeyfilePermissions
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		kek, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+		if err != nil {
+			return nil, ErrWeakKey
+		}
+
+		id, err := keys.add(keyfilePrefix, kek)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			keys.active = id
+		}
+	}
+
+	return &keys, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// Ciphertext formats. Values are sealed in envelopes: a random data key per value, wrapped by
+// the key-encryption key of the KeyProvider. Earlier versions sealed values directly with a
+// versioned ENCRYPTION_KEYS key, or with ENCRYPTION_KEY and no header at all.
+const (
+	envelopeMarker  byte = 0xDE
+	keyMarker       byte = 0xDC
+	keyHeaderSize        = 5
+	dataKeySize          = 32
+	maxEnvelopeItem      = 1<<16 - 1
+)
+
+// legacyKeyVersion is the version of ENCRYPTION_KEY, which sealed ciphertexts without a header
+const legacyKeyVersion uint32 = 0
+
+// Keyring seals values in envelopes of the key provider and opens both envelopes and values
+// sealed with the legacy raw keys, so that old values stay readable until rotate-keys re-seals them
+type Keyring struct {
+	provider KeyProvider
+	legacy   map[uint32]cipher.AEAD
+}
+
+// NewKeyring creates a keyring sealing with the provider. Legacy keys are AES keys by version
+// that are only used to open values written before envelope encryption.
+func NewKeyring(provider KeyProvider, legacy map[uint32]string) (*Keyring, error) {
+	k := Keyring{
+		provider: provider,
+		legacy:   make(map[uint32]cipher.AEAD, len(legacy)),
+	}
+
+	for version, key := range legacy {
+		gcm, err := newGCM([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+		k.legacy[version] = gcm
+	}
+
+	return &k, nil
+}
+
+// Seal encrypts a value with a new data key and stores the wrapped data key alongside:
+// marker, key ID length and key ID, wrapped key length and wrapped key, nonce and ciphertext
+func (k *Keyring) Seal(ctx context.Context, plaintext []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	wrapped, err := k.provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := k.provider.KeyID()
+	if len(keyID) > maxEnvelopeItem || len(wrapped) > maxEnvelopeItem {
+		return nil, ErrCiphertext
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := seal(gcm, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []byte{envelopeMarker}
+	out = binary.BigEndian.AppendUint16(out, uint16(len(keyID)))
+	out = append(out, keyID...)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(wrapped)))
+	out = append(out, wrapped...)
+
+	return append(out, sealed...), nil
+}
+
+// Open decrypts an envelope with its unwrapped data key, or a legacy value with the raw key of
+// its version. Headerless legacy values are opened with key version 0.
+func (k *Keyring) Open(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	// A headerless legacy nonce may start with a marker by chance, so a failed envelope
+	// falls through to the legacy keys before its error is reported
+	var envelopeErr error
+	if keyID, wrapped, sealed, ok := parseEnvelope(ciphertext); ok {
+		plaintext, err := k.openEnvelope(ctx, keyID, wrapped, sealed)
+		if err == nil {
+			return plaintext, nil
+		}
+		envelopeErr = err
+	}
+
+	if len(ciphertext) >= keyHeaderSize && ciphertext[0] == keyMarker {
+		version := binary.BigEndian.Uint32(ciphertext[1:keyHeaderSize])
+		if gcm, ok := k.legacy[version]; ok {
+			if plaintext, err := open(gcm, ciphertext[keyHeaderSize:]); err == nil {
+				return plaintext, nil
+			}
+		}
+	}
+
+	// A headerless nonce may start with a marker by chance, so fall back to the oldest key
+	if gcm, ok := k.legacy[legacyKeyVersion]; ok {
+		if plaintext, err := open(gcm, ciphertext); err == nil {
+			return plaintext, nil
+		}
+	}
+
+	if envelopeErr != nil {
+		return nil, envelopeErr
+	}
+	return nil, ErrCiphertext
+}
+
+// openEnvelope unwraps the data key of an envelope and decrypts the value with it
+func (k *Keyring) openEnvelope(ctx context.Context, keyID string, wrapped, sealed []byte) ([]byte, error) {
+	dataKey, err := k.provider.UnwrapKey(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return open(gcm, sealed)
+}
+
+// Current reports whether a value is an envelope wrapped by the active key-encryption key
+func (k *Keyring) Current(ciphertext []byte) bool {
+	keyID, _, _, ok := parseEnvelope(ciphertext)
+	return ok && keyID == k.provider.KeyID()
+}
+
+// parseEnvelope splits an envelope into the key ID, the wrapped data key and the sealed value
+func parseEnvelope(ciphertext []byte) (string, []byte, []byte, bool) {
+	if len(ciphertext) == 0 || ciphertext[0] != envelopeMarker {
+		return "", nil, nil, false
+	}
+	rest := ciphertext[1:]
+
+	item := func() ([]byte, bool) {
+		if len(rest) < 2 {
+			return nil, false
+		}
+		n := int(binary.BigEndian.Uint16(rest))
+		if len(rest) < 2+n {
+			return nil, false
+		}
+		value := rest[2 : 2+n]
+		rest = rest[2+n:]
+		return value, true
+	}
+
+	keyID, ok := item()
+	if !ok {
+		return "", nil, nil, false
+	}
+	wrapped, ok := item()
+	if !ok {
+		return "", nil, nil, false
+	}
+
+	return string(keyID), wrapped, rest, true
+}
+
+// newGCM creates an AES-GCM cipher from a raw key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(c)
+}
+
+// seal encrypts a value with AES-GCM and prepends the random nonce
+func seal(gcm cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open splits the nonce off an AES-GCM ciphertext and decrypts it
+func open(gcm cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrCiphertext
+	}
+
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
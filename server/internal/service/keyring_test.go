@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	ctx := context.Background()
+
+	provider := &localKeys{}
+	id, err := provider.add(keyfilePrefix, randomKey(t))
+	require.NoError(t, err)
+	provider.active = id
+
+	const versionedKey = "versioned-key-0123456789abcdef01"
+	keyring, err := NewKeyring(provider, map[uint32]string{
+		legacyKeyVersion: testLegacyKey,
+		1:                versionedKey,
+	})
+	require.NoError(t, err)
+
+	envelope, err := keyring.Seal(ctx, []byte("secret"))
+	require.NoError(t, err)
+
+	versioned := func() []byte {
+		gcm, err := newGCM([]byte(versionedKey))
+		require.NoError(t, err)
+		sealed, err := seal(gcm, []byte("secret"))
+		require.NoError(t, err)
+		header := binary.BigEndian.AppendUint32([]byte{keyMarker}, 1)
+		return append(header, sealed...)
+	}()
+
+	tampered := append([]byte{}, envelope...)
+	tampered[len(tampered)-1] ^= 0xFF
+
+	tests := []struct {
+		name       string
+		ciphertext []byte
+		expectErr  bool
+		current    bool
+	}{
+		{name: "envelope", ciphertext: envelope, current: true},
+		{name: "versioned legacy key", ciphertext: versioned},
+		{name: "headerless legacy key", ciphertext: []byte(sealLegacy(t, "secret"))},
+		{name: "tampered envelope", ciphertext: tampered, expectErr: true},
+		{name: "garbage", ciphertext: []byte("not a ciphertext at all"), expectErr: true},
+		{name: "empty", ciphertext: nil, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext, err := keyring.Open(ctx, tt.ciphertext)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []byte("secret"), plaintext)
+			assert.Equal(t, tt.current, keyring.Current(tt.ciphertext))
+		})
+	}
+
+	again, err := keyring.Seal(ctx, []byte("secret"))
+	require.NoError(t, err)
+	assert.NotEqual(t, envelope, again, "Every value gets its own data key and nonce")
+
+	keyID, wrapped, _, ok := parseEnvelope(envelope)
+	require.True(t, ok)
+	assert.Equal(t, id, keyID)
+	assert.Len(t, wrapped, 12+dataKeySize+16)
+
+	rotated := &localKeys{}
+	newID, err := rotated.add(keyfilePrefix, randomKey(t))
+	require.NoError(t, err)
+	rotated.active = newID
+	other, err := NewKeyring(rotated, nil)
+	require.NoError(t, err)
+
+	assert.False(t, other.Current(envelope))
+	_, err = other.Open(ctx, envelope)
+	assert.ErrorIs(t, err, ErrUnknownKey, "Envelopes of a removed key can't be opened")
+}
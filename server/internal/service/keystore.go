@@ -0,0 +1,169 @@
+package service
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"data-vault/server/internal/config"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// keystorePrefix starts the IDs of key-encryption keys loaded from a keystore
+const keystorePrefix = "keystore:"
+
+// minPassphraseLength is the shortest passphrase a keystore can be unlocked with
+const minPassphraseLength = 12
+
+// keystoreFile is the on-disk form of a keystore: key-encryption keys sealed with a key
+// derived from the passphrase with Argon2id. Active is the ID of the key new data keys use.
+type keystoreFile struct {
+	Salt    []byte        `json:"salt"`
+	Time    uint32        `json:"time"`
+	Memory  uint32        `json:"memory"`
+	Threads uint8         `json:"threads"`
+	Active  string        `json:"active"`
+	Keys    []keystoreKey `json:"keys"`
+}
+
+// keystoreKey is a sealed key-encryption key of a keystore
+type keystoreKey struct {
+	ID     string `json:"id"`
+	Sealed []byte `json:"sealed"`
+}
+
+// newKeystoreProvider unlocks the key-encryption keys of a keystore file with its passphrase
+func newKeystoreProvider(path, passphrase string) (*localKeys, error) {
+	ks, gcm, err := unlockKeystore(path, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys localKeys
+	for _, k := range ks.Keys {
+		kek, err := open(gcm, k.Sealed)
+		if err != nil {
+			return nil, ErrKeystoreLocked
+		}
+		if _, err := keys.add(keystorePrefix, kek); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := keys.keys[ks.Active]; !ok {
+		return nil, ErrUnknownKey
+	}
+	keys.active = ks.Active
+
+	return &keys, nil
+}
+
+// AddKeystoreKey generates a new key-encryption key, stores it in the keystore and makes it
+// the active one. The keystore is created if it doesn't exist. It returns the ID of the new key.
+// The passphrase must open the keys already in the keystore, otherwise the new key would be
+// sealed with a passphrase that can't unlock the others.
+func AddKeystoreKey(path, passphrase string) (string, error) {
+	ks, gcm, err := unlockKeystore(path, passphrase)
+	if errors.Is(err, os.ErrNotExist) {
+		ks, gcm, err = newKeystore(passphrase)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, k := range ks.Keys {
+		if _, err := open(gcm, k.Sealed); err != nil {
+			return "", ErrKeystoreLocked
+		}
+	}
+
+	kek := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, kek); err != nil {
+		return "", err
+	}
+
+	sealed, err := seal(gcm, kek)
+	if err != nil {
+		return "", err
+	}
+
+	id := keystorePrefix + keyFingerprint(kek)
+	ks.Keys = append(ks.Keys, keystoreKey{ID: id, Sealed: sealed})
+	ks.Active = id
+
+	raw, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	// Write to a temporary file first so that a crash can't leave a truncated keystore
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".keystore-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// newKeystore creates an empty keystore with a new salt and the current Argon2id parameters
+func newKeystore(passphrase string) (keystoreFile, cipher.AEAD, error) {
+	ks := keystoreFile{
+		Salt:    make([]byte, passwordParams.saltLen),
+		Time:    passwordParams.time,
+		Memory:  passwordParams.memory,
+		Threads: passwordParams.threads,
+	}
+	if _, err := io.ReadFull(rand.Reader, ks.Salt); err != nil {
+		return ks, nil, err
+	}
+
+	gcm, err := keystoreCipher(ks, passphrase)
+	return ks, gcm, err
+}
+
+// unlockKeystore reads a keystore and derives the key its key-encryption keys are sealed with
+func unlockKeystore(path, passphrase string) (keystoreFile, cipher.AEAD, error) {
+	var ks keystoreFile
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ks, nil, err
+	}
+	if err := json.Unmarshal(raw, &ks); err != nil {
+		return ks, nil, err
+	}
+
+	gcm, err := keystoreCipher(ks, passphrase)
+	return ks, gcm, err
+}
+
+// keystoreCipher derives the passphrase key of a keystore, rejecting weak passphrases
+func keystoreCipher(ks keystoreFile, passphrase string) (cipher.AEAD, error) {
+	if len(passphrase) < minPassphraseLength || config.DefaultSecret(passphrase) {
+		return nil, ErrWeakPassphrase
+	}
+
+	key := argon2.IDKey([]byte(passphrase), ks.Salt, ks.Time, ks.Memory, ks.Threads, dataKeySize)
+	return newGCM(key)
+}
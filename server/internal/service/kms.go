@@ -0,0 +1,199 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// kmsPrefix starts the IDs of key-encryption keys held by a KMS
+const kmsPrefix = "kms:"
+
+// kmsTimeout bounds a single wrap or unwrap request to the KMS
+const kmsTimeout = 10 * time.Second
+
+// Every value has its own data key, so reading a page of records unwraps one key per record.
+// Unwrapped keys are kept in memory for a while so that reading the same records again, e.g.
+// paging back and forth or syncing several devices, doesn't cost a KMS request each time.
+const (
+	kmsCacheTTL  = 5 * time.Minute
+	kmsCacheSize = 10000
+)
+
+// kmsProvider wraps data keys with a key that never leaves an external key management service.
+// It speaks a minimal HTTP API: POST {url}/v1/keys/{id}/wrap with {"plaintext": base64} returns
+// {"ciphertext": base64}, and POST {url}/v1/keys/{id}/unwrap does the reverse.
+type kmsProvider struct {
+	url    string
+	keyID  string
+	token  string
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cachedKey
+	now   func() time.Time
+}
+
+// cachedKey is an unwrapped data key and the time it has to be unwrapped again
+type cachedKey struct {
+	key     []byte
+	expires time.Time
+}
+
+// kmsRequest is the body of a wrap or unwrap request and response
+type kmsRequest struct {
+	Plaintext  []byte `json:"plaintext,omitempty"`
+	Ciphertext []byte `json:"ciphertext,omitempty"`
+}
+
+// newKMSProvider creates a provider for the KMS key. The KMS must be reached over HTTPS
+// unless it runs on the same host, since data keys travel to it in the clear.
+func newKMSProvider(rawURL, keyID, token string) (*kmsProvider, error) {
+	if rawURL == "" || keyID == "" {
+		return nil, ErrNoKeyProvider
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" && !(u.Scheme == "http" && loopbackHost(u.Hostname())) {
+		return nil, ErrInsecureKMS
+	}
+
+	return &kmsProvider{
+		url:    strings.TrimSuffix(rawURL, "/"),
+		keyID:  keyID,
+		token:  token,
+		client: &http.Client{Timeout: kmsTimeout},
+		cache:  make(map[string]cachedKey),
+		now:    time.Now,
+	}, nil
+}
+
+// KeyID returns the ID of the KMS key new data keys are wrapped with
+func (p *kmsProvider) KeyID() string {
+	return kmsPrefix + p.keyID
+}
+
+// WrapKey asks the KMS to encrypt a data key
+func (p *kmsProvider) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	resp, err := p.call(ctx, p.keyID, "wrap", kmsRequest{Plaintext: dataKey})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Ciphertext, nil
+}
+
+// UnwrapKey asks the KMS to decrypt a data key wrapped by one of its keys, unless it was
+// unwrapped recently
+func (p *kmsProvider) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	id, ok := strings.CutPrefix(keyID, kmsPrefix)
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	cacheKey := keyID + "\x00" + string(wrapped)
+	if dataKey, ok := p.cached(cacheKey); ok {
+		return dataKey, nil
+	}
+
+	resp, err := p.call(ctx, id, "unwrap", kmsRequest{Ciphertext: wrapped})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Plaintext) != dataKeySize {
+		return nil, ErrCiphertext
+	}
+
+	p.store(cacheKey, resp.Plaintext)
+	return resp.Plaintext, nil
+}
+
+// cached returns a data key unwrapped less than kmsCacheTTL ago
+func (p *kmsProvider) cached(cacheKey string) ([]byte, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, ok := p.cache[cacheKey]
+	if !ok {
+		return nil, false
+	}
+	if !p.now().Before(entry.expires) {
+		delete(p.cache, cacheKey)
+		return nil, false
+	}
+	return entry.key, true
+}
+
+// store caches an unwrapped data key. A full cache drops its expired keys first and is
+// emptied if that doesn't make room.
+func (p *kmsProvider) store(cacheKey string, dataKey []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if len(p.cache) >= kmsCacheSize {
+		for k, entry := range p.cache {
+			if !now.Before(entry.expires) {
+				delete(p.cache, k)
+			}
+		}
+	}
+	if len(p.cache) >= kmsCacheSize {
+		clear(p.cache)
+	}
+
+	p.cache[cacheKey] = cachedKey{key: dataKey, expires: now.Add(kmsCacheTTL)}
+}
+
+// call sends an operation on a KMS key and decodes the response
+func (p *kmsProvider) call(ctx context.Context, keyID, op string, body kmsRequest) (kmsRequest, error) {
+	var out kmsRequest
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return out, err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/keys/%s/%s", p.url, url.PathEscape(keyID), op)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return out, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return out, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return out, fmt.Errorf("%w: %s %s", ErrKMS, op, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return out, fmt.Errorf("%w: %v", ErrKMS, err)
+	}
+	return out, nil
+}
+
+// loopbackHost reports whether a host name refers to the local machine
+func loopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"errors"
+)
+
+// ListMembers returns the members of an organization the user belongs to. The keys wrapped for
+// the other members are left out.
+func (s *Vault) ListMembers(ctx context.Context, login, org string) ([]models.Member, error) {
+	if login == "" || org == "" {
+		return nil, ErrMalformedRequest
+	}
+
+	role, err := s.MemberRole(ctx, org, login)
+	if errors.Is(err, storage.ErrMemberNotFound) {
+		return nil, ErrForbidden
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !models.RoleAllows(role, models.ActionRead) {
+		return nil, ErrForbidden
+	}
+
+	members, err := s.Storage.ListMembers(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range members {
+		if members[i].Login != login {
+			members[i].WrappedKey = nil
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+)
+
+// ListOrgs returns the memberships of the user with the organization keys wrapped for them
+func (s *Vault) ListOrgs(ctx context.Context, login string) ([]models.Member, error) {
+	if login == "" {
+		return nil, ErrMalformedRequest
+	}
+
+	return s.Storage.ListOrgs(ctx, login)
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+)
+
+// ListSessions returns the live sessions of a user, most recently used first
+func (s *Vault) ListSessions(ctx context.Context, login string) ([]models.Session, error) {
+	if login == "" {
+		return nil, ErrMalformedRequest
+	}
+
+	return s.Storage.ListSessions(ctx, login)
+}
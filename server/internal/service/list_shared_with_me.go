@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+)
+
+// ListSharedWithMe returns the data entries other users shared with a user, most recently shared first
+func (s *Vault) ListSharedWithMe(ctx context.Context, login string) ([]models.SharedData, error) {
+	if login == "" {
+		return nil, ErrMalformedRequest
+	}
+
+	shared, err := s.Storage.ListSharedWithMe(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sd := range shared {
+		shared[i].Data, err = s.openData(ctx, sd.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return shared, nil
+}
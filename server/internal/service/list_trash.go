@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+)
+
+// ListTrash returns the trashed data entries of a user, most recently deleted first
+func (s *Vault) ListTrash(ctx context.Context, login string) ([]models.Data, error) {
+	if login == "" {
+		return nil, ErrMalformedRequest
+	}
+
+	data, err := s.Storage.ListTrash(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, d := range data {
+		data[i], err = s.openData(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+)
+
+// ListVersions returns the prior versions of a user's data entry, newest first
+func (s *Vault) ListVersions(ctx context.Context, login, id string) ([]models.Version, error) {
+	if login == "" || id == "" {
+		return nil, ErrMalformedRequest
+	}
+
+	versions, err := s.Storage.ListVersions(ctx, login, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range versions {
+		versions[i], err = s.openVersion(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+// openVersion removes the server-side encryption layer from a prior version and decodes its metadata
+func (s *Vault) openVersion(ctx context.Context, v models.Version) (models.Version, error) {
+	metadata, err := s.openMetadata(ctx, v.RawMetadata)
+	if err != nil {
+		return v, err
+	}
+	v.Metadata = metadata
+	v.RawMetadata = nil
+
+	if !s.serverEncryption() {
+		return v, nil
+	}
+
+	payload, err := s.decryptBytes(ctx, v.Data)
+	if err != nil {
+		return v, err
+	}
+	v.Data = payload
+
+	return v, nil
+}
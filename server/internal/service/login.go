@@ -3,23 +3,88 @@ package service
 import (
 	"context"
 	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"errors"
 )
 
-// Login authenticates a user with encrypted password verification
-func (s *Vault) Login(ctx context.Context, user models.User) error {
+// Login verifies a user's password against the stored hash and returns the key derivation salt.
+// Hashes made with outdated parameters or the legacy encryption are replaced on success. An account
+// that still logs in with the master password is switched to the derived authentication key the
+// client sends along, so the master password isn't sent again.
+func (s *Vault) Login(ctx context.Context, user models.User) ([]byte, error) {
 	if user.Login == "" || user.Password == "" {
-		return ErrMalformedRequest
+		return nil, ErrMalformedRequest
 	}
 
-	cipherPassword, err := s.encrypt(ctx, user.Password)
+	stored, err := s.Storage.GetUser(ctx, user.Login)
 	if err != nil {
-		return err
+		if errors.Is(err, storage.ErrWrongPassword) {
+			verifyPassword(user.Password, dummyHash)
+		}
+		return nil, err
 	}
-	user.Password = cipherPassword
 
-	err = s.Storage.Login(ctx, user)
+	var ok, needsRehash bool
+	if isLegacyPassword(stored.Password) {
+		ok, err = s.verifyLegacyPassword(ctx, user.Password, stored.Password)
+		needsRehash = true
+	} else {
+		ok, needsRehash, err = verifyPassword(user.Password, stored.Password)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, storage.ErrWrongPassword
+	}
+
+	if stored.RawPassword && user.AuthKey != "" {
+		return s.upgradePassword(ctx, user, stored.Salt), nil
+	}
+
+	if needsRehash {
+		hash, err := hashPassword(user.Password)
+		if err == nil {
+			err = s.Storage.UpdatePassword(ctx, user.Login, hash)
+		}
+		if err != nil {
+			s.Log.Warn("failed to rehash password", "login", user.Login, "error", err)
+		}
+	}
+
+	if len(stored.Salt) == 0 {
+		return s.issueSalt(ctx, user.Login), nil
+	}
+
+	return stored.Salt, nil
+}
+
+// issueSalt stores the salt PreLogin hands out for an account created before salts existed, so
+// the client derives its vault key with it from now on. A failure is only logged: the salt is
+// stable, it is stored on a later login.
+func (s *Vault) issueSalt(ctx context.Context, login string) []byte {
+	salt := s.issuedSalt(login)
+	if err := s.Storage.SetSalt(ctx, login, salt); err != nil {
+		s.Log.Warn("failed to store key derivation salt", "login", login, "error", err)
+	}
+	return salt
+}
+
+// upgradePassword replaces the master password hash of an account with the hash of the derived
+// authentication key and returns the salt the client derived it with. A failure is only logged,
+// the account keeps logging in with the master password and is upgraded on a later login.
+func (s *Vault) upgradePassword(ctx context.Context, user models.User, salt []byte) []byte {
+	if len(salt) == 0 {
+		salt = s.issuedSalt(user.Login)
+	}
+
+	hash, err := hashPassword(user.AuthKey)
+	if err == nil {
+		err = s.Storage.UpgradePassword(ctx, user.Login, hash, salt)
+	}
 	if err != nil {
-		return err
+		s.Log.Warn("failed to switch account to the derived authentication key", "login", user.Login, "error", err)
 	}
-	return nil
+
+	return salt
 }
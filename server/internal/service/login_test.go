@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogin(t *testing.T) {
+	ctx := context.Background()
+	salt := []byte("test-kdf-salt-16")
+
+	weak := passwordParams
+	weak.memory = 8 * 1024
+	weak.time = 1
+
+	tests := []struct {
+		name         string
+		setup        func(t *testing.T, store *storage.Storage)
+		user         models.User
+		expectErr    error
+		expectSalt   []byte
+		expectIssued bool
+		expectRehash bool
+		expectRaw    bool
+		expectLogin  string
+	}{
+		{
+			name: "hashed password",
+			setup: func(t *testing.T, store *storage.Storage) {
+				hash, err := hashPassword("auth-key")
+				require.NoError(t, err)
+				require.NoError(t, store.Register(ctx, models.User{Login: "alice", Password: hash, Salt: salt}))
+			},
+			user:        models.User{Login: "alice", Password: "auth-key"},
+			expectSalt:  salt,
+			expectLogin: "auth-key",
+		},
+		{
+			name: "wrong password",
+			setup: func(t *testing.T, store *storage.Storage) {
+				hash, err := hashPassword("auth-key")
+				require.NoError(t, err)
+				require.NoError(t, store.Register(ctx, models.User{Login: "alice", Password: hash, Salt: salt}))
+			},
+			user:      models.User{Login: "alice", Password: "wrong-key"},
+			expectErr: storage.ErrWrongPassword,
+		},
+		{
+			name:      "unknown login",
+			setup:     func(t *testing.T, store *storage.Storage) {},
+			user:      models.User{Login: "nobody", Password: "auth-key"},
+			expectErr: storage.ErrWrongPassword,
+		},
+		{
+			name:      "empty password",
+			setup:     func(t *testing.T, store *storage.Storage) {},
+			user:      models.User{Login: "alice"},
+			expectErr: ErrMalformedRequest,
+		},
+		{
+			name: "rehash outdated parameters",
+			setup: func(t *testing.T, store *storage.Storage) {
+				hash := withParams(t, weak, "auth-key")
+				require.NoError(t, store.Register(ctx, models.User{Login: "alice", Password: hash, Salt: salt}))
+			},
+			user:         models.User{Login: "alice", Password: "auth-key"},
+			expectSalt:   salt,
+			expectRehash: true,
+			expectLogin:  "auth-key",
+		},
+		{
+			name: "hashed master password without salt",
+			setup: func(t *testing.T, store *storage.Storage) {
+				hash, err := hashPassword("master-password")
+				require.NoError(t, err)
+				insertLegacyUser(t, store, "alice", hash)
+			},
+			user:         models.User{Login: "alice", Password: "master-password"},
+			expectIssued: true,
+			expectRaw:    true,
+			expectLogin:  "master-password",
+		},
+		{
+			name: "switch to the derived authentication key",
+			setup: func(t *testing.T, store *storage.Storage) {
+				hash, err := hashPassword("master-password")
+				require.NoError(t, err)
+				insertLegacyUser(t, store, "alice", hash)
+			},
+			user:         models.User{Login: "alice", Password: "master-password", AuthKey: "auth-key"},
+			expectIssued: true,
+			expectLogin:  "auth-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault, store := newTestVault(t, testConfig())
+			tt.setup(t, store)
+
+			before, _ := store.GetUser(ctx, tt.user.Login)
+
+			got, err := vault.Login(ctx, tt.user)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			expectSalt := tt.expectSalt
+			if tt.expectIssued {
+				expectSalt = vault.issuedSalt(tt.user.Login)
+			}
+			assert.Equal(t, expectSalt, got)
+
+			after, err := store.GetUser(ctx, tt.user.Login)
+			require.NoError(t, err)
+			assert.Equal(t, expectSalt, after.Salt, "The returned salt should be stored")
+			assert.Equal(t, tt.expectRaw, after.RawPassword)
+
+			if tt.expectRehash {
+				assert.NotEqual(t, before.Password, after.Password)
+			}
+			ok, rehash, err := verifyPassword(tt.expectLogin, after.Password)
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.False(t, rehash, "The stored hash should use the current parameters")
+
+			salt, raw, err := vault.PreLogin(ctx, tt.user.Login)
+			require.NoError(t, err)
+			assert.Equal(t, expectSalt, salt, "PreLogin should hand out the salt the key is derived with")
+			assert.Equal(t, tt.expectRaw, raw)
+		})
+	}
+}
+
+func TestLogin_LegacyEncryptedPassword(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := withKeyfile(t, testConfig())
+	cfg.EncryptionKey = testLegacyKey
+	vault, store := newTestVault(t, cfg)
+
+	insertLegacyUser(t, store, "alice", sealLegacy(t, "master-password"))
+
+	_, err := vault.Login(ctx, models.User{Login: "alice", Password: "wrong-password"})
+	assert.ErrorIs(t, err, storage.ErrWrongPassword)
+
+	salt, err := vault.Login(ctx, models.User{Login: "alice", Password: "master-password"})
+	require.NoError(t, err)
+	assert.Equal(t, vault.issuedSalt("alice"), salt)
+
+	stored, err := store.GetUser(ctx, "alice")
+	require.NoError(t, err)
+	assert.False(t, isLegacyPassword(stored.Password), "The encrypted password should be replaced by a hash")
+	assert.Equal(t, salt, stored.Salt)
+	assert.True(t, stored.RawPassword)
+
+	ok, _, err := verifyPassword("master-password", stored.Password)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestPreLogin_UnknownLogin(t *testing.T) {
+	ctx := context.Background()
+	vault, _ := newTestVault(t, testConfig())
+
+	salt, raw, err := vault.PreLogin(ctx, "nobody")
+	require.NoError(t, err)
+	assert.Len(t, salt, kdfSaltSize)
+	assert.False(t, raw)
+
+	again, _, err := vault.PreLogin(ctx, "nobody")
+	require.NoError(t, err)
+	assert.Equal(t, salt, again, "Unknown logins should get a stable salt")
+
+	other, _, err := vault.PreLogin(ctx, "somebody")
+	require.NoError(t, err)
+	assert.NotEqual(t, salt, other)
+}
+
+func TestLogin_LegacyPasswordWithoutKeys(t *testing.T) {
+	ctx := context.Background()
+	vault, store := newTestVault(t, testConfig())
+
+	insertLegacyUser(t, store, "alice", sealLegacy(t, "master-password"))
+
+	assert.NotPanics(t, func() {
+		_, err := vault.Login(ctx, models.User{Login: "alice", Password: "master-password"})
+		assert.ErrorIs(t, err, ErrInvalidHash)
+	})
+
+	assert.NotPanics(t, func() {
+		migrated, err := vault.MigratePasswords(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, migrated)
+	})
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+)
+
+// MemberRole returns the role of a user in an organization, storage.ErrMemberNotFound if the
+// user isn't a member
+func (s *Vault) MemberRole(ctx context.Context, org, login string) (string, error) {
+	if org == "" || login == "" {
+		return "", ErrMalformedRequest
+	}
+
+	member, err := s.Storage.GetMember(ctx, org, login)
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
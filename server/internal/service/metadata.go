@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// sealMetadata serializes entry metadata for storage, encrypting it with the server key if enabled.
+// Empty metadata is stored as NULL.
+func (s *Vault) sealMetadata(ctx context.Context, metadata map[string]string) ([]byte, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.serverEncryption() {
+		return s.encryptBytes(ctx, raw)
+	}
+	return raw, nil
+}
+
+// openMetadata restores entry metadata from its stored form
+func (s *Vault) openMetadata(ctx context.Context, raw []byte) (map[string]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	if s.serverEncryption() {
+		var err error
+		raw, err = s.decryptBytes(ctx, raw)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var metadata map[string]string
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// matchMetadata reports whether metadata holds every pair of the filter.
+// An empty filter value matches any value of the key.
+func matchMetadata(metadata, filter map[string]string) bool {
+	for k, v := range filter {
+		value, ok := metadata[k]
+		if !ok || (v != "" && value != v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+)
+
+// MigratePasswords converts passwords stored with the legacy reversible encryption into Argon2id hashes
+// and issues the key derivation salt the accounts were created without. It needs the legacy
+// ENCRYPTION_KEY that was used to encrypt them and returns the number of migrated users.
+func (s *Vault) MigratePasswords(ctx context.Context) (int, error) {
+	users, err := s.Storage.GetLegacyUsers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, u := range users {
+		plain, err := s.decryptBytes(ctx, []byte(u.Password))
+		if err != nil || plain == nil {
+			s.Log.Warn("can't decrypt legacy password, user must reset it", "login", u.Login)
+			continue
+		}
+
+		hash, err := hashPassword(string(plain))
+		if err != nil {
+			return migrated, err
+		}
+
+		if err := s.Storage.UpdatePassword(ctx, u.Login, hash); err != nil {
+			return migrated, err
+		}
+		if len(u.Salt) == 0 {
+			if err := s.Storage.SetSalt(ctx, u.Login, s.issuedSalt(u.Login)); err != nil {
+				return migrated, err
+			}
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
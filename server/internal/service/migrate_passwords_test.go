@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigratePasswords(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := withKeyfile(t, testConfig())
+	cfg.EncryptionKey = testLegacyKey
+	vault, store := newTestVault(t, cfg)
+
+	hash, err := hashPassword("auth-key")
+	require.NoError(t, err)
+	require.NoError(t, store.Register(ctx, models.User{Login: "hashed", Password: hash, Salt: []byte("test-kdf-salt-16")}))
+
+	insertLegacyUser(t, store, "alice", sealLegacy(t, "alice-password"))
+	insertLegacyUser(t, store, "bob", sealLegacy(t, "bob-password"))
+	insertLegacyUser(t, store, "broken", "not a ciphertext")
+
+	migrated, err := vault.MigratePasswords(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+
+	tests := []struct {
+		login    string
+		password string
+	}{
+		{login: "alice", password: "alice-password"},
+		{login: "bob", password: "bob-password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.login, func(t *testing.T) {
+			stored, err := store.GetUser(ctx, tt.login)
+			require.NoError(t, err)
+			assert.False(t, isLegacyPassword(stored.Password))
+			assert.Equal(t, vault.issuedSalt(tt.login), stored.Salt, "Migrated accounts should get a salt")
+
+			ok, _, err := verifyPassword(tt.password, stored.Password)
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			salt, err := vault.Login(ctx, models.User{Login: tt.login, Password: tt.password})
+			require.NoError(t, err)
+			assert.Equal(t, stored.Salt, salt)
+		})
+	}
+
+	broken, err := store.GetUser(ctx, "broken")
+	require.NoError(t, err)
+	assert.Equal(t, "not a ciphertext", broken.Password, "Values that can't be decrypted are left for a reset")
+
+	untouched, err := store.GetUser(ctx, "hashed")
+	require.NoError(t, err)
+	assert.Equal(t, hash, untouched.Password)
+
+	again, err := vault.MigratePasswords(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, again)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"data-vault/server/internal/models"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// Page size limits for GetData and GetAuditLog
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// encodePageToken turns a page cursor into the opaque token handed to clients
+func encodePageToken(cursor models.PageCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePageToken restores the page cursor from a client token, an empty token starts at the first page
+func decodePageToken(token string) (models.PageCursor, error) {
+	var cursor models.PageCursor
+	if token == "" {
+		return cursor, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, ErrInvalidPageToken
+	}
+
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID == "" || cursor.UploadedAt == "" {
+		return cursor, ErrInvalidPageToken
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// hashPrefix identifies password hashes in the PHC string format produced by hashPassword
+const hashPrefix = "$argon2id$"
+
+// hashParams holds the Argon2id parameters encoded in every stored password hash
+type hashParams struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	saltLen uint32
+	keyLen  uint32
+}
+
+// passwordParams are the current hashing parameters. Raising them makes existing
+// hashes get transparently rehashed on the user's next successful login.
+var passwordParams = hashParams{
+	memory:  64 * 1024,
+	time:    3,
+	threads: 2,
+	saltLen: 16,
+	keyLen:  32,
+}
+
+// dummyHash is verified against when a login is unknown so that response time doesn't reveal it
+var dummyHash, _ = hashPassword("data-vault-dummy-password")
+
+// hashPassword hashes a password with Argon2id and a random salt, encoding salt and parameters in the result
+func hashPassword(password string) (string, error) {
+	p := passwordParams
+
+	salt := make([]byte, p.saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, p.keyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		hashPrefix,
+		argon2.Version,
+		p.memory, p.time, p.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyPassword checks a password against an encoded hash in constant time.
+// It also reports whether the hash was made with outdated parameters and should be replaced.
+func verifyPassword(password, encoded string) (bool, bool, error) {
+	p, salt, key, err := decodeHash(encoded)
+	if err != nil {
+		return false, false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return false, false, nil
+	}
+
+	current := passwordParams
+	needsRehash := p.memory != current.memory || p.time != current.time || p.threads != current.threads ||
+		uint32(len(salt)) != current.saltLen || uint32(len(key)) != current.keyLen
+
+	return true, needsRehash, nil
+}
+
+// decodeHash parses an encoded Argon2id hash into its parameters, salt and key
+func decodeHash(encoded string) (hashParams, []byte, []byte, error) {
+	var p hashParams
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrInvalidHash
+	}
+
+	p.saltLen = uint32(len(salt))
+	p.keyLen = uint32(len(key))
+
+	return p, salt, key, nil
+}
+
+// isLegacyPassword reports whether a stored password predates hashing and is still AES-GCM encrypted
+func isLegacyPassword(stored string) bool {
+	return !strings.HasPrefix(stored, hashPrefix)
+}
+
+// verifyLegacyPassword checks a password against a value stored with the old reversible
+// encryption by decrypting it with the server key. Without server keys it can't be checked.
+func (s *Vault) verifyLegacyPassword(ctx context.Context, password, stored string) (bool, error) {
+	if !s.serverEncryption() {
+		return false, ErrInvalidHash
+	}
+
+	plain, err := s.decryptBytes(ctx, []byte(stored))
+	if err != nil || plain == nil {
+		return false, ErrInvalidHash
+	}
+
+	return subtle.ConstantTimeCompare(plain, []byte(password)) == 1, nil
+}
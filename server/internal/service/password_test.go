@@ -0,0 +1,111 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := hashPassword("correct horse")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=2$"))
+	assert.False(t, isLegacyPassword(hash))
+	assert.NotContains(t, hash, "correct horse")
+
+	other, err := hashPassword("correct horse")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "Every hash should get its own salt")
+}
+
+func TestVerifyPassword(t *testing.T) {
+	hash, err := hashPassword("correct horse")
+	require.NoError(t, err)
+
+	weak := passwordParams
+	weak.memory = 8 * 1024
+	weak.time = 1
+	oldHash := withParams(t, weak, "correct horse")
+
+	tests := []struct {
+		name         string
+		password     string
+		encoded      string
+		expectOK     bool
+		expectRehash bool
+		expectErr    error
+	}{
+		{
+			name:     "right password",
+			password: "correct horse",
+			encoded:  hash,
+			expectOK: true,
+		},
+		{
+			name:     "wrong password",
+			password: "battery staple",
+			encoded:  hash,
+		},
+		{
+			name:         "outdated parameters",
+			password:     "correct horse",
+			encoded:      oldHash,
+			expectOK:     true,
+			expectRehash: true,
+		},
+		{
+			name:      "not an argon2id hash",
+			password:  "correct horse",
+			encoded:   "$2a$10$abcdefghijklmnopqrstuv",
+			expectErr: ErrInvalidHash,
+		},
+		{
+			name:      "other argon2 version",
+			password:  "correct horse",
+			encoded:   strings.Replace(hash, "v=19", "v=16", 1),
+			expectErr: ErrInvalidHash,
+		},
+		{
+			name:      "broken salt",
+			password:  "correct horse",
+			encoded:   "$argon2id$v=19$m=65536,t=3,p=2$!!!$c2FsdA",
+			expectErr: ErrInvalidHash,
+		},
+		{
+			name:      "empty key",
+			password:  "correct horse",
+			encoded:   "$argon2id$v=19$m=65536,t=3,p=2$c2FsdHNhbHRzYWx0$",
+			expectErr: ErrInvalidHash,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, rehash, err := verifyPassword(tt.password, tt.encoded)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectOK, ok)
+			assert.Equal(t, tt.expectRehash, rehash)
+		})
+	}
+}
+
+// withParams hashes a password with other hashing parameters, as an older server version would
+func withParams(t *testing.T, p hashParams, password string) string {
+	t.Helper()
+
+	current := passwordParams
+	passwordParams = p
+	defer func() { passwordParams = current }()
+
+	hash, err := hashPassword(password)
+	require.NoError(t, err)
+	return hash
+}
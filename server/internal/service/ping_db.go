@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// PingDB checks that the database answers and returns how long it took
+func (s *Vault) PingDB(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	if err := s.Storage.Ping(ctx); err != nil {
+		return 0, err
+	}
+
+	return time.Since(start), nil
+}
@@ -4,18 +4,26 @@ import (
 	"context"
 )
 
-// PostData encrypts and stores user data in the vault
-func (s *Vault) PostData(ctx context.Context, login, dataType string, data []byte) error {
+// PostData stores client-encrypted user data with its metadata in the vault, optionally adding a server-side encryption layer
+func (s *Vault) PostData(ctx context.Context, login, dataType string, data []byte, metadata map[string]string) error {
 	if login == "" || len(data) == 0 || dataType == "" {
 		return ErrMalformedRequest
 	}
 
-	cipherData, err := s.encryptBytes(ctx, data)
+	if s.serverEncryption() {
+		cipherData, err := s.encryptBytes(ctx, data)
+		if err != nil {
+			return err
+		}
+		data = cipherData
+	}
+
+	sealed, err := s.sealMetadata(ctx, metadata)
 	if err != nil {
 		return err
 	}
 
-	err = s.Storage.PostData(ctx, login, dataType, cipherData)
+	err = s.Storage.PostData(ctx, login, dataType, data, sealed)
 	if err != nil {
 		return err
 	}
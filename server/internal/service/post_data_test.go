@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostData(t *testing.T) {
+	ctx := context.Background()
+	metadata := map[string]string{"site": "example.com"}
+
+	for name, cfg := range testConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			vault, store := newTestVault(t, cfg)
+
+			id := postTestData(t, vault, "alice", "client ciphertext", metadata)
+
+			got, err := vault.GetData(ctx, "alice", models.DataQuery{})
+			require.NoError(t, err)
+			require.Len(t, got.Data, 1)
+			assert.Equal(t, id, got.Data[0].ID)
+			assert.Equal(t, "alice", got.Data[0].User)
+			assert.Equal(t, "text", got.Data[0].Type)
+			assert.Equal(t, int64(1), got.Data[0].Version)
+			assert.Equal(t, metadata, got.Data[0].Metadata)
+			assert.Nil(t, got.Data[0].RawMetadata)
+
+			stored, err := store.GetDataByID(ctx, "alice", id)
+			require.NoError(t, err)
+			if vault.serverEncryption() {
+				assert.NotEqual(t, "client ciphertext", string(stored.Data), "The server layer should be added")
+				assert.NotContains(t, string(stored.RawMetadata), "example.com")
+			} else {
+				assert.Equal(t, "client ciphertext", string(stored.Data))
+			}
+
+			other, err := vault.GetData(ctx, "bob", models.DataQuery{})
+			require.NoError(t, err)
+			assert.Empty(t, other.Data, "Entries should only be visible to their owner")
+		})
+	}
+}
+
+func TestPostData_Malformed(t *testing.T) {
+	ctx := context.Background()
+	vault, _ := newTestVault(t, testConfig())
+
+	tests := []struct {
+		name     string
+		login    string
+		dataType string
+		data     []byte
+	}{
+		{name: "no login", dataType: "text", data: []byte("data")},
+		{name: "no type", login: "alice", data: []byte("data")},
+		{name: "no data", login: "alice", dataType: "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := vault.PostData(ctx, tt.login, tt.dataType, tt.data, nil)
+			assert.ErrorIs(t, err, ErrMalformedRequest)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"data-vault/server/internal/storage"
+	"errors"
+)
+
+// kdfSaltSize is the length of the key derivation salts issued by the server
+const kdfSaltSize = 16
+
+// PreLogin returns the key derivation salt of an account and whether it still logs in with the
+// master password. Unknown logins get the salt the account would be issued, so the answer is the
+// same whether or not it exists.
+func (s *Vault) PreLogin(ctx context.Context, login string) ([]byte, bool, error) {
+	if login == "" {
+		return nil, false, ErrMalformedRequest
+	}
+
+	stored, err := s.Storage.GetUser(ctx, login)
+	if err != nil {
+		if errors.Is(err, storage.ErrWrongPassword) {
+			return s.issuedSalt(login), false, nil
+		}
+		return nil, false, err
+	}
+
+	if len(stored.Salt) == 0 {
+		return s.issuedSalt(login), stored.RawPassword, nil
+	}
+
+	return stored.Salt, stored.RawPassword, nil
+}
+
+// issuedSalt derives the salt handed out for a login that has none. It is keyed with the JWT
+// secret so it can't be told apart from a random salt, and stable so repeated calls agree.
+func (s *Vault) issuedSalt(login string) []byte {
+	mac := hmac.New(sha256.New, []byte(s.cfg.JWTSecret))
+	mac.Write([]byte("kdf-salt:" + login))
+	return mac.Sum(nil)[:kdfSaltSize]
+}
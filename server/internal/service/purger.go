@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// purgeJob is a cleanup the background purger runs
+type purgeJob struct {
+	// what names the removed rows in the log
+	what  string
+	purge func(ctx context.Context) (int64, error)
+}
+
+// RunPurger removes expired trash, abandoned uploads and ended sessions every TrashPurgeInterval
+// until the context is canceled. The trash and upload jobs are off while their setting is 0.
+func (s *Vault) RunPurger(ctx context.Context) {
+	if s.cfg.TrashPurgeInterval <= 0 {
+		return
+	}
+
+	jobs := []purgeJob{
+		{what: "expired trash", purge: s.PurgeTrash},
+		{what: "abandoned uploads", purge: s.PurgeUploads},
+		{what: "ended sessions", purge: s.PurgeSessions},
+	}
+
+	ticker := time.NewTicker(s.cfg.TrashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, job := range jobs {
+			purged, err := job.purge(ctx)
+			if err != nil {
+				s.Log.Error("Error purging "+job.what, "error", err)
+			} else if purged > 0 {
+				s.Log.Info("Purged "+job.what, "count", purged)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAudit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		tamper func(t *testing.T, vault *Vault)
+		expect []bool
+	}{
+		{
+			name:   "untouched log",
+			tamper: func(t *testing.T, vault *Vault) {},
+			expect: []bool{true, true, true},
+		},
+		{
+			name: "edited entry",
+			tamper: func(t *testing.T, vault *Vault) {
+				execSQL(t, vault, "UPDATE audit_log SET action = 'PingDB' WHERE seq = 2")
+			},
+			expect: []bool{true, false, true},
+		},
+		{
+			name: "rewritten chain",
+			tamper: func(t *testing.T, vault *Vault) {
+				// Whoever can write the table can recompute the plain hashes, but not the MACs
+				entries, err := vault.Storage.ListAudit(ctx, "alice", 0, 10)
+				require.NoError(t, err)
+				execSQL(t, vault, "DELETE FROM audit_log")
+
+				var prev []byte
+				for i := len(entries) - 1; i >= 0; i-- {
+					e := entries[i]
+					e.Action = "Forged"
+					e.PrevHash = prev
+					e.Hash = auditHash(e)
+					prev = e.Hash
+					require.NoError(t, vault.Storage.AppendAudit(ctx, "alice", func(models.AuditEntry) models.AuditEntry { return e }))
+				}
+			},
+			expect: []bool{false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault, _ := newTestVault(t, withKeyfile(t, testConfig()))
+
+			for _, action := range []string{"Login", "PostData", "GetData"} {
+				require.NoError(t, vault.RecordAudit(ctx, models.AuditEntry{User: "alice", Action: action, Result: "OK"}))
+			}
+
+			tt.tamper(t, vault)
+
+			entries, err := vault.GetAuditLog(ctx, "alice", 0, 0)
+			require.NoError(t, err)
+			require.Len(t, entries, len(tt.expect))
+
+			for i, e := range entries {
+				// Entries come newest first
+				assert.Equal(t, tt.expect[len(tt.expect)-1-i], e.Authentic, "entry %d", e.Seq)
+			}
+		})
+	}
+}
+
+func TestAuditKey(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := withKeyfile(t, testConfig())
+	vault, store := newTestVault(t, cfg)
+
+	require.NoError(t, vault.RecordAudit(ctx, models.AuditEntry{User: "alice", Action: "Login", Result: "OK"}))
+
+	sealed, err := store.GetServerKey(ctx, auditKeyName)
+	require.NoError(t, err)
+	key, err := vault.loadAuditKey(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), string(key), "The audit key must be stored sealed")
+
+	restarted, err := New(vault.Log, cfg, store)
+	require.NoError(t, err)
+	entries, err := restarted.GetAuditLog(ctx, "alice", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, entries[0].Authentic, "A restarted server must load the same key")
+
+	plain, _ := newTestVault(t, testConfig())
+	require.NoError(t, plain.RecordAudit(ctx, models.AuditEntry{User: "alice", Action: "Login", Result: "OK"}))
+	entries, err = plain.GetAuditLog(ctx, "alice", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, entries[0].Authentic, "Without a key provider the key is derived from the JWT secret")
+}
+
+// execSQL runs a statement on the database of a test vault
+func execSQL(t *testing.T, vault *Vault, query string) {
+	t.Helper()
+
+	_, err := vault.Storage.(*storage.Storage).DB.ExecContext(context.Background(), query)
+	require.NoError(t, err)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"errors"
+	"strings"
+	"time"
+)
+
+// RefreshSession exchanges a refresh token for its replacement and returns the session it belongs to.
+// Presenting an already rotated token means it was copied, so the whole session is revoked.
+// Any other mismatch is rejected without touching the session, as the ID alone is not a secret.
+func (s *Vault) RefreshSession(ctx context.Context, refreshToken string) (models.Session, string, error) {
+	id, _, ok := strings.Cut(refreshToken, ".")
+	if !ok || id == "" {
+		return models.Session{}, "", ErrInvalidRefreshToken
+	}
+
+	session, err := s.Storage.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			return models.Session{}, "", ErrInvalidRefreshToken
+		}
+		return models.Session{}, "", err
+	}
+
+	if !sessionLive(session) {
+		return models.Session{}, "", ErrInvalidRefreshToken
+	}
+
+	hash := hashRefreshToken(refreshToken)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(session.RefreshHash)) != 1 {
+		reused, err := s.Storage.RefreshTokenRotated(ctx, session.ID, hash)
+		if err != nil {
+			return models.Session{}, "", err
+		}
+		if !reused {
+			return models.Session{}, "", ErrInvalidRefreshToken
+		}
+
+		s.Log.Warn("Refresh token reused, revoking session", "user", session.User, "session", session.ID)
+		if err := s.Storage.RevokeSession(ctx, session.User, session.ID); err != nil && !errors.Is(err, storage.ErrSessionNotFound) {
+			return models.Session{}, "", err
+		}
+		return models.Session{}, "", ErrInvalidRefreshToken
+	}
+
+	token, newHash, err := newRefreshToken(session.ID)
+	if err != nil {
+		return models.Session{}, "", err
+	}
+
+	expiresAt := time.Now().UTC().Add(s.refreshTTL()).Format(time.RFC3339)
+	err = s.Storage.RotateSession(ctx, session.ID, hash, newHash, expiresAt)
+	if err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			return models.Session{}, "", ErrInvalidRefreshToken
+		}
+		return models.Session{}, "", err
+	}
+	session.RefreshHash = newHash
+	session.ExpiresAt = expiresAt
+
+	return session, token, nil
+}
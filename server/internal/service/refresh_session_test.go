@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshSession(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		present       func(t *testing.T, vault *Vault, token string) string
+		expectRevoked bool
+	}{
+		{
+			name: "forged secret",
+			present: func(t *testing.T, vault *Vault, token string) string {
+				id, _, _ := strings.Cut(token, ".")
+				return id + ".forged-secret"
+			},
+		},
+		{
+			name: "empty secret",
+			present: func(t *testing.T, vault *Vault, token string) string {
+				id, _, _ := strings.Cut(token, ".")
+				return id + "."
+			},
+		},
+		{
+			name: "rotated token",
+			present: func(t *testing.T, vault *Vault, token string) string {
+				_, _, err := vault.RefreshSession(ctx, token)
+				require.NoError(t, err)
+				return token
+			},
+			expectRevoked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault, _ := newTestVault(t, testConfig())
+
+			session, token, err := vault.CreateSession(ctx, "alice", "laptop")
+			require.NoError(t, err)
+
+			_, _, err = vault.RefreshSession(ctx, tt.present(t, vault, token))
+			assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+			active, err := vault.SessionActive(ctx, "alice", session.ID)
+			require.NoError(t, err)
+			assert.Equal(t, !tt.expectRevoked, active)
+
+			if !tt.expectRevoked {
+				refreshed, next, err := vault.RefreshSession(ctx, token)
+				require.NoError(t, err, "A rejected forgery must leave the session usable")
+				assert.Equal(t, session.ID, refreshed.ID)
+				assert.NotEqual(t, token, next)
+			}
+		})
+	}
+}
+
+func TestPurgeSessions(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.RefreshTokenTTL = time.Second
+	vault, store := newTestVault(t, cfg)
+
+	revoked, _, err := vault.CreateSession(ctx, "alice", "laptop")
+	require.NoError(t, err)
+	require.NoError(t, vault.RevokeSession(ctx, "alice", revoked.ID))
+
+	_, token, err := vault.CreateSession(ctx, "alice", "phone")
+	require.NoError(t, err)
+	rotated, _, err := vault.RefreshSession(ctx, token)
+	require.NoError(t, err)
+
+	purged, err := vault.PurgeSessions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, purged, "Sessions that just ended should be kept")
+
+	// Session times are truncated to the second, so the cutoff passes them safely after three
+	time.Sleep(3 * time.Second)
+
+	live, _, err := vault.CreateSession(ctx, "alice", "tablet")
+	require.NoError(t, err)
+
+	purged, err = vault.PurgeSessions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged, "The revoked and the expired session should go")
+
+	reused, err := store.RefreshTokenRotated(ctx, rotated.ID, hashRefreshToken(token))
+	require.NoError(t, err)
+	assert.False(t, reused, "The rotated hashes should go with their sessions")
+
+	sessions, err := vault.ListSessions(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, live.ID, sessions[0].ID)
+}
@@ -3,36 +3,24 @@ package service
 import (
 	"context"
 	"data-vault/server/internal/models"
-	"database/sql"
 )
 
-// Register creates a new user account with encrypted password
+// Register creates a new user account with an Argon2id password hash
 func (s *Vault) Register(ctx context.Context, user models.User) error {
-	tx, err := s.Storage.DB.BeginTx(ctx, &sql.TxOptions{
-		Isolation: sql.LevelSerializable,
-	})
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	if user.Login == "" || user.Password == "" {
+	if user.Login == "" || user.Password == "" || len(user.Salt) == 0 {
 		return ErrMalformedRequest
 	}
 
-	cipherPassword, err := s.encrypt(ctx, user.Password)
-	if err != nil {
-		return err
+	// Organization records are stored under org: accounts, a user by that name would own them
+	if models.IsOrgAccount(user.Login) {
+		return ErrReservedLogin
 	}
-	user.Password = cipherPassword
 
-	err = s.Storage.Register(ctx, tx, user)
+	hash, err := hashPassword(user.Password)
 	if err != nil {
 		return err
 	}
+	user.Password = hash
 
-	if err = tx.Commit(); err != nil {
-		return err
-	}
-	return nil
+	return s.Storage.Register(ctx, user)
 }
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	ctx := context.Background()
+	salt := []byte("test-kdf-salt-16")
+
+	tests := []struct {
+		name      string
+		existing  []string
+		user      models.User
+		expectErr error
+	}{
+		{
+			name: "new account",
+			user: models.User{Login: "alice", Password: "auth-key", Salt: salt},
+		},
+		{
+			name:      "login taken",
+			existing:  []string{"alice"},
+			user:      models.User{Login: "alice", Password: "auth-key", Salt: salt},
+			expectErr: storage.ErrDuplicateLogin,
+		},
+		{
+			name:      "organization login",
+			user:      models.User{Login: "org:acme", Password: "auth-key", Salt: salt},
+			expectErr: ErrReservedLogin,
+		},
+		{
+			name:      "no login",
+			user:      models.User{Password: "auth-key", Salt: salt},
+			expectErr: ErrMalformedRequest,
+		},
+		{
+			name:      "no password",
+			user:      models.User{Login: "alice", Salt: salt},
+			expectErr: ErrMalformedRequest,
+		},
+		{
+			name:      "no salt",
+			user:      models.User{Login: "alice", Password: "auth-key"},
+			expectErr: ErrMalformedRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault, store := newTestVault(t, testConfig())
+			for _, login := range tt.existing {
+				require.NoError(t, vault.Register(ctx, models.User{Login: login, Password: "other-key", Salt: salt}))
+			}
+
+			err := vault.Register(ctx, tt.user)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			stored, err := store.GetUser(ctx, tt.user.Login)
+			require.NoError(t, err)
+			assert.NotEqual(t, tt.user.Password, stored.Password, "The password should be stored hashed")
+			assert.Equal(t, salt, stored.Salt)
+			assert.False(t, stored.RawPassword)
+
+			got, err := vault.Login(ctx, models.User{Login: tt.user.Login, Password: tt.user.Password})
+			require.NoError(t, err)
+			assert.Equal(t, salt, got)
+
+			_, err = vault.Login(ctx, models.User{Login: tt.user.Login, Password: "wrong-key"})
+			assert.ErrorIs(t, err, storage.ErrWrongPassword)
+
+			preSalt, raw, err := vault.PreLogin(ctx, tt.user.Login)
+			require.NoError(t, err)
+			assert.Equal(t, salt, preSalt)
+			assert.False(t, raw)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+)
+
+// RestoreFromTrash moves a trashed data entry back to the live entries and returns its version
+func (s *Vault) RestoreFromTrash(ctx context.Context, login, id string) (int64, error) {
+	if login == "" || id == "" {
+		return 0, ErrMalformedRequest
+	}
+
+	version, err := s.Storage.RestoreFromTrash(ctx, login, id)
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreFromTrash(t *testing.T) {
+	ctx := context.Background()
+	metadata := map[string]string{"site": "example.com"}
+
+	for name, cfg := range testConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			vault, _ := newTestVault(t, cfg)
+
+			id := postTestData(t, vault, "alice", "secret", metadata)
+			postTestData(t, vault, "alice", "kept", nil)
+
+			before, err := vault.Sync(ctx, "alice", 0, nil)
+			require.NoError(t, err)
+
+			require.NoError(t, vault.DeleteData(ctx, "alice", id))
+
+			page, err := vault.GetData(ctx, "alice", models.DataQuery{})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"kept"}, payloads(page.Data), "Trashed entries should be hidden")
+
+			trash, err := vault.ListTrash(ctx, "alice")
+			require.NoError(t, err)
+			require.Len(t, trash, 1)
+			assert.Equal(t, id, trash[0].ID)
+			assert.Equal(t, "secret", string(trash[0].Data))
+			assert.Equal(t, metadata, trash[0].Metadata)
+			assert.NotEmpty(t, trash[0].DeletedAt)
+
+			_, err = vault.UpdateData(ctx, "alice", id, "text", []byte("edited"), 1)
+			assert.ErrorIs(t, err, storage.ErrNoDataFound, "Trashed entries can't be updated")
+
+			_, err = vault.RestoreFromTrash(ctx, "bob", id)
+			assert.ErrorIs(t, err, storage.ErrNoDataFound, "Only the owner can restore an entry")
+
+			version, err := vault.RestoreFromTrash(ctx, "alice", id)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), version)
+
+			trash, err = vault.ListTrash(ctx, "alice")
+			require.NoError(t, err)
+			assert.Empty(t, trash)
+
+			page, err = vault.GetData(ctx, "alice", models.DataQuery{})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"secret", "kept"}, payloads(page.Data))
+
+			after, err := vault.Sync(ctx, "alice", before.Cursor, nil)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"secret"}, payloads(after.Created), "Devices should see the restored entry as created again")
+			assert.Empty(t, after.Deleted)
+
+			_, err = vault.RestoreFromTrash(ctx, "alice", id)
+			assert.ErrorIs(t, err, storage.ErrNoDataFound, "Live entries can't be restored")
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+)
+
+// RestoreVersion makes a prior version the current state of a data entry and returns the entry's new version
+func (s *Vault) RestoreVersion(ctx context.Context, login, id string, version int64) (int64, error) {
+	if login == "" || id == "" || version <= 0 {
+		return 0, ErrMalformedRequest
+	}
+
+	newVersion, err := s.Storage.RestoreVersion(ctx, login, id, version)
+	if err != nil {
+		return 0, err
+	}
+	return newVersion, nil
+}
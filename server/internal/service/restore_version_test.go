@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionPayloads returns the payloads of prior versions in order
+func versionPayloads(versions []models.Version) []string {
+	res := make([]string, 0, len(versions))
+	for _, v := range versions {
+		res = append(res, string(v.Data))
+	}
+	return res
+}
+
+func TestRestoreVersion(t *testing.T) {
+	ctx := context.Background()
+	metadata := map[string]string{"site": "example.com"}
+
+	for name, cfg := range testConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			vault, _ := newTestVault(t, cfg)
+
+			id := postTestData(t, vault, "alice", "v1", metadata)
+			for version, payload := range []string{"v2", "v3"} {
+				_, err := vault.UpdateData(ctx, "alice", id, "text", []byte(payload), int64(version+1))
+				require.NoError(t, err)
+			}
+
+			versions, err := vault.ListVersions(ctx, "alice", id)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"v2", "v1"}, versionPayloads(versions), "Prior versions should be listed newest first")
+			assert.Equal(t, int64(2), versions[0].Version)
+			assert.Equal(t, metadata, versions[1].Metadata)
+			assert.Nil(t, versions[1].RawMetadata)
+			assert.NotEmpty(t, versions[1].ArchivedAt)
+
+			other, err := vault.ListVersions(ctx, "bob", id)
+			require.NoError(t, err)
+			assert.Empty(t, other, "The history should only be visible to the owner")
+
+			version, err := vault.RestoreVersion(ctx, "alice", id, 1)
+			require.NoError(t, err)
+			assert.Equal(t, int64(4), version)
+
+			page, err := vault.GetData(ctx, "alice", models.DataQuery{})
+			require.NoError(t, err)
+			require.Len(t, page.Data, 1)
+			assert.Equal(t, "v1", string(page.Data[0].Data))
+			assert.Equal(t, int64(4), page.Data[0].Version)
+			assert.Equal(t, metadata, page.Data[0].Metadata)
+
+			versions, err = vault.ListVersions(ctx, "alice", id)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"v3", "v2", "v1"}, versionPayloads(versions), "The replaced state should be kept")
+
+			_, err = vault.RestoreVersion(ctx, "alice", id, 9)
+			assert.ErrorIs(t, err, storage.ErrVersionNotFound)
+
+			_, err = vault.RestoreVersion(ctx, "bob", id, 1)
+			assert.Error(t, err, "Only the owner can restore a version")
+
+			_, err = vault.RestoreVersion(ctx, "alice", id, 0)
+			assert.ErrorIs(t, err, ErrMalformedRequest)
+		})
+	}
+}
+
+func TestRestoreVersion_Retention(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := testConfig()
+	cfg.HistoryVersions = 2
+	vault, _ := newTestVault(t, cfg)
+
+	id := postTestData(t, vault, "alice", "v1", nil)
+	for version, payload := range []string{"v2", "v3", "v4"} {
+		_, err := vault.UpdateData(ctx, "alice", id, "text", []byte(payload), int64(version+1))
+		require.NoError(t, err)
+	}
+
+	versions, err := vault.ListVersions(ctx, "alice", id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v3", "v2"}, versionPayloads(versions), "Only the newest versions should be kept")
+
+	_, err = vault.RestoreVersion(ctx, "alice", id, 1)
+	assert.ErrorIs(t, err, storage.ErrVersionNotFound)
+
+	require.NoError(t, vault.DeleteData(ctx, "alice", id))
+
+	versions, err = vault.ListVersions(ctx, "alice", id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v3", "v2"}, versionPayloads(versions), "The history should outlive the delete")
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+)
+
+// RevokeSession ends a session of a user, e.g. on logout or for a lost device
+func (s *Vault) RevokeSession(ctx context.Context, login, id string) error {
+	if login == "" || id == "" {
+		return ErrMalformedRequest
+	}
+
+	return s.Storage.RevokeSession(ctx, login, id)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+)
+
+// RevokeShare removes the access of a user to a data entry of the owner
+func (s *Vault) RevokeShare(ctx context.Context, login, id, recipient string) error {
+	if login == "" || id == "" || recipient == "" {
+		return ErrMalformedRequest
+	}
+
+	return s.Storage.RevokeShare(ctx, login, id, recipient)
+}
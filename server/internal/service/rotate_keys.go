@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/storage"
+)
+
+// RotateKeys re-encrypts every server-side encrypted value that isn't an envelope wrapped by the
+// active key-encryption key yet, including values sealed with legacy keys, batchSize rows at a
+// time. Each value is replaced only if its row is unchanged, so the server can keep serving
+// meanwhile; values written concurrently already use the active key.
+// It returns the number of re-encrypted values.
+func (s *Vault) RotateKeys(ctx context.Context, batchSize uint64) (int, error) {
+	if !s.serverEncryption() {
+		return 0, ErrNoKeyProvider
+	}
+
+	rotated := 0
+	for _, col := range storage.EncryptedColumns {
+		var after []any
+		for {
+			batch, err := s.Storage.ListCiphertexts(ctx, col, after, batchSize)
+			if err != nil {
+				return rotated, err
+			}
+			if len(batch) == 0 {
+				break
+			}
+
+			for _, c := range batch {
+				if s.keys.Current(c.Value) {
+					continue
+				}
+
+				plain, err := s.keys.Open(ctx, c.Value)
+				if err != nil {
+					s.Log.Warn("can't decrypt value, leaving it as is", "table", col.Table, "column", col.Column, "key", c.Key)
+					continue
+				}
+
+				sealed, err := s.keys.Seal(ctx, plain)
+				if err != nil {
+					return rotated, err
+				}
+
+				replaced, err := s.Storage.ReplaceCiphertext(ctx, col, c, sealed)
+				if err != nil {
+					return rotated, err
+				}
+				if replaced {
+					rotated++
+				}
+			}
+
+			s.Log.Info("Re-encrypted batch", "table", col.Table, "column", col.Column, "rotated", rotated)
+			after = batch[len(batch)-1].Key
+		}
+	}
+
+	return rotated, nil
+}
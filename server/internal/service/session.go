@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// refreshSecretSize is the number of random bytes in a refresh token
+const refreshSecretSize = 32
+
+// CreateSession starts a new session of a user on a device and returns it together with its refresh token
+func (s *Vault) CreateSession(ctx context.Context, login, device string) (models.Session, string, error) {
+	if login == "" {
+		return models.Session{}, "", ErrMalformedRequest
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return models.Session{}, "", err
+	}
+
+	now := time.Now().UTC()
+	session := models.Session{
+		ID:         hex.EncodeToString(id),
+		User:       login,
+		Device:     device,
+		CreatedAt:  now.Format(time.RFC3339),
+		LastUsedAt: now.Format(time.RFC3339),
+		ExpiresAt:  now.Add(s.refreshTTL()).Format(time.RFC3339),
+	}
+
+	token, hash, err := newRefreshToken(session.ID)
+	if err != nil {
+		return models.Session{}, "", err
+	}
+	session.RefreshHash = hash
+
+	if err := s.Storage.CreateSession(ctx, session); err != nil {
+		return models.Session{}, "", err
+	}
+
+	return session, token, nil
+}
+
+// SessionActive reports whether a session belongs to the user and is neither revoked nor expired
+func (s *Vault) SessionActive(ctx context.Context, login, id string) (bool, error) {
+	if login == "" || id == "" {
+		return false, nil
+	}
+
+	session, err := s.Storage.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrSessionNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return session.User == login && sessionLive(session), nil
+}
+
+// sessionLive reports whether a session was neither revoked nor has expired
+func sessionLive(session models.Session) bool {
+	return session.RevokedAt == "" && session.ExpiresAt > time.Now().UTC().Format(time.RFC3339)
+}
+
+// PurgeSessions removes the sessions of all users that ended longer than the refresh token
+// lifetime ago, together with the refresh token hashes rotated that long ago, and returns how
+// many sessions were removed. Their tokens have expired by then, so nothing checks them anymore.
+func (s *Vault) PurgeSessions(ctx context.Context) (int64, error) {
+	before := time.Now().UTC().Add(-s.refreshTTL()).Format(time.RFC3339)
+	return s.Storage.PurgeSessions(ctx, before)
+}
+
+// refreshTTL returns the configured refresh token lifetime
+func (s *Vault) refreshTTL() time.Duration {
+	if s.cfg.RefreshTokenTTL > 0 {
+		return s.cfg.RefreshTokenTTL
+	}
+	return config.DefaultRefreshTokenTTL
+}
+
+// newRefreshToken generates a refresh token for a session and the hash it is stored under.
+// The token is prefixed with the session ID so that it can be looked up without a scan.
+func newRefreshToken(sessionID string) (string, string, error) {
+	secret := make([]byte, refreshSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	token := sessionID + "." + base64.RawURLEncoding.EncodeToString(secret)
+	return token, hashRefreshToken(token), nil
+}
+
+// hashRefreshToken returns the hex SHA-256 of a refresh token. The tokens are random,
+// so a fast hash is enough to keep a database leak from exposing usable tokens.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
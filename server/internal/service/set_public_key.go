@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+)
+
+// publicKeySize is the size of the X25519 public keys record keys are wrapped for
+const publicKeySize = 32
+
+// SetPublicKey publishes the public key of a user, so that other users can share records with them
+func (s *Vault) SetPublicKey(ctx context.Context, login string, key []byte) error {
+	if login == "" || len(key) != publicKeySize {
+		return ErrMalformedRequest
+	}
+
+	return s.Storage.SetPublicKey(ctx, login, key)
+}
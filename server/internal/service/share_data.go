@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"time"
+)
+
+// ShareData grants another user read or write access to a data entry of the owner. The wrapped
+// key is the entry's record key sealed by the owner's client to the recipient's public key.
+func (s *Vault) ShareData(ctx context.Context, login, id, recipient, permission string, wrappedKey []byte) error {
+	if login == "" || id == "" || recipient == "" || len(wrappedKey) == 0 || !models.ValidPermission(permission) {
+		return ErrMalformedRequest
+	}
+
+	if recipient == login {
+		return ErrSelfShare
+	}
+
+	// The recipient can only have been wrapped for if they published a key
+	if _, err := s.Storage.GetPublicKey(ctx, recipient); err != nil {
+		return err
+	}
+
+	return s.Storage.ShareData(ctx, models.Share{
+		DataID:     id,
+		Owner:      login,
+		Recipient:  recipient,
+		Permission: permission,
+		WrappedKey: wrappedKey,
+		SharedAt:   time.Now().UTC().Format(time.RFC3339),
+	})
+}
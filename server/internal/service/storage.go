@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+)
+
+// Storage defines the persistence the vault runs on. Operations that change several rows are
+// atomic within the storage, which keeps transactions out of the service. storage.Storage
+// implements it on PostgreSQL, SQLite and in-memory databases.
+type Storage interface {
+	Ping(ctx context.Context) error
+
+	Register(ctx context.Context, user models.User) error
+	GetUser(ctx context.Context, login string) (models.User, error)
+	GetLegacyUsers(ctx context.Context) ([]models.User, error)
+	UpdatePassword(ctx context.Context, login, hash string) error
+	UpgradePassword(ctx context.Context, login, hash string, salt []byte) error
+	SetSalt(ctx context.Context, login string, salt []byte) error
+
+	CreateSession(ctx context.Context, session models.Session) error
+	GetSession(ctx context.Context, id string) (models.Session, error)
+	ListSessions(ctx context.Context, login string) ([]models.Session, error)
+	GetServerKey(ctx context.Context, name string) ([]byte, error)
+	CreateServerKey(ctx context.Context, name string, sealed []byte) error
+	RotateSession(ctx context.Context, id, oldHash, newHash, expiresAt string) error
+	RefreshTokenRotated(ctx context.Context, id, hash string) (bool, error)
+	RevokeSession(ctx context.Context, login, id string) error
+	PurgeSessions(ctx context.Context, before string) (int64, error)
+
+	GetTOTP(ctx context.Context, login string) (models.TOTP, error)
+	SetPendingTOTP(ctx context.Context, login string, secret []byte) error
+	EnableTOTP(ctx context.Context, login string, secret []byte, step int64, codeHashes []string) error
+	UseTOTPStep(ctx context.Context, login string, step int64) error
+	UseRecoveryCode(ctx context.Context, login, codeHash string) error
+
+	PostData(ctx context.Context, login, dataType string, data, metadata []byte) error
+	GetData(ctx context.Context, user string, query models.DataQuery, after models.PageCursor, limit uint64) ([]models.Data, error)
+	GetDataByID(ctx context.Context, login, id string) (models.Data, error)
+	UpdateData(ctx context.Context, login, id, dataType string, data []byte, version int64) (int64, error)
+	DeleteData(ctx context.Context, login, id string, version int64) error
+	GetChanges(ctx context.Context, login string, cursor int64) (models.SyncResult, error)
+
+	ListVersions(ctx context.Context, login, id string) ([]models.Version, error)
+	RestoreVersion(ctx context.Context, login, id string, version int64) (int64, error)
+	ListTrash(ctx context.Context, login string) ([]models.Data, error)
+	RestoreFromTrash(ctx context.Context, login, id string) (int64, error)
+	EmptyTrash(ctx context.Context, login string) (int64, error)
+	PurgeTrash(ctx context.Context, before string) (int64, error)
+
+	CreateFile(ctx context.Context, login string, meta []byte, size, chunkSize int64) (models.File, error)
+	GetFile(ctx context.Context, login, id string) (models.File, error)
+	AppendChunk(ctx context.Context, id string, seq int64, data []byte) error
+	CompleteFile(ctx context.Context, login, id string) error
+	PurgeUploads(ctx context.Context, before string) (int64, error)
+	GetChunks(ctx context.Context, id string, from int64, fn func(seq int64, data []byte) error) error
+
+	SetPublicKey(ctx context.Context, login string, key []byte) error
+	GetPublicKey(ctx context.Context, login string) ([]byte, error)
+	ShareData(ctx context.Context, share models.Share) error
+	GetShare(ctx context.Context, id, recipient string) (models.Share, error)
+	ListSharedWithMe(ctx context.Context, login string) ([]models.SharedData, error)
+	RevokeShare(ctx context.Context, owner, id, recipient string) error
+
+	CreateOrg(ctx context.Context, org models.Org, owner models.Member) error
+	GetMember(ctx context.Context, org, login string) (models.Member, error)
+	PutMember(ctx context.Context, member models.Member) error
+	ListMembers(ctx context.Context, org string) ([]models.Member, error)
+	ListOrgs(ctx context.Context, login string) ([]models.Member, error)
+
+	AppendAudit(ctx context.Context, user string, link func(last models.AuditEntry) models.AuditEntry) error
+	ListAudit(ctx context.Context, user string, beforeSeq int64, limit int) ([]models.AuditEntry, error)
+
+	ListCiphertexts(ctx context.Context, col storage.EncryptedColumn, after []any, limit uint64) ([]models.Ciphertext, error)
+	ReplaceCiphertext(ctx context.Context, col storage.EncryptedColumn, old models.Ciphertext, value []byte) (bool, error)
+}
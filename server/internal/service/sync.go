@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"errors"
+)
+
+// Sync applies the changes pushed by a client and returns everything that changed after its cursor.
+// Pushed edits based on a stale version are not applied and are reported as conflicts instead.
+func (s *Vault) Sync(ctx context.Context, login string, cursor int64, changes []models.SyncChange) (models.SyncResult, error) {
+	if login == "" || cursor < 0 {
+		return models.SyncResult{}, ErrMalformedRequest
+	}
+
+	conflicts := make([]models.SyncConflict, 0)
+	for _, c := range changes {
+		var err error
+		switch {
+		case c.ID == "":
+			err = s.PostData(ctx, login, c.Type, c.Data, nil)
+		case c.Deleted:
+			err = s.Storage.DeleteData(ctx, login, c.ID, c.Version)
+		default:
+			_, err = s.UpdateData(ctx, login, c.ID, c.Type, c.Data, c.Version)
+		}
+
+		if errors.Is(err, storage.ErrVersionConflict) || errors.Is(err, storage.ErrNoDataFound) {
+			conflict, err := s.conflict(ctx, login, c)
+			if err != nil {
+				return models.SyncResult{}, err
+			}
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+		if err != nil {
+			return models.SyncResult{}, err
+		}
+	}
+
+	res, err := s.Storage.GetChanges(ctx, login, cursor)
+	if err != nil {
+		return models.SyncResult{}, err
+	}
+
+	for i := range res.Created {
+		if res.Created[i], err = s.openData(ctx, res.Created[i]); err != nil {
+			return models.SyncResult{}, err
+		}
+	}
+	for i := range res.Updated {
+		if res.Updated[i], err = s.openData(ctx, res.Updated[i]); err != nil {
+			return models.SyncResult{}, err
+		}
+	}
+	res.Conflicts = conflicts
+
+	return res, nil
+}
+
+// conflict builds a conflict report for a rejected change with the server's current copy of the entry
+func (s *Vault) conflict(ctx context.Context, login string, c models.SyncChange) (models.SyncConflict, error) {
+	conflict := models.SyncConflict{
+		ID:            c.ID,
+		ClientVersion: c.Version,
+	}
+
+	current, err := s.Storage.GetDataByID(ctx, login, c.ID)
+	if errors.Is(err, storage.ErrNoDataFound) {
+		return conflict, nil
+	}
+	if err != nil {
+		return conflict, err
+	}
+
+	current, err = s.openData(ctx, current)
+	if err != nil {
+		return conflict, err
+	}
+	conflict.Current = &current
+
+	return conflict, nil
+}
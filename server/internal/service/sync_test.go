@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// payloads returns the payloads of data entries in order
+func payloads(data []models.Data) []string {
+	res := make([]string, 0, len(data))
+	for _, d := range data {
+		res = append(res, string(d.Data))
+	}
+	return res
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+
+	for name, cfg := range testConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			vault, _ := newTestVault(t, cfg)
+
+			first, err := vault.Sync(ctx, "alice", 0, []models.SyncChange{
+				{Type: "text", Data: []byte("one")},
+				{Type: "text", Data: []byte("two")},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"one", "two"}, payloads(first.Created))
+			assert.Empty(t, first.Updated)
+			assert.Empty(t, first.Deleted)
+			assert.Empty(t, first.Conflicts)
+			assert.Positive(t, first.Cursor)
+
+			idle, err := vault.Sync(ctx, "alice", first.Cursor, nil)
+			require.NoError(t, err)
+			assert.Empty(t, idle.Created)
+			assert.Empty(t, idle.Updated)
+			assert.Empty(t, idle.Deleted)
+			assert.Equal(t, first.Cursor, idle.Cursor)
+
+			one, two := first.Created[0], first.Created[1]
+			second, err := vault.Sync(ctx, "alice", first.Cursor, []models.SyncChange{
+				{ID: one.ID, Type: "text", Data: []byte("one edited"), Version: one.Version},
+				{ID: two.ID, Version: two.Version, Deleted: true},
+				{Type: "text", Data: []byte("three")},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"three"}, payloads(second.Created))
+			assert.Equal(t, []string{"one edited"}, payloads(second.Updated))
+			assert.Equal(t, int64(2), second.Updated[0].Version)
+			require.Len(t, second.Deleted, 1)
+			assert.Equal(t, two.ID, second.Deleted[0].ID)
+			assert.Empty(t, second.Conflicts)
+			assert.Greater(t, second.Cursor, first.Cursor)
+
+			// A device still at the first cursor pushes edits of both entries
+			stale, err := vault.Sync(ctx, "alice", second.Cursor, []models.SyncChange{
+				{ID: one.ID, Type: "text", Data: []byte("one elsewhere"), Version: one.Version},
+				{ID: two.ID, Type: "text", Data: []byte("two elsewhere"), Version: two.Version},
+			})
+			require.NoError(t, err)
+			assert.Empty(t, stale.Created)
+			assert.Empty(t, stale.Updated)
+			require.Len(t, stale.Conflicts, 2)
+
+			assert.Equal(t, one.ID, stale.Conflicts[0].ID)
+			assert.Equal(t, one.Version, stale.Conflicts[0].ClientVersion)
+			require.NotNil(t, stale.Conflicts[0].Current)
+			assert.Equal(t, "one edited", string(stale.Conflicts[0].Current.Data))
+			assert.Equal(t, int64(2), stale.Conflicts[0].Current.Version)
+
+			assert.Equal(t, two.ID, stale.Conflicts[1].ID)
+			assert.Nil(t, stale.Conflicts[1].Current, "A deleted entry should be reported without a current copy")
+
+			all, err := vault.Sync(ctx, "alice", 0, nil)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"one edited", "three"}, append(payloads(all.Created), payloads(all.Updated)...))
+
+			other, err := vault.Sync(ctx, "bob", 0, nil)
+			require.NoError(t, err)
+			assert.Empty(t, other.Created)
+			assert.Empty(t, other.Deleted)
+		})
+	}
+}
+
+func TestSync_Malformed(t *testing.T) {
+	ctx := context.Background()
+	vault, _ := newTestVault(t, testConfig())
+
+	_, err := vault.Sync(ctx, "", 0, nil)
+	assert.ErrorIs(t, err, ErrMalformedRequest)
+
+	_, err = vault.Sync(ctx, "alice", -1, nil)
+	assert.ErrorIs(t, err, ErrMalformedRequest)
+}
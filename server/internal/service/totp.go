@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238). These are the defaults every authenticator app supports.
+const (
+	totpDigits     = 6
+	totpPeriod     = 30
+	totpSkew       = 1
+	totpSecretSize = 20
+	totpIssuer     = "DataVault"
+
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+// totpEncoding is the unpadded base32 used for secrets in authenticator apps
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPEnabled reports whether the user has confirmed two-factor authentication
+func (s *Vault) TOTPEnabled(ctx context.Context, login string) (bool, error) {
+	totp, err := s.Storage.GetTOTP(ctx, login)
+	if err != nil {
+		return false, err
+	}
+
+	return len(totp.Secret) > 0, nil
+}
+
+// totpCode computes the code of a secret for a time step
+func totpCode(secret []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// matchTOTP returns the time step of the code if it is valid for now or an adjacent step,
+// which allows for clock drift and codes entered just as they rolled over
+func matchTOTP(secret []byte, code string, now time.Time) (int64, bool) {
+	step := now.Unix() / totpPeriod
+	for d := int64(-totpSkew); d <= totpSkew; d++ {
+		if hmac.Equal([]byte(totpCode(secret, step+d)), []byte(code)) {
+			return step + d, true
+		}
+	}
+	return 0, false
+}
+
+// newRecoveryCodes generates the recovery codes shown to the user and the hashes they are stored under
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 8)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+
+		code := strings.ToLower(totpEncoding.EncodeToString(raw))[:recoveryCodeLength]
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeCode strips the separators users tend to type and lowercases a code
+func normalizeCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
+
+// isRecoveryCode reports whether a normalized code has the shape of a recovery code
+func isRecoveryCode(code string) bool {
+	return len(code) == recoveryCodeLength && strings.Trim(code, "0123456789") != ""
+}
+
+// hashRecoveryCode returns the hex SHA-256 of a normalized recovery code
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// sealSecret encrypts a two-factor secret for storage if server-side encryption is enabled
+func (s *Vault) sealSecret(ctx context.Context, secret []byte) ([]byte, error) {
+	if !s.serverEncryption() {
+		return secret, nil
+	}
+	return s.encryptBytes(ctx, secret)
+}
+
+// openSecret decrypts a stored two-factor secret if server-side encryption is enabled
+func (s *Vault) openSecret(ctx context.Context, secret []byte) ([]byte, error) {
+	if !s.serverEncryption() {
+		return secret, nil
+	}
+	return s.decryptBytes(ctx, secret)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+	"errors"
+)
+
+// UpdateData replaces a data entry if the client's version is current and returns the new version.
+// Entries of other users can be updated if they were shared with write permission.
+func (s *Vault) UpdateData(ctx context.Context, login, id, dataType string, data []byte, version int64) (int64, error) {
+	if login == "" || id == "" || len(data) == 0 || dataType == "" || version <= 0 {
+		return 0, ErrMalformedRequest
+	}
+
+	if s.serverEncryption() {
+		cipherData, err := s.encryptBytes(ctx, data)
+		if err != nil {
+			return 0, err
+		}
+		data = cipherData
+	}
+
+	newVersion, err := s.Storage.UpdateData(ctx, login, id, dataType, data, version)
+	if errors.Is(err, storage.ErrNoDataFound) {
+		share, shareErr := s.Storage.GetShare(ctx, id, login)
+		if shareErr == nil && share.Permission == models.PermissionWrite {
+			newVersion, err = s.Storage.UpdateData(ctx, share.Owner, id, dataType, data, version)
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return newVersion, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateData(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		login         string
+		permission    string
+		version       int64
+		wrongID       bool
+		expectErr     error
+		expectVersion int64
+	}{
+		{
+			name:          "current version",
+			login:         "alice",
+			version:       1,
+			expectVersion: 2,
+		},
+		{
+			name:      "stale version",
+			login:     "alice",
+			version:   7,
+			expectErr: storage.ErrVersionConflict,
+		},
+		{
+			name:      "unknown entry",
+			login:     "alice",
+			version:   1,
+			wrongID:   true,
+			expectErr: storage.ErrNoDataFound,
+		},
+		{
+			name:          "shared with write permission",
+			login:         "bob",
+			permission:    models.PermissionWrite,
+			version:       1,
+			expectVersion: 2,
+		},
+		{
+			name:       "shared read-only",
+			login:      "bob",
+			permission: models.PermissionRead,
+			version:    1,
+			expectErr:  storage.ErrNoDataFound,
+		},
+		{
+			name:      "not shared",
+			login:     "bob",
+			version:   1,
+			expectErr: storage.ErrNoDataFound,
+		},
+		{
+			name:      "no version",
+			login:     "alice",
+			expectErr: ErrMalformedRequest,
+		},
+	}
+
+	for name, cfg := range testConfigs(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					vault, _ := newTestVault(t, cfg)
+					id := postTestData(t, vault, "alice", "original", map[string]string{"site": "example.com"})
+
+					if tt.permission != "" {
+						require.NoError(t, vault.Register(ctx, models.User{Login: "bob", Password: "auth-key", Salt: []byte("test-kdf-salt-16")}))
+						require.NoError(t, vault.SetPublicKey(ctx, "bob", make([]byte, publicKeySize)))
+						require.NoError(t, vault.ShareData(ctx, "alice", id, "bob", tt.permission, []byte("wrapped")))
+					}
+					if tt.wrongID {
+						id = "999"
+					}
+
+					version, err := vault.UpdateData(ctx, tt.login, id, "text", []byte("updated"), tt.version)
+
+					page, getErr := vault.GetData(ctx, "alice", models.DataQuery{})
+					require.NoError(t, getErr)
+					require.Len(t, page.Data, 1)
+
+					if tt.expectErr != nil {
+						assert.ErrorIs(t, err, tt.expectErr)
+						assert.Equal(t, "original", string(page.Data[0].Data), "A rejected update should change nothing")
+						assert.Equal(t, int64(1), page.Data[0].Version)
+						return
+					}
+					require.NoError(t, err)
+					assert.Equal(t, tt.expectVersion, version)
+					assert.Equal(t, "updated", string(page.Data[0].Data))
+					assert.Equal(t, tt.expectVersion, page.Data[0].Version)
+					assert.Equal(t, map[string]string{"site": "example.com"}, page.Data[0].Metadata, "An update should keep the metadata")
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"time"
+)
+
+// MaxChunkSize limits the plaintext size of a single file chunk
+const MaxChunkSize = 1 << 20
+
+// StartUpload creates a new chunked binary entry described by the client-encrypted meta
+func (s *Vault) StartUpload(ctx context.Context, login string, meta []byte, size, chunkSize int64) (models.File, error) {
+	if login == "" || len(meta) == 0 || size < 0 || chunkSize <= 0 || chunkSize > MaxChunkSize {
+		return models.File{}, ErrMalformedRequest
+	}
+
+	stored := meta
+	if s.serverEncryption() {
+		cipherMeta, err := s.encryptBytes(ctx, meta)
+		if err != nil {
+			return models.File{}, err
+		}
+		stored = cipherMeta
+	}
+
+	file, err := s.Storage.CreateFile(ctx, login, stored, size, chunkSize)
+	if err != nil {
+		return file, err
+	}
+	file.Meta = meta
+
+	return file, nil
+}
+
+// WriteChunk stores the chunk with the given sequence number of an unfinished upload
+func (s *Vault) WriteChunk(ctx context.Context, file models.File, seq int64, chunk []byte) error {
+	if file.Complete || seq < 0 || len(chunk) == 0 {
+		return ErrMalformedRequest
+	}
+
+	if seq >= file.ExpectedChunks() {
+		return ErrTooManyChunks
+	}
+
+	if s.serverEncryption() {
+		cipherChunk, err := s.encryptBytes(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		chunk = cipherChunk
+	}
+
+	return s.Storage.AppendChunk(ctx, file.ID, seq, chunk)
+}
+
+// FinishUpload makes a fully uploaded file visible to the user's devices
+func (s *Vault) FinishUpload(ctx context.Context, file models.File) error {
+	if file.Chunks != file.ExpectedChunks() {
+		return ErrIncompleteFile
+	}
+
+	return s.Storage.CompleteFile(ctx, file.User, file.ID)
+}
+
+// PurgeUploads removes the uploads of all users left unfinished longer than the configured
+// timeout, together with their chunks, and returns how many were removed
+func (s *Vault) PurgeUploads(ctx context.Context) (int64, error) {
+	if s.cfg.UploadTimeout <= 0 {
+		return 0, nil
+	}
+
+	before := time.Now().UTC().Add(-s.cfg.UploadTimeout).Format(time.RFC3339)
+	return s.Storage.PurgeUploads(ctx, before)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeUploads(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		expectPurged int64
+	}{
+		{name: "abandoned", timeout: time.Nanosecond, expectPurged: 1},
+		{name: "within timeout", timeout: time.Hour},
+		{name: "timeout disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.UploadTimeout = tt.timeout
+			vault, store := newTestVault(t, cfg)
+
+			finished, err := vault.StartUpload(ctx, "alice", []byte("finished"), 4, 4)
+			require.NoError(t, err)
+			require.NoError(t, vault.WriteChunk(ctx, finished, 0, []byte("data")))
+			finished, err = store.GetFile(ctx, "alice", finished.ID)
+			require.NoError(t, err)
+			require.NoError(t, vault.FinishUpload(ctx, finished))
+
+			abandoned, err := vault.StartUpload(ctx, "alice", []byte("abandoned"), 8, 4)
+			require.NoError(t, err)
+			require.NoError(t, vault.WriteChunk(ctx, abandoned, 0, []byte("half")))
+
+			// Upload start times have a resolution of a second
+			if tt.expectPurged > 0 {
+				time.Sleep(time.Second)
+			}
+
+			purged, err := vault.PurgeUploads(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectPurged, purged)
+
+			_, err = store.GetFile(ctx, "alice", abandoned.ID)
+			if tt.expectPurged > 0 {
+				assert.ErrorIs(t, err, storage.ErrNoDataFound)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var chunks int
+			require.NoError(t, store.DB.QueryRowContext(ctx, "SELECT count(*) FROM file_chunks").Scan(&chunks))
+			assert.Equal(t, 2-int(tt.expectPurged), chunks, "The chunks should go with the upload")
+
+			_, err = store.GetFile(ctx, "alice", finished.ID)
+			assert.NoError(t, err, "Finished uploads should be kept")
+		})
+	}
+}
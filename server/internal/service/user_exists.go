@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/storage"
+	"errors"
+)
+
+// UserExists reports whether a login belongs to a registered user
+func (s *Vault) UserExists(ctx context.Context, login string) (bool, error) {
+	if login == "" {
+		return false, nil
+	}
+
+	_, err := s.Storage.GetUser(ctx, login)
+	if err != nil {
+		if errors.Is(err, storage.ErrWrongPassword) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
@@ -4,32 +4,114 @@ import (
 	"context"
 	"data-vault/server/internal/config"
 	"data-vault/server/internal/models"
-	"data-vault/server/internal/storage"
 	"log/slog"
+	"sync"
+	"time"
 )
 
 // Service defines the interface for vault operations
 type Service interface {
 	Register(ctx context.Context, user models.User) error
-	Login(ctx context.Context, user models.User) error
-	PostData(ctx context.Context, login, dataType string, data []byte) error
-	GetData(ctx context.Context, login string) ([]models.Data, error)
+	PreLogin(ctx context.Context, login string) ([]byte, bool, error)
+	Login(ctx context.Context, user models.User) ([]byte, error)
+	CreateSession(ctx context.Context, login, device string) (models.Session, string, error)
+	RefreshSession(ctx context.Context, refreshToken string) (models.Session, string, error)
+	SessionActive(ctx context.Context, login, id string) (bool, error)
+	ListSessions(ctx context.Context, login string) ([]models.Session, error)
+	RevokeSession(ctx context.Context, login, id string) error
+	TOTPEnabled(ctx context.Context, login string) (bool, error)
+	EnableTOTP(ctx context.Context, login string) (string, string, error)
+	ConfirmTOTP(ctx context.Context, login, code string) ([]string, error)
+	VerifyTOTP(ctx context.Context, login, code string) ([]byte, error)
+	PostData(ctx context.Context, login, dataType string, data []byte, metadata map[string]string) error
+	GetData(ctx context.Context, login string, query models.DataQuery) (models.DataPage, error)
+	UpdateData(ctx context.Context, login, id, dataType string, data []byte, version int64) (int64, error)
 	DeleteData(ctx context.Context, login, id string) error
+	ListVersions(ctx context.Context, login, id string) ([]models.Version, error)
+	RestoreVersion(ctx context.Context, login, id string, version int64) (int64, error)
+	ListTrash(ctx context.Context, login string) ([]models.Data, error)
+	RestoreFromTrash(ctx context.Context, login, id string) (int64, error)
+	EmptyTrash(ctx context.Context, login string) (int64, error)
+	SetPublicKey(ctx context.Context, login string, key []byte) error
+	GetPublicKey(ctx context.Context, login string) ([]byte, error)
+	ShareData(ctx context.Context, login, id, recipient, permission string, wrappedKey []byte) error
+	ListSharedWithMe(ctx context.Context, login string) ([]models.SharedData, error)
+	RevokeShare(ctx context.Context, login, id, recipient string) error
+	CreateOrg(ctx context.Context, login, name string, wrappedKey []byte) error
+	InviteMember(ctx context.Context, login, org, invitee, role string, wrappedKey []byte) error
+	ListMembers(ctx context.Context, login, org string) ([]models.Member, error)
+	ListOrgs(ctx context.Context, login string) ([]models.Member, error)
+	MemberRole(ctx context.Context, org, login string) (string, error)
+	UserExists(ctx context.Context, login string) (bool, error)
+	RecordAudit(ctx context.Context, entry models.AuditEntry) error
+	GetAuditLog(ctx context.Context, login string, beforeSeq int64, pageSize int) ([]models.AuditEntry, error)
+	PingDB(ctx context.Context) (time.Duration, error)
+	Sync(ctx context.Context, login string, cursor int64, changes []models.SyncChange) (models.SyncResult, error)
+	StartUpload(ctx context.Context, login string, meta []byte, size, chunkSize int64) (models.File, error)
+	GetFile(ctx context.Context, login, id string) (models.File, error)
+	WriteChunk(ctx context.Context, file models.File, seq int64, chunk []byte) error
+	FinishUpload(ctx context.Context, file models.File) error
+	ReadChunks(ctx context.Context, file models.File, from int64, fn func(chunk []byte) error) error
 }
 
 // Vault implements the Service interface with storage and logging
 type Vault struct {
 	Log     *slog.Logger
 	cfg     *config.Config
-	Storage *storage.Storage
+	keys    *Keyring
+	Storage Storage
+
+	auditMu  sync.Mutex
+	auditKey []byte
+}
+
+// serverEncryption reports whether stored data gets a second, server-side encryption layer
+// on top of the client-side encryption. It is enabled when encryption keys are configured.
+func (s *Vault) serverEncryption() bool {
+	return s.keys != nil
 }
 
-// New creates a new Vault service instance
-func New(log *slog.Logger, cfg config.Config, storage *storage.Storage) *Vault {
+// New creates a new Vault service instance. Server-side encryption is enabled by KEY_PROVIDER;
+// it fails if the provider can't load its keys, or if legacy keys are configured without a
+// provider, since values they encrypted would be written back unencrypted.
+func New(log *slog.Logger, cfg config.Config, storage Storage) (*Vault, error) {
 	service := Vault{
 		Log:     log,
 		cfg:     &cfg,
 		Storage: storage,
 	}
-	return &service
+
+	keys := make(map[uint32]string, len(cfg.EncryptionKeys)+1)
+	for version, key := range cfg.EncryptionKeys {
+		keys[version] = key
+	}
+	if _, ok := keys[legacyKeyVersion]; !ok && cfg.EncryptionKey != "" {
+		keys[legacyKeyVersion] = cfg.EncryptionKey
+	}
+
+	for version, key := range keys {
+		if defaultKey(key) {
+			log.Warn("Legacy encryption key is a published default, anyone can decrypt values it sealed; the server won't start until it is removed",
+				"version", version)
+		}
+	}
+
+	provider, err := NewKeyProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		if len(keys) > 0 {
+			return nil, ErrNoKeyProvider
+		}
+		return &service, nil
+	}
+
+	keyring, err := NewKeyring(provider, keys)
+	if err != nil {
+		return nil, err
+	}
+	service.keys = keyring
+
+	return &service, nil
 }
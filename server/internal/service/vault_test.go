@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testLegacyKey is the ENCRYPTION_KEY the legacy values of the tests are sealed with
+const testLegacyKey = "legacy-key-0123456789abcdef01234"
+
+func testConfig() config.Config {
+	return config.Config{
+		DatabaseURI: "memory://",
+		JWTSecret:   "test-jwt-secret-key-for-testing-0123456789",
+	}
+}
+
+// testConfigs returns the configurations the data tests run with: without server-side encryption
+// and with the keyfile provider
+func testConfigs(t *testing.T) map[string]config.Config {
+	return map[string]config.Config{
+		"plain":             testConfig(),
+		"server encryption": withKeyfile(t, testConfig()),
+	}
+}
+
+// withKeyfile configures the keyfile provider with a fresh random key-encryption key
+func withKeyfile(t *testing.T, cfg config.Config) config.Config {
+	t.Helper()
+
+	kek := make([]byte, 32)
+	_, err := io.ReadFull(rand.Reader, kek)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "kek")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(kek)), 0o600))
+
+	cfg.KeyProvider = ProviderKeyfile
+	cfg.KeyFiles = []string{path}
+	return cfg
+}
+
+// newTestVault creates a vault on a migrated in-memory database
+func newTestVault(t *testing.T, cfg config.Config) (*Vault, *storage.Storage) {
+	t.Helper()
+	ctx := context.Background()
+
+	store, err := storage.New(ctx, &cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.DB.Close() })
+	require.NoError(t, store.Migrate(ctx))
+
+	vault, err := New(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg, store)
+	require.NoError(t, err)
+
+	return vault, store
+}
+
+// sealLegacy encrypts a value the way the server did before envelopes: headerless AES-GCM
+// with ENCRYPTION_KEY
+func sealLegacy(t *testing.T, plaintext string) string {
+	t.Helper()
+
+	gcm, err := newGCM([]byte(testLegacyKey))
+	require.NoError(t, err)
+	sealed, err := seal(gcm, []byte(plaintext))
+	require.NoError(t, err)
+
+	return string(sealed)
+}
+
+// insertLegacyUser stores an account the way it was created before password hashing and
+// key derivation salts
+func insertLegacyUser(t *testing.T, store *storage.Storage, login, stored string) {
+	t.Helper()
+
+	_, err := store.DB.ExecContext(context.Background(),
+		"INSERT INTO users (login, password, salt, raw_password) VALUES ($1, $2, NULL, true)", login, stored)
+	require.NoError(t, err)
+}
+
+// postTestData stores a text entry through the vault and returns its ID
+func postTestData(t *testing.T, vault *Vault, login, payload string, metadata map[string]string) string {
+	t.Helper()
+	ctx := context.Background()
+
+	require.NoError(t, vault.PostData(ctx, login, "text", []byte(payload), metadata))
+
+	page, err := vault.GetData(ctx, login, models.DataQuery{PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	require.Equal(t, payload, string(page.Data[0].Data))
+
+	return page.Data[0].ID
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"data-vault/server/internal/storage"
+	"errors"
+	"time"
+)
+
+// VerifyTOTP checks the second factor of a login, either a current TOTP code or an unused
+// recovery code, and returns the user's key derivation salt. Each code is accepted once.
+func (s *Vault) VerifyTOTP(ctx context.Context, login, code string) ([]byte, error) {
+	totp, err := s.Storage.GetTOTP(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if len(totp.Secret) == 0 {
+		return nil, ErrTOTPNotEnabled
+	}
+
+	code = normalizeCode(code)
+	if isRecoveryCode(code) {
+		err = s.Storage.UseRecoveryCode(ctx, login, hashRecoveryCode(code))
+	} else {
+		var secret []byte
+		secret, err = s.openSecret(ctx, totp.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		step, ok := matchTOTP(secret, code, time.Now())
+		if !ok {
+			return nil, ErrInvalidTOTP
+		}
+		err = s.Storage.UseTOTPStep(ctx, login, step)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrCodeUsed) {
+			return nil, ErrInvalidTOTP
+		}
+		return nil, err
+	}
+
+	user, err := s.Storage.GetUser(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Salt, nil
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AppendAudit appends an entry to the audit log of a user. The user's audit chain stays locked
+// while link builds the entry from the user's latest one, a zero entry if the user has none yet.
+func (s *Storage) AppendAudit(ctx context.Context, user string, link func(last models.AuditEntry) models.AuditEntry) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	last, err := s.lastAudit(ctx, tx, user)
+	if err != nil {
+		return err
+	}
+
+	entry := link(last)
+	_, err = sq.Insert("audit_log").
+		Columns("login", "seq", "at", "action", "record_id", "org", "client_ip", "user_agent", "result", "prev_hash", "hash", "mac").
+		Values(entry.User, entry.Seq, entry.At, entry.Action, entry.RecordID, entry.Org, entry.ClientIP, entry.UserAgent,
+			entry.Result, entry.PrevHash, entry.Hash, entry.MAC).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
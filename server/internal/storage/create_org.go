@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// CreateOrg creates an organization together with the membership of its first owner
+func (s *Storage) CreateOrg(ctx context.Context, org models.Org, owner models.Member) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = sq.Insert("organizations").
+		Columns("name", "created_by", "created_at").
+		Values(org.Name, org.CreatedBy, org.CreatedAt).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		if s.dialect.uniqueViolation(err) {
+			return ErrDuplicateOrg
+		}
+		return err
+	}
+
+	if err = s.putMember(ctx, tx, owner); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// CreateServerKey stores a sealed server key under a name unless one is stored already, so
+// that servers starting together agree on the key stored first
+func (s *Storage) CreateServerKey(ctx context.Context, name string, sealed []byte) error {
+	_, err := sq.Insert("server_keys").
+		Columns("name", "sealed").
+		Values(name, sealed).
+		Suffix("ON CONFLICT (name) DO NOTHING").
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+
+	return err
+}
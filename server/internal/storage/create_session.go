@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// CreateSession stores a new login session of a user
+func (s *Storage) CreateSession(ctx context.Context, session models.Session) error {
+	_, err := sq.Insert("sessions").
+		Columns("id", "login", "device", "refresh_hash", "created_at", "last_used_at", "expires_at").
+		Values(session.ID, session.User, session.Device, session.RefreshHash, session.CreatedAt, session.LastUsedAt, session.ExpiresAt).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	return err
+}
@@ -2,24 +2,92 @@ package storage
 
 import (
 	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
 
-// DeleteData removes a specific data entry for a user from the database
-func (s *Storage) DeleteData(ctx context.Context, login, id string) error {
-	_, err := sq.Delete("storage").
-		Where(sq.And{
-			sq.Eq{"user": login},
-			sq.Eq{"id": id},
-		}).
+// DeleteData moves a specific data entry of a user to the trash and leaves a tombstone
+// for synchronizing devices. A positive version makes the delete conditional on the entry's version.
+// The entry is removed for good by EmptyTrash or once the trash retention has passed.
+func (s *Storage) DeleteData(ctx context.Context, login, id string, version int64) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	where := sq.And{
+		sq.Eq{"login": login},
+		sq.Eq{"id": id},
+		sq.NotEq{"status": models.StatusDeleted},
+	}
+	if version > 0 {
+		where = append(where, sq.Eq{"version": version})
+	}
+
+	revision, err := s.nextRevision(ctx, tx, login)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	res, err := sq.Update("storage").
+		Set("status", models.StatusDeleted).
+		Set("deleted_at", now).
+		Set("revision", revision).
+		Where(where).
 		PlaceholderFormat(sq.Dollar).
-		RunWith(s.DB).
+		RunWith(tx).
 		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
 
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		if version > 0 {
+			// A live entry that wasn't deleted is at another version. The check runs in the
+			// transaction, which holds the write lock on SQLite.
+			var current int64
+			err = sq.Select("version").
+				From("storage").
+				Where(sq.And{
+					sq.Eq{"login": login},
+					sq.Eq{"id": id},
+					sq.NotEq{"status": models.StatusDeleted},
+				}).
+				RunWith(tx).
+				PlaceholderFormat(sq.Dollar).
+				QueryRowContext(ctx).
+				Scan(&current)
+			if err == nil {
+				return ErrVersionConflict
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	_, err = sq.Insert("tombstones").
+		Columns("id", "login", "revision", "deleted_at").
+		Values(id, login, revision, now).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"strings"
+)
+
+// dialect holds what differs between the database engines the storage runs on
+type dialect struct {
+	// driver is the database/sql driver opening the database
+	driver string
+	// migrations is the directory of the engine's schema migrations
+	migrations string
+	// nextRevision allocates the next value of the change revision and returns it
+	nextRevision string
+	// lockRows is the suffix of a select locking the selected rows until the transaction ends
+	lockRows string
+	// lockAudit locks the audit chain of the user in $1 until the transaction ends
+	lockAudit string
+	// lockChanges locks the change feed of the user in $1 until the transaction ends, so that
+	// the user's changes commit in the order of their revisions
+	lockChanges string
+	// lockMigrations and unlockMigrations guard the migrations against concurrently
+	// starting server instances
+	lockMigrations   string
+	unlockMigrations string
+	// uniqueViolation reports whether err violated a unique or primary key constraint
+	uniqueViolation func(err error) bool
+}
+
+// openDialect picks the database engine by the scheme of a DATABASE_URI and returns it with the
+// data source name its driver takes. URIs without a scheme are Postgres connection strings.
+func openDialect(uri string) (dialect, string, error) {
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return postgresDialect, uri, nil
+	}
+
+	switch scheme {
+	case "postgres", "postgresql":
+		return postgresDialect, uri, nil
+	case "sqlite":
+		return sqliteDialect, sqliteDSN(rest), nil
+	case "memory":
+		return sqliteDialect, memoryDSN(), nil
+	default:
+		return dialect{}, "", ErrUnknownDatabase
+	}
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetChunks reads the chunks of a file in order starting at the given sequence number
+// and passes each of them to fn without loading the whole file into memory
+func (s *Storage) GetChunks(ctx context.Context, id string, from int64, fn func(seq int64, data []byte) error) error {
+	rows, err := sq.Select("seq", "data").
+		From("file_chunks").
+		Where(sq.And{
+			sq.Eq{"data_id": id},
+			sq.GtOrEq{"seq": from},
+		}).
+		OrderBy("seq ASC").
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seq int64
+		var data []byte
+		if err := rows.Scan(&seq, &data); err != nil {
+			return err
+		}
+
+		if err := fn(seq, data); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
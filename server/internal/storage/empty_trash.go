@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// EmptyTrash permanently removes all trashed data entries of a user and returns how many were removed
+func (s *Storage) EmptyTrash(ctx context.Context, login string) (int64, error) {
+	return s.purgeTrash(ctx, sq.Eq{"login": login})
+}
+
+// PurgeTrash permanently removes the data entries of all users trashed before the given
+// RFC 3339 UTC time and returns how many were removed
+func (s *Storage) PurgeTrash(ctx context.Context, before string) (int64, error) {
+	return s.purgeTrash(ctx, sq.Lt{"deleted_at": before})
+}
+
+// purgeTrash permanently removes the trashed entries matching the condition together with
+// their history and file chunks. Tombstones are kept for devices that haven't synced the delete yet.
+func (s *Storage) purgeTrash(ctx context.Context, cond sq.Sqlizer) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	where := sq.And{
+		sq.Eq{"status": models.StatusDeleted},
+		cond,
+	}
+
+	trashed := sq.Select("id").
+		From("storage").
+		Where(where)
+
+	_, err = sq.Delete("history").
+		Where(sq.Expr("data_id IN (?)", trashed)).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := sq.Delete("storage").
+		Where(where).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, tx.Commit()
+}
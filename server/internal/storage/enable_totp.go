@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// EnableTOTP turns on two-factor authentication with a confirmed secret and replaces the
+// user's recovery codes. step is the time step of the code the secret was confirmed with.
+func (s *Storage) EnableTOTP(ctx context.Context, login string, secret []byte, step int64, codeHashes []string) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = sq.Update("users").
+		Set("totp_secret", secret).
+		Set("totp_pending", nil).
+		Set("totp_last_step", step).
+		Where(sq.Eq{"login": login}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = sq.Delete("recovery_codes").
+		Where(sq.Eq{"login": login}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(codeHashes) > 0 {
+		insert := sq.Insert("recovery_codes").Columns("login", "code_hash")
+		for _, hash := range codeHashes {
+			insert = insert.Values(login, hash)
+		}
+
+		_, err = insert.
+			RunWith(tx).
+			PlaceholderFormat(sq.Dollar).
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
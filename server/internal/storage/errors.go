@@ -6,9 +6,22 @@ import (
 
 // Package level errors for the storage layer
 var (
-	ErrBadConn        = errors.New("error connecting to DB")
-	ErrDuplicateLogin = errors.New("login already taken")
-	ErrWrongPassword  = errors.New("login/password pair is wrong")
-	ErrUnauthorized   = errors.New("user not logged in")
-	ErrNoDataFound    = errors.New("no data found for user")
+	ErrBadConn           = errors.New("error connecting to DB")
+	ErrUnknownDatabase   = errors.New("DATABASE_URI must start with postgres://, sqlite:// or memory://")
+	ErrDuplicateLogin    = errors.New("login already taken")
+	ErrWrongPassword     = errors.New("login/password pair is wrong")
+	ErrUnauthorized      = errors.New("user not logged in")
+	ErrNoDataFound       = errors.New("no data found for user")
+	ErrVersionConflict   = errors.New("data was modified by another client")
+	ErrChunkOutOfOrder   = errors.New("file chunk does not follow the stored ones")
+	ErrVersionNotFound   = errors.New("data version not found in history")
+	ErrSessionNotFound   = errors.New("session not found or revoked")
+	ErrCodeUsed          = errors.New("one-time code was already used")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrNoPublicKey       = errors.New("user has not published a public key yet")
+	ErrShareNotFound     = errors.New("share not found")
+	ErrDuplicateOrg      = errors.New("organization name already taken")
+	ErrMemberNotFound    = errors.New("user is not a member of the organization")
+	ErrBadMigration      = errors.New("invalid schema migration")
+	ErrServerKeyNotFound = errors.New("server key not found")
 )
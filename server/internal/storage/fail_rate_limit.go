@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// FailRateLimit counts a failed attempt under a rate limit key and returns the number of
+// consecutive failures
+func (s *Storage) FailRateLimit(ctx context.Context, key string, now time.Time) (int64, error) {
+	var failures int64
+	err := sq.Insert("rate_limits").
+		Columns("limit_key", "failures", "last_failure").
+		Values(key, 1, now.UnixNano()).
+		Suffix(`ON CONFLICT (limit_key) DO UPDATE SET
+			failures = rate_limits.failures + 1,
+			last_failure = EXCLUDED.last_failure
+			RETURNING failures`).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&failures)
+	return failures, err
+}
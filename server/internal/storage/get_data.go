@@ -7,14 +7,34 @@ import (
 	sq "github.com/Masterminds/squirrel"
 )
 
-// GetData retrieves all data for a specific user from the database
-func (s *Storage) GetData(ctx context.Context, user string) ([]models.Data, error) {
+// GetData retrieves up to limit data entries of a user from the database, newest first, starting
+// after the given cursor. The type and upload date filters of the query are applied here,
+// metadata and paging fields are left to the caller.
+func (s *Storage) GetData(ctx context.Context, user string, query models.DataQuery, after models.PageCursor, limit uint64) ([]models.Data, error) {
 	data := make([]models.Data, 0)
 
-	rows, err := sq.Select("id", "user", "status", "type", "data", "uploaded_at").
+	where := sq.And{
+		sq.Eq{"login": user},
+		sq.NotEq{"status": []string{models.StatusUploading, models.StatusDeleted}},
+	}
+	if query.Type != "" {
+		where = append(where, sq.Eq{"type": query.Type})
+	}
+	if query.UploadedFrom != "" {
+		where = append(where, sq.GtOrEq{"uploaded_at": query.UploadedFrom})
+	}
+	if query.UploadedTo != "" {
+		where = append(where, sq.Lt{"uploaded_at": query.UploadedTo})
+	}
+	if after.ID != "" {
+		where = append(where, sq.Expr("(uploaded_at, id) < (?, ?)", after.UploadedAt, after.ID))
+	}
+
+	rows, err := sq.Select("id", "login", "status", "type", "data", "uploaded_at", "version", "revision", "metadata").
 		From("storage").
-		Where(sq.Eq{"user": user}).
-		OrderBy("uploaded_at DESC").
+		Where(where).
+		OrderBy("uploaded_at DESC", "id DESC").
+		Limit(limit).
 		RunWith(s.DB).
 		PlaceholderFormat(sq.Dollar).
 		QueryContext(ctx)
@@ -25,7 +45,7 @@ func (s *Storage) GetData(ctx context.Context, user string) ([]models.Data, erro
 
 	for rows.Next() {
 		var o models.Data
-		err := rows.Scan(&o.ID, &o.User, &o.Status, &o.Type, &o.Data, &o.UploadedAt)
+		err := rows.Scan(&o.ID, &o.User, &o.Status, &o.Type, &o.Data, &o.UploadedAt, &o.Version, &o.Revision, &o.RawMetadata)
 		if err != nil {
 			return nil, err
 		}
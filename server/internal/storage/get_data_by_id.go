@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetDataByID retrieves a single live data entry of a user from the database, trashed entries are not found
+func (s *Storage) GetDataByID(ctx context.Context, login, id string) (models.Data, error) {
+	var o models.Data
+
+	err := sq.Select("id", "login", "status", "type", "data", "uploaded_at", "version", "revision", "metadata").
+		From("storage").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+			sq.NotEq{"status": models.StatusDeleted},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&o.ID, &o.User, &o.Status, &o.Type, &o.Data, &o.UploadedAt, &o.Version, &o.Revision, &o.RawMetadata)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return o, ErrNoDataFound
+		}
+		return o, err
+	}
+
+	return o, nil
+}
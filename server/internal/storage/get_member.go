@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetMember fetches the membership of a user in an organization
+func (s *Storage) GetMember(ctx context.Context, org, login string) (models.Member, error) {
+	var member models.Member
+
+	err := sq.Select("org", "login", "role", "wrapped_key", "joined_at").
+		From("org_members").
+		Where(sq.Eq{"org": org, "login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&member.Org, &member.Login, &member.Role, &member.WrappedKey, &member.JoinedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Member{}, ErrMemberNotFound
+		}
+		return models.Member{}, err
+	}
+
+	return member, nil
+}
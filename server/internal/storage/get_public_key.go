@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetPublicKey fetches the public key a user published for sharing
+func (s *Storage) GetPublicKey(ctx context.Context, login string) ([]byte, error) {
+	var key []byte
+
+	err := sq.Select("public_key").
+		From("users").
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if len(key) == 0 {
+		return nil, ErrNoPublicKey
+	}
+	return key, nil
+}
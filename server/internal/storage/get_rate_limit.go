@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RateLimitFailures returns the number of consecutive failures counted under a rate limit key
+// and the time of the last one
+func (s *Storage) RateLimitFailures(ctx context.Context, key string) (int64, time.Time, error) {
+	var failures, last int64
+	err := sq.Select("failures", "last_failure").
+		From("rate_limits").
+		Where(sq.Eq{"limit_key": key}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&failures, &last)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, time.Time{}, nil
+		}
+		return 0, time.Time{}, err
+	}
+
+	return failures, time.Unix(0, last), nil
+}
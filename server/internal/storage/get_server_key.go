@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetServerKey retrieves a sealed key the server keeps for its own use by name
+func (s *Storage) GetServerKey(ctx context.Context, name string) ([]byte, error) {
+	var sealed []byte
+
+	err := sq.Select("sealed").
+		From("server_keys").
+		Where(sq.Eq{"name": name}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&sealed)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrServerKeyNotFound
+		}
+		return nil, err
+	}
+
+	return sealed, nil
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetSession retrieves a session by its ID, including revoked and expired ones
+func (s *Storage) GetSession(ctx context.Context, id string) (models.Session, error) {
+	var session models.Session
+
+	err := sq.Select("id", "login", "device", "refresh_hash", "created_at", "last_used_at", "expires_at", "revoked_at").
+		From("sessions").
+		Where(sq.Eq{"id": id}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&session.ID, &session.User, &session.Device, &session.RefreshHash, &session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt, &session.RevokedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return session, ErrSessionNotFound
+		}
+		return session, err
+	}
+
+	return session, nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetShare fetches the grant of a user to a data entry shared with them
+func (s *Storage) GetShare(ctx context.Context, id, recipient string) (models.Share, error) {
+	var share models.Share
+
+	err := sq.Select("data_id", "owner", "recipient", "permission", "wrapped_key", "shared_at").
+		From("shares").
+		Where(sq.And{
+			sq.Eq{"data_id": id},
+			sq.Eq{"recipient": recipient},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&share.DataID, &share.Owner, &share.Recipient, &share.Permission, &share.WrappedKey, &share.SharedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return share, ErrShareNotFound
+		}
+		return share, err
+	}
+
+	return share, nil
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetTOTP fetches a user's two-factor authentication state by login
+func (s *Storage) GetTOTP(ctx context.Context, login string) (models.TOTP, error) {
+	var totp models.TOTP
+
+	err := sq.Select("totp_secret", "totp_pending", "totp_last_step").
+		From("users").
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&totp.Secret, &totp.Pending, &totp.LastStep)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return totp, ErrWrongPassword
+		}
+		return totp, err
+	}
+
+	return totp, nil
+}
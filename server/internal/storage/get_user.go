@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetUser fetches a user's stored password hash, key derivation salt and whether the hash is
+// of the master password itself by login
+func (s *Storage) GetUser(ctx context.Context, login string) (models.User, error) {
+	user := models.User{Login: login}
+
+	row := sq.Select("password", "salt", "raw_password").
+		From("users").
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx)
+
+	err := row.Scan(&user.Password, &user.Salt, &user.RawPassword)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return user, ErrWrongPassword
+		}
+		return user, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// archiveVersion copies the current state of an entry into its history before it is replaced.
+// A positive version archives the entry only if it is still at that version.
+func (s *Storage) archiveVersion(ctx context.Context, runner sq.BaseRunner, login, id string, version int64) error {
+	where := sq.And{
+		sq.Eq{"login": login},
+		sq.Eq{"id": id},
+		sq.NotEq{"status": models.StatusUploading},
+	}
+	if version > 0 {
+		where = append(where, sq.Eq{"version": version})
+	}
+
+	current := sq.Select("id").
+		Column("?", login).
+		Columns("version", "type", "data", "metadata", "uploaded_at").
+		Column("?", time.Now().UTC().Format(time.RFC3339)).
+		From("storage").
+		Where(where)
+
+	_, err := sq.Insert("history").
+		Columns("data_id", "login", "version", "type", "data", "metadata", "uploaded_at", "archived_at").
+		Select(current).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(runner).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	return err
+}
+
+// pruneHistory drops the versions of an entry beyond the configured retention
+func (s *Storage) pruneHistory(ctx context.Context, runner sq.BaseRunner, login, id string) error {
+	if s.cfg.HistoryVersions > 0 {
+		_, err := sq.Delete("history").
+			Where(sq.And{
+				sq.Eq{"data_id": id},
+				sq.Expr("version NOT IN (SELECT version FROM history WHERE data_id = ? ORDER BY version DESC LIMIT ?)", id, s.cfg.HistoryVersions),
+			}).
+			RunWith(runner).
+			PlaceholderFormat(sq.Dollar).
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.HistoryMaxAge > 0 {
+		_, err := sq.Delete("history").
+			Where(sq.And{
+				sq.Eq{"login": login},
+				sq.Lt{"archived_at": time.Now().UTC().Add(-s.cfg.HistoryMaxAge).Format(time.RFC3339)},
+			}).
+			RunWith(runner).
+			PlaceholderFormat(sq.Dollar).
+			ExecContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// HitRateLimit counts a call under a rate limit key in the fixed window running at now, starting
+// a new window of the given length if the last one is over. It returns the calls counted in the
+// window and the window's end.
+func (s *Storage) HitRateLimit(ctx context.Context, key string, now time.Time, window time.Duration) (int64, time.Time, error) {
+	var hits, end int64
+	err := sq.Insert("rate_limits").
+		Columns("limit_key", "hits", "window_end").
+		Values(key, 1, now.Add(window).UnixNano()).
+		Suffix(`ON CONFLICT (limit_key) DO UPDATE SET
+			hits = CASE WHEN rate_limits.window_end <= ? THEN 1 ELSE rate_limits.hits + 1 END,
+			window_end = CASE WHEN rate_limits.window_end <= ? THEN EXCLUDED.window_end ELSE rate_limits.window_end END
+			RETURNING hits, window_end`, now.UnixNano(), now.UnixNano()).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&hits, &end)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	return hits, time.Unix(0, end), nil
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// lastAudit locks the audit chain of a user until the transaction ends and fetches its latest
+// entry, a zero entry if the user has none yet
+func (s *Storage) lastAudit(ctx context.Context, tx *sql.Tx, user string) (models.AuditEntry, error) {
+	if s.dialect.lockAudit != "" {
+		if _, err := tx.ExecContext(ctx, s.dialect.lockAudit, "audit:"+user); err != nil {
+			return models.AuditEntry{}, err
+		}
+	}
+
+	var entry models.AuditEntry
+	err := sq.Select("login", "seq", "hash").
+		From("audit_log").
+		Where(sq.Eq{"login": user}).
+		OrderBy("seq DESC").
+		Limit(1).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&entry.User, &entry.Seq, &entry.Hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.AuditEntry{}, nil
+		}
+		return models.AuditEntry{}, err
+	}
+
+	return entry, nil
+}
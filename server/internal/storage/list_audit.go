@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListAudit retrieves up to limit entries of a user's audit log, newest first. A positive
+// beforeSeq only returns entries older than it.
+func (s *Storage) ListAudit(ctx context.Context, user string, beforeSeq int64, limit int) ([]models.AuditEntry, error) {
+	where := sq.And{sq.Eq{"login": user}}
+	if beforeSeq > 0 {
+		where = append(where, sq.Lt{"seq": beforeSeq})
+	}
+
+	rows, err := sq.Select("login", "seq", "at", "action", "record_id", "org", "client_ip", "user_agent", "result", "prev_hash", "hash", "mac").
+		From("audit_log").
+		Where(where).
+		OrderBy("seq DESC").
+		Limit(uint64(limit)).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.AuditEntry, 0)
+	for rows.Next() {
+		var e models.AuditEntry
+		err := rows.Scan(&e.User, &e.Seq, &e.At, &e.Action, &e.RecordID, &e.Org, &e.ClientIP, &e.UserAgent,
+			&e.Result, &e.PrevHash, &e.Hash, &e.MAC)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListCiphertexts returns up to limit non-null values of an encrypted column in primary key
+// order, starting after the row with the given key. A nil key starts with the first row.
+func (s *Storage) ListCiphertexts(ctx context.Context, col EncryptedColumn, after []any, limit uint64) ([]models.Ciphertext, error) {
+	where := sq.And{sq.NotEq{col.Column: nil}}
+	if after != nil {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(col.Key)), ", ")
+		where = append(where, sq.Expr(fmt.Sprintf("(%s) > (%s)", strings.Join(col.Key, ", "), placeholders), after...))
+	}
+
+	rows, err := sq.Select(append(append([]string{}, col.Key...), col.Column)...).
+		From(col.Table).
+		Where(where).
+		OrderBy(col.Key...).
+		Limit(limit).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]models.Ciphertext, 0)
+	for rows.Next() {
+		c := models.Ciphertext{Key: make([]any, len(col.Key))}
+
+		dest := make([]any, 0, len(col.Key)+1)
+		for i := range c.Key {
+			dest = append(dest, &c.Key[i])
+		}
+		dest = append(dest, &c.Value)
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		values = append(values, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
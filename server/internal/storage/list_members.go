@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListMembers retrieves the members of an organization in the order they joined
+func (s *Storage) ListMembers(ctx context.Context, org string) ([]models.Member, error) {
+	return s.listMembers(ctx, sq.Eq{"org": org}, "joined_at", "login")
+}
+
+// ListOrgs retrieves the memberships of a user, ordered by organization name
+func (s *Storage) ListOrgs(ctx context.Context, login string) ([]models.Member, error) {
+	return s.listMembers(ctx, sq.Eq{"login": login}, "org")
+}
+
+// listMembers retrieves the memberships matching a condition
+func (s *Storage) listMembers(ctx context.Context, where sq.Eq, orderBy ...string) ([]models.Member, error) {
+	rows, err := sq.Select("org", "login", "role", "wrapped_key", "joined_at").
+		From("org_members").
+		Where(where).
+		OrderBy(orderBy...).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]models.Member, 0)
+	for rows.Next() {
+		var m models.Member
+		if err := rows.Scan(&m.Org, &m.Login, &m.Role, &m.WrappedKey, &m.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"slices"
+)
+
+// ListMigrations returns the known migrations with the time each was applied, ordered by version.
+// Applied migrations this build doesn't know, made by a newer server, are included.
+func (s *Storage) ListMigrations(ctx context.Context) ([]models.Migration, error) {
+	migrations, err := loadMigrations(s.dialect.migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []models.Migration
+	err = s.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			record, ok := applied[m.version]
+			if !ok {
+				record = models.Migration{Version: m.version, Name: m.name}
+			}
+			res = append(res, record)
+			delete(applied, m.version)
+		}
+		for _, record := range applied {
+			res = append(res, record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(res, func(a, b models.Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return res, nil
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListSessions retrieves the sessions of a user that are neither revoked nor expired, most recently used first
+func (s *Storage) ListSessions(ctx context.Context, login string) ([]models.Session, error) {
+	rows, err := sq.Select("id", "login", "device", "created_at", "last_used_at", "expires_at").
+		From("sessions").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"revoked_at": ""},
+			sq.Gt{"expires_at": time.Now().UTC().Format(time.RFC3339)},
+		}).
+		OrderBy("last_used_at DESC").
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]models.Session, 0)
+	for rows.Next() {
+		var session models.Session
+		err := rows.Scan(&session.ID, &session.User, &session.Device, &session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListSharedWithMe retrieves the live data entries other users shared with a user, most recently shared first
+func (s *Storage) ListSharedWithMe(ctx context.Context, login string) ([]models.SharedData, error) {
+	rows, err := sq.Select(
+		"s.id", "s.login", "s.status", "s.type", "s.data", "s.uploaded_at", "s.version", "s.revision", "s.metadata",
+		"sh.owner", "sh.recipient", "sh.permission", "sh.wrapped_key", "sh.shared_at",
+	).
+		From("shares sh").
+		Join("storage s ON s.id = sh.data_id").
+		Where(sq.And{
+			sq.Eq{"sh.recipient": login},
+			sq.NotEq{"s.status": models.StatusDeleted},
+		}).
+		OrderBy("sh.shared_at DESC", "s.id DESC").
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shared := make([]models.SharedData, 0)
+	for rows.Next() {
+		var o models.SharedData
+		err := rows.Scan(&o.Data.ID, &o.Data.User, &o.Data.Status, &o.Data.Type, &o.Data.Data, &o.Data.UploadedAt,
+			&o.Data.Version, &o.Data.Revision, &o.Data.RawMetadata,
+			&o.Share.Owner, &o.Share.Recipient, &o.Share.Permission, &o.Share.WrappedKey, &o.Share.SharedAt)
+		if err != nil {
+			return nil, err
+		}
+		o.Share.DataID = o.Data.ID
+		shared = append(shared, o)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return shared, nil
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListTrash retrieves the trashed data entries of a user, most recently deleted first
+func (s *Storage) ListTrash(ctx context.Context, login string) ([]models.Data, error) {
+	rows, err := sq.Select("id", "login", "status", "type", "data", "uploaded_at", "version", "revision", "metadata", "deleted_at").
+		From("storage").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"status": models.StatusDeleted},
+		}).
+		OrderBy("deleted_at DESC", "id DESC").
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data := make([]models.Data, 0)
+	for rows.Next() {
+		var o models.Data
+		err := rows.Scan(&o.ID, &o.User, &o.Status, &o.Type, &o.Data, &o.UploadedAt, &o.Version, &o.Revision, &o.RawMetadata, &o.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, o)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
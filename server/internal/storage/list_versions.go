@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ListVersions retrieves the prior versions of a user's data entry, newest first.
+// The history outlives the entry, so versions of deleted entries are listed as well.
+func (s *Storage) ListVersions(ctx context.Context, login, id string) ([]models.Version, error) {
+	rows, err := sq.Select("data_id", "version", "type", "data", "metadata", "uploaded_at", "archived_at").
+		From("history").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"data_id": id},
+		}).
+		OrderBy("version DESC").
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make([]models.Version, 0)
+	for rows.Next() {
+		var v models.Version
+		err := rows.Scan(&v.DataID, &v.Version, &v.Type, &v.Data, &v.RawMetadata, &v.UploadedAt, &v.ArchivedAt)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// migrationFiles holds the schema migrations of each database engine in a directory of its own,
+// each a <version>_<name>.up.sql file applying the change and a .down.sql file reverting it.
+// Statements are separated by semicolons.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// migrationFile matches the file names of the migrations
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// SchemaMigrationsQuery creates the table recording the applied migrations
+var SchemaMigrationsQuery = `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint PRIMARY KEY, name text NOT NULL, applied_at text NOT NULL);`
+
+// migration is an embedded schema migration
+type migration struct {
+	version int64
+	name    string
+	up      string
+	down    string
+}
+
+// Migrate applies all pending migrations
+func (s *Storage) Migrate(ctx context.Context) error {
+	_, err := s.MigrateUp(ctx, 0)
+	return err
+}
+
+// loadMigrations reads the embedded migrations of a directory ordered by version
+func loadMigrations(dir string) ([]migration, error) {
+	files, err := fs.Glob(migrationFiles, dir+"/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*migration)
+	for _, file := range files {
+		name := path.Base(file)
+		parts := migrationFile.FindStringSubmatch(name)
+		if parts == nil {
+			return nil, fmt.Errorf("%w: %s", ErrBadMigration, name)
+		}
+
+		version, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrBadMigration, name)
+		}
+
+		body, err := migrationFiles.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: parts[2]}
+			byVersion[version] = m
+		}
+		if m.name != parts[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %s and %s", ErrBadMigration, version, m.name, parts[2])
+		}
+
+		if parts[3] == "up" {
+			m.up = string(body)
+		} else {
+			m.down = string(body)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("%w: %d_%s needs both an up and a down file", ErrBadMigration, m.version, m.name)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b migration) int {
+		return cmp.Compare(a.version, b.version)
+	})
+
+	return migrations, nil
+}
+
+// withMigrationLock runs fn on a connection holding the lock of the migrations, so that server
+// instances starting at the same time don't migrate the database concurrently
+func (s *Storage) withMigrationLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := s.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if s.dialect.lockMigrations != "" {
+		if _, err := conn.ExecContext(ctx, s.dialect.lockMigrations); err != nil {
+			return err
+		}
+		defer conn.ExecContext(context.WithoutCancel(ctx), s.dialect.unlockMigrations)
+	}
+
+	if _, err := conn.ExecContext(ctx, SchemaMigrationsQuery); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// appliedMigrations returns the migrations recorded as applied, by version
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (map[int64]models.Migration, error) {
+	query, args, err := sq.Select("version", "name", "applied_at").
+		From("schema_migrations").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]models.Migration)
+	for rows.Next() {
+		var m models.Migration
+		if err := rows.Scan(&m.Version, &m.Name, &m.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied[m.Version] = m
+	}
+
+	return applied, rows.Err()
+}
+
+// runMigration executes the statements of a migration script and records the change in a single
+// transaction. It reports false without running the script when the version is already in the
+// state the migration leads to, i.e. applied by an up or reverted by a down migration of another
+// server instance. On SQLite the transaction takes the write lock when it begins, which makes the
+// check and the change atomic where the advisory lock of Postgres is missing.
+func runMigration(ctx context.Context, conn *sql.Conn, version int64, up bool, script string, record sq.Sqlizer) (bool, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var recorded int
+	err = sq.Select("count(*)").
+		From("schema_migrations").
+		Where(sq.Eq{"version": version}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&recorded)
+	if err != nil {
+		return false, err
+	}
+	if (recorded > 0) == up {
+		return false, nil
+	}
+
+	for _, stmt := range strings.Split(script, ";") {
+		if stmt = strings.TrimSpace(stmt); stmt == "" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return false, err
+		}
+	}
+
+	query, args, err := record.ToSql()
+	if err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// MigrateDown reverts the latest applied migrations, steps of them, and returns the reverted ones
+func (s *Storage) MigrateDown(ctx context.Context, steps int) ([]models.Migration, error) {
+	if steps <= 0 {
+		return nil, ErrBadMigration
+	}
+
+	migrations, err := loadMigrations(s.dialect.migrations)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(migrations)
+
+	var done []models.Migration
+	err = s.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if len(done) == steps {
+				break
+			}
+
+			record, ok := applied[m.version]
+			if !ok {
+				continue
+			}
+
+			del := sq.Delete("schema_migrations").
+				Where(sq.Eq{"version": m.version}).
+				PlaceholderFormat(sq.Dollar)
+
+			ran, err := runMigration(ctx, conn, m.version, false, m.down, del)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", m.version, m.name, err)
+			}
+			if ran {
+				done = append(done, record)
+			}
+		}
+
+		return nil
+	})
+
+	return done, err
+}
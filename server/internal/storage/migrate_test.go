@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestStorage opens a storage on a DATABASE_URI and closes it when the test ends
+func openTestStorage(t *testing.T, uri string) *Storage {
+	t.Helper()
+
+	store, err := New(context.Background(), &config.Config{DatabaseURI: uri})
+	require.NoError(t, err)
+	t.Cleanup(func() { store.DB.Close() })
+
+	return store
+}
+
+// testDatabases lists the embedded databases the storage tests run on by the URI of a fresh one
+var testDatabases = map[string]func(t *testing.T) string{
+	"sqlite": func(t *testing.T) string { return "sqlite://" + filepath.Join(t.TempDir(), "vault.db") },
+	"memory": func(t *testing.T) string { return "memory://" },
+}
+
+// listStatus lists the migrations of a storage
+func listStatus(t *testing.T, store *Storage) []models.Migration {
+	t.Helper()
+
+	migrations, err := store.ListMigrations(context.Background())
+	require.NoError(t, err)
+	return migrations
+}
+
+// countApplied returns how many of the listed migrations are applied
+func countApplied(t *testing.T, migrations []models.Migration) int {
+	t.Helper()
+
+	n := 0
+	for _, m := range migrations {
+		if m.AppliedAt != "" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestMigrate_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+
+	for name, uri := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			store := openTestStorage(t, uri(t))
+
+			known, err := loadMigrations(store.dialect.migrations)
+			require.NoError(t, err)
+			require.NotEmpty(t, known)
+
+			status := listStatus(t, store)
+			require.Len(t, status, len(known))
+			assert.Zero(t, countApplied(t, status))
+
+			done, err := store.MigrateUp(ctx, 3)
+			require.NoError(t, err)
+			require.Len(t, done, 3)
+			for i, m := range done {
+				assert.Equal(t, known[i].version, m.Version)
+				assert.Equal(t, known[i].name, m.Name)
+				assert.NotEmpty(t, m.AppliedAt)
+			}
+
+			status = listStatus(t, store)
+			assert.Equal(t, 3, countApplied(t, status))
+			assert.NotEmpty(t, status[2].AppliedAt)
+			assert.Empty(t, status[3].AppliedAt)
+
+			done, err = store.MigrateUp(ctx, 0)
+			require.NoError(t, err)
+			assert.Len(t, done, len(known)-3)
+			assert.Equal(t, len(known), countApplied(t, listStatus(t, store)))
+
+			done, err = store.MigrateUp(ctx, 0)
+			require.NoError(t, err)
+			assert.Empty(t, done)
+
+			done, err = store.MigrateDown(ctx, 1)
+			require.NoError(t, err)
+			require.Len(t, done, 1)
+			assert.Equal(t, known[len(known)-1].version, done[0].Version)
+			assert.Equal(t, len(known)-1, countApplied(t, listStatus(t, store)))
+
+			done, err = store.MigrateDown(ctx, len(known))
+			require.NoError(t, err)
+			assert.Len(t, done, len(known)-1)
+			assert.Zero(t, countApplied(t, listStatus(t, store)))
+
+			_, err = store.DB.ExecContext(ctx, "SELECT login FROM storage")
+			assert.Error(t, err, "the down migrations drop the tables")
+
+			require.NoError(t, store.Migrate(ctx))
+			assert.Equal(t, len(known), countApplied(t, listStatus(t, store)))
+
+			_, err = store.MigrateDown(ctx, 0)
+			assert.ErrorIs(t, err, ErrBadMigration)
+		})
+	}
+}
+
+func TestMigrate_ListsUnknownVersions(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStorage(t, "memory://")
+	require.NoError(t, store.Migrate(ctx))
+
+	_, err := store.DB.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, applied_at) VALUES (9999, 'newer', '2026-01-01T00:00:00Z')")
+	require.NoError(t, err)
+
+	migrations, err := store.ListMigrations(ctx)
+	require.NoError(t, err)
+
+	last := migrations[len(migrations)-1]
+	assert.Equal(t, int64(9999), last.Version)
+	assert.Equal(t, "newer", last.Name)
+}
+
+func TestMigrate_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	uri := "sqlite://" + filepath.Join(t.TempDir(), "vault.db")
+
+	const instances = 8
+	stores := make([]*Storage, instances)
+	for i := range stores {
+		stores[i] = openTestStorage(t, uri)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, instances)
+	for i, store := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = store.Migrate(ctx)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+
+	known, err := loadMigrations(sqliteDialect.migrations)
+	require.NoError(t, err)
+
+	var applied int
+	require.NoError(t, stores[0].DB.QueryRowContext(ctx, "SELECT count(*) FROM schema_migrations").Scan(&applied))
+	assert.Equal(t, len(known), applied)
+}
+
+func TestMigrate_AdvisoryLock(t *testing.T) {
+	ctx := context.Background()
+	lock := regexp.QuoteMeta(postgresDialect.lockMigrations)
+	unlock := regexp.QuoteMeta(postgresDialect.unlockMigrations)
+	schema := regexp.QuoteMeta(SchemaMigrationsQuery)
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name      string
+		expect    func(mock sqlmock.Sqlmock)
+		expectErr error
+	}{
+		{
+			name: "runs under the lock",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(lock).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(schema).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(unlock).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "unlocks when the migration fails",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(lock).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(schema).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("SELECT 1").WillReturnError(errFailed)
+				mock.ExpectExec(unlock).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectErr: errFailed,
+		},
+		{
+			name: "lock not taken",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(lock).WillReturnError(context.DeadlineExceeded)
+			},
+			expectErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStorage(t)
+			tt.expect(mock)
+
+			err := store.withMigrationLock(ctx, func(conn *sql.Conn) error {
+				_, err := conn.ExecContext(ctx, "SELECT 1")
+				return err
+			})
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
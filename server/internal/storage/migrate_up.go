@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// MigrateUp applies the pending migrations in order, at most steps of them or all if steps is 0,
+// and returns the applied ones
+func (s *Storage) MigrateUp(ctx context.Context, steps int) ([]models.Migration, error) {
+	migrations, err := loadMigrations(s.dialect.migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []models.Migration
+	err = s.withMigrationLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if _, ok := applied[m.version]; ok {
+				continue
+			}
+			if steps > 0 && len(done) == steps {
+				break
+			}
+
+			record := models.Migration{
+				Version:   m.version,
+				Name:      m.name,
+				AppliedAt: time.Now().UTC().Format(time.RFC3339),
+			}
+			insert := sq.Insert("schema_migrations").
+				Columns("version", "name", "applied_at").
+				Values(record.Version, record.Name, record.AppliedAt).
+				PlaceholderFormat(sq.Dollar)
+
+			ran, err := runMigration(ctx, conn, m.version, true, m.up, insert)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", m.version, m.name, err)
+			}
+			if ran {
+				done = append(done, record)
+			}
+		}
+
+		return nil
+	})
+
+	return done, err
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS storage;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS users (login text PRIMARY KEY, password text, salt bytea);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS salt bytea;
+CREATE TABLE IF NOT EXISTS storage (id SERIAL PRIMARY KEY, login text, status text, type text, data bytea, uploaded_at text);
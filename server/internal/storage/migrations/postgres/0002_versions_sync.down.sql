@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tombstones;
+ALTER TABLE storage DROP COLUMN IF EXISTS created_revision;
+ALTER TABLE storage DROP COLUMN IF EXISTS revision;
+ALTER TABLE storage DROP COLUMN IF EXISTS version;
+DROP SEQUENCE IF EXISTS storage_revision_seq;
@@ -0,0 +1,6 @@
+CREATE SEQUENCE IF NOT EXISTS storage_revision_seq;
+ALTER TABLE storage ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+ALTER TABLE storage ADD COLUMN IF NOT EXISTS revision bigint NOT NULL DEFAULT nextval('storage_revision_seq');
+ALTER TABLE storage ADD COLUMN IF NOT EXISTS created_revision bigint NOT NULL DEFAULT 0;
+UPDATE storage SET created_revision = revision WHERE created_revision = 0;
+CREATE TABLE IF NOT EXISTS tombstones (id integer, login text, revision bigint, deleted_at text);
@@ -0,0 +1,2 @@
+CREATE TABLE IF NOT EXISTS files (data_id integer PRIMARY KEY REFERENCES storage (id) ON DELETE CASCADE, size bigint NOT NULL, chunk_size bigint NOT NULL, chunks bigint NOT NULL DEFAULT 0);
+CREATE TABLE IF NOT EXISTS file_chunks (data_id integer REFERENCES files (data_id) ON DELETE CASCADE, seq bigint, data bytea, PRIMARY KEY (data_id, seq));
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS history;
+ALTER TABLE storage DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE storage DROP COLUMN IF EXISTS metadata;
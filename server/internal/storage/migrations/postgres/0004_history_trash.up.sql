@@ -0,0 +1,3 @@
+ALTER TABLE storage ADD COLUMN IF NOT EXISTS metadata bytea;
+ALTER TABLE storage ADD COLUMN IF NOT EXISTS deleted_at text;
+CREATE TABLE IF NOT EXISTS history (data_id integer, login text, version bigint, type text, data bytea, metadata bytea, uploaded_at text, archived_at text, PRIMARY KEY (data_id, version));
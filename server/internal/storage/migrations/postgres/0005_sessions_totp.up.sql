@@ -0,0 +1,3 @@
+CREATE TABLE IF NOT EXISTS sessions (id text PRIMARY KEY, login text, device text, refresh_hash text, created_at text, last_used_at text, expires_at text, revoked_at text NOT NULL DEFAULT '');
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret bytea, ADD COLUMN IF NOT EXISTS totp_pending bytea, ADD COLUMN IF NOT EXISTS totp_last_step bigint NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS recovery_codes (login text, code_hash text, PRIMARY KEY (login, code_hash));
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS organizations;
+DROP TABLE IF EXISTS shares;
+ALTER TABLE users DROP COLUMN IF EXISTS public_key;
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS public_key bytea;
+CREATE TABLE IF NOT EXISTS shares (data_id integer REFERENCES storage (id) ON DELETE CASCADE, owner text, recipient text, permission text, wrapped_key bytea, shared_at text, PRIMARY KEY (data_id, recipient));
+CREATE TABLE IF NOT EXISTS organizations (name text PRIMARY KEY, created_by text, created_at text);
+CREATE TABLE IF NOT EXISTS org_members (org text REFERENCES organizations (name) ON DELETE CASCADE, login text, role text, wrapped_key bytea, joined_at text, PRIMARY KEY (org, login));
@@ -0,0 +1,2 @@
+CREATE TABLE IF NOT EXISTS audit_log (login text, seq bigint, at text, action text, record_id text, org text, client_ip text, user_agent text, result text, prev_hash bytea, hash bytea, PRIMARY KEY (login, seq));
+CREATE TABLE IF NOT EXISTS rate_limits (limit_key text PRIMARY KEY, hits bigint NOT NULL DEFAULT 0, window_end bigint NOT NULL DEFAULT 0, failures bigint NOT NULL DEFAULT 0, last_failure bigint NOT NULL DEFAULT 0);
@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS raw_password boolean NOT NULL DEFAULT true;
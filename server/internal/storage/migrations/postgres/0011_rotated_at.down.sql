@@ -0,0 +1 @@
+ALTER TABLE rotated_refresh_tokens DROP COLUMN IF EXISTS rotated_at;
@@ -0,0 +1,2 @@
+ALTER TABLE rotated_refresh_tokens ADD COLUMN IF NOT EXISTS rotated_at text NOT NULL DEFAULT '';
+UPDATE rotated_refresh_tokens SET rotated_at = to_char(now() AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"') WHERE rotated_at = '';
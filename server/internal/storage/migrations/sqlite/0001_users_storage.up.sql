@@ -0,0 +1,2 @@
+CREATE TABLE IF NOT EXISTS users (login text PRIMARY KEY, password text, salt blob);
+CREATE TABLE IF NOT EXISTS storage (id integer PRIMARY KEY AUTOINCREMENT, login text, status text, type text, data blob, uploaded_at text);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS tombstones;
+ALTER TABLE storage DROP COLUMN created_revision;
+ALTER TABLE storage DROP COLUMN revision;
+ALTER TABLE storage DROP COLUMN version;
+DROP TABLE IF EXISTS storage_revision_seq;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS storage_revision_seq (value integer NOT NULL);
+INSERT INTO storage_revision_seq (value) SELECT COALESCE(MAX(id), 0) FROM storage;
+ALTER TABLE storage ADD COLUMN version integer NOT NULL DEFAULT 1;
+ALTER TABLE storage ADD COLUMN revision integer NOT NULL DEFAULT 0;
+ALTER TABLE storage ADD COLUMN created_revision integer NOT NULL DEFAULT 0;
+UPDATE storage SET revision = id, created_revision = id;
+CREATE TABLE IF NOT EXISTS tombstones (id integer, login text, revision integer, deleted_at text);
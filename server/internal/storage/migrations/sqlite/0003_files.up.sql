@@ -0,0 +1,2 @@
+CREATE TABLE IF NOT EXISTS files (data_id integer PRIMARY KEY REFERENCES storage (id) ON DELETE CASCADE, size integer NOT NULL, chunk_size integer NOT NULL, chunks integer NOT NULL DEFAULT 0);
+CREATE TABLE IF NOT EXISTS file_chunks (data_id integer REFERENCES files (data_id) ON DELETE CASCADE, seq integer, data blob, PRIMARY KEY (data_id, seq));
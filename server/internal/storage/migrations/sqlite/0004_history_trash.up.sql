@@ -0,0 +1,3 @@
+ALTER TABLE storage ADD COLUMN metadata blob;
+ALTER TABLE storage ADD COLUMN deleted_at text;
+CREATE TABLE IF NOT EXISTS history (data_id integer, login text, version integer, type text, data blob, metadata blob, uploaded_at text, archived_at text, PRIMARY KEY (data_id, version));
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS sessions (id text PRIMARY KEY, login text, device text, refresh_hash text, created_at text, last_used_at text, expires_at text, revoked_at text NOT NULL DEFAULT '');
+ALTER TABLE users ADD COLUMN totp_secret blob;
+ALTER TABLE users ADD COLUMN totp_pending blob;
+ALTER TABLE users ADD COLUMN totp_last_step integer NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS recovery_codes (login text, code_hash text, PRIMARY KEY (login, code_hash));
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN public_key blob;
+CREATE TABLE IF NOT EXISTS shares (data_id integer REFERENCES storage (id) ON DELETE CASCADE, owner text, recipient text, permission text, wrapped_key blob, shared_at text, PRIMARY KEY (data_id, recipient));
+CREATE TABLE IF NOT EXISTS organizations (name text PRIMARY KEY, created_by text, created_at text);
+CREATE TABLE IF NOT EXISTS org_members (org text REFERENCES organizations (name) ON DELETE CASCADE, login text, role text, wrapped_key blob, joined_at text, PRIMARY KEY (org, login));
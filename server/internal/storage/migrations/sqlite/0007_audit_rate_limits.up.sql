@@ -0,0 +1,2 @@
+CREATE TABLE IF NOT EXISTS audit_log (login text, seq integer, at text, action text, record_id text, org text, client_ip text, user_agent text, result text, prev_hash blob, hash blob, PRIMARY KEY (login, seq));
+CREATE TABLE IF NOT EXISTS rate_limits (limit_key text PRIMARY KEY, hits integer NOT NULL DEFAULT 0, window_end integer NOT NULL DEFAULT 0, failures integer NOT NULL DEFAULT 0, last_failure integer NOT NULL DEFAULT 0);
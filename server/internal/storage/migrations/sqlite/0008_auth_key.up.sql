@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN raw_password boolean NOT NULL DEFAULT 1;
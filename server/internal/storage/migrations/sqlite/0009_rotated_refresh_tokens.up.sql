@@ -0,0 +1 @@
+CREATE TABLE IF NOT EXISTS rotated_refresh_tokens (session_id text, refresh_hash text, PRIMARY KEY (session_id, refresh_hash));
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS server_keys;
+ALTER TABLE audit_log DROP COLUMN mac;
@@ -0,0 +1,2 @@
+ALTER TABLE audit_log ADD COLUMN mac blob;
+CREATE TABLE IF NOT EXISTS server_keys (name text PRIMARY KEY, sealed blob);
@@ -0,0 +1 @@
+ALTER TABLE rotated_refresh_tokens DROP COLUMN rotated_at;
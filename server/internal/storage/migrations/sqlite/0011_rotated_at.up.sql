@@ -0,0 +1,2 @@
+ALTER TABLE rotated_refresh_tokens ADD COLUMN rotated_at text NOT NULL DEFAULT '';
+UPDATE rotated_refresh_tokens SET rotated_at = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE rotated_at = '';
@@ -0,0 +1,10 @@
+package storage
+
+import (
+	"context"
+)
+
+// Ping checks that the database answers
+func (s *Storage) Ping(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
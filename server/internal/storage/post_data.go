@@ -2,17 +2,30 @@ package storage
 
 import (
 	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
 
-// PostData stores user data in the database with timestamp
-func (s *Storage) PostData(ctx context.Context, login, dataType string, data []byte) error {
-	_, err := sq.Insert("storage").
-		Columns("user", "status", "type", "data", "uploaded_at").
-		Values(login, "NEW", dataType, data, time.Now().UTC().Format(time.RFC3339)).
-		RunWith(s.DB).
+// PostData stores user data and its sealed metadata in the database with timestamp and a fresh revision
+func (s *Storage) PostData(ctx context.Context, login, dataType string, data, metadata []byte) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	revision, err := s.nextRevision(ctx, tx, login)
+	if err != nil {
+		return err
+	}
+
+	_, err = sq.Insert("storage").
+		Columns("login", "status", "type", "data", "uploaded_at", "revision", "created_revision", "metadata").
+		Values(login, models.StatusNew, dataType, data, time.Now().UTC().Format(time.RFC3339), revision, revision, metadata).
+		RunWith(tx).
 		PlaceholderFormat(sq.Dollar).
 		ExecContext(ctx)
 
@@ -20,5 +33,25 @@ func (s *Storage) PostData(ctx context.Context, login, dataType string, data []b
 		return err
 	}
 
-	return nil
+	return tx.Commit()
+}
+
+// nextRevision locks the change feed of a user until the transaction ends and allocates the next
+// value of the global monotonic change revision. Without the lock a change could commit after
+// one with a higher revision, and a client synchronizing in between would skip it for good.
+func (s *Storage) nextRevision(ctx context.Context, tx *sql.Tx, login string) (int64, error) {
+	if s.dialect.lockChanges != "" {
+		if _, err := tx.ExecContext(ctx, s.dialect.lockChanges, "changes:"+login); err != nil {
+			return 0, err
+		}
+	}
+
+	var revision int64
+
+	err := tx.QueryRowContext(ctx, s.dialect.nextRevision).Scan(&revision)
+	if err != nil {
+		return 0, err
+	}
+
+	return revision, nil
 }
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"errors"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// postgresDialect runs the storage on PostgreSQL through pgx
+var postgresDialect = dialect{
+	driver:           "pgx",
+	migrations:       "migrations/postgres",
+	nextRevision:     `SELECT nextval('storage_revision_seq')`,
+	lockRows:         "FOR UPDATE",
+	lockAudit:        `SELECT pg_advisory_xact_lock(hashtext($1))`,
+	lockChanges:      `SELECT pg_advisory_xact_lock(hashtext($1))`,
+	lockMigrations:   `SELECT pg_advisory_lock(hashtext('schema_migrations'))`,
+	unlockMigrations: `SELECT pg_advisory_unlock(hashtext('schema_migrations'))`,
+	uniqueViolation:  postgresUniqueViolation,
+}
+
+// postgresUniqueViolation reports whether err is a PostgreSQL unique violation
+func postgresUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation
+}
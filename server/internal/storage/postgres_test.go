@@ -0,0 +1,46 @@
+//go:build cgo
+
+package storage
+
+import (
+	"io/fs"
+	"path"
+	"testing"
+
+	pg_query "github.com/pganalyze/pg_query_go/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresMigrations_Parse checks the Postgres migrations and statements against the parser of
+// Postgres itself, which rejects reserved words such as user used as column names
+func TestPostgresMigrations_Parse(t *testing.T) {
+	files, err := fs.Glob(migrationFiles, postgresDialect.migrations+"/*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(path.Base(file), func(t *testing.T) {
+			body, err := migrationFiles.ReadFile(file)
+			require.NoError(t, err)
+
+			_, err = pg_query.Parse(string(body))
+			assert.NoError(t, err)
+		})
+	}
+
+	statements := []string{
+		SchemaMigrationsQuery,
+		postgresDialect.nextRevision,
+		postgresDialect.lockAudit,
+		postgresDialect.lockMigrations,
+		postgresDialect.unlockMigrations,
+	}
+	for _, stmt := range statements {
+		_, err := pg_query.Parse(stmt)
+		assert.NoError(t, err, stmt)
+	}
+
+	_, err = pg_query.Parse("CREATE TABLE storage (id SERIAL PRIMARY KEY, user text)")
+	assert.Error(t, err, "the parser rejects reserved column names")
+}
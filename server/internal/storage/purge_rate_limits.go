@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// PurgeRateLimits removes the rate limit keys whose window ended and whose last failure happened
+// before the given time and returns how many were removed
+func (s *Storage) PurgeRateLimits(ctx context.Context, before time.Time) (int64, error) {
+	res, err := sq.Delete("rate_limits").
+		Where(sq.And{
+			sq.Lt{"window_end": before.UnixNano()},
+			sq.Lt{"last_failure": before.UnixNano()},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
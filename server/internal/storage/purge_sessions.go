@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// PurgeSessions removes the sessions of all users that expired or were revoked before the given
+// RFC 3339 UTC time and the refresh token hashes rotated before it, and returns how many sessions
+// were removed. A session's hashes are rotated before it ends, so none outlive it.
+func (s *Storage) PurgeSessions(ctx context.Context, before string) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = sq.Delete("rotated_refresh_tokens").
+		Where(sq.Lt{"rotated_at": before}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := sq.Delete("sessions").
+		Where(sq.Or{
+			sq.Lt{"expires_at": before},
+			sq.And{
+				sq.NotEq{"revoked_at": ""},
+				sq.Lt{"revoked_at": before},
+			},
+		}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, tx.Commit()
+}
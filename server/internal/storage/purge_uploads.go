@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// PurgeUploads removes the uploads of all users started before the given RFC 3339 UTC time and
+// still unfinished, and returns how many were removed. Their chunks go with them by cascade.
+func (s *Storage) PurgeUploads(ctx context.Context, before string) (int64, error) {
+	res, err := sq.Delete("storage").
+		Where(sq.And{
+			sq.Eq{"status": models.StatusUploading},
+			sq.Lt{"uploaded_at": before},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
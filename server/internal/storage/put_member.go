@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// PutMember adds a user to an organization or replaces the role and wrapped key of a member
+func (s *Storage) PutMember(ctx context.Context, member models.Member) error {
+	return s.putMember(ctx, s.DB, member)
+}
+
+// putMember writes the membership with the given runner, so that it can join a transaction
+func (s *Storage) putMember(ctx context.Context, runner sq.BaseRunner, member models.Member) error {
+	_, err := sq.Insert("org_members").
+		Columns("org", "login", "role", "wrapped_key", "joined_at").
+		Values(member.Org, member.Login, member.Role, member.WrappedKey, member.JoinedAt).
+		Suffix("ON CONFLICT (org, login) DO UPDATE SET role = EXCLUDED.role, wrapped_key = EXCLUDED.wrapped_key").
+		RunWith(runner).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	return err
+}
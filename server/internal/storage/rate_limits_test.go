@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockStorage creates a Postgres storage on a mocked database
+func newMockStorage(t *testing.T) (*Storage, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	return &Storage{DB: db, dialect: postgresDialect}, mock
+}
+
+func TestHitRateLimit(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := now.Add(time.Minute)
+
+	tests := []struct {
+		name      string
+		rows      *sqlmock.Rows
+		err       error
+		expectHit int64
+		expectEnd time.Time
+	}{
+		{
+			name:      "new window",
+			rows:      sqlmock.NewRows([]string{"hits", "window_end"}).AddRow(1, end.UnixNano()),
+			expectHit: 1,
+			expectEnd: end,
+		},
+		{
+			name:      "running window",
+			rows:      sqlmock.NewRows([]string{"hits", "window_end"}).AddRow(7, end.Add(-30*time.Second).UnixNano()),
+			expectHit: 7,
+			expectEnd: end.Add(-30 * time.Second),
+		},
+		{
+			name: "database error",
+			err:  context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStorage(t)
+
+			query := mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO rate_limits (limit_key,hits,window_end) VALUES ($1,$2,$3) ON CONFLICT (limit_key) DO UPDATE SET")).
+				WithArgs("login:alice", 1, end.UnixNano(), now.UnixNano(), now.UnixNano())
+			if tt.err != nil {
+				query.WillReturnError(tt.err)
+			} else {
+				query.WillReturnRows(tt.rows)
+			}
+
+			hits, windowEnd, err := store.HitRateLimit(ctx, "login:alice", now, time.Minute)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectHit, hits)
+			assert.True(t, tt.expectEnd.Equal(windowEnd))
+		})
+	}
+}
+
+func TestFailRateLimit(t *testing.T) {
+	store, mock := newMockStorage(t)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO rate_limits (limit_key,failures,last_failure) VALUES ($1,$2,$3) ON CONFLICT (limit_key) DO UPDATE SET")).
+		WithArgs("lockout:alice", 1, now.UnixNano()).
+		WillReturnRows(sqlmock.NewRows([]string{"failures"}).AddRow(4))
+
+	failures, err := store.FailRateLimit(context.Background(), "lockout:alice", now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), failures)
+}
+
+func TestRateLimitFailures(t *testing.T) {
+	last := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		rows        *sqlmock.Rows
+		expectCount int64
+		expectLast  time.Time
+	}{
+		{
+			name:        "failures counted",
+			rows:        sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(3, last.UnixNano()),
+			expectCount: 3,
+			expectLast:  last,
+		},
+		{
+			name: "unknown key",
+			rows: sqlmock.NewRows([]string{"failures", "last_failure"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock := newMockStorage(t)
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT failures, last_failure FROM rate_limits WHERE limit_key = $1")).
+				WithArgs("lockout:alice").
+				WillReturnRows(tt.rows)
+
+			failures, lastFailure, err := store.RateLimitFailures(context.Background(), "lockout:alice")
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectCount, failures)
+			if tt.expectCount > 0 {
+				assert.True(t, tt.expectLast.Equal(lastFailure))
+			} else {
+				assert.True(t, lastFailure.IsZero())
+			}
+		})
+	}
+}
+
+func TestResetRateLimit(t *testing.T) {
+	store, mock := newMockStorage(t)
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE rate_limits SET failures = $1, last_failure = $2 WHERE limit_key = $3")).
+		WithArgs(0, 0, "lockout:alice").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, store.ResetRateLimit(context.Background(), "lockout:alice"))
+}
+
+func TestPurgeRateLimits(t *testing.T) {
+	store, mock := newMockStorage(t)
+	before := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM rate_limits WHERE (window_end < $1 AND last_failure < $2)")).
+		WithArgs(before.UnixNano(), before.UnixNano()).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	purged, err := store.PurgeRateLimits(context.Background(), before)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), purged)
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RefreshTokenRotated reports whether a refresh token hash was held by the session before a rotation
+func (s *Storage) RefreshTokenRotated(ctx context.Context, id, hash string) (bool, error) {
+	var count int
+
+	err := sq.Select("COUNT(*)").
+		From("rotated_refresh_tokens").
+		Where(sq.Eq{"session_id": id, "refresh_hash": hash}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -3,24 +3,21 @@ package storage
 import (
 	"context"
 	"data-vault/server/internal/models"
-	"errors"
 
 	sq "github.com/Masterminds/squirrel"
-	"github.com/jackc/pgerrcode"
-	"github.com/jackc/pgx/v5/pgconn"
 )
 
-// Register creates a new user account in the database
-func (s *Storage) Register(ctx context.Context, runner sq.BaseRunner, user models.User) error {
+// Register creates a new user account in the database. New accounts log in with the derived
+// authentication key, never with the master password.
+func (s *Storage) Register(ctx context.Context, user models.User) error {
 	_, err := sq.Insert("users").
-		Columns("login", "password").
-		Values(user.Login, user.Password).
-		RunWith(runner).
+		Columns("login", "password", "salt", "raw_password").
+		Values(user.Login, user.Password, user.Salt, false).
+		RunWith(s.DB).
 		PlaceholderFormat(sq.Dollar).
 		ExecContext(ctx)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		if s.dialect.uniqueViolation(err) {
 			return ErrDuplicateLogin
 		}
 		return err
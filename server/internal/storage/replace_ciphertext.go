@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ReplaceCiphertext overwrites an encrypted value with its re-encrypted form unless the row
+// was changed since it was read. It reports whether the value was replaced.
+func (s *Storage) ReplaceCiphertext(ctx context.Context, col EncryptedColumn, old models.Ciphertext, value []byte) (bool, error) {
+	where := sq.And{sq.Eq{col.Column: old.Value}}
+	for i, key := range col.Key {
+		where = append(where, sq.Eq{key: old.Key[i]})
+	}
+
+	res, err := sq.Update(col.Table).
+		Set(col.Column, value).
+		Where(where).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	replaced, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return replaced > 0, nil
+}
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ResetRateLimit clears the failures counted under a rate limit key after a successful attempt
+func (s *Storage) ResetRateLimit(ctx context.Context, key string) error {
+	_, err := sq.Update("rate_limits").
+		Set("failures", 0).
+		Set("last_failure", 0).
+		Where(sq.Eq{"limit_key": key}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RestoreFromTrash moves a trashed data entry of a user back to the live entries and returns its version.
+// The entry gets a fresh revision and drops its tombstone, so synchronizing devices see it as created again.
+func (s *Storage) RestoreFromTrash(ctx context.Context, login, id string) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	revision, err := s.nextRevision(ctx, tx, login)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err = sq.Update("storage").
+		Set("status", models.StatusNew).
+		Set("deleted_at", nil).
+		Set("revision", revision).
+		Set("created_revision", revision).
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+			sq.Eq{"status": models.StatusDeleted},
+		}).
+		Suffix("RETURNING version").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoDataFound
+		}
+		return 0, err
+	}
+
+	err = s.dropTombstone(ctx, tx, login, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, tx.Commit()
+}
+
+// dropTombstone removes the deletion marker of an entry that is live again
+func (s *Storage) dropTombstone(ctx context.Context, runner sq.BaseRunner, login, id string) error {
+	_, err := sq.Delete("tombstones").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+		}).
+		RunWith(runner).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	return err
+}
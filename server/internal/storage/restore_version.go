@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RestoreVersion makes a prior version the current state of a data entry and returns the entry's new version.
+// The replaced state is archived in turn. A trashed entry is restored from the trash,
+// an entry removed for good is recreated under its old ID.
+func (s *Storage) RestoreVersion(ctx context.Context, login, id string, version int64) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var old models.Version
+	err = sq.Select("type", "data", "metadata").
+		From("history").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"data_id": id},
+			sq.Eq{"version": version},
+		}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&old.Type, &old.Data, &old.RawMetadata)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrVersionNotFound
+		}
+		return 0, err
+	}
+
+	revision, err := s.nextRevision(ctx, tx, login)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+	var state string
+	err = sq.Select("version", "status").
+		From("storage").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+		}).
+		Suffix(s.dialect.lockRows).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&current, &state)
+	switch {
+	case err == nil:
+		err = s.archiveVersion(ctx, tx, login, id, current)
+		if err != nil {
+			return 0, err
+		}
+
+		update := sq.Update("storage").
+			Set("type", old.Type).
+			Set("data", old.Data).
+			Set("metadata", old.RawMetadata).
+			Set("version", current+1).
+			Set("revision", revision)
+		if state == models.StatusDeleted {
+			update = update.
+				Set("status", models.StatusNew).
+				Set("deleted_at", nil).
+				Set("created_revision", revision)
+		}
+
+		_, err = update.
+			Where(sq.And{
+				sq.Eq{"login": login},
+				sq.Eq{"id": id},
+			}).
+			RunWith(tx).
+			PlaceholderFormat(sq.Dollar).
+			ExecContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if state == models.StatusDeleted {
+			err = s.dropTombstone(ctx, tx, login, id)
+			if err != nil {
+				return 0, err
+			}
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		err = sq.Select("MAX(version)").
+			From("history").
+			Where(sq.Eq{"data_id": id}).
+			RunWith(tx).
+			PlaceholderFormat(sq.Dollar).
+			QueryRowContext(ctx).
+			Scan(&current)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = sq.Insert("storage").
+			Columns("id", "login", "status", "type", "data", "uploaded_at", "version", "revision", "created_revision", "metadata").
+			Values(id, login, models.StatusNew, old.Type, old.Data, time.Now().UTC().Format(time.RFC3339), current+1, revision, revision, old.RawMetadata).
+			RunWith(tx).
+			PlaceholderFormat(sq.Dollar).
+			ExecContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		err = s.dropTombstone(ctx, tx, login, id)
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, err
+	}
+
+	err = s.pruneHistory(ctx, tx, login, id)
+	if err != nil {
+		return 0, err
+	}
+
+	return current + 1, tx.Commit()
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RevokeSession ends a live session of a user, its access and refresh tokens stop being accepted
+func (s *Storage) RevokeSession(ctx context.Context, login, id string) error {
+	res, err := sq.Update("sessions").
+		Set("revoked_at", time.Now().UTC().Format(time.RFC3339)).
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+			sq.Eq{"revoked_at": ""},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RevokeShare removes the access of a user to a data entry of its owner
+func (s *Storage) RevokeShare(ctx context.Context, owner, id, recipient string) error {
+	res, err := sq.Delete("shares").
+		Where(sq.And{
+			sq.Eq{"owner": owner},
+			sq.Eq{"data_id": id},
+			sq.Eq{"recipient": recipient},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return ErrShareNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// RotateSession replaces the refresh token hash of a live session and extends its expiry.
+// The swap only happens if the session still holds oldHash, so a refresh token can be used once.
+// The replaced hash is kept so that a later attempt to use it again can be told from a forgery.
+func (s *Storage) RotateSession(ctx context.Context, id, oldHash, newHash, expiresAt string) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	res, err := sq.Update("sessions").
+		Set("refresh_hash", newHash).
+		Set("last_used_at", now).
+		Set("expires_at", expiresAt).
+		Where(sq.And{
+			sq.Eq{"id": id},
+			sq.Eq{"refresh_hash": oldHash},
+			sq.Eq{"revoked_at": ""},
+		}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	rotated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rotated == 0 {
+		return ErrSessionNotFound
+	}
+
+	_, err = sq.Insert("rotated_refresh_tokens").
+		Columns("session_id", "refresh_hash", "rotated_at").
+		Values(id, oldHash, now).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
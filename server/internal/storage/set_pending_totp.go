@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// SetPendingTOTP stores a provisioned two-factor secret until the user confirms it with a code
+func (s *Storage) SetPendingTOTP(ctx context.Context, login string, secret []byte) error {
+	_, err := sq.Update("users").
+		Set("totp_pending", secret).
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// SetPublicKey stores the public key other users wrap record keys for when sharing with a user
+func (s *Storage) SetPublicKey(ctx context.Context, login string, key []byte) error {
+	res, err := sq.Update("users").
+		Set("public_key", key).
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ShareData grants a user access to a live data entry of its owner, replacing an earlier grant
+// to the same user
+func (s *Storage) ShareData(ctx context.Context, share models.Share) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var id string
+	err = sq.Select("id").
+		From("storage").
+		Where(sq.And{
+			sq.Eq{"login": share.Owner},
+			sq.Eq{"id": share.DataID},
+			sq.NotEq{"status": models.StatusDeleted},
+		}).
+		Suffix(s.dialect.lockRows).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoDataFound
+		}
+		return err
+	}
+
+	_, err = sq.Insert("shares").
+		Columns("data_id", "owner", "recipient", "permission", "wrapped_key", "shared_at").
+		Values(share.DataID, share.Owner, share.Recipient, share.Permission, share.WrappedKey, share.SharedAt).
+		Suffix("ON CONFLICT (data_id, recipient) DO UPDATE SET permission = EXCLUDED.permission, wrapped_key = EXCLUDED.wrapped_key, shared_at = EXCLUDED.shared_at").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
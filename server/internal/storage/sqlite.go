@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteOptions are the connection options of every SQLite database. Transactions take the write
+// lock when they begin, which serializes writers the way the row and advisory locks of Postgres do,
+// so a SQLite database serves a single server instance.
+const sqliteOptions = "_pragma=foreign_keys(1)&_pragma=busy_timeout(10000)&_txlock=immediate"
+
+// memoryDatabases numbers the in-memory databases, each storage opened on memory:// gets its own
+var memoryDatabases atomic.Int64
+
+// sqliteDialect runs the storage on an embedded SQLite database through the pure Go driver
+var sqliteDialect = dialect{
+	driver:          "sqlite",
+	migrations:      "migrations/sqlite",
+	nextRevision:    `UPDATE storage_revision_seq SET value = value + 1 RETURNING value`,
+	uniqueViolation: sqliteUniqueViolation,
+}
+
+// sqliteDSN returns the data source name of a SQLite database file, given as the path of a
+// sqlite:// URI with optional driver parameters
+func sqliteDSN(path string) string {
+	path, params, _ := strings.Cut(path, "?")
+
+	dsn := "file:" + path + "?" + sqliteOptions + "&_pragma=journal_mode(WAL)"
+	if params != "" {
+		dsn += "&" + params
+	}
+	return dsn
+}
+
+// memoryDSN returns the data source name of a new in-memory SQLite database. The memdb VFS shares
+// it between the connections of the pool; it is gone once they are all closed.
+func memoryDSN() string {
+	return fmt.Sprintf("file:/data-vault-%d?vfs=memdb&%s", memoryDatabases.Add(1), sqliteOptions)
+}
+
+// sqliteUniqueViolation reports whether err is a SQLite unique or primary key constraint failure
+func sqliteUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY
+}
@@ -8,44 +8,59 @@ import (
 
 // Storage handles database operations and configuration
 type Storage struct {
-	cfg *config.Config
-	DB  *sql.DB
+	cfg     *config.Config
+	DB      *sql.DB
+	dialect dialect
 }
 
-// Database table creation queries
-var (
-	UsersQuery   = `CREATE TABLE IF NOT EXISTS users (login text PRIMARY KEY, password text);`
-	StorageQuery = `CREATE TABLE IF NOT EXISTS storage (id SERIAL PRIMARY KEY, user text, status text, type text, data bytea, uploaded_at text);`
-)
+// EncryptedColumn names a column holding values encrypted with the server keyring and the
+// primary key columns of its table
+type EncryptedColumn struct {
+	Table  string
+	Key    []string
+	Column string
+}
+
+// EncryptedColumns lists every column the server encrypts when KEY_PROVIDER is configured
+var EncryptedColumns = []EncryptedColumn{
+	{Table: "storage", Key: []string{"id"}, Column: "data"},
+	{Table: "storage", Key: []string{"id"}, Column: "metadata"},
+	{Table: "history", Key: []string{"data_id", "version"}, Column: "data"},
+	{Table: "history", Key: []string{"data_id", "version"}, Column: "metadata"},
+	{Table: "file_chunks", Key: []string{"data_id", "seq"}, Column: "data"},
+	{Table: "users", Key: []string{"login"}, Column: "totp_secret"},
+	{Table: "users", Key: []string{"login"}, Column: "totp_pending"},
+	{Table: "server_keys", Key: []string{"name"}, Column: "sealed"},
+}
 
-// New creates and initializes a new storage instance with database connection
+// New creates and initializes a new storage instance with database connection. The scheme of
+// DATABASE_URI selects the database: postgres:// (the default), sqlite:// for an embedded
+// database file or memory:// for an in-memory database that is lost on exit.
 func New(ctx context.Context, cfg *config.Config) (*Storage, error) {
 	if cfg.DatabaseURI == "" {
 		return nil, ErrBadConn
 	}
 
-	db, err := sql.Open("pgx", cfg.DatabaseURI)
+	dialect, dsn, err := openDialect(cfg.DatabaseURI)
 	if err != nil {
-		return nil, ErrBadConn
+		return nil, err
 	}
 
-	err = db.Ping()
+	db, err := sql.Open(dialect.driver, dsn)
 	if err != nil {
 		return nil, ErrBadConn
 	}
 
-	tables := []string{UsersQuery, StorageQuery}
-
-	for _, q := range tables {
-		_, err = db.ExecContext(ctx, q)
-		if err != nil {
-			return nil, err
-		}
+	err = db.PingContext(ctx)
+	if err != nil {
+		db.Close()
+		return nil, ErrBadConn
 	}
 
 	storage := Storage{
-		cfg: cfg,
-		DB:  db,
+		cfg:     cfg,
+		DB:      db,
+		dialect: dialect,
 	}
 
 	return &storage, nil
@@ -0,0 +1,363 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStorage opens a migrated storage on a DATABASE_URI
+func newTestStorage(t *testing.T, uri string) *Storage {
+	t.Helper()
+
+	store := openTestStorage(t, uri)
+	require.NoError(t, store.Migrate(context.Background()))
+	return store
+}
+
+// postEntry stores a data entry and returns it as the storage lists it
+func postEntry(t *testing.T, store *Storage, login, payload string) models.Data {
+	t.Helper()
+	ctx := context.Background()
+
+	require.NoError(t, store.PostData(ctx, login, "text", []byte(payload), []byte(`{"k":"v"}`)))
+
+	data, err := store.GetData(ctx, login, models.DataQuery{}, models.PageCursor{}, 1)
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+	require.Equal(t, payload, string(data[0].Data))
+
+	return data[0]
+}
+
+// TestStorage_Contract checks the behavior the service relies on against every embedded
+// database, so that the dialects can't drift apart
+func TestStorage_Contract(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store *Storage)
+	}{
+		{
+			name: "users",
+			run: func(t *testing.T, store *Storage) {
+				user := models.User{Login: "alice", Password: "hash", Salt: []byte("salt")}
+				require.NoError(t, store.Register(ctx, user))
+				assert.ErrorIs(t, store.Register(ctx, user), ErrDuplicateLogin)
+
+				got, err := store.GetUser(ctx, "alice")
+				require.NoError(t, err)
+				assert.Equal(t, "hash", got.Password)
+				assert.Equal(t, []byte("salt"), got.Salt)
+				assert.False(t, got.RawPassword)
+
+				_, err = store.GetUser(ctx, "nobody")
+				assert.ErrorIs(t, err, ErrWrongPassword)
+
+				require.NoError(t, store.UpdatePassword(ctx, "alice", "new-hash"))
+				got, err = store.GetUser(ctx, "alice")
+				require.NoError(t, err)
+				assert.Equal(t, "new-hash", got.Password)
+			},
+		},
+		{
+			name: "data",
+			run: func(t *testing.T, store *Storage) {
+				first := postEntry(t, store, "alice", "first")
+				second := postEntry(t, store, "alice", "second")
+				postEntry(t, store, "bob", "theirs")
+
+				assert.Equal(t, "alice", first.User)
+				assert.Equal(t, int64(1), first.Version)
+				assert.Greater(t, second.Revision, first.Revision)
+				assert.JSONEq(t, `{"k":"v"}`, string(first.RawMetadata))
+
+				data, err := store.GetData(ctx, "alice", models.DataQuery{}, models.PageCursor{}, 10)
+				require.NoError(t, err)
+				require.Len(t, data, 2)
+				assert.Equal(t, second.ID, data[0].ID, "Entries should be listed newest first")
+
+				data, err = store.GetData(ctx, "alice", models.DataQuery{}, models.PageCursor{UploadedAt: second.UploadedAt, ID: second.ID}, 10)
+				require.NoError(t, err)
+				require.Len(t, data, 1)
+				assert.Equal(t, first.ID, data[0].ID, "A cursor should continue after its entry")
+
+				_, err = store.GetDataByID(ctx, "bob", first.ID)
+				assert.ErrorIs(t, err, ErrNoDataFound)
+
+				version, err := store.UpdateData(ctx, "alice", first.ID, "text", []byte("first edited"), 1)
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), version)
+
+				_, err = store.UpdateData(ctx, "alice", first.ID, "text", []byte("stale"), 1)
+				assert.ErrorIs(t, err, ErrVersionConflict)
+
+				_, err = store.UpdateData(ctx, "bob", first.ID, "text", []byte("foreign"), 2)
+				assert.ErrorIs(t, err, ErrNoDataFound)
+
+				got, err := store.GetDataByID(ctx, "alice", first.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "first edited", string(got.Data))
+				assert.JSONEq(t, `{"k":"v"}`, string(got.RawMetadata))
+			},
+		},
+		{
+			name: "changes",
+			run: func(t *testing.T, store *Storage) {
+				first := postEntry(t, store, "alice", "first")
+				second := postEntry(t, store, "alice", "second")
+
+				changes, err := store.GetChanges(ctx, "alice", 0)
+				require.NoError(t, err)
+				assert.Len(t, changes.Created, 2)
+				assert.Equal(t, second.Revision, changes.Cursor)
+				cursor := changes.Cursor
+
+				_, err = store.UpdateData(ctx, "alice", first.ID, "text", []byte("first edited"), 1)
+				require.NoError(t, err)
+				assert.ErrorIs(t, store.DeleteData(ctx, "alice", second.ID, 7), ErrVersionConflict)
+				require.NoError(t, store.DeleteData(ctx, "alice", second.ID, 1))
+
+				changes, err = store.GetChanges(ctx, "alice", cursor)
+				require.NoError(t, err)
+				assert.Empty(t, changes.Created)
+				require.Len(t, changes.Updated, 1)
+				assert.Equal(t, first.ID, changes.Updated[0].ID)
+				require.Len(t, changes.Deleted, 1)
+				assert.Equal(t, second.ID, changes.Deleted[0].ID)
+				assert.Equal(t, "alice", changes.Deleted[0].User)
+				assert.Greater(t, changes.Cursor, cursor)
+
+				changes, err = store.GetChanges(ctx, "bob", 0)
+				require.NoError(t, err)
+				assert.Empty(t, changes.Created)
+				assert.Empty(t, changes.Deleted)
+			},
+		},
+		{
+			name: "trash",
+			run: func(t *testing.T, store *Storage) {
+				entry := postEntry(t, store, "alice", "secret")
+				require.NoError(t, store.DeleteData(ctx, "alice", entry.ID, 0))
+
+				_, err := store.GetDataByID(ctx, "alice", entry.ID)
+				assert.ErrorIs(t, err, ErrNoDataFound)
+
+				trash, err := store.ListTrash(ctx, "alice")
+				require.NoError(t, err)
+				require.Len(t, trash, 1)
+				assert.NotEmpty(t, trash[0].DeletedAt)
+
+				version, err := store.RestoreFromTrash(ctx, "alice", entry.ID)
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), version)
+
+				changes, err := store.GetChanges(ctx, "alice", entry.Revision)
+				require.NoError(t, err)
+				assert.Len(t, changes.Created, 1)
+				assert.Empty(t, changes.Deleted, "A restore should drop the tombstone")
+
+				_, err = store.RestoreFromTrash(ctx, "alice", entry.ID)
+				assert.ErrorIs(t, err, ErrNoDataFound)
+
+				require.NoError(t, store.DeleteData(ctx, "alice", entry.ID, 0))
+				purged, err := store.PurgeTrash(ctx, "2000-01-01T00:00:00Z")
+				require.NoError(t, err)
+				assert.Zero(t, purged)
+
+				purged, err = store.EmptyTrash(ctx, "alice")
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), purged)
+
+				trash, err = store.ListTrash(ctx, "alice")
+				require.NoError(t, err)
+				assert.Empty(t, trash)
+			},
+		},
+		{
+			name: "history",
+			run: func(t *testing.T, store *Storage) {
+				entry := postEntry(t, store, "alice", "v1")
+				_, err := store.UpdateData(ctx, "alice", entry.ID, "text", []byte("v2"), 1)
+				require.NoError(t, err)
+
+				versions, err := store.ListVersions(ctx, "alice", entry.ID)
+				require.NoError(t, err)
+				require.Len(t, versions, 1)
+				assert.Equal(t, "v1", string(versions[0].Data))
+				assert.Equal(t, int64(1), versions[0].Version)
+
+				versions, err = store.ListVersions(ctx, "bob", entry.ID)
+				require.NoError(t, err)
+				assert.Empty(t, versions)
+
+				version, err := store.RestoreVersion(ctx, "alice", entry.ID, 1)
+				require.NoError(t, err)
+				assert.Equal(t, int64(3), version)
+
+				got, err := store.GetDataByID(ctx, "alice", entry.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "v1", string(got.Data))
+
+				_, err = store.RestoreVersion(ctx, "alice", entry.ID, 9)
+				assert.ErrorIs(t, err, ErrVersionNotFound)
+			},
+		},
+		{
+			name: "sessions",
+			run: func(t *testing.T, store *Storage) {
+				now := time.Now().UTC()
+				session := models.Session{
+					ID:          "session",
+					User:        "alice",
+					Device:      "laptop",
+					RefreshHash: "first",
+					CreatedAt:   now.Format(time.RFC3339),
+					LastUsedAt:  now.Format(time.RFC3339),
+					ExpiresAt:   now.Add(time.Hour).Format(time.RFC3339),
+				}
+				require.NoError(t, store.CreateSession(ctx, session))
+
+				got, err := store.GetSession(ctx, "session")
+				require.NoError(t, err)
+				assert.Equal(t, "alice", got.User)
+				assert.Equal(t, "first", got.RefreshHash)
+
+				require.NoError(t, store.RotateSession(ctx, "session", "first", "second", session.ExpiresAt))
+				assert.ErrorIs(t, store.RotateSession(ctx, "session", "first", "third", session.ExpiresAt), ErrSessionNotFound)
+
+				rotated, err := store.RefreshTokenRotated(ctx, "session", "first")
+				require.NoError(t, err)
+				assert.True(t, rotated)
+				rotated, err = store.RefreshTokenRotated(ctx, "session", "second")
+				require.NoError(t, err)
+				assert.False(t, rotated)
+
+				sessions, err := store.ListSessions(ctx, "alice")
+				require.NoError(t, err)
+				require.Len(t, sessions, 1)
+
+				assert.ErrorIs(t, store.RevokeSession(ctx, "bob", "session"), ErrSessionNotFound)
+				require.NoError(t, store.RevokeSession(ctx, "alice", "session"))
+
+				sessions, err = store.ListSessions(ctx, "alice")
+				require.NoError(t, err)
+				assert.Empty(t, sessions)
+
+				expired := session
+				expired.ID = "expired"
+				expired.ExpiresAt = now.Add(-2 * time.Hour).Format(time.RFC3339)
+				require.NoError(t, store.CreateSession(ctx, expired))
+				live := session
+				live.ID = "live"
+				require.NoError(t, store.CreateSession(ctx, live))
+
+				purged, err := store.PurgeSessions(ctx, now.Add(-time.Hour).Format(time.RFC3339))
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), purged, "Only the session that expired before the cutoff should go")
+				_, err = store.GetSession(ctx, "expired")
+				assert.ErrorIs(t, err, ErrSessionNotFound)
+
+				purged, err = store.PurgeSessions(ctx, now.Add(time.Minute).Format(time.RFC3339))
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), purged, "The revoked session should go once revoked before the cutoff")
+				rotated, err = store.RefreshTokenRotated(ctx, "session", "first")
+				require.NoError(t, err)
+				assert.False(t, rotated, "The rotated hashes should go with the session")
+
+				_, err = store.GetSession(ctx, "live")
+				assert.NoError(t, err, "Live sessions should be kept")
+			},
+		},
+		{
+			name: "audit",
+			run: func(t *testing.T, store *Storage) {
+				for _, action := range []string{"Login", "PostData", "GetData"} {
+					err := store.AppendAudit(ctx, "alice", func(last models.AuditEntry) models.AuditEntry {
+						return models.AuditEntry{
+							User:     "alice",
+							Seq:      last.Seq + 1,
+							Action:   action,
+							PrevHash: last.Hash,
+							Hash:     []byte(action),
+						}
+					})
+					require.NoError(t, err)
+				}
+
+				entries, err := store.ListAudit(ctx, "alice", 0, 10)
+				require.NoError(t, err)
+				require.Len(t, entries, 3)
+				assert.Equal(t, int64(3), entries[0].Seq, "Entries should be listed newest first")
+				assert.Equal(t, []byte("PostData"), entries[0].PrevHash)
+
+				entries, err = store.ListAudit(ctx, "alice", 3, 10)
+				require.NoError(t, err)
+				assert.Len(t, entries, 2)
+
+				entries, err = store.ListAudit(ctx, "bob", 0, 10)
+				require.NoError(t, err)
+				assert.Empty(t, entries)
+			},
+		},
+		{
+			name: "uploads",
+			run: func(t *testing.T, store *Storage) {
+				file, err := store.CreateFile(ctx, "alice", []byte("meta"), 8, 4)
+				require.NoError(t, err)
+				require.NoError(t, store.AppendChunk(ctx, file.ID, 0, []byte("half")))
+
+				done, err := store.CreateFile(ctx, "alice", []byte("meta"), 4, 4)
+				require.NoError(t, err)
+				require.NoError(t, store.AppendChunk(ctx, done.ID, 0, []byte("full")))
+				require.NoError(t, store.CompleteFile(ctx, "alice", done.ID))
+
+				purged, err := store.PurgeUploads(ctx, "2000-01-01T00:00:00Z")
+				require.NoError(t, err)
+				assert.Zero(t, purged)
+
+				purged, err = store.PurgeUploads(ctx, time.Now().UTC().Add(time.Hour).Format(time.RFC3339))
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), purged)
+
+				_, err = store.GetFile(ctx, "alice", file.ID)
+				assert.ErrorIs(t, err, ErrNoDataFound)
+				_, err = store.GetFile(ctx, "alice", done.ID)
+				assert.NoError(t, err)
+
+				var chunks int
+				require.NoError(t, store.DB.QueryRowContext(ctx, "SELECT count(*) FROM file_chunks").Scan(&chunks))
+				assert.Equal(t, 1, chunks, "The chunks should go with the upload")
+			},
+		},
+		{
+			name: "server keys",
+			run: func(t *testing.T, store *Storage) {
+				_, err := store.GetServerKey(ctx, "audit")
+				assert.ErrorIs(t, err, ErrServerKeyNotFound)
+
+				require.NoError(t, store.CreateServerKey(ctx, "audit", []byte("first")))
+				require.NoError(t, store.CreateServerKey(ctx, "audit", []byte("second")))
+
+				sealed, err := store.GetServerKey(ctx, "audit")
+				require.NoError(t, err)
+				assert.Equal(t, []byte("first"), sealed, "The first key created should win")
+			},
+		},
+	}
+
+	for name, uri := range testDatabases {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					tt.run(t, newTestStorage(t, uri(t)))
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// GetChanges returns entries created, updated or deleted after the given revision cursor,
+// together with the new cursor the client should send next time. Entries and tombstones are read
+// from one snapshot, so that the cursor can't pass a change committed between the two reads.
+func (s *Storage) GetChanges(ctx context.Context, login string, cursor int64) (models.SyncResult, error) {
+	res := models.SyncResult{
+		Cursor:  cursor,
+		Created: make([]models.Data, 0),
+		Updated: make([]models.Data, 0),
+		Deleted: make([]models.Tombstone, 0),
+	}
+
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	rows, err := sq.Select("id", "login", "status", "type", "data", "uploaded_at", "version", "revision", "created_revision", "metadata").
+		From("storage").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Gt{"revision": cursor},
+			sq.NotEq{"status": []string{models.StatusUploading, models.StatusDeleted}},
+		}).
+		OrderBy("revision ASC").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var o models.Data
+		var createdRevision int64
+		err := rows.Scan(&o.ID, &o.User, &o.Status, &o.Type, &o.Data, &o.UploadedAt, &o.Version, &o.Revision, &createdRevision, &o.RawMetadata)
+		if err != nil {
+			return res, err
+		}
+
+		if createdRevision > cursor {
+			res.Created = append(res.Created, o)
+		} else {
+			res.Updated = append(res.Updated, o)
+		}
+		res.Cursor = max(res.Cursor, o.Revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return res, err
+	}
+
+	tombs, err := sq.Select("id", "login", "revision", "deleted_at").
+		From("tombstones").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Gt{"revision": cursor},
+		}).
+		OrderBy("revision ASC").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tombs.Close()
+
+	for tombs.Next() {
+		var t models.Tombstone
+		if err := tombs.Scan(&t.ID, &t.User, &t.Revision, &t.DeletedAt); err != nil {
+			return res, err
+		}
+		res.Deleted = append(res.Deleted, t)
+		res.Cursor = max(res.Cursor, t.Revision)
+	}
+
+	if err = tombs.Err(); err != nil {
+		return res, err
+	}
+
+	return res, tx.Commit()
+}
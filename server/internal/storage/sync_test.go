@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetChanges_CommitOrder holds a change open after it took its revision and checks that a later
+// change of the same user can't commit first. The change feed would otherwise hand out a cursor past
+// the open change and skip it once it commits. Set TEST_POSTGRES_URI to run it on Postgres too,
+// where writers don't serialize on their own.
+func TestGetChanges_CommitOrder(t *testing.T) {
+	ctx := context.Background()
+
+	databases := map[string]func(t *testing.T) string{}
+	for name, uri := range testDatabases {
+		databases[name] = uri
+	}
+	if uri := os.Getenv("TEST_POSTGRES_URI"); uri != "" {
+		databases["postgres"] = func(t *testing.T) string { return uri }
+	}
+
+	for name, uri := range databases {
+		t.Run(name, func(t *testing.T) {
+			store := newTestStorage(t, uri(t))
+			login := fmt.Sprintf("alice-%d", time.Now().UnixNano())
+
+			before := postEntry(t, store, login, "before")
+
+			tx, err := store.DB.BeginTx(ctx, nil)
+			require.NoError(t, err)
+			defer tx.Rollback()
+
+			first, err := store.nextRevision(ctx, tx, login)
+			require.NoError(t, err)
+
+			second := make(chan error, 1)
+			go func() {
+				second <- store.PostData(ctx, login, "text", []byte("second"), nil)
+			}()
+
+			select {
+			case err := <-second:
+				t.Fatalf("The later change should wait for the open one, it returned %v", err)
+			case <-time.After(200 * time.Millisecond):
+			}
+
+			_, err = sq.Insert("storage").
+				Columns("login", "status", "type", "data", "uploaded_at", "revision", "created_revision").
+				Values(login, models.StatusNew, "text", []byte("first"), time.Now().UTC().Format(time.RFC3339), first, first).
+				RunWith(tx).
+				PlaceholderFormat(sq.Dollar).
+				ExecContext(ctx)
+			require.NoError(t, err)
+			require.NoError(t, tx.Commit())
+
+			select {
+			case err := <-second:
+				require.NoError(t, err)
+			case <-time.After(10 * time.Second):
+				t.Fatal("The later change should commit once the open one did")
+			}
+
+			changes, err := store.GetChanges(ctx, login, before.Revision)
+			require.NoError(t, err)
+			require.Len(t, changes.Created, 2)
+			assert.Equal(t, "first", string(changes.Created[0].Data))
+			assert.Equal(t, first, changes.Created[0].Revision)
+			assert.Equal(t, "second", string(changes.Created[1].Data))
+			assert.Greater(t, changes.Created[1].Revision, first, "Revisions should follow the commit order")
+			assert.Equal(t, changes.Created[1].Revision, changes.Cursor)
+		})
+	}
+}
+
+func TestPostData_LocksChanges(t *testing.T) {
+	ctx := context.Background()
+	store, mock := newMockStorage(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(postgresDialect.lockChanges)).
+		WithArgs("changes:alice").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(postgresDialect.nextRevision)).
+		WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(7))
+	mock.ExpectExec("INSERT INTO storage").
+		WithArgs("alice", models.StatusNew, "text", []byte("payload"), sqlmock.AnyArg(), int64(7), int64(7), []byte("{}")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, store.PostData(ctx, "alice", "text", []byte("payload"), []byte("{}")))
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdateData replaces a data entry if it is still at the expected version and returns the new version.
+// The replaced state is kept in the entry's history. Trashed entries can't be updated.
+func (s *Storage) UpdateData(ctx context.Context, login, id, dataType string, data []byte, version int64) (int64, error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	revision, err := s.nextRevision(ctx, tx, login)
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.archiveVersion(ctx, tx, login, id, version)
+	if err != nil {
+		return 0, err
+	}
+
+	var newVersion int64
+
+	err = sq.Update("storage").
+		Set("type", dataType).
+		Set("data", data).
+		Set("version", sq.Expr("version + 1")).
+		Set("revision", revision).
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+			sq.Eq{"version": version},
+			sq.NotEq{"status": models.StatusDeleted},
+		}).
+		Suffix("RETURNING version").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&newVersion)
+	if err == nil {
+		err = s.pruneHistory(ctx, tx, login, id)
+		if err != nil {
+			return 0, err
+		}
+		return newVersion, tx.Commit()
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	var current int64
+	err = sq.Select("version").
+		From("storage").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+			sq.NotEq{"status": models.StatusDeleted},
+		}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoDataFound
+		}
+		return 0, err
+	}
+
+	return 0, ErrVersionConflict
+}
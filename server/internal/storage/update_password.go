@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UpdatePassword replaces the stored password hash for a user
+func (s *Storage) UpdatePassword(ctx context.Context, login, hash string) error {
+	_, err := sq.Update("users").
+		Set("password", hash).
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpgradePassword replaces the hash of an account's master password with the hash of the
+// derived authentication key and stores the key derivation salt it was derived with
+func (s *Storage) UpgradePassword(ctx context.Context, login, hash string, salt []byte) error {
+	_, err := sq.Update("users").
+		Set("password", hash).
+		Set("salt", salt).
+		Set("raw_password", false).
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+
+	return err
+}
+
+// SetSalt stores the key derivation salt issued to an account that was created without one
+func (s *Storage) SetSalt(ctx context.Context, login string, salt []byte) error {
+	_, err := sq.Update("users").
+		Set("salt", salt).
+		Where(sq.Eq{"login": login}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+
+	return err
+}
+
+// GetLegacyUsers returns users whose password is still stored with the old reversible encryption
+func (s *Storage) GetLegacyUsers(ctx context.Context) ([]models.User, error) {
+	users := make([]models.User, 0)
+
+	rows, err := sq.Select("login", "password", "salt").
+		From("users").
+		Where(sq.NotLike{"password": "$argon2id$%"}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.Login, &u.Password, &u.Salt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
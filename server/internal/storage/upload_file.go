@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"data-vault/server/internal/models"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// CreateFile creates a binary entry in the uploading state together with its chunk bookkeeping
+func (s *Storage) CreateFile(ctx context.Context, login string, meta []byte, size, chunkSize int64) (models.File, error) {
+	file := models.File{
+		User:      login,
+		Meta:      meta,
+		Size:      size,
+		ChunkSize: chunkSize,
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return file, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	err = sq.Insert("storage").
+		Columns("login", "status", "type", "data", "uploaded_at").
+		Values(login, models.StatusUploading, models.DataTypeBinary, meta, time.Now().UTC().Format(time.RFC3339)).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&id)
+	if err != nil {
+		return file, err
+	}
+
+	_, err = sq.Insert("files").
+		Columns("data_id", "size", "chunk_size").
+		Values(id, size, chunkSize).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return file, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return file, err
+	}
+
+	file.ID = strconv.FormatInt(id, 10)
+	return file, nil
+}
+
+// GetFile retrieves the upload state of a user's binary entry
+func (s *Storage) GetFile(ctx context.Context, login, id string) (models.File, error) {
+	var file models.File
+	var status string
+
+	err := sq.Select("s.id", "s.login", "s.data", "s.status", "f.size", "f.chunk_size", "f.chunks").
+		From("storage s").
+		Join("files f ON f.data_id = s.id").
+		Where(sq.And{
+			sq.Eq{"s.login": login},
+			sq.Eq{"s.id": id},
+			sq.NotEq{"s.status": models.StatusDeleted},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		QueryRowContext(ctx).
+		Scan(&file.ID, &file.User, &file.Meta, &status, &file.Size, &file.ChunkSize, &file.Chunks)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return file, ErrNoDataFound
+		}
+		return file, err
+	}
+
+	file.Complete = status != models.StatusUploading
+	return file, nil
+}
+
+// AppendChunk stores the next chunk of an upload. The chunk must directly follow
+// the ones already stored, otherwise ErrChunkOutOfOrder is returned.
+func (s *Storage) AppendChunk(ctx context.Context, id string, seq int64, data []byte) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := sq.Update("files").
+		Set("chunks", seq+1).
+		Where(sq.And{
+			sq.Eq{"data_id": id},
+			sq.Eq{"chunks": seq},
+		}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrChunkOutOfOrder
+	}
+
+	_, err = sq.Insert("file_chunks").
+		Columns("data_id", "seq", "data").
+		Values(id, seq, data).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CompleteFile marks an upload as finished so that the entry shows up in data listings and the change feed
+func (s *Storage) CompleteFile(ctx context.Context, login, id string) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	revision, err := s.nextRevision(ctx, tx, login)
+	if err != nil {
+		return err
+	}
+
+	_, err = sq.Update("storage").
+		Set("status", models.StatusNew).
+		Set("revision", revision).
+		Set("created_revision", revision).
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"id": id},
+			sq.Eq{"status": models.StatusUploading},
+		}).
+		RunWith(tx).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UseRecoveryCode consumes one of the user's recovery codes by its hash, each code works once
+func (s *Storage) UseRecoveryCode(ctx context.Context, login, codeHash string) error {
+	res, err := sq.Delete("recovery_codes").
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Eq{"code_hash": codeHash},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	used, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return ErrCodeUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// UseTOTPStep records the time step of an accepted two-factor code. A code of the same or an
+// earlier step was already used or superseded, so it is rejected to prevent replays.
+func (s *Storage) UseTOTPStep(ctx context.Context, login string, step int64) error {
+	res, err := sq.Update("users").
+		Set("totp_last_step", step).
+		Where(sq.And{
+			sq.Eq{"login": login},
+			sq.Lt{"totp_last_step": step},
+		}).
+		RunWith(s.DB).
+		PlaceholderFormat(sq.Dollar).
+		ExecContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	used, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return ErrCodeUsed
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package transport
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"path"
+	"time"
+
+	"data-vault/server/internal/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// SubjectResolver attributes calls to the users they are made by or for, including the login calls
+type SubjectResolver interface {
+	AuditSubject(ctx context.Context, req any) string
+}
+
+// Auditor attributes calls to users and appends them to the users' audit logs
+type Auditor interface {
+	SubjectResolver
+	KnownUser(ctx context.Context, login string) (bool, error)
+	RecordAudit(ctx context.Context, entry models.AuditEntry) error
+}
+
+// AuditInterceptor records every call that can be attributed to a user in the user's audit log,
+// including failed login attempts. Calls made without an access token name their user
+// themselves, so they are only recorded for registered users; otherwise anyone could grow the
+// log with made-up logins. A failure to record is logged and doesn't fail the call.
+func AuditInterceptor(auditor Auditor, log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+
+		user := auditor.AuditSubject(ctx, req)
+		if user == "" {
+			return resp, err
+		}
+
+		if publicMethods[info.FullMethod] {
+			known, kerr := auditor.KnownUser(context.WithoutCancel(ctx), user)
+			if kerr != nil {
+				log.Error("failed to look up audited user", "action", path.Base(info.FullMethod), "error", kerr)
+				return resp, err
+			}
+			if !known {
+				return resp, err
+			}
+		}
+
+		record(ctx, auditor, log, auditEntry(ctx, user, info.FullMethod, req, err))
+		return resp, err
+	}
+}
+
+// StreamAuditInterceptor records streaming calls in the audit log of the authenticated user
+func StreamAuditInterceptor(auditor Auditor, log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		stream := &auditedStream{ServerStream: ss}
+		err := handler(srv, stream)
+
+		ctx := ss.Context()
+		if user := auditor.AuditSubject(ctx, nil); user != "" {
+			record(ctx, auditor, log, auditEntry(ctx, user, info.FullMethod, stream.first, err))
+		}
+
+		return err
+	}
+}
+
+// auditedStream keeps the first message received on a stream, which names the record of the call
+type auditedStream struct {
+	grpc.ServerStream
+	first any
+}
+
+// RecvMsg receives a message and keeps it if it is the first one
+func (s *auditedStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.first == nil {
+		s.first = m
+	}
+	return err
+}
+
+// auditEntry describes a completed call: the operation, the record it named, the organization
+// vault it was made on, where it came from and its result
+func auditEntry(ctx context.Context, user, method string, req any, err error) models.AuditEntry {
+	entry := models.AuditEntry{
+		User:   user,
+		At:     time.Now().UTC().Format(time.RFC3339Nano),
+		Action: path.Base(method),
+		Result: status.Code(err).String(),
+	}
+
+	if r, ok := req.(interface{ GetId() string }); ok {
+		entry.RecordID = r.GetId()
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if orgs := md.Get(orgHeader); len(orgs) > 0 {
+			entry.Org = orgs[0]
+		}
+		if agents := md.Get("user-agent"); len(agents) > 0 {
+			entry.UserAgent = agents[0]
+		}
+	}
+
+	entry.ClientIP = peerAddress(ctx)
+
+	return entry
+}
+
+// peerAddress returns the IP address of the client of a call, empty if it is unknown
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// record appends an entry to the audit log. The call's context may already be cancelled, so the
+// entry is written detached from it.
+func record(ctx context.Context, auditor Auditor, log *slog.Logger, entry models.AuditEntry) {
+	if err := auditor.RecordAudit(context.WithoutCancel(ctx), entry); err != nil {
+		log.Error("failed to record audit entry",
+			"user", entry.User,
+			"action", entry.Action,
+			"error", err,
+		)
+	}
+}
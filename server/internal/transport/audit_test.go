@@ -0,0 +1,122 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"data-vault/server/internal/models"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuditor attributes calls to the login they name and keeps the recorded entries
+type fakeAuditor struct {
+	users   map[string]bool
+	lookups int
+	entries []models.AuditEntry
+}
+
+func (a *fakeAuditor) AuditSubject(ctx context.Context, req any) string {
+	if r, ok := req.(*proto.LoginRequest); ok {
+		return r.GetUser().GetLogin()
+	}
+	if login, ok := ctx.Value(testUserKey{}).(string); ok {
+		return login
+	}
+	return ""
+}
+
+func (a *fakeAuditor) KnownUser(ctx context.Context, login string) (bool, error) {
+	a.lookups++
+	return a.users[login], nil
+}
+
+func (a *fakeAuditor) RecordAudit(ctx context.Context, entry models.AuditEntry) error {
+	a.entries = append(a.entries, entry)
+	return nil
+}
+
+// testUserKey marks the authenticated caller of a test call
+type testUserKey struct{}
+
+func TestAuditInterceptor(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	failed := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid credentials")
+	}
+	succeeded := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &proto.GetDataResponse{}, nil
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		method        string
+		req           any
+		handler       grpc.UnaryHandler
+		expectUser    string
+		expectResult  string
+		expectLookups int
+	}{
+		{
+			name:          "failed login of a registered user",
+			ctx:           context.Background(),
+			method:        proto.VaultService_Login_FullMethodName,
+			req:           &proto.LoginRequest{User: &proto.User{Login: "alice"}},
+			handler:       failed,
+			expectUser:    "alice",
+			expectResult:  codes.Unauthenticated.String(),
+			expectLookups: 1,
+		},
+		{
+			name:          "failed login of an unknown login",
+			ctx:           context.Background(),
+			method:        proto.VaultService_Login_FullMethodName,
+			req:           &proto.LoginRequest{User: &proto.User{Login: "made-up-login"}},
+			handler:       failed,
+			expectLookups: 1,
+		},
+		{
+			name:          "authenticated call",
+			ctx:           context.WithValue(context.Background(), testUserKey{}, "alice"),
+			method:        proto.VaultService_GetData_FullMethodName,
+			req:           &proto.GetDataRequest{},
+			handler:       succeeded,
+			expectUser:    "alice",
+			expectResult:  codes.OK.String(),
+			expectLookups: 0,
+		},
+		{
+			name:    "anonymous call",
+			ctx:     context.Background(),
+			method:  proto.VaultService_Register_FullMethodName,
+			req:     &proto.RegisterRequest{},
+			handler: succeeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditor := &fakeAuditor{users: map[string]bool{"alice": true}}
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+
+			_, _ = AuditInterceptor(auditor, log)(tt.ctx, tt.req, info, tt.handler)
+
+			assert.Equal(t, tt.expectLookups, auditor.lookups)
+			if tt.expectUser == "" {
+				assert.Empty(t, auditor.entries)
+				return
+			}
+			if assert.Len(t, auditor.entries, 1) {
+				assert.Equal(t, tt.expectUser, auditor.entries[0].User)
+				assert.Equal(t, tt.expectResult, auditor.entries[0].Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package transport
+
+import (
+	"context"
+	"time"
+
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Health checking intervals
+const (
+	// healthCheckInterval is how often the readiness of the server is checked against the database
+	healthCheckInterval = 10 * time.Second
+	// healthCheckTimeout is how long the database may take to answer a readiness check
+	healthCheckTimeout = 2 * time.Second
+)
+
+// healthMethods are the health checks, served without an access token even before the server is ready
+var healthMethods = map[string]bool{
+	proto.VaultService_PingDB_FullMethodName: true,
+	healthpb.Health_Check_FullMethodName:     true,
+	healthpb.Health_Watch_FullMethodName:     true,
+}
+
+// DatabaseChecker reports whether the database answers
+type DatabaseChecker interface {
+	CheckDatabase(ctx context.Context) error
+}
+
+// SetReady sets the readiness of the server. The health service reports the liveness of the
+// process for the empty service name and the readiness for vault.VaultService, which serves
+// calls only while it is ready.
+func (g *Transport) SetReady(ready bool) {
+	g.ready.Store(ready)
+
+	state := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		state = healthpb.HealthCheckResponse_SERVING
+	}
+	g.health.SetServingStatus(proto.VaultService_ServiceDesc.ServiceName, state)
+}
+
+// Shutdown reports the server as not serving for good, so that load balancers stop sending calls
+// before it stops, and ends the health Watch streams
+func (g *Transport) Shutdown() {
+	g.ready.Store(false)
+	g.health.Shutdown()
+	g.stopWatches()
+}
+
+// healthServer serves the health checks and ends the Watch streams when the server shuts down.
+// health.Server only reports NOT_SERVING to its watchers, whose streams would keep a graceful
+// stop waiting until the shutdown timeout.
+type healthServer struct {
+	*health.Server
+	stopped context.Context
+}
+
+// Watch streams the serving status of a service until the client leaves or the server shuts down
+func (h healthServer) Watch(in *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	stop := context.AfterFunc(h.stopped, cancel)
+	defer stop()
+
+	err := h.Server.Watch(in, watchStream{Health_WatchServer: stream, ctx: ctx})
+	if h.stopped.Err() != nil {
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	return err
+}
+
+// watchStream is a Watch stream whose context also ends when the server shuts down
+type watchStream struct {
+	healthpb.Health_WatchServer
+	ctx context.Context
+}
+
+// Context returns the context of the stream
+func (s watchStream) Context() context.Context {
+	return s.ctx
+}
+
+// GracefulStop shuts the server down in order: it reports the server as not serving, stops
+// accepting connections and waits for the calls in flight to finish. Calls still running after
+// the timeout are cancelled.
+func (g *Transport) GracefulStop(server *grpc.Server, timeout time.Duration) {
+	g.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		g.log.Warn("Calls still running after the shutdown timeout, cancelling them", "timeout", timeout)
+		server.Stop()
+		<-done
+	}
+}
+
+// RunHealthChecks periodically checks the database and updates the readiness of the server until
+// the context is cancelled
+func (g *Transport) RunHealthChecks(ctx context.Context, db DatabaseChecker) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := db.CheckDatabase(checkCtx)
+		cancel()
+
+		if ready := err == nil; ready != g.ready.Load() && ctx.Err() == nil {
+			if ready {
+				g.log.Info("Database is reachable again, server is ready")
+			} else {
+				g.log.Error("Database is unreachable, server is not ready", "error", err)
+			}
+			g.SetReady(ready)
+		}
+	}
+}
+
+// ReadinessInterceptor rejects calls other than health checks while the server isn't ready
+func ReadinessInterceptor(g *Transport) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !healthMethods[info.FullMethod] && !g.ready.Load() {
+			return nil, status.Error(codes.Unavailable, "server is not ready")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamReadinessInterceptor rejects streaming calls other than health checks while the server isn't ready
+func StreamReadinessInterceptor(g *Transport) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !healthMethods[info.FullMethod] && !g.ready.Load() {
+			return status.Error(codes.Unavailable, "server is not ready")
+		}
+		return handler(srv, ss)
+	}
+}
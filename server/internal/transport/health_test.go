@@ -0,0 +1,160 @@
+package transport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/handler"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// writeTestCert writes a self-signed certificate for 127.0.0.1 and returns its files and a pool
+// trusting it
+func writeTestCert(t *testing.T) (string, string, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "data-vault test"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
+
+// slowDatabase is a service whose database checks block until released. The methods the tests
+// don't call are left to the nil embedded service.
+type slowDatabase struct {
+	handler.Service
+	started chan struct{}
+	release chan struct{}
+	// closed is set once the database is closed, closedInCall if a check was still running then
+	closed       atomic.Bool
+	closedInCall atomic.Bool
+}
+
+// PingDB blocks until the test releases it and records whether the database was closed meanwhile
+func (s *slowDatabase) PingDB(ctx context.Context) (time.Duration, error) {
+	close(s.started)
+	<-s.release
+
+	if s.closed.Load() {
+		s.closedInCall.Store(true)
+	}
+	return time.Millisecond, nil
+}
+
+func TestGracefulStop(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	certFile, keyFile, pool := writeTestCert(t)
+	cfg := config.Config{TLSCertFile: certFile, TLSKeyFile: keyFile, JWTSecret: "test-jwt-secret-key-for-testing-0123456789"}
+
+	db := &slowDatabase{started: make(chan struct{}), release: make(chan struct{})}
+	g := New(handler.New(ctx, db, cfg, log), NewRateLimiter(NewMemoryLimitStore(), cfg, log), cfg, log)
+
+	server, err := NewRouter(g)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(lis)
+	g.SetReady(true)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(pool, "")))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	watch, err := healthpb.NewHealthClient(conn).Watch(watchCtx, &healthpb.HealthCheckRequest{Service: proto.VaultService_ServiceDesc.ServiceName})
+	require.NoError(t, err)
+	state, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, state.Status)
+
+	callErr := make(chan error, 1)
+	go func() {
+		_, err := proto.NewVaultServiceClient(conn).PingDB(ctx, &proto.PingDBRequest{})
+		callErr <- err
+	}()
+	<-db.started
+
+	// Shut down the way main does: the database closes once the server has stopped
+	stopped := make(chan struct{})
+	go func() {
+		g.GracefulStop(server, time.Minute)
+		db.closed.Store(true)
+		close(stopped)
+	}()
+
+	for {
+		_, err = watch.Recv()
+		if err != nil {
+			break
+		}
+	}
+	assert.Equal(t, codes.Unavailable, status.Code(err), "The shutdown should end the health watchers")
+
+	select {
+	case <-stopped:
+		t.Fatal("The stop should wait for the call in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(db.release)
+	require.NoError(t, <-callErr, "The call in flight should finish")
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("The stop should end once the call in flight finished, not at the timeout")
+	}
+	assert.False(t, db.closedInCall.Load(), "The database should close only after the call returned")
+}
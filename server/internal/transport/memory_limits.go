@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limitCounter holds the counters of a rate limit key
+type limitCounter struct {
+	hits        int64
+	windowEnd   time.Time
+	failures    int64
+	lastFailure time.Time
+}
+
+// MemoryLimitStore keeps the rate limiter's counters in memory, for a single server instance
+type MemoryLimitStore struct {
+	mu       sync.Mutex
+	counters map[string]*limitCounter
+}
+
+// NewMemoryLimitStore creates an empty in-memory rate limit store
+func NewMemoryLimitStore() *MemoryLimitStore {
+	return &MemoryLimitStore{
+		counters: make(map[string]*limitCounter),
+	}
+}
+
+// counter returns the counters of a key, creating them if needed. The caller holds the lock.
+func (m *MemoryLimitStore) counter(key string) *limitCounter {
+	c, ok := m.counters[key]
+	if !ok {
+		c = &limitCounter{}
+		m.counters[key] = c
+	}
+	return c
+}
+
+// HitRateLimit counts a call under a key in the fixed window running at now and returns the
+// calls counted in the window and the window's end
+func (m *MemoryLimitStore) HitRateLimit(ctx context.Context, key string, now time.Time, window time.Duration) (int64, time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := m.counter(key)
+	if !now.Before(c.windowEnd) {
+		c.hits = 0
+		c.windowEnd = now.Add(window)
+	}
+	c.hits++
+
+	return c.hits, c.windowEnd, nil
+}
+
+// FailRateLimit counts a failed attempt under a key and returns the number of consecutive failures
+func (m *MemoryLimitStore) FailRateLimit(ctx context.Context, key string, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := m.counter(key)
+	c.failures++
+	c.lastFailure = now
+
+	return c.failures, nil
+}
+
+// RateLimitFailures returns the number of consecutive failures under a key and the time of the last one
+func (m *MemoryLimitStore) RateLimitFailures(ctx context.Context, key string) (int64, time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.counters[key]
+	if !ok {
+		return 0, time.Time{}, nil
+	}
+
+	return c.failures, c.lastFailure, nil
+}
+
+// ResetRateLimit clears the failures under a key
+func (m *MemoryLimitStore) ResetRateLimit(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if c, ok := m.counters[key]; ok {
+		c.failures = 0
+		c.lastFailure = time.Time{}
+	}
+
+	return nil
+}
+
+// PurgeRateLimits removes the keys whose window ended and whose last failure happened before
+// the given time and returns how many were removed
+func (m *MemoryLimitStore) PurgeRateLimits(ctx context.Context, before time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var purged int64
+	for key, c := range m.counters {
+		if c.windowEnd.Before(before) && c.lastFailure.Before(before) {
+			delete(m.counters, key)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
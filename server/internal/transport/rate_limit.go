@@ -0,0 +1,208 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// retryAfterHeader is the response header telling a rate limited client how many seconds to wait
+const retryAfterHeader = "retry-after"
+
+// loginMethods are the calls that check a user's credentials and count towards the lockout
+var loginMethods = map[string]bool{
+	proto.VaultService_Login_FullMethodName:      true,
+	proto.VaultService_VerifyTOTP_FullMethodName: true,
+}
+
+// LimitStore keeps the counters of the rate limiter. The in-memory store serves a single server
+// instance, storage.Storage shares the counters between instances through the database.
+type LimitStore interface {
+	HitRateLimit(ctx context.Context, key string, now time.Time, window time.Duration) (int64, time.Time, error)
+	FailRateLimit(ctx context.Context, key string, now time.Time) (int64, error)
+	RateLimitFailures(ctx context.Context, key string) (int64, time.Time, error)
+	ResetRateLimit(ctx context.Context, key string) error
+	PurgeRateLimits(ctx context.Context, before time.Time) (int64, error)
+}
+
+// RateLimiter limits the authentication calls per login and per client address and locks a
+// login out for exponentially growing periods after repeated failed attempts
+type RateLimiter struct {
+	store LimitStore
+	cfg   config.Config
+	log   *slog.Logger
+	now   func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter keeping its counters in the store
+func NewRateLimiter(store LimitStore, cfg config.Config, log *slog.Logger) *RateLimiter {
+	return &RateLimiter{
+		store: store,
+		cfg:   cfg,
+		log:   log,
+		now:   time.Now,
+	}
+}
+
+// Run periodically removes the counters that no longer limit anything until the context is cancelled
+func (l *RateLimiter) Run(ctx context.Context) {
+	if l.cfg.RateLimitWindow <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(l.cfg.RateLimitWindow)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		before := l.now().Add(-max(l.cfg.RateLimitWindow, l.cfg.LockoutMax))
+		if _, err := l.store.PurgeRateLimits(ctx, before); err != nil {
+			l.log.Error("Error purging rate limits", "error", err)
+		}
+	}
+}
+
+// RateLimitInterceptor rate limits the calls made without an access token and rejects the login
+// calls of locked out users. Rejected calls fail with ResourceExhausted and a retry-after header.
+func RateLimitInterceptor(limiter *RateLimiter, subjects SubjectResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		login := subjects.AuditSubject(ctx, req)
+
+		wait, err := limiter.check(ctx, peerAddress(ctx), login)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to check rate limit")
+		}
+		if wait > 0 {
+			seconds := int64(math.Ceil(wait.Seconds()))
+			_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, strconv.FormatInt(seconds, 10)))
+			return nil, status.Error(codes.ResourceExhausted, fmt.Sprintf("too many attempts, retry in %d seconds", seconds))
+		}
+
+		resp, err := handler(ctx, req)
+
+		if login != "" && loginMethods[info.FullMethod] {
+			limiter.record(context.WithoutCancel(ctx), login, resp, err)
+		}
+
+		return resp, err
+	}
+}
+
+// check counts a call from a client address for a login, which is empty if the call isn't made
+// for a user, and returns how long the caller has to wait if the call exceeds a limit
+func (l *RateLimiter) check(ctx context.Context, addr, login string) (time.Duration, error) {
+	now := l.now()
+
+	if login != "" {
+		wait, err := l.lockedFor(ctx, login, now)
+		if err != nil || wait > 0 {
+			return wait, err
+		}
+	}
+
+	if addr != "" {
+		wait, err := l.hit(ctx, "peer:"+addr, l.cfg.RateLimitPerPeer, now)
+		if err != nil || wait > 0 {
+			return wait, err
+		}
+	}
+
+	if login != "" {
+		return l.hit(ctx, "login:"+login, l.cfg.RateLimitPerLogin, now)
+	}
+
+	return 0, nil
+}
+
+// hit counts a call under a key and returns the rest of the window if the limit is exceeded
+func (l *RateLimiter) hit(ctx context.Context, key string, limit int, now time.Time) (time.Duration, error) {
+	if limit <= 0 || l.cfg.RateLimitWindow <= 0 {
+		return 0, nil
+	}
+
+	hits, end, err := l.store.HitRateLimit(ctx, key, now, l.cfg.RateLimitWindow)
+	if err != nil {
+		return 0, err
+	}
+	if hits > int64(limit) {
+		return end.Sub(now), nil
+	}
+
+	return 0, nil
+}
+
+// lockedFor returns how long a login stays locked out after its consecutive failed attempts
+func (l *RateLimiter) lockedFor(ctx context.Context, login string, now time.Time) (time.Duration, error) {
+	failures, last, err := l.store.RateLimitFailures(ctx, "lockout:"+login)
+	if err != nil {
+		return 0, err
+	}
+
+	wait := last.Add(l.lockout(failures)).Sub(now)
+	return max(wait, 0), nil
+}
+
+// lockout returns the lockout after a number of consecutive failures: none below the threshold,
+// then LockoutBase doubled with every further failure, at most LockoutMax
+func (l *RateLimiter) lockout(failures int64) time.Duration {
+	if l.cfg.LockoutThreshold <= 0 || failures < int64(l.cfg.LockoutThreshold) {
+		return 0
+	}
+
+	lockout := l.cfg.LockoutBase
+	for i := int64(l.cfg.LockoutThreshold); i < failures && lockout < l.cfg.LockoutMax; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, l.cfg.LockoutMax)
+}
+
+// record updates the lockout of a login after a login call. Wrong credentials count as a failure,
+// a completed login clears the failures. Failures older than LockoutMax are forgotten first.
+// Passing the password check of a login with two-factor authentication clears nothing, or the
+// code could be guessed by logging in again between attempts.
+func (l *RateLimiter) record(ctx context.Context, login string, resp any, err error) {
+	key := "lockout:" + login
+
+	switch status.Code(err) {
+	case codes.Unauthenticated:
+		now := l.now()
+		failures, last, err := l.store.RateLimitFailures(ctx, key)
+		if err == nil && failures > 0 && now.Sub(last) > l.cfg.LockoutMax {
+			err = l.store.ResetRateLimit(ctx, key)
+		}
+		if err == nil {
+			_, err = l.store.FailRateLimit(ctx, key, now)
+		}
+		if err != nil {
+			l.log.Error("failed to record login failure", "login", login, "error", err)
+		}
+	case codes.OK:
+		if r, ok := resp.(*proto.LoginResponse); ok && r.GetTotpRequired() {
+			return
+		}
+		if err := l.store.ResetRateLimit(ctx, key); err != nil {
+			l.log.Error("failed to reset login failures", "login", login, "error", err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"data-vault/server/internal/config"
+	"data-vault/server/internal/proto"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// headerStream captures the headers a handler sets
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(md metadata.MD) error { return nil }
+
+// limitTest drives the rate limit interceptor with a fake clock
+type limitTest struct {
+	t           *testing.T
+	limiter     *RateLimiter
+	interceptor grpc.UnaryServerInterceptor
+	now         time.Time
+	calls       int
+}
+
+func newLimitTest(t *testing.T, cfg config.Config) *limitTest {
+	lt := &limitTest{t: t, now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	lt.limiter = NewRateLimiter(NewMemoryLimitStore(), cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	lt.limiter.now = func() time.Time { return lt.now }
+	lt.interceptor = RateLimitInterceptor(lt.limiter, &fakeAuditor{})
+	return lt
+}
+
+// login makes a login call from an address, answered with result unless it is rate limited.
+// It returns the status code of the call and its retry-after header.
+func (lt *limitTest) login(addr, login string, result codes.Code) (codes.Code, string) {
+	lt.t.Helper()
+
+	stream := &headerStream{}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 4000}})
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	info := &grpc.UnaryServerInfo{FullMethod: proto.VaultService_Login_FullMethodName}
+	req := &proto.LoginRequest{User: &proto.User{Login: login}}
+
+	_, err := lt.interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		lt.calls++
+		if result != codes.OK {
+			return nil, status.Error(result, "Invalid credentials")
+		}
+		return &proto.LoginResponse{}, nil
+	})
+
+	retryAfter := ""
+	if values := stream.header.Get(retryAfterHeader); len(values) > 0 {
+		retryAfter = values[0]
+	}
+	return status.Code(err), retryAfter
+}
+
+func TestRateLimitInterceptor_Limits(t *testing.T) {
+	cfg := config.Config{
+		RateLimitWindow:   time.Minute,
+		RateLimitPerLogin: 2,
+		RateLimitPerPeer:  3,
+	}
+
+	tests := []struct {
+		name   string
+		calls  [][2]string
+		expect []codes.Code
+	}{
+		{
+			name:   "per login",
+			calls:  [][2]string{{"10.0.0.1", "alice"}, {"10.0.0.2", "alice"}, {"10.0.0.3", "alice"}, {"10.0.0.4", "bob"}},
+			expect: []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted, codes.OK},
+		},
+		{
+			name:   "per address",
+			calls:  [][2]string{{"10.0.0.1", "alice"}, {"10.0.0.1", "bob"}, {"10.0.0.1", "carol"}, {"10.0.0.1", "dave"}, {"10.0.0.2", "dave"}},
+			expect: []codes.Code{codes.OK, codes.OK, codes.OK, codes.ResourceExhausted, codes.OK},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lt := newLimitTest(t, cfg)
+
+			for i, c := range tt.calls {
+				code, retryAfter := lt.login(c[0], c[1], codes.OK)
+				assert.Equal(t, tt.expect[i], code, "call %d", i+1)
+				if code == codes.ResourceExhausted {
+					assert.Equal(t, "60", retryAfter, "The client should wait for the rest of the window")
+				} else {
+					assert.Empty(t, retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitInterceptor_WindowEnds(t *testing.T) {
+	lt := newLimitTest(t, config.Config{RateLimitWindow: time.Minute, RateLimitPerLogin: 1})
+
+	code, _ := lt.login("10.0.0.1", "alice", codes.OK)
+	require.Equal(t, codes.OK, code)
+
+	lt.now = lt.now.Add(45 * time.Second)
+	code, retryAfter := lt.login("10.0.0.1", "alice", codes.OK)
+	assert.Equal(t, codes.ResourceExhausted, code)
+	assert.Equal(t, "15", retryAfter)
+
+	lt.now = lt.now.Add(15 * time.Second)
+	code, _ = lt.login("10.0.0.1", "alice", codes.OK)
+	assert.Equal(t, codes.OK, code, "A new window should start")
+}
+
+func TestRateLimitInterceptor_Lockout(t *testing.T) {
+	lt := newLimitTest(t, config.Config{
+		LockoutThreshold: 3,
+		LockoutBase:      30 * time.Second,
+		LockoutMax:       2 * time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		code, _ := lt.login("10.0.0.1", "alice", codes.Unauthenticated)
+		require.Equal(t, codes.Unauthenticated, code, "Failures below the threshold don't lock")
+	}
+
+	// Every further failure doubles the lockout up to LockoutMax
+	for _, wait := range []string{"30", "60", "120", "120"} {
+		calls := lt.calls
+		code, retryAfter := lt.login("10.0.0.1", "alice", codes.OK)
+		assert.Equal(t, codes.ResourceExhausted, code)
+		assert.Equal(t, wait, retryAfter)
+		assert.Equal(t, calls, lt.calls, "A locked out login must not reach the handler")
+
+		code, _ = lt.login("10.0.0.2", "bob", codes.OK)
+		assert.Equal(t, codes.OK, code, "Other logins are not locked")
+
+		seconds, _ := time.ParseDuration(wait + "s")
+		lt.now = lt.now.Add(seconds)
+		code, _ = lt.login("10.0.0.1", "alice", codes.Unauthenticated)
+		require.Equal(t, codes.Unauthenticated, code)
+	}
+
+	lt.now = lt.now.Add(2 * time.Minute)
+	code, _ := lt.login("10.0.0.1", "alice", codes.OK)
+	require.Equal(t, codes.OK, code)
+
+	code, _ = lt.login("10.0.0.1", "alice", codes.Unauthenticated)
+	require.Equal(t, codes.Unauthenticated, code)
+	code, retryAfter := lt.login("10.0.0.1", "alice", codes.OK)
+	assert.Equal(t, codes.OK, code, "A successful login should reset the failures")
+	assert.Empty(t, retryAfter)
+}
+
+func TestRateLimitInterceptor_AuthenticatedCalls(t *testing.T) {
+	lt := newLimitTest(t, config.Config{RateLimitWindow: time.Minute, RateLimitPerPeer: 1})
+	info := &grpc.UnaryServerInfo{FullMethod: proto.VaultService_GetData_FullMethodName}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}})
+
+	for i := 0; i < 3; i++ {
+		_, err := lt.interceptor(ctx, &proto.GetDataRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &proto.GetDataResponse{}, nil
+		})
+		assert.NoError(t, err, "Calls with an access token are not rate limited")
+	}
+}
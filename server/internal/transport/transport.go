@@ -3,6 +3,7 @@ package transport
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
 
 	"data-vault/server/internal/config"
 	"data-vault/server/internal/handler"
@@ -15,44 +16,73 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-// contextKey is a type for keys used in context to avoid collisions
-type contextKey string
+// publicMethods are the calls that are made before the client holds an access token
+var publicMethods = map[string]bool{
+	proto.VaultService_Register_FullMethodName:     true,
+	proto.VaultService_PreLogin_FullMethodName:     true,
+	proto.VaultService_Login_FullMethodName:        true,
+	proto.VaultService_RefreshToken_FullMethodName: true,
+	proto.VaultService_VerifyTOTP_FullMethodName:   true,
+}
 
-const (
-	registerMethod            = "/proto.VaultService/Register"
-	loginMethod               = "/proto.VaultService/Login"
-	userIDKey      contextKey = "user_id"
-)
+// orgHeader is the metadata header selecting the organization vault a call operates on
+const orgHeader = "x-org"
+
+// SessionChecker reports whether the session an access token was issued for is still live
+type SessionChecker interface {
+	SessionActive(ctx context.Context, login, sessionID string) (bool, error)
+}
+
+// RoleChecker returns the role of a user in an organization, empty if the user isn't a member
+type RoleChecker interface {
+	MemberRole(ctx context.Context, org, login string) (string, error)
+}
 
 // Transport handles gRPC transport layer operations
 type Transport struct {
 	handler *handler.Handler
+	limiter *RateLimiter
+	health  *health.Server
+	ready   atomic.Bool
 	cfg     config.Config
 	log     *slog.Logger
+
+	// stopped ends when the server shuts down, closing the health Watch streams
+	stopped     context.Context
+	stopWatches context.CancelFunc
 }
 
 // New creates a new Transport instance
-func New(h *handler.Handler, cfg config.Config, log *slog.Logger) *Transport {
+func New(h *handler.Handler, limiter *RateLimiter, cfg config.Config, log *slog.Logger) *Transport {
+	stopped, stopWatches := context.WithCancel(context.Background())
+
 	return &Transport{
-		handler: h,
-		cfg:     cfg,
-		log:     log,
+		handler:     h,
+		limiter:     limiter,
+		health:      health.NewServer(),
+		cfg:         cfg,
+		log:         log,
+		stopped:     stopped,
+		stopWatches: stopWatches,
 	}
 }
 
 // Claim represents JWT claims structure
 type Claim struct {
 	jwt.RegisteredClaims
-	Login string
+	Login     string
+	SessionID string
 }
 
 // NewRouter creates and returns a new configured gRPC server with interceptors
 func NewRouter(g *Transport) (*grpc.Server, error) {
-	creds, err := credentials.NewServerTLSFromFile("server.crt", "server.key")
+	creds, err := credentials.NewServerTLSFromFile(g.cfg.TLSCertFile, g.cfg.TLSKeyFile)
 	if err != nil {
 		return nil, err
 	}
@@ -61,11 +91,22 @@ func NewRouter(g *Transport) (*grpc.Server, error) {
 		grpc.Creds(creds),
 		grpc.ChainUnaryInterceptor(
 			LoggingInterceptor(g.log),
-			AuthInterceptor(g.cfg.JWTSecret),
+			ReadinessInterceptor(g),
+			RateLimitInterceptor(g.limiter, g.handler),
+			AuthInterceptor(g.cfg.JWTSecret, g.handler, g.handler),
+			AuditInterceptor(g.handler, g.log),
+		),
+		grpc.ChainStreamInterceptor(
+			StreamLoggingInterceptor(g.log),
+			StreamReadinessInterceptor(g),
+			StreamAuthInterceptor(g.cfg.JWTSecret, g.handler, g.handler),
+			StreamAuditInterceptor(g.handler, g.log),
 		),
 	)
 
 	proto.RegisterVaultServiceServer(server, g.handler)
+	healthpb.RegisterHealthServer(server, healthServer{Server: g.health, stopped: g.stopped})
+	g.SetReady(false)
 
 	return server, nil
 }
@@ -98,42 +139,129 @@ func LoggingInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
 	}
 }
 
-// AuthInterceptor handles JWT authentication via metadata
-func AuthInterceptor(JWTSecret string) grpc.UnaryServerInterceptor {
+// StreamLoggingInterceptor adds request logging for streaming gRPC calls
+func StreamLoggingInterceptor(log *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		code := codes.OK
+		if err != nil {
+			if st, ok := status.FromError(err); ok {
+				code = st.Code()
+			} else {
+				code = codes.Internal
+			}
+		}
+
+		log.Info("stream completed",
+			"method", info.FullMethod,
+			"duration", time.Since(start).String(),
+			"status", code.String(),
+		)
+
+		return err
+	}
+}
+
+// AuthInterceptor handles JWT authentication via metadata and rejects tokens of revoked sessions.
+// Calls on an organization vault are rejected unless the user is a member of the organization.
+// The login calls and the health checks are served without a token.
+func AuthInterceptor(JWTSecret string, sessions SessionChecker, roles RoleChecker) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if info.FullMethod == registerMethod || info.FullMethod == loginMethod {
+		if publicMethods[info.FullMethod] || healthMethods[info.FullMethod] {
 			return handler(ctx, req)
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Internal, "failed to get metadata from context")
+		ctx, err := authenticate(ctx, JWTSecret, sessions, roles)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor handles JWT authentication and organization membership checks via metadata
+// for streaming calls, except for the health checks
+func StreamAuthInterceptor(JWTSecret string, sessions SessionChecker, roles RoleChecker) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if healthMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), JWTSecret, sessions, roles)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticatedStream overrides the stream context with one carrying the user ID
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-		var userID string
-
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) > 0 {
-			authHeader := authHeaders[0]
-			if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-				tokenString := authHeader[7:]
-
-				claims := &Claim{}
-				token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-					if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-						return nil, status.Error(codes.InvalidArgument, "unexpected signing method")
-					}
-					return []byte(JWTSecret), nil
-				})
-
-				if err == nil && token.Valid {
-					userID = claims.Login
-					ctx = context.WithValue(ctx, userIDKey, userID)
-					return handler(ctx, req)
+// Context returns the authenticated context of the stream
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate validates the bearer token from the metadata, checks that its session wasn't revoked
+// and stores the user and session IDs and the selected organization in the context
+func authenticate(ctx context.Context, JWTSecret string, sessions SessionChecker, roles RoleChecker) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "failed to get metadata from context")
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) > 0 {
+		authHeader := authHeaders[0]
+		if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+			tokenString := authHeader[7:]
+
+			claims := &Claim{}
+			token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+				if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+					return nil, status.Error(codes.InvalidArgument, "unexpected signing method")
+				}
+				return []byte(JWTSecret), nil
+			})
+
+			if err == nil && token.Valid && claims.SessionID != "" {
+				active, err := sessions.SessionActive(ctx, claims.Login, claims.SessionID)
+				if err != nil {
+					return nil, status.Error(codes.Internal, "failed to check session")
+				}
+				if active {
+					return selectOrg(handler.WithSession(ctx, claims.Login, claims.SessionID), md, claims.Login, roles)
 				}
 			}
 		}
+	}
 
-		return nil, status.Error(codes.Unauthenticated, "valid authentication required")
+	return nil, status.Error(codes.Unauthenticated, "valid authentication required")
+}
+
+// selectOrg checks that the authenticated user is a member of the organization named in the
+// x-org header and stores the organization and the user's role in the context. The handlers
+// check the role against the operation.
+func selectOrg(ctx context.Context, md metadata.MD, login string, roles RoleChecker) (context.Context, error) {
+	orgs := md.Get(orgHeader)
+	if len(orgs) == 0 || orgs[0] == "" {
+		return ctx, nil
+	}
+
+	role, err := roles.MemberRole(ctx, orgs[0], login)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check organization membership")
+	}
+	if role == "" {
+		return nil, status.Error(codes.PermissionDenied, "not a member of the organization")
 	}
+
+	return handler.WithOrg(ctx, orgs[0], role), nil
 }